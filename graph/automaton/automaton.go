@@ -0,0 +1,244 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package automaton
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// Epsilon is the label of a transition that consumes no input.
+const Epsilon = ""
+
+// Transition is a labeled transition between two states of an automaton.
+type Transition struct {
+	From, To int64
+	Label    string
+}
+
+// Automaton is a nondeterministic finite automaton with epsilon transitions.
+// The states of the automaton are identified by the IDs used in its Start,
+// Accept and Transitions fields.
+type Automaton struct {
+	// Start is the ID of the start state.
+	Start int64
+
+	// Accept holds the IDs of the accepting states.
+	Accept []int64
+
+	// Transitions holds the transitions of the automaton.
+	Transitions []Transition
+}
+
+// States returns the IDs of the states of a sorted ascending.
+func (a *Automaton) States() []int64 {
+	seen := map[int64]bool{a.Start: true}
+	ids := []int64{a.Start}
+	add := func(id int64) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range a.Accept {
+		add(id)
+	}
+	for _, t := range a.Transitions {
+		add(t.From)
+		add(t.To)
+	}
+	sort.Sort(ordered.Int64s(ids))
+	return ids
+}
+
+// Accepts returns whether a accepts the sequence of input symbols in word.
+func (a *Automaton) Accepts(word []string) bool {
+	from := a.transitionsFrom()
+	current := closure(map[int64]bool{a.Start: true}, from)
+	for _, sym := range word {
+		next := make(map[int64]bool)
+		for id := range current {
+			for _, t := range from[id] {
+				if t.Label == sym && sym != Epsilon {
+					next[t.To] = true
+				}
+			}
+		}
+		if len(next) == 0 {
+			return false
+		}
+		current = closure(next, from)
+	}
+	for _, id := range a.Accept {
+		if current[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// closure adds the states reachable by epsilon transitions from the states
+// in set to set and returns it.
+func closure(set map[int64]bool, from map[int64][]Transition) map[int64]bool {
+	var stack []int64
+	for id := range set {
+		stack = append(stack, id)
+	}
+	for len(stack) != 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, t := range from[id] {
+			if t.Label == Epsilon && !set[t.To] {
+				set[t.To] = true
+				stack = append(stack, t.To)
+			}
+		}
+	}
+	return set
+}
+
+// transitionsFrom returns the transitions of a indexed by their from state.
+func (a *Automaton) transitionsFrom() map[int64][]Transition {
+	from := make(map[int64][]Transition)
+	for _, t := range a.Transitions {
+		from[t.From] = append(from[t.From], t)
+	}
+	return from
+}
+
+// Line is a labeled multigraph line representing an automaton transition.
+type Line struct {
+	F, T  graph.Node
+	UID   int64
+	Label string
+}
+
+// From returns the from-node of the line.
+func (l Line) From() graph.Node { return l.F }
+
+// To returns the to-node of the line.
+func (l Line) To() graph.Node { return l.T }
+
+// ReversedLine returns a new Line with the F and T fields
+// swapped. The UID and Label of the new Line are the same
+// as the UID and Label of the receiver.
+func (l Line) ReversedLine() graph.Line { l.F, l.T = l.T, l.F; return l }
+
+// ID returns the ID of the line.
+func (l Line) ID() int64 { return l.UID }
+
+// Graph returns a directed multigraph with a node for each state of a and a
+// Line for each transition. The node IDs are the state IDs and the ID of each
+// line is the index of its transition in a.Transitions.
+func (a *Automaton) Graph() *multi.DirectedGraph {
+	g := multi.NewDirectedGraph()
+	for _, id := range a.States() {
+		g.AddNode(multi.Node(id))
+	}
+	for i, t := range a.Transitions {
+		g.SetLine(Line{F: g.Node(t.From), T: g.Node(t.To), UID: int64(i), Label: t.Label})
+	}
+	return g
+}
+
+// FromGraph returns an automaton with the given start and accepting states and
+// a transition for each line of g. The label of each transition is given by
+// calling label with the line. If label is nil, the lines of g must be Line
+// values and their Label fields are used. Nodes of g without lines that are
+// not start or accepting states are not represented in the returned automaton.
+// Transitions are ordered by from node ID, to node ID and then line ID.
+func FromGraph(g graph.DirectedMultigraph, start int64, accept []int64, label func(graph.Line) string) *Automaton {
+	if label == nil {
+		label = lineLabel
+	}
+	a := &Automaton{Start: start, Accept: append([]int64(nil), accept...)}
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	for _, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			lines := graph.LinesOf(g.Lines(uid, vid))
+			sort.Sort(ordered.LinesByIDs(lines))
+			for _, l := range lines {
+				a.Transitions = append(a.Transitions, Transition{From: uid, To: vid, Label: label(l)})
+			}
+		}
+	}
+	return a
+}
+
+// lineLabel returns the label of l, which must be a Line.
+func lineLabel(l graph.Line) string {
+	switch l := l.(type) {
+	case Line:
+		return l.Label
+	case *Line:
+		return l.Label
+	default:
+		panic(fmt.Sprintf("automaton: no label for line type %T", l))
+	}
+}
+
+// Trim returns a copy of a with its dead states removed. A state is dead when
+// it is not reachable from the start state or when no accepting state is
+// reachable from it. The start state is always retained, so an automaton that
+// accepts no input is trimmed to a start state without transitions.
+//
+// The returned automaton accepts the same language as a, and each of its
+// states lies on a path from the start state to an accepting state.
+func (a *Automaton) Trim() *Automaton {
+	g := a.Graph()
+
+	var fwd traverse.BreadthFirst
+	fwd.Walk(g, g.Node(a.Start), nil)
+
+	var rev traverse.BreadthFirst
+	r := reversed{g}
+	for _, id := range a.Accept {
+		if fwd.Visited(multi.Node(id)) {
+			rev.Walk(r, g.Node(id), nil)
+		}
+	}
+	live := func(id int64) bool {
+		n := multi.Node(id)
+		return fwd.Visited(n) && rev.Visited(n)
+	}
+
+	t := &Automaton{Start: a.Start}
+	for _, id := range a.Accept {
+		if live(id) {
+			t.Accept = append(t.Accept, id)
+		}
+	}
+	for _, tr := range a.Transitions {
+		if live(tr.From) && live(tr.To) {
+			t.Transitions = append(t.Transitions, tr)
+		}
+	}
+	return t
+}
+
+// reversed is a traverse.Graph with the directions of the
+// edges in the underlying graph reversed.
+type reversed struct {
+	g graph.Directed
+}
+
+func (g reversed) From(id int64) graph.Nodes { return g.g.To(id) }
+func (g reversed) Edge(uid, vid int64) graph.Edge {
+	e := g.g.Edge(vid, uid)
+	if e == nil {
+		return nil
+	}
+	return e.ReversedEdge()
+}
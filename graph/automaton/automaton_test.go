@@ -0,0 +1,224 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package automaton
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// evenAs accepts strings over {a, b} with an even number of a.
+var evenAs = &Automaton{
+	Start:  0,
+	Accept: []int64{0},
+	Transitions: []Transition{
+		{From: 0, To: 0, Label: "b"},
+		{From: 0, To: 1, Label: "a"},
+		{From: 1, To: 1, Label: "b"},
+		{From: 1, To: 0, Label: "a"},
+	},
+}
+
+// endsB accepts strings over {a, b} ending in b.
+var endsB = &Automaton{
+	Start:  0,
+	Accept: []int64{2},
+	Transitions: []Transition{
+		{From: 0, To: 0, Label: "a"},
+		{From: 0, To: 0, Label: "b"},
+		{From: 0, To: 1, Label: Epsilon},
+		{From: 1, To: 2, Label: "b"},
+	},
+}
+
+// dead has states 3 and 4 unreachable from the start
+// and states 5 and 6 unable to reach an accepting state.
+var dead = &Automaton{
+	Start:  0,
+	Accept: []int64{2, 4},
+	Transitions: []Transition{
+		{From: 0, To: 1, Label: "a"},
+		{From: 1, To: 2, Label: "b"},
+		{From: 1, To: 1, Label: "a"},
+		{From: 3, To: 4, Label: "a"},
+		{From: 3, To: 1, Label: "a"},
+		{From: 0, To: 5, Label: "b"},
+		{From: 5, To: 6, Label: Epsilon},
+		{From: 6, To: 5, Label: "a"},
+	},
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "")
+}
+
+func TestAccepts(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		a    *Automaton
+		word string
+		want bool
+	}{
+		{a: evenAs, word: "", want: true},
+		{a: evenAs, word: "a", want: false},
+		{a: evenAs, word: "abba", want: true},
+		{a: evenAs, word: "babab", want: true},
+		{a: evenAs, word: "aaab", want: false},
+		{a: evenAs, word: "ac", want: false},
+		{a: endsB, word: "", want: false},
+		{a: endsB, word: "b", want: true},
+		{a: endsB, word: "aab", want: true},
+		{a: endsB, word: "aba", want: false},
+	} {
+		if got := test.a.Accepts(split(test.word)); got != test.want {
+			t.Errorf("unexpected acceptance of %q: got:%t want:%t", test.word, got, test.want)
+		}
+	}
+}
+
+func TestGraphRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, a := range []*Automaton{evenAs, endsB, dead} {
+		g := a.Graph()
+		if got, want := g.Nodes().Len(), len(a.States()); got != want {
+			t.Errorf("unexpected number of nodes: got:%d want:%d", got, want)
+		}
+		var n int
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			for _, v := range graph.NodesOf(g.From(u.ID())) {
+				n += g.Lines(u.ID(), v.ID()).Len()
+			}
+		}
+		if n != len(a.Transitions) {
+			t.Errorf("unexpected number of lines: got:%d want:%d", n, len(a.Transitions))
+		}
+
+		got := FromGraph(g, a.Start, a.Accept, nil)
+		if !sameTransitions(got.Transitions, a.Transitions) {
+			t.Errorf("unexpected transitions after round trip:\ngot: %v\nwant:%v", got.Transitions, a.Transitions)
+		}
+	}
+}
+
+func TestFromGraphLabel(t *testing.T) {
+	t.Parallel()
+	g := multi.NewDirectedGraph()
+	g.SetLine(g.NewLine(multi.Node(0), multi.Node(1)))
+	g.SetLine(g.NewLine(multi.Node(0), multi.Node(1)))
+	g.SetLine(g.NewLine(multi.Node(1), multi.Node(0)))
+	a := FromGraph(g, 0, []int64{1}, func(l graph.Line) string {
+		return string(rune('a' + l.ID()))
+	})
+	want := []Transition{
+		{From: 0, To: 1, Label: "a"},
+		{From: 0, To: 1, Label: "b"},
+		{From: 1, To: 0, Label: "c"},
+	}
+	if !reflect.DeepEqual(a.Transitions, want) {
+		t.Errorf("unexpected transitions: got:%v want:%v", a.Transitions, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unlabeled lines")
+		}
+	}()
+	FromGraph(g, 0, []int64{1}, nil)
+}
+
+func TestGraphSCC(t *testing.T) {
+	t.Parallel()
+	// The strong components of the graph of an automaton
+	// are the looping parts of the automaton.
+	var got [][]int64
+	for _, c := range topo.TarjanSCC(dead.Graph()) {
+		var ids []int64
+		for _, n := range c {
+			ids = append(ids, n.ID())
+		}
+		if len(ids) > 1 {
+			got = append(got, ids)
+		}
+	}
+	if len(got) != 1 || len(got[0]) != 2 || got[0][0]+got[0][1] != 11 {
+		t.Errorf("unexpected strong components: got:%v want:[[5 6]]", got)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	t.Parallel()
+	got := dead.Trim()
+	if want := []int64{0, 1, 2}; !reflect.DeepEqual(got.States(), want) {
+		t.Errorf("unexpected states after trim: got:%v want:%v", got.States(), want)
+	}
+	if want := []int64{2}; !reflect.DeepEqual(got.Accept, want) {
+		t.Errorf("unexpected accepting states after trim: got:%v want:%v", got.Accept, want)
+	}
+	if len(got.Transitions) != 3 {
+		t.Errorf("unexpected number of transitions after trim: got:%d want:3", len(got.Transitions))
+	}
+	for _, w := range []string{"", "ab", "aab", "b", "ba", "aaaab"} {
+		if got.Accepts(split(w)) != dead.Accepts(split(w)) {
+			t.Errorf("trim changed acceptance of %q", w)
+		}
+	}
+
+	empty := (&Automaton{Start: 0, Transitions: []Transition{{From: 0, To: 1, Label: "a"}}}).Trim()
+	if !reflect.DeepEqual(empty, &Automaton{Start: 0}) {
+		t.Errorf("unexpected trim of empty language automaton: got:%+v", empty)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	t.Parallel()
+	p, states := Product(evenAs, endsB, nil)
+	if len(states) != p.Graph().Nodes().Len() {
+		t.Errorf("unexpected number of product states: got:%d want:%d", len(states), p.Graph().Nodes().Len())
+	}
+	if states[p.Start] != [2]int64{evenAs.Start, endsB.Start} {
+		t.Errorf("unexpected start state: got:%v", states[p.Start])
+	}
+
+	union, _ := Product(evenAs, endsB, func(inA, inB bool) bool { return inA || inB })
+	for _, w := range []string{"", "a", "b", "ab", "aab", "aba", "abab", "baab", "bbbb", "aaaa"} {
+		word := split(w)
+		inA, inB := evenAs.Accepts(word), endsB.Accepts(word)
+		if got, want := p.Accepts(word), inA && inB; got != want {
+			t.Errorf("unexpected intersection acceptance of %q: got:%t want:%t", w, got, want)
+		}
+		// endsB is not complete, but its start state reads every
+		// word, so the union product still accepts the union.
+		if got, want := union.Accepts(word), inA || inB; got != want {
+			t.Errorf("unexpected union acceptance of %q: got:%t want:%t", w, got, want)
+		}
+	}
+}
+
+// sameTransitions returns whether a and b hold the same transitions
+// irrespective of order.
+func sameTransitions(a, b []Transition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[Transition]int)
+	for _, t := range a {
+		count[t]++
+	}
+	for _, t := range b {
+		count[t]--
+		if count[t] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,13 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package automaton provides conversion between finite automata and labeled
+// directed multigraphs.
+//
+// The states of an automaton become the nodes of a graph and its labeled
+// transitions become lines, so that the strong component, dominator and
+// shortest path functions of the graph packages may be applied to automata.
+// The package also provides dead-state pruning, product automaton construction
+// and Thompson's construction of automata from regular expressions.
+package automaton // import "gonum.org/v1/gonum/graph/automaton"
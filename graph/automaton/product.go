@@ -0,0 +1,79 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package automaton
+
+// Product returns the product automaton of a and b and the pairs of states of
+// a and b corresponding to each of its states. The states of p are numbered
+// from zero in breadth-first order from the start state, and states[i] holds
+// the IDs of the states of a and b that make up state i of p. Only the states
+// reachable from the pair of start states are constructed.
+//
+// A state of p has a transition with a non-epsilon label when both component
+// states have a transition with that label, and has an epsilon transition
+// when either component state has one. The accept function determines whether
+// a state of p is accepting from whether its component states are accepting.
+// If accept is nil, a state of p is accepting when both component states are,
+// and p accepts the intersection of the languages of a and b. Other accept
+// functions, for example for union or difference, only give the corresponding
+// language when a and b are complete deterministic automata over the same
+// alphabet.
+func Product(a, b *Automaton, accept func(inA, inB bool) bool) (p *Automaton, states [][2]int64) {
+	if accept == nil {
+		accept = func(inA, inB bool) bool { return inA && inB }
+	}
+	acceptA := make(map[int64]bool)
+	for _, id := range a.Accept {
+		acceptA[id] = true
+	}
+	acceptB := make(map[int64]bool)
+	for _, id := range b.Accept {
+		acceptB[id] = true
+	}
+	fromA := a.transitionsFrom()
+	fromB := b.transitionsFrom()
+
+	p = &Automaton{}
+	index := make(map[[2]int64]int64)
+	stateOf := func(pair [2]int64) int64 {
+		id, ok := index[pair]
+		if !ok {
+			id = int64(len(states))
+			index[pair] = id
+			states = append(states, pair)
+		}
+		return id
+	}
+	p.Start = stateOf([2]int64{a.Start, b.Start})
+	for i := 0; i < len(states); i++ {
+		pair := states[i]
+		id := int64(i)
+		if accept(acceptA[pair[0]], acceptB[pair[1]]) {
+			p.Accept = append(p.Accept, id)
+		}
+		for _, ta := range fromA[pair[0]] {
+			if ta.Label == Epsilon {
+				p.Transitions = append(p.Transitions, Transition{
+					From: id, To: stateOf([2]int64{ta.To, pair[1]}), Label: Epsilon,
+				})
+				continue
+			}
+			for _, tb := range fromB[pair[1]] {
+				if tb.Label == ta.Label {
+					p.Transitions = append(p.Transitions, Transition{
+						From: id, To: stateOf([2]int64{ta.To, tb.To}), Label: ta.Label,
+					})
+				}
+			}
+		}
+		for _, tb := range fromB[pair[1]] {
+			if tb.Label == Epsilon {
+				p.Transitions = append(p.Transitions, Transition{
+					From: id, To: stateOf([2]int64{pair[0], tb.To}), Label: Epsilon,
+				})
+			}
+		}
+	}
+	return p, states
+}
@@ -0,0 +1,160 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package automaton
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"unicode"
+)
+
+// MaxClassRunes is the largest number of runes in a character class
+// accepted by FromRegexp.
+const MaxClassRunes = 256
+
+// FromRegexp returns an automaton accepting the strings matched in full by
+// the regular expression expr, constructed by Thompson's construction. The
+// expression uses the Perl syntax accepted by the regexp package. Each
+// non-epsilon transition of the returned automaton is labeled with a single
+// rune, so the input to Accepts should be the runes of a string each converted
+// to a string. Character classes are expanded to a transition for each rune in
+// the class, and classes with more than MaxClassRunes runes are rejected, as
+// are the any-character and empty-width operators. States are numbered from
+// zero and the automaton has a single accepting state.
+//
+// See Thompson, "Programming Techniques: Regular expression search algorithm",
+// Commun. ACM 11(6):419-422 (1968) doi:10.1145/363347.363387.
+func FromRegexp(expr string) (*Automaton, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	var c thompson
+	start, end, err := c.build(re.Simplify())
+	if err != nil {
+		return nil, err
+	}
+	return &Automaton{Start: start, Accept: []int64{end}, Transitions: c.transitions}, nil
+}
+
+// thompson holds the state of a Thompson's construction.
+type thompson struct {
+	states      int64
+	transitions []Transition
+}
+
+// state returns a new state ID.
+func (c *thompson) state() int64 {
+	id := c.states
+	c.states++
+	return id
+}
+
+// link adds a transition from u to v with the given label.
+func (c *thompson) link(u, v int64, label string) {
+	c.transitions = append(c.transitions, Transition{From: u, To: v, Label: label})
+}
+
+// build adds the states and transitions of an automaton accepting re
+// and returns its start and accepting states.
+func (c *thompson) build(re *syntax.Regexp) (start, end int64, err error) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return c.state(), c.state(), nil
+
+	case syntax.OpEmptyMatch:
+		start, end = c.state(), c.state()
+		c.link(start, end, Epsilon)
+		return start, end, nil
+
+	case syntax.OpLiteral:
+		start = c.state()
+		end = start
+		for _, r := range re.Rune {
+			next := c.state()
+			for _, f := range fold(r, re.Flags&syntax.FoldCase != 0) {
+				c.link(end, next, string(f))
+			}
+			end = next
+		}
+		return start, end, nil
+
+	case syntax.OpCharClass:
+		var n int
+		for i := 0; i < len(re.Rune); i += 2 {
+			n += int(re.Rune[i+1]-re.Rune[i]) + 1
+		}
+		if n > MaxClassRunes {
+			return 0, 0, fmt.Errorf("automaton: character class %v has more than %d runes", re, MaxClassRunes)
+		}
+		start, end = c.state(), c.state()
+		for i := 0; i < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				c.link(start, end, string(r))
+			}
+		}
+		return start, end, nil
+
+	case syntax.OpCapture:
+		return c.build(re.Sub[0])
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		start, end = c.state(), c.state()
+		s, e, err := c.build(re.Sub[0])
+		if err != nil {
+			return 0, 0, err
+		}
+		c.link(start, s, Epsilon)
+		c.link(e, end, Epsilon)
+		if re.Op != syntax.OpPlus {
+			c.link(start, end, Epsilon)
+		}
+		if re.Op != syntax.OpQuest {
+			c.link(e, s, Epsilon)
+		}
+		return start, end, nil
+
+	case syntax.OpConcat:
+		start = c.state()
+		end = start
+		for _, sub := range re.Sub {
+			s, e, err := c.build(sub)
+			if err != nil {
+				return 0, 0, err
+			}
+			c.link(end, s, Epsilon)
+			end = e
+		}
+		return start, end, nil
+
+	case syntax.OpAlternate:
+		start, end = c.state(), c.state()
+		for _, sub := range re.Sub {
+			s, e, err := c.build(sub)
+			if err != nil {
+				return 0, 0, err
+			}
+			c.link(start, s, Epsilon)
+			c.link(e, end, Epsilon)
+		}
+		return start, end, nil
+
+	default:
+		return 0, 0, fmt.Errorf("automaton: unsupported regular expression operation %v in %v", re.Op, re)
+	}
+}
+
+// fold returns r and, if foldCase is true, the runes
+// equivalent to r under simple case folding.
+func fold(r rune, foldCase bool) []rune {
+	runes := []rune{r}
+	if !foldCase {
+		return runes
+	}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		runes = append(runes, f)
+	}
+	return runes
+}
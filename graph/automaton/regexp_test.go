@@ -0,0 +1,59 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package automaton
+
+import (
+	"regexp"
+	"testing"
+)
+
+var fromRegexpTests = []struct {
+	expr  string
+	words []string
+}{
+	{expr: "", words: []string{"", "a"}},
+	{expr: "abc", words: []string{"", "abc", "ab", "abcd"}},
+	{expr: "a(b|c)*d", words: []string{"ad", "abd", "acbcd", "abc", "bd", "abcda"}},
+	{expr: "(?i)go+", words: []string{"go", "GOO", "gO", "g", "goa"}},
+	{expr: "[a-c]?x[^\\x00-\\x{10ffff}]?", words: []string{"x", "ax", "cx", "dx", "abx"}},
+	{expr: "(ab){2,3}", words: []string{"ab", "abab", "ababab", "abababab"}},
+	{expr: "x|y*|z+?", words: []string{"", "x", "yy", "zzz", "xy"}},
+	{expr: "[0-9]+(\\.[0-9]*)?", words: []string{"1", "12.", "3.14", ".5", "1.2.3"}},
+}
+
+func TestFromRegexp(t *testing.T) {
+	t.Parallel()
+	for _, test := range fromRegexpTests {
+		a, err := FromRegexp(test.expr)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", test.expr, err)
+			continue
+		}
+		re := regexp.MustCompile("^(?:" + test.expr + ")$")
+		for _, w := range test.words {
+			if got, want := a.Accepts(split(w)), re.MatchString(w); got != want {
+				t.Errorf("unexpected acceptance of %q by %q: got:%t want:%t", w, test.expr, got, want)
+			}
+			if got, want := a.Trim().Accepts(split(w)), re.MatchString(w); got != want {
+				t.Errorf("unexpected acceptance of %q by trimmed %q: got:%t want:%t", w, test.expr, got, want)
+			}
+		}
+	}
+}
+
+func TestFromRegexpErrors(t *testing.T) {
+	t.Parallel()
+	for _, expr := range []string{
+		"a(",
+		"a.b",
+		"^ab$",
+		"\\bab",
+		"[^a]",
+	} {
+		if _, err := FromRegexp(expr); err == nil {
+			t.Errorf("expected error for %q", expr)
+		}
+	}
+}
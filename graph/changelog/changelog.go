@@ -0,0 +1,252 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package changelog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Op is a graph mutation operation.
+type Op uint8
+
+const (
+	// AddNode adds the node U.
+	AddNode Op = iota + 1
+	// RemoveNode removes the node U
+	// and its edges.
+	RemoveNode
+	// SetEdge sets an edge from U to V.
+	SetEdge
+	// SetWeightedEdge sets an edge from
+	// U to V with the given Weight.
+	SetWeightedEdge
+	// RemoveEdge removes the edge from
+	// U to V.
+	RemoveEdge
+)
+
+var opNames = []string{
+	AddNode:         "AddNode",
+	RemoveNode:      "RemoveNode",
+	SetEdge:         "SetEdge",
+	SetWeightedEdge: "SetWeightedEdge",
+	RemoveEdge:      "RemoveEdge",
+}
+
+// String implements the fmt.Stringer interface.
+func (o Op) String() string {
+	if o == 0 || int(o) >= len(opNames) {
+		return fmt.Sprintf("Op(%d)", o)
+	}
+	return opNames[o]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (o Op) MarshalText() ([]byte, error) {
+	if o == 0 || int(o) >= len(opNames) {
+		return nil, fmt.Errorf("changelog: invalid op %d", o)
+	}
+	return []byte(opNames[o]), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (o *Op) UnmarshalText(text []byte) error {
+	for i, name := range opNames {
+		if i != 0 && name == string(text) {
+			*o = Op(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("changelog: invalid op %q", text)
+}
+
+// Change is a recorded graph mutation.
+type Change struct {
+	// Time is the time of the mutation.
+	Time time.Time `json:"time"`
+
+	// Op is the mutation operation.
+	Op Op `json:"op"`
+
+	// U and V are the IDs of the nodes
+	// involved in the mutation. V is
+	// only used by edge operations.
+	U int64 `json:"u"`
+	V int64 `json:"v,omitempty"`
+
+	// Weight is the weight of an edge
+	// set by SetWeightedEdge.
+	Weight float64 `json:"weight,omitempty"`
+}
+
+// Log is an append-only log of graph mutations ordered by time.
+type Log struct {
+	changes []Change
+}
+
+// ErrOutOfOrder is returned when a change is appended to a Log
+// with a time before the last change in the Log.
+var ErrOutOfOrder = errors.New("changelog: change out of order")
+
+// Append appends c to the log. If c is before the last change in the
+// log, Append returns ErrOutOfOrder and the log is not altered.
+func (l *Log) Append(c Change) error {
+	if !l.inOrder(c) {
+		return ErrOutOfOrder
+	}
+	l.changes = append(l.changes, c)
+	return nil
+}
+
+// inOrder returns whether c may be appended to the log.
+func (l *Log) inOrder(c Change) bool {
+	return len(l.changes) == 0 || !c.Time.Before(l.changes[len(l.changes)-1].Time)
+}
+
+// Len returns the number of changes in the log.
+func (l *Log) Len() int { return len(l.changes) }
+
+// Changes returns the changes in the log. The returned
+// slice must not be altered.
+func (l *Log) Changes() []Change { return l.changes }
+
+// Mutable is a graph that can be reconstructed by replaying a Log.
+type Mutable interface {
+	graph.Graph
+	graph.NodeAdder
+	graph.NodeRemover
+	graph.EdgeRemover
+}
+
+// Replay applies the changes in the log up to and including the time
+// until to dst. SetWeightedEdge changes are applied with dst.NewWeightedEdge
+// if dst is a graph.WeightedEdgeAdder, and SetEdge changes, and
+// SetWeightedEdge changes when dst is not a graph.WeightedEdgeAdder, are
+// applied with dst.NewEdge if dst is a graph.EdgeAdder. Replay returns an
+// error if an edge change cannot be applied. Nodes that are not yet in dst
+// are created with the recorded ID. An AddNode change for a node already
+// in dst is ignored.
+//
+// Replaying a log into an empty graph reconstructs the state of the
+// recorded graph at time until.
+func (l *Log) Replay(dst Mutable, until time.Time) error {
+	for _, c := range l.changes {
+		if c.Time.After(until) {
+			break
+		}
+		err := apply(dst, c)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply applies the change c to dst.
+func apply(dst Mutable, c Change) error {
+	switch c.Op {
+	case AddNode:
+		if dst.Node(c.U) == nil {
+			dst.AddNode(nodeFor(dst, c.U))
+		}
+	case RemoveNode:
+		dst.RemoveNode(c.U)
+	case SetEdge, SetWeightedEdge:
+		u := nodeFor(dst, c.U)
+		v := nodeFor(dst, c.V)
+		wb, isWeighted := dst.(graph.WeightedEdgeAdder)
+		b, isUnweighted := dst.(graph.EdgeAdder)
+		switch {
+		case c.Op == SetWeightedEdge && isWeighted:
+			wb.SetWeightedEdge(wb.NewWeightedEdge(u, v, c.Weight))
+		case isUnweighted:
+			b.SetEdge(b.NewEdge(u, v))
+		default:
+			return fmt.Errorf("changelog: cannot apply %v to %T", c.Op, dst)
+		}
+	case RemoveEdge:
+		dst.RemoveEdge(c.U, c.V)
+	default:
+		return fmt.Errorf("changelog: invalid op %d", c.Op)
+	}
+	return nil
+}
+
+// nodeFor returns the node in g with the given ID or a new node
+// with the ID if it is not in g.
+func nodeFor(g graph.Graph, id int64) graph.Node {
+	n := g.Node(id)
+	if n == nil {
+		n = node(id)
+	}
+	return n
+}
+
+// node is a graph.Node created during replay.
+type node int64
+
+func (n node) ID() int64 { return int64(n) }
+
+// WriteTo writes the log to w as a sequence of JSON objects, one per line.
+func (l *Log) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: w}
+	enc := json.NewEncoder(cw)
+	for _, c := range l.changes {
+		err := enc.Encode(c)
+		if err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, nil
+}
+
+// ReadFrom appends the changes read from r, written by WriteTo, to the log.
+func (l *Log) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countReader{r: r}
+	dec := json.NewDecoder(bufio.NewReader(cr))
+	for {
+		var c Change
+		err := dec.Decode(&c)
+		if err == io.EOF {
+			return cr.n, nil
+		}
+		if err != nil {
+			return cr.n, err
+		}
+		err = l.Append(c)
+		if err != nil {
+			return cr.n, err
+		}
+	}
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,231 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package changelog
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// clock returns a function returning successive
+// seconds after epoch.
+func clock() func() time.Time {
+	var t int
+	return func() time.Time {
+		t++
+		return epoch.Add(time.Duration(t) * time.Second)
+	}
+}
+
+// weightedEdges returns the edges of g as sorted
+// from, to and weight triples.
+func weightedEdges(g graph.WeightedDirected) [][3]float64 {
+	var edges [][3]float64
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			w, _ := g.Weight(u.ID(), v.ID())
+			edges = append(edges, [3]float64{float64(u.ID()), float64(v.ID()), w})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i][0] != edges[j][0] {
+			return edges[i][0] < edges[j][0]
+		}
+		return edges[i][1] < edges[j][1]
+	})
+	return edges
+}
+
+func nodeIDs(g graph.Graph) []int64 {
+	var ids []int64
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		ids = append(ids, n.ID())
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+	var log Log
+	r := NewRecorder(simple.NewWeightedDirectedGraph(0, math.Inf(1)), &log)
+	r.Now = clock()
+
+	r.AddNode(simple.Node(0))                                                           // 1s
+	r.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 2})  // 2s
+	r.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 3})  // 3s
+	r.RemoveEdge(0, 1)                                                                  // 4s
+	r.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(0), W: 5})  // 5s
+	r.RemoveNode(1)                                                                     // 6s
+	r.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(3), W: -1}) // 7s
+
+	if log.Len() != 7 {
+		t.Fatalf("unexpected log length: got:%d want:7", log.Len())
+	}
+
+	for _, test := range []struct {
+		at        int
+		wantNodes []int64
+		wantEdges [][3]float64
+	}{
+		{at: 0, wantNodes: nil, wantEdges: nil},
+		{at: 1, wantNodes: []int64{0}, wantEdges: nil},
+		{at: 3, wantNodes: []int64{0, 1, 2}, wantEdges: [][3]float64{{0, 1, 2}, {1, 2, 3}}},
+		{at: 4, wantNodes: []int64{0, 1, 2}, wantEdges: [][3]float64{{1, 2, 3}}},
+		{at: 6, wantNodes: []int64{0, 2}, wantEdges: [][3]float64{{2, 0, 5}}},
+		{at: 7, wantNodes: []int64{0, 2, 3}, wantEdges: [][3]float64{{0, 3, -1}, {2, 0, 5}}},
+	} {
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		err := log.Replay(g, epoch.Add(time.Duration(test.at)*time.Second))
+		if err != nil {
+			t.Errorf("unexpected error replaying to %ds: %v", test.at, err)
+			continue
+		}
+		if got := nodeIDs(g); !reflect.DeepEqual(got, test.wantNodes) {
+			t.Errorf("unexpected nodes at %ds: got:%v want:%v", test.at, got, test.wantNodes)
+		}
+		if got := weightedEdges(g); !reflect.DeepEqual(got, test.wantEdges) {
+			t.Errorf("unexpected edges at %ds: got:%v want:%v", test.at, got, test.wantEdges)
+		}
+	}
+
+	// The final replay must match the recorded graph.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	err := log.Replay(g, epoch.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error replaying log: %v", err)
+	}
+	want := r.Mutable.(graph.WeightedDirected)
+	if !reflect.DeepEqual(nodeIDs(g), nodeIDs(want)) || !reflect.DeepEqual(weightedEdges(g), weightedEdges(want)) {
+		t.Errorf("replayed graph does not match recorded graph")
+	}
+}
+
+func TestReplayUnweighted(t *testing.T) {
+	t.Parallel()
+	var log Log
+	r := NewRecorder(simple.NewUndirectedGraph(), &log)
+	r.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	r.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+
+	// Weighted changes are applied without
+	// weights to an unweighted graph.
+	log.Append(Change{Time: time.Now(), Op: SetWeightedEdge, U: 2, V: 3, Weight: 4})
+
+	g := simple.NewUndirectedGraph()
+	err := log.Replay(g, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error replaying log: %v", err)
+	}
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 3}} {
+		if !g.HasEdgeBetween(e[0], e[1]) {
+			t.Errorf("missing edge %v", e)
+		}
+	}
+
+	// Unweighted changes cannot be applied
+	// to a weighted-only graph.
+	err = log.Replay(simple.NewWeightedUndirectedGraph(0, math.Inf(1)), time.Now())
+	if err == nil {
+		t.Error("expected error replaying unweighted edges into weighted graph")
+	}
+}
+
+func TestLogEncoding(t *testing.T) {
+	t.Parallel()
+	var log Log
+	r := NewRecorder(simple.NewWeightedDirectedGraph(0, math.Inf(1)), &log)
+	r.Now = clock()
+	r.AddNode(simple.Node(5))
+	r.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(5), T: simple.Node(6), W: 0.5})
+	r.RemoveEdge(5, 6)
+	r.RemoveNode(6)
+
+	var buf bytes.Buffer
+	n, err := log.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error writing log: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("unexpected written byte count: got:%d want:%d", n, buf.Len())
+	}
+	text := buf.String()
+
+	var got Log
+	n, err = got.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error reading log: %v", err)
+	}
+	if n != int64(len(text)) {
+		t.Errorf("unexpected read byte count: got:%d want:%d", n, len(text))
+	}
+	if !reflect.DeepEqual(got.Changes(), log.Changes()) {
+		t.Errorf("unexpected round trip:\ngot: %v\nwant:%v\nencoding:\n%s", got.Changes(), log.Changes(), text)
+	}
+
+	_, err = got.ReadFrom(bytes.NewBufferString(`{"time":"2020-01-01T00:00:00Z","op":"Explode","u":1}`))
+	if err == nil {
+		t.Error("expected error reading invalid op")
+	}
+}
+
+func TestLogAppendOrder(t *testing.T) {
+	t.Parallel()
+	var log Log
+	if err := log.Append(Change{Time: epoch.Add(time.Second), Op: AddNode}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := log.Append(Change{Time: epoch, Op: AddNode, U: 1}); err != ErrOutOfOrder {
+		t.Errorf("unexpected error for out of order change: got:%v want:%v", err, ErrOutOfOrder)
+	}
+	if log.Len() != 1 {
+		t.Errorf("unexpected log length after failed append: got:%d want:1", log.Len())
+	}
+}
+
+func TestRecorderOutOfOrder(t *testing.T) {
+	t.Parallel()
+	var log Log
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	r := NewRecorder(g, &log)
+	times := []time.Time{epoch.Add(2 * time.Second), epoch.Add(time.Second), epoch.Add(3 * time.Second)}
+	r.Now = func() time.Time {
+		t := times[0]
+		times = times[1:]
+		return t
+	}
+
+	r.AddNode(simple.Node(0))
+	func() {
+		defer func() {
+			if r := recover(); r != ErrOutOfOrder {
+				t.Errorf("unexpected panic value for out of order change: got:%v want:%v", r, ErrOutOfOrder)
+			}
+		}()
+		r.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 2})
+	}()
+	r.AddNode(simple.Node(2))
+
+	want := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	err := log.Replay(want, epoch.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error replaying log: %v", err)
+	}
+	if !reflect.DeepEqual(nodeIDs(g), []int64{0, 2}) {
+		t.Errorf("unexpected nodes after out of order change: got:%v want:[0 2]", nodeIDs(g))
+	}
+	if !reflect.DeepEqual(nodeIDs(g), nodeIDs(want)) || !reflect.DeepEqual(weightedEdges(g), weightedEdges(want)) {
+		t.Errorf("replayed graph does not match recorded graph after out of order change")
+	}
+}
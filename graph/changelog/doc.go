@@ -0,0 +1,8 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package changelog provides append-only recording of graph mutations
+// and replay of recorded mutations to reconstruct the state of a graph
+// at a point in time.
+package changelog // import "gonum.org/v1/gonum/graph/changelog"
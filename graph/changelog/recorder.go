@@ -0,0 +1,129 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package changelog
+
+import (
+	"time"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Recorder is a mutable graph that records its mutations in a Log. The time
+// of each mutation is checked against the Log before it is applied to the
+// embedded Mutable graph, so a mutation that would be out of order leaves both
+// the graph and the Log unaltered. Graph queries are answered by the embedded
+// graph.
+type Recorder struct {
+	Mutable
+
+	// Log is the destination
+	// of recorded changes.
+	Log *Log
+
+	// Now returns the time of each
+	// recorded change. If Now is nil,
+	// time.Now is used.
+	Now func() time.Time
+}
+
+// NewRecorder returns a new Recorder that applies mutations to g and
+// records them in log.
+func NewRecorder(g Mutable, log *Log) *Recorder {
+	return &Recorder{Mutable: g, Log: log}
+}
+
+// AddNode adds n to the graph and records the change. AddNode
+// panics if the node ID matches an existing node ID.
+func (r *Recorder) AddNode(n graph.Node) {
+	c := r.stamp(Change{Op: AddNode, U: n.ID()})
+	r.Mutable.AddNode(n)
+	r.record(c)
+}
+
+// RemoveNode removes the node with the given ID from the graph,
+// as well as any edges attached to it, and records the change.
+func (r *Recorder) RemoveNode(id int64) {
+	c := r.stamp(Change{Op: RemoveNode, U: id})
+	r.Mutable.RemoveNode(id)
+	r.record(c)
+}
+
+// NewEdge returns a new Edge from the source to the destination node.
+// NewEdge panics if the embedded graph is not a graph.EdgeAdder.
+func (r *Recorder) NewEdge(from, to graph.Node) graph.Edge {
+	return r.edgeAdder().NewEdge(from, to)
+}
+
+// SetEdge adds e to the graph and records the change. SetEdge
+// panics if the embedded graph is not a graph.EdgeAdder.
+func (r *Recorder) SetEdge(e graph.Edge) {
+	b := r.edgeAdder()
+	c := r.stamp(Change{Op: SetEdge, U: e.From().ID(), V: e.To().ID()})
+	b.SetEdge(e)
+	r.record(c)
+}
+
+// NewWeightedEdge returns a new WeightedEdge from the source to the
+// destination node. NewWeightedEdge panics if the embedded graph is
+// not a graph.WeightedEdgeAdder.
+func (r *Recorder) NewWeightedEdge(from, to graph.Node, weight float64) graph.WeightedEdge {
+	return r.weightedEdgeAdder().NewWeightedEdge(from, to, weight)
+}
+
+// SetWeightedEdge adds e to the graph and records the change.
+// SetWeightedEdge panics if the embedded graph is not a
+// graph.WeightedEdgeAdder.
+func (r *Recorder) SetWeightedEdge(e graph.WeightedEdge) {
+	b := r.weightedEdgeAdder()
+	c := r.stamp(Change{Op: SetWeightedEdge, U: e.From().ID(), V: e.To().ID(), Weight: e.Weight()})
+	b.SetWeightedEdge(e)
+	r.record(c)
+}
+
+// RemoveEdge removes the edge with the given end IDs from the graph,
+// leaving the terminal nodes, and records the change.
+func (r *Recorder) RemoveEdge(fid, tid int64) {
+	c := r.stamp(Change{Op: RemoveEdge, U: fid, V: tid})
+	r.Mutable.RemoveEdge(fid, tid)
+	r.record(c)
+}
+
+func (r *Recorder) edgeAdder() graph.EdgeAdder {
+	b, ok := r.Mutable.(graph.EdgeAdder)
+	if !ok {
+		panic("changelog: graph is not an edge adder")
+	}
+	return b
+}
+
+func (r *Recorder) weightedEdgeAdder() graph.WeightedEdgeAdder {
+	b, ok := r.Mutable.(graph.WeightedEdgeAdder)
+	if !ok {
+		panic("changelog: graph is not a weighted edge adder")
+	}
+	return b
+}
+
+// stamp returns c with the current time. stamp panics with
+// ErrOutOfOrder if the time is before the last change in the log.
+func (r *Recorder) stamp(c Change) Change {
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	c.Time = now()
+	if !r.Log.inOrder(c) {
+		panic(ErrOutOfOrder)
+	}
+	return c
+}
+
+// record appends the stamped change c to the log.
+func (r *Recorder) record(c Change) {
+	err := r.Log.Append(c)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Aggregation is a community-level view of a graph. Each community of the
+// aggregated graph is represented by a node of a quotient graph, and the
+// edges between the members of each pair of communities are represented by a
+// single weighted quotient graph edge. An Aggregation of a modularised graph
+// may be expanded to give the aggregation of the subgraph induced by each
+// community at the next lower level of the module clustering, allowing large
+// networks to be explored from the top down.
+type Aggregation struct {
+	g graph.Graph
+
+	// members holds the nodes of g in
+	// each community and communityOf
+	// maps node IDs to communities.
+	members     [][]graph.Node
+	communityOf map[int64]int
+
+	// internal holds the sum of the weights
+	// of edges within each community.
+	internal []float64
+
+	quotient graph.Weighted
+
+	// r and structure are the reduced graph
+	// and indices into r.Communities() for
+	// each community of a hierarchical
+	// aggregation.
+	r         ReducedGraph
+	structure []int
+}
+
+// NewAggregation returns the aggregation of g into the given communities. Each
+// node of g must be in exactly one community. Edge weights of g are summed to
+// give the quotient graph edge weights, with unweighted graphs having unit
+// weight for each edge. NewAggregation will panic if g has a node that is not
+// in a community or is in more than one community.
+func NewAggregation(g graph.Graph, communities [][]graph.Node) *Aggregation {
+	return newAggregation(g, communities, nil, nil)
+}
+
+// NewHierarchicalAggregation returns the aggregation of g into the top level
+// communities of the module clustering r, which must be a modularization of g,
+// such as the value returned by Modularize. The communities of the returned
+// Aggregation may be expanded to the next lower level of the clustering
+// with the Expand method.
+func NewHierarchicalAggregation(g graph.Graph, r ReducedGraph) *Aggregation {
+	structure := make([]int, len(r.Communities()))
+	for i := range structure {
+		structure[i] = i
+	}
+	return newHierarchicalAggregation(g, r, structure)
+}
+
+// newHierarchicalAggregation returns the aggregation of g into the communities
+// of r indexed by structure.
+func newHierarchicalAggregation(g graph.Graph, r ReducedGraph, structure []int) *Aggregation {
+	all := r.Communities()
+	communities := make([][]graph.Node, len(structure))
+	for i, c := range structure {
+		communities[i] = all[c]
+	}
+	return newAggregation(g, communities, r, structure)
+}
+
+// expanded returns the next lower level of the module clustering r,
+// or nil if r is at the lowest level.
+func expanded(r ReducedGraph) ReducedGraph {
+	lower := r.Expanded()
+	switch lower := lower.(type) {
+	case *ReducedUndirected:
+		if lower == nil {
+			return nil
+		}
+	case *ReducedDirected:
+		if lower == nil {
+			return nil
+		}
+	}
+	return lower
+}
+
+func newAggregation(g graph.Graph, communities [][]graph.Node, r ReducedGraph, structure []int) *Aggregation {
+	a := &Aggregation{
+		g:           g,
+		members:     communities,
+		communityOf: make(map[int64]int),
+		internal:    make([]float64, len(communities)),
+		r:           r,
+		structure:   structure,
+	}
+	for c, members := range communities {
+		for _, n := range members {
+			id := n.ID()
+			if _, ok := a.communityOf[id]; ok {
+				panic(fmt.Sprintf("community: node %d in more than one community", id))
+			}
+			a.communityOf[id] = c
+		}
+	}
+
+	_, undirected := g.(graph.Undirected)
+	var q interface {
+		graph.Weighted
+		AddNode(graph.Node)
+		SetWeightedEdge(graph.WeightedEdge)
+	}
+	if undirected {
+		q = simple.NewWeightedUndirectedGraph(0, 0)
+	} else {
+		q = simple.NewWeightedDirectedGraph(0, 0)
+	}
+	for c := range communities {
+		q.AddNode(simple.Node(c))
+	}
+
+	weight := positiveWeightFuncFor(g)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		cu, ok := a.communityOf[uid]
+		if !ok {
+			panic(fmt.Sprintf("community: node %d not in a community", uid))
+		}
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if undirected && vid < uid {
+				continue
+			}
+			w := weight(uid, vid)
+			cv := a.communityOf[vid]
+			if cu == cv {
+				a.internal[cu] += w
+				continue
+			}
+			if e := q.WeightedEdge(int64(cu), int64(cv)); e != nil {
+				w += e.Weight()
+			}
+			q.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(cu), T: simple.Node(cv), W: w})
+		}
+	}
+	a.quotient = q
+
+	return a
+}
+
+// Graph returns the aggregated graph.
+func (a *Aggregation) Graph() graph.Graph { return a.g }
+
+// Len returns the number of communities in the aggregation.
+func (a *Aggregation) Len() int { return len(a.members) }
+
+// Quotient returns the quotient graph of the aggregation. The quotient graph
+// has a node for each community, with node IDs equal to the community index,
+// and an edge between communities with a weight equal to the sum of weights
+// of edges between the members of the communities. The quotient graph is
+// undirected if the aggregated graph is undirected, and directed otherwise.
+// The returned graph should not be mutated.
+func (a *Aggregation) Quotient() graph.Weighted { return a.quotient }
+
+// Members returns the nodes of the aggregated graph in community c.
+// The returned value should not be mutated.
+func (a *Aggregation) Members(c int) []graph.Node { return a.members[c] }
+
+// Internal returns the sum of the weights of edges between members
+// of community c.
+func (a *Aggregation) Internal(c int) float64 { return a.internal[c] }
+
+// CommunityOf returns the community of the node with the given ID,
+// or -1 if the node is not in the aggregated graph.
+func (a *Aggregation) CommunityOf(id int64) int {
+	c, ok := a.communityOf[id]
+	if !ok {
+		return -1
+	}
+	return c
+}
+
+// Subgraph returns the subgraph of the aggregated graph induced by the
+// members of community c. The returned graph holds the nodes and edges
+// of the aggregated graph and is weighted if the aggregated graph is
+// weighted. Weighted subgraphs have self and absent weights of zero
+// and positive infinity.
+func (a *Aggregation) Subgraph(c int) graph.Graph {
+	type builder interface {
+		graph.Graph
+		graph.NodeAdder
+	}
+	var dst builder
+	wg, weighted := a.g.(graph.Weighted)
+	switch a.g.(type) {
+	case graph.Undirected:
+		if weighted {
+			dst = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		} else {
+			dst = simple.NewUndirectedGraph()
+		}
+	default:
+		if weighted {
+			dst = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		} else {
+			dst = simple.NewDirectedGraph()
+		}
+	}
+
+	for _, n := range a.members[c] {
+		dst.AddNode(n)
+	}
+	for _, u := range a.members[c] {
+		uid := u.ID()
+		to := a.g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if a.communityOf[vid] != c {
+				continue
+			}
+			if weighted {
+				dst.(graph.WeightedEdgeAdder).SetWeightedEdge(wg.WeightedEdge(uid, vid))
+			} else {
+				dst.(graph.EdgeAdder).SetEdge(a.g.Edge(uid, vid))
+			}
+		}
+	}
+	return dst
+}
+
+// Expand returns the aggregation of the subgraph induced by community c into
+// the communities of the next lower level of the module clustering used to
+// construct a. Expand returns nil if a was not constructed by
+// NewHierarchicalAggregation or community c is at the lowest level of the
+// clustering.
+func (a *Aggregation) Expand(c int) *Aggregation {
+	if a.r == nil {
+		return nil
+	}
+	lower := expanded(a.r)
+	if lower == nil {
+		return nil
+	}
+	structure := a.r.Structure()[a.structure[c]]
+	next := make([]int, len(structure))
+	for i, n := range structure {
+		next[i] = int(n.ID())
+	}
+	return newHierarchicalAggregation(a.Subgraph(c), lower, next)
+}
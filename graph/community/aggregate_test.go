@@ -0,0 +1,170 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestAggregation(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for u, e := range smallDumbell {
+		if g.Node(int64(u)) == nil {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	a := NewAggregation(g, [][]graph.Node{
+		{simple.Node(0), simple.Node(1), simple.Node(2)},
+		{simple.Node(3), simple.Node(4), simple.Node(5)},
+	})
+
+	if a.Len() != 2 {
+		t.Fatalf("unexpected number of communities: got:%d want:2", a.Len())
+	}
+	for c := 0; c < a.Len(); c++ {
+		if got := a.Internal(c); got != 3 {
+			t.Errorf("unexpected internal weight for community %d: got:%v want:3", c, got)
+		}
+		sub := a.Subgraph(c)
+		if n := sub.Nodes().Len(); n != 3 {
+			t.Errorf("unexpected number of subgraph nodes for community %d: got:%d want:3", c, n)
+		}
+		if n := len(graph.EdgesOf(sub.(*simple.UndirectedGraph).Edges())); n != 3 {
+			t.Errorf("unexpected number of subgraph edges for community %d: got:%d want:3", c, n)
+		}
+		if a.Expand(c) != nil {
+			t.Errorf("unexpected expansion of flat aggregation community %d", c)
+		}
+	}
+	q := a.Quotient()
+	if _, ok := q.(graph.Undirected); !ok {
+		t.Errorf("quotient of undirected graph is not undirected: %T", q)
+	}
+	if w, ok := q.Weight(0, 1); !ok || w != 1 {
+		t.Errorf("unexpected quotient edge weight: got:%v,%t want:1,true", w, ok)
+	}
+	for id, want := range []int{0, 0, 0, 1, 1, 1} {
+		if got := a.CommunityOf(int64(id)); got != want {
+			t.Errorf("unexpected community for node %d: got:%d want:%d", id, got, want)
+		}
+	}
+	if got := a.CommunityOf(-1); got != -1 {
+		t.Errorf("unexpected community for missing node: got:%d want:-1", got)
+	}
+}
+
+func TestAggregationDirected(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, 0)
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 2},
+		{F: simple.Node(0), T: simple.Node(3), W: 3},
+		{F: simple.Node(2), T: simple.Node(3), W: 4},
+		{F: simple.Node(3), T: simple.Node(1), W: 5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	a := NewAggregation(g, [][]graph.Node{
+		{simple.Node(0), simple.Node(1)},
+		{simple.Node(2), simple.Node(3)},
+	})
+	q := a.Quotient()
+	if _, ok := q.(graph.Directed); !ok {
+		t.Fatalf("quotient of directed graph is not directed: %T", q)
+	}
+	for _, test := range []struct {
+		u, v int64
+		want float64
+	}{
+		{u: 0, v: 1, want: 5},
+		{u: 1, v: 0, want: 5},
+	} {
+		if w, _ := q.Weight(test.u, test.v); w != test.want {
+			t.Errorf("unexpected quotient edge weight %d->%d: got:%v want:%v", test.u, test.v, w, test.want)
+		}
+	}
+	if got := a.Internal(0); got != 1 {
+		t.Errorf("unexpected internal weight: got:%v want:1", got)
+	}
+	if got := a.Internal(1); got != 4 {
+		t.Errorf("unexpected internal weight: got:%v want:4", got)
+	}
+	sub := a.Subgraph(1).(graph.Weighted)
+	if w, ok := sub.Weight(2, 3); !ok || w != 4 {
+		t.Errorf("unexpected subgraph edge weight: got:%v,%t want:4,true", w, ok)
+	}
+}
+
+func TestHierarchicalAggregation(t *testing.T) {
+	for _, test := range communityUndirectedQTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		r := Modularize(g, 1, rand.NewSource(1))
+		checkAggregation(t, test.name, NewHierarchicalAggregation(g, r), g)
+	}
+}
+
+// checkAggregation checks that the communities of a partition the nodes of g
+// and that the edge weights of g are preserved by the quotient graph, and
+// recursively checks the expansions of a.
+func checkAggregation(t *testing.T, name string, a *Aggregation, g graph.Graph) {
+	t.Helper()
+	var n int
+	for c := 0; c < a.Len(); c++ {
+		for _, m := range a.Members(c) {
+			if a.CommunityOf(m.ID()) != c {
+				t.Errorf("%q: unexpected community for node %d: got:%d want:%d", name, m.ID(), a.CommunityOf(m.ID()), c)
+			}
+			n++
+		}
+	}
+	if n != g.Nodes().Len() {
+		t.Errorf("%q: communities do not partition graph: got %d nodes want %d", name, n, g.Nodes().Len())
+	}
+
+	var want float64
+	for _, e := range graph.EdgesOf(g.(*simple.UndirectedGraph).Edges()) {
+		if e != nil {
+			want++
+		}
+	}
+	var got float64
+	for c := 0; c < a.Len(); c++ {
+		got += a.Internal(c)
+	}
+	for _, e := range graph.WeightedEdgesOf(a.Quotient().(*simple.WeightedUndirectedGraph).WeightedEdges()) {
+		got += e.Weight()
+	}
+	if got != want {
+		t.Errorf("%q: unexpected total aggregated weight: got:%v want:%v", name, got, want)
+	}
+
+	for c := 0; c < a.Len(); c++ {
+		sub := a.Expand(c)
+		if sub == nil {
+			continue
+		}
+		if sub.Graph().Nodes().Len() != len(a.Members(c)) {
+			t.Errorf("%q: unexpected number of nodes in expansion of community %d: got:%d want:%d",
+				name, c, sub.Graph().Nodes().Len(), len(a.Members(c)))
+		}
+		checkAggregation(t, name, sub, sub.Graph())
+	}
+}
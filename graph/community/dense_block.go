@@ -0,0 +1,211 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// Block is a dense block of a bipartite graph.
+type Block struct {
+	// Nodes holds the nodes of the
+	// block, sorted by ID.
+	Nodes []graph.Node
+
+	// Density is the total suspiciousness
+	// of the edges within the block divided
+	// by the number of nodes in the block.
+	Density float64
+}
+
+// DenseBlocks returns up to n dense blocks of the bipartite graph g, found by
+// Fraudar greedy peeling. The nodes in part form one side of the bipartition of
+// g, typically the users of a user-item interaction graph, so every edge of g
+// must join a node in part to a node not in part. Edge weights are obtained from
+// g if it implements graph.Weighted, otherwise each edge has unit weight.
+//
+// The suspiciousness of an edge is its weight. If camouflage is true, the
+// suspiciousness of each edge is divided by log(d+5), where d is the degree of
+// the end of the edge that is not in part, so edges to popular items count for
+// less. This makes the density of a fraudulent block resistant to camouflage
+// edges added from the block to popular items.
+//
+// Each block is found by repeatedly removing the node whose removal reduces the
+// total suspiciousness of the remaining edges the least, and taking the set of
+// remaining nodes with the greatest density. The edges within the block are then
+// removed before the next block is found. The density of each block is at least
+// half that of the densest block of the remaining edges. Blocks are returned in
+// the order they are found, and fewer than n blocks are returned if no edges
+// remain.
+//
+// DenseBlocks will panic if g has an edge that does not join a node in part to a
+// node not in part, or if g has a negative edge weight.
+//
+// See Hooi et al., "FRAUDAR: bounding graph fraud in the face of camouflage", KDD
+// '16 doi:10.1145/2939672.2939747.
+func DenseBlocks(g graph.Undirected, part []graph.Node, n int, camouflage bool) []Block {
+	inPart := make(set.Int64s, len(part))
+	for _, u := range part {
+		inPart.Add(u.ID())
+	}
+	weight := positiveWeightFuncFor(g)
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+
+	// adj holds the suspiciousness of the
+	// remaining edges of each node.
+	adj := make([]map[int]float64, len(nodes))
+	for i, u := range nodes {
+		uid := u.ID()
+		adj[i] = make(map[int]float64)
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if inPart.Has(uid) == inPart.Has(vid) {
+				panic("community: edge does not cross bipartition")
+			}
+			adj[i][indexOf[vid]] = weight(uid, vid)
+		}
+	}
+	if camouflage {
+		for i, u := range nodes {
+			if inPart.Has(u.ID()) {
+				continue
+			}
+			c := 1 / math.Log(float64(len(adj[i]))+5)
+			for j := range adj[i] {
+				adj[i][j] *= c
+				adj[j][i] *= c
+			}
+		}
+	}
+
+	var blocks []Block
+	for len(blocks) < n {
+		block, density := peel(adj)
+		if len(block) == 0 {
+			break
+		}
+		b := Block{Nodes: make([]graph.Node, len(block)), Density: density}
+		for i, k := range block {
+			b.Nodes[i] = nodes[k]
+		}
+		sort.Sort(ordered.ByID(b.Nodes))
+		blocks = append(blocks, b)
+
+		// Remove the edges within the block.
+		for _, i := range block {
+			for _, j := range block {
+				delete(adj[i], j)
+			}
+		}
+	}
+	return blocks
+}
+
+// peel returns the indices of the nodes of the densest set found by greedy
+// peeling of the weighted graph with adjacency adj, and its density. Only
+// nodes with edges are considered.
+func peel(adj []map[int]float64) (block []int, density float64) {
+	var (
+		f        float64
+		priority = make([]float64, len(adj))
+		queue    peelQueue
+	)
+	for i, to := range adj {
+		if len(to) == 0 {
+			continue
+		}
+		for _, w := range to {
+			priority[i] += w
+		}
+		f += priority[i]
+		queue = append(queue, peelItem{node: i, priority: priority[i]})
+	}
+	if len(queue) == 0 {
+		return nil, 0
+	}
+	// Each edge was counted from both ends.
+	f /= 2
+	heap.Init(&queue)
+
+	size := len(queue)
+	best := f / float64(size)
+	bestRemoved := 0
+	removed := make([]bool, len(adj))
+	var order []int
+	for size > 1 {
+		it := heap.Pop(&queue).(peelItem)
+		u := it.node
+		if removed[u] || it.priority != priority[u] {
+			continue
+		}
+		removed[u] = true
+		order = append(order, u)
+		size--
+		f -= priority[u]
+		for v, w := range adj[u] {
+			if removed[v] {
+				continue
+			}
+			priority[v] -= w
+			heap.Push(&queue, peelItem{node: v, priority: priority[v]})
+		}
+		if d := f / float64(size); d > best {
+			best = d
+			bestRemoved = len(order)
+		}
+	}
+
+	for i := range removed {
+		removed[i] = false
+	}
+	for _, u := range order[:bestRemoved] {
+		removed[u] = true
+	}
+	for i, to := range adj {
+		if len(to) != 0 && !removed[i] {
+			block = append(block, i)
+		}
+	}
+	return block, best
+}
+
+// peelItem is a node index and its current priority.
+type peelItem struct {
+	node     int
+	priority float64
+}
+
+// peelQueue is a min-heap of peelItems.
+type peelQueue []peelItem
+
+func (q peelQueue) Len() int { return len(q) }
+func (q peelQueue) Less(i, j int) bool {
+	if q[i].priority == q[j].priority {
+		return q[i].node < q[j].node
+	}
+	return q[i].priority < q[j].priority
+}
+func (q peelQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *peelQueue) Push(x interface{}) { *q = append(*q, x.(peelItem)) }
+func (q *peelQueue) Pop() interface{} {
+	old := *q
+	n := len(old) - 1
+	x := old[n]
+	*q = old[:n]
+	return x
+}
@@ -0,0 +1,128 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDenseBlocksComplete(t *testing.T) {
+	// The complete bipartite graph K_{2,3} is
+	// its own densest block.
+	g := simple.NewUndirectedGraph()
+	part := []graph.Node{simple.Node(0), simple.Node(1)}
+	for _, u := range part {
+		for v := 2; v < 5; v++ {
+			g.SetEdge(simple.Edge{F: u, T: simple.Node(v)})
+		}
+	}
+	blocks := DenseBlocks(g, part, 3, false)
+	if len(blocks) != 1 {
+		t.Fatalf("unexpected number of blocks: got:%d want:1", len(blocks))
+	}
+	if got := nodeIDs(blocks[0].Nodes); !reflect.DeepEqual(got, []int64{0, 1, 2, 3, 4}) {
+		t.Errorf("unexpected block: got:%v want:[0 1 2 3 4]", got)
+	}
+	if !scalar.EqualWithinAbsOrRel(blocks[0].Density, 6.0/5, 1e-10, 1e-10) {
+		t.Errorf("unexpected block density: got:%v want:%v", blocks[0].Density, 6.0/5)
+	}
+}
+
+func TestDenseBlocksInjected(t *testing.T) {
+	const (
+		users = 200
+		items = 200
+	)
+	for _, camouflage := range []bool{false, true} {
+		rnd := rand.New(rand.NewSource(1))
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		part := make([]graph.Node, users)
+		for i := range part {
+			part[i] = simple.Node(i)
+			g.AddNode(part[i])
+		}
+		item := func(i int) graph.Node { return simple.Node(users + i) }
+
+		// Sparse background interactions.
+		for i := 0; i < 600; i++ {
+			u, v := part[rnd.Intn(users)], item(rnd.Intn(items))
+			g.SetWeightedEdge(simple.WeightedEdge{F: u, T: v, W: 1})
+		}
+		// Popular items.
+		for i := 0; i < users; i += 2 {
+			g.SetWeightedEdge(simple.WeightedEdge{F: part[i], T: item(0), W: 1})
+		}
+
+		// Two injected blocks of fraudulent
+		// users rating fraudulent items. The
+		// users of the first block also add
+		// camouflage ratings of the popular item.
+		var want [][]int64
+		for k, b := range []struct{ users, items []int }{
+			{users: []int{10, 11, 12, 13, 14, 15}, items: []int{50, 51, 52, 53, 54}},
+			{users: []int{100, 101, 102, 103}, items: []int{150, 151, 152}},
+		} {
+			var ids []int64
+			for _, u := range b.users {
+				ids = append(ids, int64(u))
+				for _, v := range b.items {
+					g.SetWeightedEdge(simple.WeightedEdge{F: part[u], T: item(v), W: 2})
+				}
+				if k == 0 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: part[u], T: item(0), W: 1})
+				}
+			}
+			if k == 0 && !camouflage {
+				// Without camouflage resistance the popular
+				// item is absorbed into the first block.
+				ids = append(ids, item(0).ID())
+			}
+			for _, v := range b.items {
+				ids = append(ids, item(v).ID())
+			}
+			want = append(want, ids)
+		}
+
+		blocks := DenseBlocks(g, part, 2, camouflage)
+		if len(blocks) != 2 {
+			t.Fatalf("unexpected number of blocks with camouflage=%t: got:%d want:2", camouflage, len(blocks))
+		}
+		for i, b := range blocks {
+			if got := nodeIDs(b.Nodes); !reflect.DeepEqual(got, want[i]) {
+				t.Errorf("unexpected block %d with camouflage=%t: got:%v want:%v", i, camouflage, got, want[i])
+			}
+		}
+		if blocks[0].Density < blocks[1].Density {
+			t.Errorf("unexpected block density order with camouflage=%t: %v < %v",
+				camouflage, blocks[0].Density, blocks[1].Density)
+		}
+	}
+}
+
+func TestDenseBlocksNotBipartite(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for edge within part")
+		}
+	}()
+	DenseBlocks(g, []graph.Node{simple.Node(0), simple.Node(1)}, 1, false)
+}
+
+func nodeIDs(nodes []graph.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
@@ -0,0 +1,178 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Comparison is a report of the differences between two graphs, a and b,
+// returned by Compare. Node and edge differences are sorted by node ID.
+type Comparison struct {
+	// MissingNodes holds the IDs of nodes in a
+	// that are not in b, and ExtraNodes holds the
+	// IDs of nodes in b that are not in a.
+	MissingNodes, ExtraNodes []int64
+
+	// MissingEdges holds the from and to node IDs
+	// of edges in a that are not in b, and ExtraEdges
+	// holds the IDs of edges in b that are not in a.
+	MissingEdges, ExtraEdges [][2]int64
+
+	// Weights holds the edges in both a and b with
+	// weights that differ by more than the tolerance
+	// used for the comparison.
+	Weights []WeightDifference
+}
+
+// WeightDifference is a difference between the weights of an edge in two graphs.
+type WeightDifference struct {
+	// From and To are the IDs of the
+	// end nodes of the edge.
+	From, To int64
+
+	// A and B are the weights of
+	// the edge in a and b.
+	A, B float64
+}
+
+// Equal returns whether the comparison found no differences.
+func (c Comparison) Equal() bool {
+	return len(c.MissingNodes) == 0 && len(c.ExtraNodes) == 0 &&
+		len(c.MissingEdges) == 0 && len(c.ExtraEdges) == 0 &&
+		len(c.Weights) == 0
+}
+
+// String returns a description of the differences found by the comparison,
+// with one difference per line.
+func (c Comparison) String() string {
+	if c.Equal() {
+		return "no differences"
+	}
+	var buf strings.Builder
+	for _, id := range c.MissingNodes {
+		fmt.Fprintf(&buf, "- node %d\n", id)
+	}
+	for _, id := range c.ExtraNodes {
+		fmt.Fprintf(&buf, "+ node %d\n", id)
+	}
+	for _, e := range c.MissingEdges {
+		fmt.Fprintf(&buf, "- edge %d->%d\n", e[0], e[1])
+	}
+	for _, e := range c.ExtraEdges {
+		fmt.Fprintf(&buf, "+ edge %d->%d\n", e[0], e[1])
+	}
+	for _, w := range c.Weights {
+		fmt.Fprintf(&buf, "~ edge %d->%d weight %v != %v\n", w.From, w.To, w.A, w.B)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Compare returns a report of the differences between the nodes and edges of
+// the graphs a and b. Nodes and edges are matched by node ID. If both a and b
+// are undirected, each edge is reported once with the lower node ID first,
+// otherwise edges are compared as the directed edges reachable by the From
+// methods of the graphs. If both a and b implement Weighted, the weights of
+// edges in both graphs are compared and reported if they differ by more than
+// tol. Weights that are both NaN or that are equal infinities do not differ.
+func Compare(a, b Graph, tol float64) Comparison {
+	var c Comparison
+
+	_, aUndirected := a.(Undirected)
+	_, bUndirected := b.(Undirected)
+	undirected := aUndirected && bUndirected
+	aw, aWeighted := a.(Weighted)
+	bw, bWeighted := b.(Weighted)
+	weighted := aWeighted && bWeighted
+
+	nodes := a.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		if b.Node(uid) == nil {
+			c.MissingNodes = append(c.MissingNodes, uid)
+		}
+		to := a.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if undirected && vid < uid {
+				continue
+			}
+			if b.Edge(uid, vid) == nil {
+				c.MissingEdges = append(c.MissingEdges, [2]int64{uid, vid})
+				continue
+			}
+			if !weighted {
+				continue
+			}
+			wa, _ := aw.Weight(uid, vid)
+			wb, _ := bw.Weight(uid, vid)
+			if weightsDiffer(wa, wb, tol) {
+				c.Weights = append(c.Weights, WeightDifference{From: uid, To: vid, A: wa, B: wb})
+			}
+		}
+	}
+	nodes = b.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		if a.Node(uid) == nil {
+			c.ExtraNodes = append(c.ExtraNodes, uid)
+		}
+		to := b.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if undirected && vid < uid {
+				continue
+			}
+			if a.Edge(uid, vid) == nil {
+				c.ExtraEdges = append(c.ExtraEdges, [2]int64{uid, vid})
+			}
+		}
+	}
+
+	sortIDs(c.MissingNodes)
+	sortIDs(c.ExtraNodes)
+	sortIDPairs(c.MissingEdges)
+	sortIDPairs(c.ExtraEdges)
+	sort.Slice(c.Weights, func(i, j int) bool {
+		return pairLess(
+			[2]int64{c.Weights[i].From, c.Weights[i].To},
+			[2]int64{c.Weights[j].From, c.Weights[j].To},
+		)
+	})
+
+	return c
+}
+
+// Equal returns whether the graphs a and b have the same nodes and edges, and
+// edge weights that differ by no more than tol, as determined by Compare.
+func Equal(a, b Graph, tol float64) bool {
+	return Compare(a, b, tol).Equal()
+}
+
+// weightsDiffer returns whether the weights a and b differ by more than tol.
+func weightsDiffer(a, b, tol float64) bool {
+	if a == b || (math.IsNaN(a) && math.IsNaN(b)) {
+		return false
+	}
+	return !(math.Abs(a-b) <= tol)
+}
+
+func sortIDs(ids []int64) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
+
+func sortIDPairs(pairs [][2]int64) {
+	sort.Slice(pairs, func(i, j int) bool { return pairLess(pairs[i], pairs[j]) })
+}
+
+func pairLess(a, b [2]int64) bool {
+	if a[0] != b[0] {
+		return a[0] < b[0]
+	}
+	return a[1] < b[1]
+}
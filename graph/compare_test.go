@@ -0,0 +1,97 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func weightedDirected(edges ...simple.WeightedEdge) *simple.WeightedDirectedGraph {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range edges {
+		g.SetWeightedEdge(e)
+	}
+	return g
+}
+
+func TestCompare(t *testing.T) {
+	a := weightedDirected(
+		simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1},
+		simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 2},
+		simple.WeightedEdge{F: simple.Node(2), T: simple.Node(3), W: 3},
+		simple.WeightedEdge{F: simple.Node(3), T: simple.Node(0), W: math.NaN()},
+	)
+	b := weightedDirected(
+		simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1.05},
+		simple.WeightedEdge{F: simple.Node(1), T: simple.Node(2), W: 2.5},
+		simple.WeightedEdge{F: simple.Node(2), T: simple.Node(1), W: 3},
+		simple.WeightedEdge{F: simple.Node(3), T: simple.Node(0), W: math.NaN()},
+		simple.WeightedEdge{F: simple.Node(4), T: simple.Node(0), W: 1},
+	)
+	b.RemoveEdge(2, 3)
+	a.AddNode(simple.Node(5))
+
+	got := graph.Compare(a, b, 0.1)
+	want := graph.Comparison{
+		MissingNodes: []int64{5},
+		ExtraNodes:   []int64{4},
+		MissingEdges: [][2]int64{{2, 3}},
+		ExtraEdges:   [][2]int64{{2, 1}, {4, 0}},
+		Weights:      []graph.WeightDifference{{From: 1, To: 2, A: 2, B: 2.5}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected comparison:\ngot: %+v\nwant:%+v", got, want)
+	}
+	if got.Equal() {
+		t.Error("unexpected equality of different graphs")
+	}
+	wantString := `- node 5
++ node 4
+- edge 2->3
++ edge 2->1
++ edge 4->0
+~ edge 1->2 weight 2 != 2.5`
+	if got.String() != wantString {
+		t.Errorf("unexpected report:\ngot:\n%s\nwant:\n%s", got, wantString)
+	}
+
+	if !graph.Equal(a, a, 0) {
+		t.Error("unexpected inequality of graph with itself")
+	}
+	if got := graph.Compare(a, a, 0).String(); got != "no differences" {
+		t.Errorf("unexpected report for equal graphs: %q", got)
+	}
+}
+
+func TestCompareUndirected(t *testing.T) {
+	a := simple.NewUndirectedGraph()
+	b := simple.NewUndirectedGraph()
+	a.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	b.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(0)})
+	a.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(1)})
+	b.AddNode(simple.Node(2))
+
+	got := graph.Compare(a, b, 0)
+	want := graph.Comparison{MissingEdges: [][2]int64{{1, 2}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected comparison:\ngot: %+v\nwant:%+v", got, want)
+	}
+
+	// An undirected graph compared with a directed graph
+	// is compared as a graph with edges in both directions.
+	d := simple.NewDirectedGraph()
+	d.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	d.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(0)})
+	d.AddNode(simple.Node(2))
+	got = graph.Compare(b, d, 0)
+	if !got.Equal() {
+		t.Errorf("unexpected differences between undirected and directed graphs:\n%s", got)
+	}
+}
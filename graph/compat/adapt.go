@@ -0,0 +1,364 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package compat
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// CurrentNode is a legacy Node adapted to the graph.Node interface.
+type CurrentNode struct {
+	Node
+}
+
+// ID returns the ID of the legacy node.
+func (n CurrentNode) ID() int64 { return int64(n.Node.ID()) }
+
+// LegacyNode is a graph.Node adapted to the legacy Node interface.
+type LegacyNode struct {
+	graph.Node
+}
+
+// ID returns the ID of the node. ID will panic if the ID
+// of the node cannot be represented by an int.
+func (n LegacyNode) ID() int {
+	id := n.Node.ID()
+	if int64(int(id)) != id {
+		panic(fmt.Sprintf("compat: node ID %d out of range", id))
+	}
+	return int(id)
+}
+
+// current returns n as a graph.Node, unwrapping LegacyNodes.
+func current(n Node) graph.Node {
+	if n, ok := n.(LegacyNode); ok {
+		return n.Node
+	}
+	return CurrentNode{n}
+}
+
+// legacy returns n as a legacy Node, unwrapping CurrentNodes.
+func legacy(n graph.Node) Node {
+	if n, ok := n.(CurrentNode); ok {
+		return n.Node
+	}
+	return LegacyNode{n}
+}
+
+// FromLegacy returns the legacy graph g adapted to the graph.Graph and
+// graph.Weighted interfaces. If g is a Directed or Undirected, the returned
+// graph is a graph.WeightedDirected or graph.WeightedUndirected respectively.
+//
+// Edge weights are obtained from g if it is a Weighter. Otherwise the weight
+// of an edge is the weight held by the legacy edge, the weight between a node
+// and itself is zero, and the weight between unconnected nodes is +Inf.
+//
+// Legacy graphs can only be queried by node, so the returned graph holds an
+// index of the nodes of g by ID that is built when g is adapted. Nodes must
+// not be added to or removed from g after it is adapted.
+func FromLegacy(g Graph) graph.Graph {
+	f := &fromLegacy{g: g, nodes: make(map[int64]Node)}
+	for _, n := range g.Nodes() {
+		f.nodes[int64(n.ID())] = n
+	}
+	switch g := g.(type) {
+	case Directed:
+		return fromLegacyDirected{fromLegacy: f, g: g}
+	case Undirected:
+		return fromLegacyUndirected{fromLegacy: f, g: g}
+	default:
+		return f
+	}
+}
+
+// fromLegacy adapts a legacy Graph to graph.Graph and graph.Weighted.
+type fromLegacy struct {
+	g     Graph
+	nodes map[int64]Node
+}
+
+// lookup returns the legacy node with the given ID, or nil
+// if it is not in the graph.
+func (g *fromLegacy) lookup(id int64) Node {
+	return g.nodes[id]
+}
+
+// nodesOf returns the legacy nodes as a graph.Nodes.
+func nodesOf(nodes []Node) graph.Nodes {
+	if len(nodes) == 0 {
+		return graph.Empty
+	}
+	c := make([]graph.Node, len(nodes))
+	for i, n := range nodes {
+		c[i] = current(n)
+	}
+	return iterator.NewOrderedNodes(c)
+}
+
+// Node returns the node with the given ID if it exists in the graph,
+// and nil otherwise.
+func (g *fromLegacy) Node(id int64) graph.Node {
+	n := g.lookup(id)
+	if n == nil {
+		return nil
+	}
+	return current(n)
+}
+
+// Nodes returns all the nodes in the graph.
+func (g *fromLegacy) Nodes() graph.Nodes {
+	return nodesOf(g.g.Nodes())
+}
+
+// From returns all nodes that can be reached directly from the node
+// with the given ID.
+func (g *fromLegacy) From(id int64) graph.Nodes {
+	u := g.lookup(id)
+	if u == nil {
+		return graph.Empty
+	}
+	return nodesOf(g.g.From(u))
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes with IDs
+// xid and yid without considering direction.
+func (g *fromLegacy) HasEdgeBetween(xid, yid int64) bool {
+	x, y := g.lookup(xid), g.lookup(yid)
+	if x == nil || y == nil {
+		return false
+	}
+	return g.g.HasEdgeBetween(x, y)
+}
+
+// Edge returns the edge from u to v, with IDs uid and vid, if such an
+// edge exists and nil otherwise.
+func (g *fromLegacy) Edge(uid, vid int64) graph.Edge {
+	return g.WeightedEdge(uid, vid)
+}
+
+// WeightedEdge returns the weighted edge from u to v, with IDs uid and
+// vid, if such an edge exists and nil otherwise.
+func (g *fromLegacy) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	u, v := g.lookup(uid), g.lookup(vid)
+	if u == nil || v == nil {
+		return nil
+	}
+	return currentEdge(g.g.Edge(u, v))
+}
+
+// currentEdge returns the legacy edge e as a graph.WeightedEdge.
+func currentEdge(e Edge) graph.WeightedEdge {
+	if e == nil {
+		return nil
+	}
+	return simple.WeightedEdge{F: current(e.From()), T: current(e.To()), W: e.Weight()}
+}
+
+// Weight returns the weight for the edge between x and y, with IDs xid
+// and yid, if Edge(xid, yid) returns a non-nil Edge.
+func (g *fromLegacy) Weight(xid, yid int64) (w float64, ok bool) {
+	x, y := g.lookup(xid), g.lookup(yid)
+	if wg, isWeighter := g.g.(Weighter); isWeighter && x != nil && y != nil {
+		return wg.Weight(x, y)
+	}
+	if xid == yid && x != nil {
+		return 0, true
+	}
+	if x == nil || y == nil {
+		return math.Inf(1), false
+	}
+	e := g.g.Edge(x, y)
+	if e == nil {
+		return math.Inf(1), false
+	}
+	return e.Weight(), true
+}
+
+// fromLegacyDirected adapts a legacy Directed to graph.WeightedDirected.
+type fromLegacyDirected struct {
+	*fromLegacy
+	g Directed
+}
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v
+// with IDs uid and vid.
+func (g fromLegacyDirected) HasEdgeFromTo(uid, vid int64) bool {
+	u, v := g.lookup(uid), g.lookup(vid)
+	if u == nil || v == nil {
+		return false
+	}
+	return g.g.HasEdgeFromTo(u, v)
+}
+
+// To returns all nodes that can reach directly to the node with the
+// given ID.
+func (g fromLegacyDirected) To(id int64) graph.Nodes {
+	v := g.lookup(id)
+	if v == nil {
+		return graph.Empty
+	}
+	return nodesOf(g.g.To(v))
+}
+
+// fromLegacyUndirected adapts a legacy Undirected to graph.WeightedUndirected.
+type fromLegacyUndirected struct {
+	*fromLegacy
+	g Undirected
+}
+
+// EdgeBetween returns the edge between nodes x and y with IDs xid and yid.
+func (g fromLegacyUndirected) EdgeBetween(xid, yid int64) graph.Edge {
+	return g.WeightedEdgeBetween(xid, yid)
+}
+
+// WeightedEdgeBetween returns the weighted edge between nodes x and y
+// with IDs xid and yid.
+func (g fromLegacyUndirected) WeightedEdgeBetween(xid, yid int64) graph.WeightedEdge {
+	x, y := g.lookup(xid), g.lookup(yid)
+	if x == nil || y == nil {
+		return nil
+	}
+	return currentEdge(g.g.EdgeBetween(x, y))
+}
+
+// ToLegacy returns the graph g adapted to the legacy Graph and Weighter
+// interfaces. If g is a graph.Directed or graph.Undirected, the returned
+// graph is a Directed or Undirected respectively.
+//
+// Edge weights are obtained from g if it implements graph.Weighted. Otherwise
+// the weight of an edge is the weight of the edge if it is a graph.WeightedEdge
+// and one if not, the weight between a node and itself is zero, and the weight
+// between unconnected nodes is +Inf.
+func ToLegacy(g graph.Graph) Graph {
+	t := toLegacy{g: g}
+	if wg, ok := g.(graph.Weighted); ok {
+		t.weight = wg.Weight
+	} else {
+		t.weight = path.UniformCost(g)
+	}
+	switch g := g.(type) {
+	case graph.Directed:
+		return toLegacyDirected{toLegacy: t, g: g}
+	case graph.Undirected:
+		return toLegacyUndirected{toLegacy: t, g: g}
+	default:
+		return t
+	}
+}
+
+// toLegacy adapts a graph.Graph to the legacy Graph and Weighter.
+type toLegacy struct {
+	g      graph.Graph
+	weight path.Weighting
+}
+
+// legacyNodes returns the nodes in it as legacy nodes.
+func legacyNodes(it graph.Nodes) []Node {
+	var nodes []Node
+	for it.Next() {
+		nodes = append(nodes, legacy(it.Node()))
+	}
+	return nodes
+}
+
+// Has returns whether the node exists within the graph.
+func (g toLegacy) Has(n Node) bool {
+	return g.g.Node(current(n).ID()) != nil
+}
+
+// Nodes returns all the nodes in the graph.
+func (g toLegacy) Nodes() []Node {
+	return legacyNodes(g.g.Nodes())
+}
+
+// From returns all nodes that can be reached directly from the given node.
+func (g toLegacy) From(n Node) []Node {
+	return legacyNodes(g.g.From(current(n).ID()))
+}
+
+// HasEdgeBetween returns whether an edge exists between nodes x and y
+// without considering direction.
+func (g toLegacy) HasEdgeBetween(x, y Node) bool {
+	return g.g.HasEdgeBetween(current(x).ID(), current(y).ID())
+}
+
+// Edge returns the edge from u to v if such an edge exists and nil otherwise.
+func (g toLegacy) Edge(u, v Node) Edge {
+	return g.legacyEdge(g.g.Edge(current(u).ID(), current(v).ID()))
+}
+
+// legacyEdge returns e as a legacy Edge.
+func (g toLegacy) legacyEdge(e graph.Edge) Edge {
+	if e == nil {
+		return nil
+	}
+	from, to := e.From(), e.To()
+	var w float64
+	if _, ok := g.g.(graph.Weighted); ok {
+		w, _ = g.weight(from.ID(), to.ID())
+	} else if we, ok := e.(graph.WeightedEdge); ok {
+		w = we.Weight()
+	} else {
+		w = 1
+	}
+	return legacyEdge{from: legacy(from), to: legacy(to), weight: w}
+}
+
+// Weight returns the weight for the edge between x and y if Edge(x, y)
+// returns a non-nil Edge.
+func (g toLegacy) Weight(x, y Node) (w float64, ok bool) {
+	xid, yid := current(x).ID(), current(y).ID()
+	if _, isWeighted := g.g.(graph.Weighted); !isWeighted && xid != yid {
+		e := g.Edge(x, y)
+		if e == nil {
+			return math.Inf(1), false
+		}
+		return e.Weight(), true
+	}
+	return g.weight(xid, yid)
+}
+
+// toLegacyDirected adapts a graph.Directed to the legacy Directed.
+type toLegacyDirected struct {
+	toLegacy
+	g graph.Directed
+}
+
+// HasEdgeFromTo returns whether an edge exists in the graph from u to v.
+func (g toLegacyDirected) HasEdgeFromTo(u, v Node) bool {
+	return g.g.HasEdgeFromTo(current(u).ID(), current(v).ID())
+}
+
+// To returns all nodes that can reach directly to the given node.
+func (g toLegacyDirected) To(n Node) []Node {
+	return legacyNodes(g.g.To(current(n).ID()))
+}
+
+// toLegacyUndirected adapts a graph.Undirected to the legacy Undirected.
+type toLegacyUndirected struct {
+	toLegacy
+	g graph.Undirected
+}
+
+// EdgeBetween returns the edge between nodes x and y.
+func (g toLegacyUndirected) EdgeBetween(x, y Node) Edge {
+	return g.legacyEdge(g.g.EdgeBetween(current(x).ID(), current(y).ID()))
+}
+
+// legacyEdge is a legacy Edge.
+type legacyEdge struct {
+	from, to Node
+	weight   float64
+}
+
+func (e legacyEdge) From() Node      { return e.from }
+func (e legacyEdge) To() Node        { return e.to }
+func (e legacyEdge) Weight() float64 { return e.weight }
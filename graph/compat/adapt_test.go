@@ -0,0 +1,265 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package compat_test
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/compat"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/testgraph"
+)
+
+// roundTripBuilder returns a testgraph.Builder that builds a weighted simple graph
+// and adapts it to the legacy interfaces and back.
+func roundTripBuilder(directed bool) testgraph.Builder {
+	return func(nodes []graph.Node, edges []testgraph.WeightedLine, self, absent float64) (g graph.Graph, n []graph.Node, e []testgraph.Edge, s, a float64, ok bool) {
+		seen := set.NewNodes()
+		var sg interface {
+			graph.Graph
+			AddNode(graph.Node)
+			SetWeightedEdge(graph.WeightedEdge)
+		}
+		if directed {
+			sg = simple.NewWeightedDirectedGraph(self, absent)
+		} else {
+			sg = simple.NewWeightedUndirectedGraph(self, absent)
+		}
+		for _, n := range nodes {
+			seen.Add(n)
+			sg.AddNode(n)
+		}
+		for _, edge := range edges {
+			if edge.From().ID() == edge.To().ID() {
+				continue
+			}
+			f := sg.Node(edge.From().ID())
+			if f == nil {
+				f = edge.From()
+			}
+			t := sg.Node(edge.To().ID())
+			if t == nil {
+				t = edge.To()
+			}
+			ce := simple.WeightedEdge{F: f, T: t, W: edge.Weight()}
+			seen.Add(ce.F)
+			seen.Add(ce.T)
+			e = append(e, ce)
+			sg.SetWeightedEdge(ce)
+		}
+		if len(e) == 0 && len(edges) != 0 {
+			return nil, nil, nil, math.NaN(), math.NaN(), false
+		}
+		if len(seen) != 0 {
+			n = make([]graph.Node, 0, len(seen))
+		}
+		for _, sn := range seen {
+			n = append(n, sn)
+		}
+		return compat.FromLegacy(compat.ToLegacy(sg)), n, e, self, absent, true
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		directed bool
+	}{
+		{name: "directed", directed: true},
+		{name: "undirected", directed: false},
+	} {
+		b := roundTripBuilder(test.directed)
+		t.Run(test.name+"/EdgeExistence", func(t *testing.T) {
+			testgraph.EdgeExistence(t, b)
+		})
+		t.Run(test.name+"/NodeExistence", func(t *testing.T) {
+			testgraph.NodeExistence(t, b)
+		})
+		t.Run(test.name+"/ReturnAdjacentNodes", func(t *testing.T) {
+			testgraph.ReturnAdjacentNodes(t, b, true)
+		})
+		t.Run(test.name+"/ReturnAllNodes", func(t *testing.T) {
+			testgraph.ReturnAllNodes(t, b, true)
+		})
+		t.Run(test.name+"/ReturnNodeSlice", func(t *testing.T) {
+			testgraph.ReturnNodeSlice(t, b, true)
+		})
+		t.Run(test.name+"/Weight", func(t *testing.T) {
+			testgraph.Weight(t, b)
+		})
+	}
+}
+
+// legacyGraph is a minimal legacy directed graph.
+type legacyGraph struct {
+	nodes map[int]compat.Node
+	from  map[int]map[int]legacyEdge
+}
+
+type legacyNode int
+
+func (n legacyNode) ID() int { return int(n) }
+
+type legacyEdge struct {
+	f, t compat.Node
+	w    float64
+}
+
+func (e legacyEdge) From() compat.Node { return e.f }
+func (e legacyEdge) To() compat.Node   { return e.t }
+func (e legacyEdge) Weight() float64   { return e.w }
+
+func newLegacyGraph(edges []legacyEdge) *legacyGraph {
+	g := &legacyGraph{nodes: make(map[int]compat.Node), from: make(map[int]map[int]legacyEdge)}
+	for _, e := range edges {
+		g.nodes[e.f.ID()] = e.f
+		g.nodes[e.t.ID()] = e.t
+		if g.from[e.f.ID()] == nil {
+			g.from[e.f.ID()] = make(map[int]legacyEdge)
+		}
+		g.from[e.f.ID()][e.t.ID()] = e
+	}
+	return g
+}
+
+func (g *legacyGraph) Has(n compat.Node) bool {
+	_, ok := g.nodes[n.ID()]
+	return ok
+}
+func (g *legacyGraph) Nodes() []compat.Node {
+	var nodes []compat.Node
+	for _, n := range g.nodes {
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+func (g *legacyGraph) From(n compat.Node) []compat.Node {
+	var nodes []compat.Node
+	for id := range g.from[n.ID()] {
+		nodes = append(nodes, g.nodes[id])
+	}
+	return nodes
+}
+func (g *legacyGraph) To(n compat.Node) []compat.Node {
+	var nodes []compat.Node
+	for id, to := range g.from {
+		if _, ok := to[n.ID()]; ok {
+			nodes = append(nodes, g.nodes[id])
+		}
+	}
+	return nodes
+}
+func (g *legacyGraph) HasEdgeBetween(x, y compat.Node) bool {
+	return g.HasEdgeFromTo(x, y) || g.HasEdgeFromTo(y, x)
+}
+func (g *legacyGraph) HasEdgeFromTo(u, v compat.Node) bool {
+	_, ok := g.from[u.ID()][v.ID()]
+	return ok
+}
+func (g *legacyGraph) Edge(u, v compat.Node) compat.Edge {
+	e, ok := g.from[u.ID()][v.ID()]
+	if !ok {
+		return nil
+	}
+	return e
+}
+
+func TestFromLegacy(t *testing.T) {
+	lg := newLegacyGraph([]legacyEdge{
+		{f: legacyNode(0), t: legacyNode(1), w: 1},
+		{f: legacyNode(1), t: legacyNode(2), w: 1},
+		{f: legacyNode(0), t: legacyNode(2), w: 3},
+		{f: legacyNode(2), t: legacyNode(3), w: 2},
+	})
+	g := compat.FromLegacy(lg)
+	dg, ok := g.(graph.WeightedDirected)
+	if !ok {
+		t.Fatalf("adapted legacy directed graph is not a graph.WeightedDirected: %T", g)
+	}
+
+	pt := path.DijkstraFrom(dg.Node(0), dg)
+	p, weight := pt.To(3)
+	var got []int64
+	for _, n := range p {
+		got = append(got, n.ID())
+	}
+	if want := []int64{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected shortest path: got:%v want:%v", got, want)
+	}
+	if weight != 4 {
+		t.Errorf("unexpected shortest path weight: got:%v want:4", weight)
+	}
+
+	to := graph.NodesOf(dg.To(2))
+	ids := make([]int64, len(to))
+	for i, n := range to {
+		ids[i] = n.ID()
+		if _, ok := n.(compat.CurrentNode); !ok {
+			t.Errorf("unexpected node type: %T", n)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if want := []int64{0, 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("unexpected nodes to 2: got:%v want:%v", ids, want)
+	}
+	if w, ok := dg.Weight(0, 0); w != 0 || !ok {
+		t.Errorf("unexpected self weight: got:(%v, %t) want:(0, true)", w, ok)
+	}
+	if w, ok := dg.Weight(3, 0); !math.IsInf(w, 1) || ok {
+		t.Errorf("unexpected absent weight: got:(%v, %t) want:(+Inf, false)", w, ok)
+	}
+
+	// Legacy nodes are recovered when adapted back.
+	back := compat.ToLegacy(g)
+	for _, n := range back.Nodes() {
+		if _, ok := n.(legacyNode); !ok {
+			t.Errorf("unexpected legacy node type: %T", n)
+		}
+	}
+	if e := back.Edge(legacyNode(0), legacyNode(2)); e == nil || e.Weight() != 3 {
+		t.Errorf("unexpected edge: got:%v want weight 3", e)
+	}
+}
+
+func TestToLegacyUnweighted(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.AddNode(simple.Node(2))
+
+	lg := compat.ToLegacy(g)
+	ug, ok := lg.(compat.Undirected)
+	if !ok {
+		t.Fatalf("adapted undirected graph is not a legacy Undirected: %T", lg)
+	}
+	e := ug.EdgeBetween(compat.LegacyNode{Node: simple.Node(1)}, compat.LegacyNode{Node: simple.Node(0)})
+	if e == nil || e.Weight() != 1 {
+		t.Errorf("unexpected edge: got:%v want unit weight edge", e)
+	}
+	if len(ug.Nodes()) != 3 {
+		t.Errorf("unexpected number of nodes: got:%d want:3", len(ug.Nodes()))
+	}
+	wg := lg.(compat.Weighter)
+	for _, test := range []struct {
+		x, y int64
+		w    float64
+		ok   bool
+	}{
+		{x: 0, y: 1, w: 1, ok: true},
+		{x: 2, y: 2, w: 0, ok: true},
+		{x: 0, y: 2, w: math.Inf(1), ok: false},
+	} {
+		w, ok := wg.Weight(compat.LegacyNode{Node: simple.Node(test.x)}, compat.LegacyNode{Node: simple.Node(test.y)})
+		if w != test.w || ok != test.ok {
+			t.Errorf("unexpected weight between %d and %d: got:(%v, %t) want:(%v, %t)",
+				test.x, test.y, w, ok, test.w, test.ok)
+		}
+	}
+}
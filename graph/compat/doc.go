@@ -0,0 +1,12 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package compat provides adapters between the graph interfaces and the
+// legacy interfaces of github.com/gonum/graph.
+//
+// The legacy interfaces identify nodes with int IDs, take nodes rather than
+// node IDs as method parameters and return slices of nodes rather than node
+// iterators. The adapters allow code written against either API to be used
+// together while migrating from the legacy API.
+package compat // import "gonum.org/v1/gonum/graph/compat"
@@ -0,0 +1,77 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package compat
+
+// Node is a legacy graph node.
+type Node interface {
+	ID() int
+}
+
+// Edge is a legacy graph edge. The weight of an edge
+// is part of the edge.
+type Edge interface {
+	From() Node
+	To() Node
+	Weight() float64
+}
+
+// Graph is a legacy generalized graph.
+type Graph interface {
+	// Has returns whether the node exists
+	// within the graph.
+	Has(Node) bool
+
+	// Nodes returns all the nodes in the graph.
+	Nodes() []Node
+
+	// From returns all nodes that can be reached
+	// directly from the given node.
+	From(Node) []Node
+
+	// HasEdgeBetween returns whether an edge exists
+	// between nodes x and y without considering
+	// direction.
+	HasEdgeBetween(x, y Node) bool
+
+	// Edge returns the edge from u to v if such an
+	// edge exists and nil otherwise.
+	Edge(u, v Node) Edge
+}
+
+// Undirected is a legacy undirected graph.
+type Undirected interface {
+	Graph
+
+	// EdgeBetween returns the edge between
+	// nodes x and y.
+	EdgeBetween(x, y Node) Edge
+}
+
+// Directed is a legacy directed graph.
+type Directed interface {
+	Graph
+
+	// HasEdgeFromTo returns whether an edge exists
+	// in the graph from u to v.
+	HasEdgeFromTo(u, v Node) bool
+
+	// To returns all nodes that can reach directly
+	// to the given node.
+	To(Node) []Node
+}
+
+// Weighter defines legacy graphs that can report edge
+// weights.
+type Weighter interface {
+	// Weight returns the weight for the edge between
+	// x and y if Edge(x, y) returns a non-nil Edge.
+	// If x and y are the same node or there is no
+	// joining edge between the two nodes the weight
+	// value returned is implementation dependent.
+	// Weight returns true if an edge exists between
+	// x and y or if x and y have the same ID, false
+	// otherwise.
+	Weight(x, y Node) (w float64, ok bool)
+}
@@ -0,0 +1,155 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package annotate
+
+import (
+	"strconv"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/encoding/dot"
+	"gonum.org/v1/gonum/graph/iterator"
+)
+
+// Annotations holds encoding attributes for the nodes and edges of a graph.
+type Annotations struct {
+	// Nodes holds node attributes
+	// keyed by node ID.
+	Nodes map[int64][]encoding.Attribute
+	// Edges holds edge attributes
+	// keyed by from and to node IDs.
+	Edges map[[2]int64][]encoding.Attribute
+}
+
+// NewAnnotations returns an empty set of annotations.
+func NewAnnotations() Annotations {
+	return Annotations{
+		Nodes: make(map[int64][]encoding.Attribute),
+		Edges: make(map[[2]int64][]encoding.Attribute),
+	}
+}
+
+// AddNode adds the attributes to the node with the given ID.
+func (a Annotations) AddNode(id int64, attrs ...encoding.Attribute) {
+	a.Nodes[id] = append(a.Nodes[id], attrs...)
+}
+
+// AddEdge adds the attributes to the edge from uid to vid. For undirected
+// graphs the order of uid and vid is not significant.
+func (a Annotations) AddEdge(uid, vid int64, attrs ...encoding.Attribute) {
+	a.Edges[[2]int64{uid, vid}] = append(a.Edges[[2]int64{uid, vid}], attrs...)
+}
+
+// Graph returns a view of g with nodes and edges annotated by a. Nodes and edges
+// returned by the view implement encoding.Attributer, returning the attributes
+// of the corresponding node or edge of g, if it is an encoding.Attributer,
+// followed by the attributes held in a. Nodes of the view also implement
+// dot.Node. The returned graph implements graph.Directed if g is directed and
+// graph.Undirected otherwise.
+func Graph(g graph.Graph, a Annotations) graph.Graph {
+	switch g := g.(type) {
+	case graph.Directed:
+		return directed{annotated{Graph: g, a: a}, g}
+	case graph.Undirected:
+		return undirected{annotated{Graph: g, a: a}}
+	default:
+		return annotated{Graph: g, a: a}
+	}
+}
+
+type annotated struct {
+	graph.Graph
+	a Annotations
+}
+
+func (g annotated) Node(id int64) graph.Node {
+	n := g.Graph.Node(id)
+	if n == nil {
+		return nil
+	}
+	return node{Node: n, attrs: g.a.Nodes[id]}
+}
+
+func (g annotated) Nodes() graph.Nodes { return g.wrap(g.Graph.Nodes()) }
+
+func (g annotated) From(id int64) graph.Nodes { return g.wrap(g.Graph.From(id)) }
+
+func (g annotated) Edge(uid, vid int64) graph.Edge {
+	e := g.Graph.Edge(uid, vid)
+	if e == nil {
+		return nil
+	}
+	attrs, ok := g.a.Edges[[2]int64{uid, vid}]
+	if _, isDirected := g.Graph.(graph.Directed); !ok && !isDirected {
+		attrs = g.a.Edges[[2]int64{vid, uid}]
+	}
+	return edge{Edge: e, attrs: attrs}
+}
+
+func (g annotated) wrap(it graph.Nodes) graph.Nodes {
+	nodes := graph.NodesOf(it)
+	if len(nodes) == 0 {
+		return graph.Empty
+	}
+	for i, n := range nodes {
+		nodes[i] = node{Node: n, attrs: g.a.Nodes[n.ID()]}
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
+
+type directed struct {
+	annotated
+	d graph.Directed
+}
+
+func (g directed) HasEdgeFromTo(uid, vid int64) bool { return g.d.HasEdgeFromTo(uid, vid) }
+func (g directed) To(id int64) graph.Nodes           { return g.wrap(g.d.To(id)) }
+
+type undirected struct {
+	annotated
+}
+
+func (g undirected) EdgeBetween(xid, yid int64) graph.Edge { return g.Edge(xid, yid) }
+
+// node is an annotated graph.Node.
+type node struct {
+	graph.Node
+	attrs []encoding.Attribute
+}
+
+func (n node) DOTID() string {
+	if d, ok := n.Node.(dot.Node); ok {
+		return d.DOTID()
+	}
+	return strconv.FormatInt(n.ID(), 10)
+}
+
+func (n node) Attributes() []encoding.Attribute {
+	return merge(n.Node, n.attrs)
+}
+
+// edge is an annotated graph.Edge.
+type edge struct {
+	graph.Edge
+	attrs []encoding.Attribute
+}
+
+func (e edge) ReversedEdge() graph.Edge {
+	return edge{Edge: e.Edge.ReversedEdge(), attrs: e.attrs}
+}
+
+func (e edge) Attributes() []encoding.Attribute {
+	return merge(e.Edge, e.attrs)
+}
+
+// merge returns the attributes of v if it is an encoding.Attributer
+// followed by attrs.
+func merge(v interface{}, attrs []encoding.Attribute) []encoding.Attribute {
+	a, ok := v.(encoding.Attributer)
+	if !ok {
+		return attrs
+	}
+	return append(a.Attributes()[:len(a.Attributes()):len(a.Attributes())], attrs...)
+}
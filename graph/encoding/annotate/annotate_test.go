@@ -0,0 +1,155 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package annotate
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/encoding/dot"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+type attrNode struct {
+	simple.Node
+	attrs []encoding.Attribute
+}
+
+func (n attrNode) Attributes() []encoding.Attribute { return n.attrs }
+
+func TestGraph(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name string
+		g    interface {
+			graph.Graph
+			SetEdge(graph.Edge)
+		}
+		want string
+	}{
+		{
+			name: "directed",
+			g:    simple.NewDirectedGraph(),
+			want: `strict digraph {
+  // Node definitions.
+  0 [
+    shape=box
+    color=red
+  ];
+  1;
+  2 [label=two];
+
+  // Edge definitions.
+  0 -> 1 [penwidth=2];
+  1 -> 2;
+}`,
+		},
+		{
+			name: "undirected",
+			g:    simple.NewUndirectedGraph(),
+			want: `strict graph {
+  // Node definitions.
+  0 [
+    shape=box
+    color=red
+  ];
+  1;
+  2 [label=two];
+
+  // Edge definitions.
+  0 -- 1 [penwidth=2];
+  1 -- 2;
+}`,
+		},
+	} {
+		test.g.SetEdge(simple.Edge{
+			F: attrNode{Node: 0, attrs: []encoding.Attribute{{Key: "shape", Value: "box"}}},
+			T: simple.Node(1),
+		})
+		test.g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+
+		a := NewAnnotations()
+		a.AddNode(0, encoding.Attribute{Key: "color", Value: "red"})
+		a.AddNode(2, encoding.Attribute{Key: "label", Value: "two"})
+		// Add the undirected edge annotation in reverse
+		// order to check order is ignored.
+		if test.name == "undirected" {
+			a.AddEdge(1, 0, encoding.Attribute{Key: "penwidth", Value: "2"})
+		} else {
+			a.AddEdge(0, 1, encoding.Attribute{Key: "penwidth", Value: "2"})
+		}
+
+		b, err := dot.Marshal(Graph(test.g, a), "", "", "  ")
+		if err != nil {
+			t.Errorf("unexpected error marshaling %s graph: %v", test.name, err)
+			continue
+		}
+		if got := string(b); got != test.want {
+			t.Errorf("unexpected DOT for %s graph:\ngot:\n%s\nwant:\n%s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	// Two triangles joined by a bridge between 2 and 3.
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {0, 2}, {1, 2}, {2, 3}, {3, 4}, {3, 5}, {4, 5}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+
+	a := Metrics(g, All, rand.NewSource(1))
+
+	attr := func(attrs []encoding.Attribute, key string) string {
+		for _, a := range attrs {
+			if a.Key == key {
+				return a.Value
+			}
+		}
+		return ""
+	}
+	for id, want := range map[int64]struct{ betweenness, width, core string }{
+		0: {betweenness: "0", width: "0.5", core: "2"},
+		2: {betweenness: "12", width: "2", core: "2"},
+		3: {betweenness: "12", width: "2", core: "2"},
+	} {
+		attrs := a.Nodes[id]
+		if got := attr(attrs, "betweenness"); got != want.betweenness {
+			t.Errorf("unexpected betweenness for node %d: got:%s want:%s", id, got, want.betweenness)
+		}
+		if got := attr(attrs, "width"); got != want.width {
+			t.Errorf("unexpected width for node %d: got:%s want:%s", id, got, want.width)
+		}
+		if got := attr(attrs, "core"); got != want.core {
+			t.Errorf("unexpected core for node %d: got:%s want:%s", id, got, want.core)
+		}
+	}
+
+	for _, c := range [][]int64{{0, 1, 2}, {3, 4, 5}} {
+		want := attr(a.Nodes[c[0]], "fillcolor")
+		if want == "" {
+			t.Errorf("missing fillcolor for node %d", c[0])
+		}
+		for _, id := range c[1:] {
+			if got := attr(a.Nodes[id], "fillcolor"); got != want {
+				t.Errorf("unexpected fillcolor for node %d: got:%s want:%s", id, got, want)
+			}
+		}
+	}
+	if attr(a.Nodes[0], "community") == attr(a.Nodes[3], "community") {
+		t.Error("unexpected shared community across bridge")
+	}
+
+	e := Graph(g, a).Edge(3, 2)
+	if got := attr(e.(encoding.Attributer).Attributes(), "penwidth"); got != "5" {
+		t.Errorf("unexpected penwidth for bridge: got:%s want:5", got)
+	}
+	if _, err := dot.Marshal(Graph(g, a), "", "", "  "); err != nil {
+		t.Errorf("unexpected error marshaling annotated graph: %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package annotate provides graph wrappers that attach encoding attributes
+// to the nodes and edges of a graph, and functions that annotate graphs with
+// network analysis metrics for visualization by graph encoders.
+package annotate // import "gonum.org/v1/gonum/graph/encoding/annotate"
@@ -0,0 +1,163 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package annotate
+
+import (
+	"strconv"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/community"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/network"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// Palette is the set of colors used to fill nodes by community.
+// Communities beyond the length of the palette reuse colors.
+var Palette = []string{
+	"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd",
+	"#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf",
+}
+
+// Metric is a set of graph metrics.
+type Metric uint
+
+const (
+	// Betweenness annotates nodes with their betweenness centrality
+	// and scales node sizes by it, and annotates edges with their
+	// edge betweenness and scales edge pen widths by it.
+	Betweenness Metric = 1 << iota
+	// Community annotates nodes with their community from a
+	// Louvain modularization of the graph and fills nodes with
+	// a color from Palette by community.
+	Community
+	// Core annotates nodes with their core number. The core
+	// numbers of directed graphs are calculated on the
+	// undirected view of the graph.
+	Core
+
+	// All is the set of all metrics.
+	All = Betweenness | Community | Core
+)
+
+// Size and width ranges for attributes scaled by metric values.
+const (
+	minNodeSize = 0.5
+	maxNodeSize = 2.0
+	minPenWidth = 1.0
+	maxPenWidth = 5.0
+)
+
+// Metrics returns annotations for g holding the requested metrics. Each
+// metric is recorded as a raw attribute, "betweenness", "community" or "core",
+// and as visual DOT attributes; node betweenness sets "width" and "height",
+// community sets "style" and "fillcolor", and edge betweenness sets
+// "penwidth". The random source src is used for community detection and
+// may be nil.
+//
+// The returned annotations may be passed with g to Graph to obtain a graph
+// suitable for encoding, for example
+//
+//	b, err := dot.Marshal(annotate.Graph(g, annotate.Metrics(g, annotate.All, nil)), "", "", "")
+func Metrics(g graph.Graph, which Metric, src rand.Source) Annotations {
+	a := NewAnnotations()
+
+	if which&Betweenness != 0 {
+		nodes := graph.NodesOf(g.Nodes())
+		nb := network.Betweenness(g)
+		vals := make([]float64, len(nodes))
+		for i, n := range nodes {
+			vals[i] = nb[n.ID()]
+		}
+		lo, hi := bounds(vals)
+		for i, n := range nodes {
+			b := vals[i]
+			size := format(scale(b, lo, hi, minNodeSize, maxNodeSize))
+			a.AddNode(n.ID(),
+				encoding.Attribute{Key: "betweenness", Value: format(b)},
+				encoding.Attribute{Key: "width", Value: size},
+				encoding.Attribute{Key: "height", Value: size},
+			)
+		}
+
+		eb := network.EdgeBetweenness(g)
+		vals = vals[:0]
+		for _, b := range eb {
+			vals = append(vals, b)
+		}
+		lo, hi = bounds(vals)
+		for k, b := range eb {
+			a.AddEdge(k[0], k[1],
+				encoding.Attribute{Key: "betweenness", Value: format(b)},
+				encoding.Attribute{Key: "penwidth", Value: format(scale(b, lo, hi, minPenWidth, maxPenWidth))},
+			)
+		}
+	}
+
+	if which&Community != 0 {
+		r := community.Modularize(g, 1, src)
+		for i, c := range r.Communities() {
+			for _, n := range c {
+				a.AddNode(n.ID(),
+					encoding.Attribute{Key: "community", Value: strconv.Itoa(i)},
+					encoding.Attribute{Key: "style", Value: "filled"},
+					encoding.Attribute{Key: "fillcolor", Value: `"` + Palette[i%len(Palette)] + `"`},
+				)
+			}
+		}
+	}
+
+	if which&Core != 0 {
+		var u graph.Undirected
+		switch g := g.(type) {
+		case graph.Undirected:
+			u = g
+		case graph.Directed:
+			u = graph.Undirect{G: g}
+		default:
+			panic("annotate: graph is neither directed nor undirected")
+		}
+		_, cores := topo.DegeneracyOrdering(u)
+		for k, c := range cores {
+			for _, n := range c {
+				a.AddNode(n.ID(), encoding.Attribute{Key: "core", Value: strconv.Itoa(k)})
+			}
+		}
+	}
+
+	return a
+}
+
+// bounds returns the minimum and maximum values in s.
+func bounds(s []float64) (lo, hi float64) {
+	if len(s) == 0 {
+		return 0, 0
+	}
+	lo, hi = s[0], s[0]
+	for _, v := range s[1:] {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	return lo, hi
+}
+
+// scale returns v linearly mapped from [lo, hi] to [min, max]. If lo
+// and hi are equal, scale returns min.
+func scale(v, lo, hi, min, max float64) float64 {
+	if hi <= lo {
+		return min
+	}
+	return min + (v-lo)/(hi-lo)*(max-min)
+}
+
+func format(v float64) string {
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
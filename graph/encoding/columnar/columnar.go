@@ -0,0 +1,253 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package columnar
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Batch is a chunk of an edge table. Each edge is held at the same
+// index of all the columns of the batch.
+type Batch struct {
+	// From and To hold the IDs of the
+	// source and destination nodes.
+	From, To []int64
+
+	// Weight holds the edge weights. Weight
+	// is nil if the table is unweighted.
+	Weight []float64
+
+	// Attributes holds the attribute columns.
+	Attributes []Column
+}
+
+// Column is a named string attribute column of an edge table.
+type Column struct {
+	Name   string
+	Values []string
+}
+
+// Len returns the number of edges in the batch.
+func (b Batch) Len() int { return len(b.From) }
+
+// check returns an error if the columns of b differ in length.
+func (b Batch) check() error {
+	n := len(b.From)
+	if len(b.To) != n {
+		return fmt.Errorf("columnar: mismatched column length: from:%d to:%d", n, len(b.To))
+	}
+	if b.Weight != nil && len(b.Weight) != n {
+		return fmt.Errorf("columnar: mismatched column length: from:%d weight:%d", n, len(b.Weight))
+	}
+	for _, c := range b.Attributes {
+		if len(c.Values) != n {
+			return fmt.Errorf("columnar: mismatched column length: from:%d %s:%d", n, c.Name, len(c.Values))
+		}
+	}
+	return nil
+}
+
+// Reader is a source of edge table batches.
+type Reader interface {
+	// Read returns the next batch of the
+	// table. At the end of the table Read
+	// returns io.EOF.
+	Read() (Batch, error)
+}
+
+// Writer is a destination for edge table batches.
+type Writer interface {
+	// Write writes a batch to the table.
+	// The columns of the batch are not
+	// retained after Write returns.
+	Write(Batch) error
+}
+
+// Export writes the edges of g to dst in batches of at most size edges.
+// Edges are written in order of source node ID and then destination node
+// ID, and each undirected edge is written once with the lower ID as the
+// source. Nodes without edges are not written.
+//
+// If g is a graph.Weighted the weight column holds the weights of the
+// edges as reported by g. The attribute columns are named by keys and
+// hold the values of the corresponding encoding.Attribute of each edge
+// implementing encoding.Attributer, with the empty string for edges
+// without the attribute.
+//
+// The column slices are reused between calls to dst.Write.
+func Export(dst Writer, g graph.Graph, size int, keys ...string) error {
+	if size < 1 {
+		return errors.New("columnar: invalid batch size")
+	}
+	col := make(map[string]int, len(keys))
+	for i, k := range keys {
+		if _, dup := col[k]; dup {
+			return fmt.Errorf("columnar: duplicate attribute key %q", k)
+		}
+		col[k] = i
+	}
+	wg, weighted := g.(graph.Weighted)
+	_, undirected := g.(graph.Undirected)
+
+	var b Batch
+	b.From = make([]int64, 0, size)
+	b.To = make([]int64, 0, size)
+	if weighted {
+		b.Weight = make([]float64, 0, size)
+	}
+	b.Attributes = make([]Column, len(keys))
+	for i, k := range keys {
+		b.Attributes[i] = Column{Name: k, Values: make([]string, 0, size)}
+	}
+	flush := func() error {
+		if b.Len() == 0 {
+			return nil
+		}
+		err := dst.Write(b)
+		b.From = b.From[:0]
+		b.To = b.To[:0]
+		if weighted {
+			b.Weight = b.Weight[:0]
+		}
+		for i := range b.Attributes {
+			b.Attributes[i].Values = b.Attributes[i].Values[:0]
+		}
+		return err
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	for _, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		sort.Sort(ordered.ByID(to))
+		for _, v := range to {
+			vid := v.ID()
+			if undirected && vid < uid {
+				continue
+			}
+			b.From = append(b.From, uid)
+			b.To = append(b.To, vid)
+			if weighted {
+				w, _ := wg.Weight(uid, vid)
+				b.Weight = append(b.Weight, w)
+			}
+			for i := range b.Attributes {
+				b.Attributes[i].Values = append(b.Attributes[i].Values, "")
+			}
+			if a, ok := g.Edge(uid, vid).(encoding.Attributer); ok {
+				last := b.Len() - 1
+				for _, attr := range a.Attributes() {
+					if i, ok := col[attr.Key]; ok {
+						b.Attributes[i].Values[last] = attr.Value
+					}
+				}
+			}
+			if b.Len() == size {
+				err := flush()
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return flush()
+}
+
+// Builder is a graph that can have nodes added. A Builder passed to
+// Import must also be a graph.Builder or a graph.WeightedBuilder.
+type Builder interface {
+	graph.Graph
+	graph.NodeAdder
+}
+
+// Import adds the edges read from src to dst until src returns io.EOF.
+//
+// Nodes that do not exist in dst are added as simple.Node values. If
+// dst is a graph.WeightedBuilder and a batch has a weight column, edges
+// are created with dst.NewWeightedEdge and added with SetWeightedEdge.
+// Otherwise, if dst is a graph.Builder, they are created with dst.NewEdge
+// and added with SetEdge. It is an error for dst to be neither, or for a
+// batch without a weight column to be read when dst is only a
+// graph.WeightedBuilder. Attribute values are set on edges implementing
+// encoding.AttributeSetter. Empty attribute values are not set.
+func Import(dst Builder, src Reader) error {
+	var (
+		wb graph.WeightedBuilder
+		ub graph.Builder
+	)
+	switch dst := dst.(type) {
+	case graph.WeightedBuilder:
+		wb = dst
+		ub, _ = dst.(graph.Builder)
+	case graph.Builder:
+		ub = dst
+	default:
+		return errors.New("columnar: destination cannot have edges added")
+	}
+	for {
+		b, err := src.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = b.check()
+		if err != nil {
+			return err
+		}
+		weighted := wb != nil && b.Weight != nil
+		if !weighted && ub == nil {
+			return errors.New("columnar: missing weight column for weighted destination")
+		}
+		for i, uid := range b.From {
+			u := nodeFor(dst, uid)
+			v := nodeFor(dst, b.To[i])
+
+			var e graph.Edge
+			if weighted {
+				e = wb.NewWeightedEdge(u, v, b.Weight[i])
+			} else {
+				e = ub.NewEdge(u, v)
+			}
+			if s, ok := e.(encoding.AttributeSetter); ok {
+				for _, c := range b.Attributes {
+					if c.Values[i] == "" {
+						continue
+					}
+					err = s.SetAttribute(encoding.Attribute{Key: c.Name, Value: c.Values[i]})
+					if err != nil {
+						return err
+					}
+				}
+			}
+			if weighted {
+				wb.SetWeightedEdge(e.(graph.WeightedEdge))
+			} else {
+				ub.SetEdge(e)
+			}
+		}
+	}
+}
+
+// nodeFor returns the node in dst with the given ID, adding a
+// simple.Node to dst if it does not exist.
+func nodeFor(dst Builder, id int64) graph.Node {
+	n := dst.Node(id)
+	if n == nil {
+		n = simple.Node(id)
+		dst.AddNode(n)
+	}
+	return n
+}
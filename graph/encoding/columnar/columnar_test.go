@@ -0,0 +1,246 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package columnar
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestRoundTrip(t *testing.T) {
+	g := newWeightedUndirected()
+	for _, e := range []struct {
+		u, v  int64
+		w     float64
+		label string
+	}{
+		{u: 0, v: 1, w: 1.5, label: "a"},
+		{u: 0, v: 2, w: 2},
+		{u: 1, v: 2, w: 0.5, label: "c"},
+		{u: 3, v: 2, w: 4, label: "d"},
+		{u: 3, v: 4, w: 1, label: "e"},
+	} {
+		attrs := attributes{}
+		if e.label != "" {
+			attrs["label"] = e.label
+		}
+		g.SetWeightedEdge(&edge{
+			WeightedEdge: simple.WeightedEdge{F: simple.Node(e.u), T: simple.Node(e.v), W: e.w},
+			attributes:   attrs,
+		})
+	}
+
+	var tab table
+	err := Export(&tab, g, 2, "label", "missing")
+	if err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+	want := table{
+		{
+			From:   []int64{0, 0},
+			To:     []int64{1, 2},
+			Weight: []float64{1.5, 2},
+			Attributes: []Column{
+				{Name: "label", Values: []string{"a", ""}},
+				{Name: "missing", Values: []string{"", ""}},
+			},
+		},
+		{
+			From:   []int64{1, 2},
+			To:     []int64{2, 3},
+			Weight: []float64{0.5, 4},
+			Attributes: []Column{
+				{Name: "label", Values: []string{"c", "d"}},
+				{Name: "missing", Values: []string{"", ""}},
+			},
+		},
+		{
+			From:   []int64{3},
+			To:     []int64{4},
+			Weight: []float64{1},
+			Attributes: []Column{
+				{Name: "label", Values: []string{"e"}},
+				{Name: "missing", Values: []string{""}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(tab, want) {
+		t.Errorf("unexpected exported table:\ngot: %+v\nwant:%+v", tab, want)
+	}
+
+	dst := newWeightedUndirected()
+	err = Import(dst, &tab)
+	if err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	if got, want := edgesOf(dst), edgesOf(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected imported edges:\ngot: %v\nwant:%v", got, want)
+	}
+}
+
+func TestExportUnweightedDirected(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(0)})
+
+	var tab table
+	err := Export(&tab, g, 10)
+	if err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+	want := table{{From: []int64{1, 1, 2}, To: []int64{0, 2, 1}, Attributes: []Column{}}}
+	if !reflect.DeepEqual(tab, want) {
+		t.Errorf("unexpected exported table:\ngot: %+v\nwant:%+v", tab, want)
+	}
+
+	dst := simple.NewDirectedGraph()
+	err = Import(dst, &tab)
+	if err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	if dst.Edges().Len() != 3 || !dst.HasEdgeFromTo(2, 1) || !dst.HasEdgeFromTo(1, 2) || !dst.HasEdgeFromTo(1, 0) {
+		t.Errorf("unexpected imported edges: %v", graph.EdgesOf(dst.Edges()))
+	}
+}
+
+func TestImportWeighted(t *testing.T) {
+	tab := table{{From: []int64{0, 2}, To: []int64{1, 1}, Weight: []float64{3, 0.5}}}
+	dst := simple.NewWeightedDirectedGraph(0, 0)
+	err := Import(dst, &tab)
+	if err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 3},
+		{F: simple.Node(2), T: simple.Node(1), W: 0.5},
+	} {
+		w, ok := dst.Weight(e.F.ID(), e.T.ID())
+		if !ok || w != e.W {
+			t.Errorf("unexpected weight for edge %d->%d: got:%v want:%v", e.F.ID(), e.T.ID(), w, e.W)
+		}
+	}
+
+	tab = table{{From: []int64{0}, To: []int64{1}}}
+	err = Import(simple.NewWeightedDirectedGraph(0, 0), &tab)
+	if err == nil {
+		t.Error("expected error for unweighted batch into weighted destination")
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	for _, b := range []Batch{
+		{From: []int64{0, 1}, To: []int64{1}},
+		{From: []int64{0}, To: []int64{1}, Weight: []float64{1, 2}},
+		{From: []int64{0}, To: []int64{1}, Attributes: []Column{{Name: "x"}}},
+	} {
+		tab := table{b}
+		err := Import(simple.NewUndirectedGraph(), &tab)
+		if err == nil {
+			t.Errorf("expected error for batch %+v", b)
+		}
+	}
+	if err := Export(&table{}, simple.NewUndirectedGraph(), 0); err == nil {
+		t.Error("expected error for zero batch size")
+	}
+}
+
+// table is an in-memory edge table.
+type table []Batch
+
+func (t *table) Write(b Batch) error {
+	c := Batch{
+		From:       append([]int64(nil), b.From...),
+		To:         append([]int64(nil), b.To...),
+		Attributes: make([]Column, len(b.Attributes)),
+	}
+	if b.Weight != nil {
+		c.Weight = append([]float64(nil), b.Weight...)
+	}
+	for i, col := range b.Attributes {
+		c.Attributes[i] = Column{Name: col.Name, Values: append([]string(nil), col.Values...)}
+	}
+	*t = append(*t, c)
+	return nil
+}
+
+func (t *table) Read() (Batch, error) {
+	if len(*t) == 0 {
+		return Batch{}, io.EOF
+	}
+	b := (*t)[0]
+	*t = (*t)[1:]
+	return b, nil
+}
+
+// weightedUndirected is a weighted undirected graph with edges
+// holding encoding attributes.
+type weightedUndirected struct {
+	*simple.WeightedUndirectedGraph
+}
+
+func newWeightedUndirected() weightedUndirected {
+	return weightedUndirected{simple.NewWeightedUndirectedGraph(0, 0)}
+}
+
+func (g weightedUndirected) NewWeightedEdge(from, to graph.Node, w float64) graph.WeightedEdge {
+	return &edge{WeightedEdge: simple.WeightedEdge{F: from, T: to, W: w}, attributes: attributes{}}
+}
+
+type edge struct {
+	simple.WeightedEdge
+	attributes
+}
+
+func (e *edge) ReversedEdge() graph.Edge {
+	return &edge{WeightedEdge: simple.WeightedEdge{F: e.T, T: e.F, W: e.W}, attributes: e.attributes}
+}
+
+type attributes map[string]string
+
+func (a attributes) SetAttribute(attr encoding.Attribute) error {
+	a[attr.Key] = attr.Value
+	return nil
+}
+
+func (a attributes) Attributes() []encoding.Attribute {
+	var attrs []encoding.Attribute
+	for k, v := range a {
+		attrs = append(attrs, encoding.Attribute{Key: k, Value: v})
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	return attrs
+}
+
+// edgesOf returns a sorted description of the edges of g.
+func edgesOf(g weightedUndirected) []string {
+	var edges []string
+	for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+		u, v := e.From().ID(), e.To().ID()
+		if u > v {
+			u, v = v, u
+		}
+		var label string
+		if a, ok := e.(encoding.Attributer); ok {
+			for _, attr := range a.Attributes() {
+				label += attr.Key + "=" + attr.Value
+			}
+		}
+		edges = append(edges, fmtEdge(u, v, e.Weight(), label))
+	}
+	sort.Strings(edges)
+	return edges
+}
+
+func fmtEdge(u, v int64, w float64, label string) string {
+	return fmt.Sprintf("%d--%d:%v[%s]", u, v, w, label)
+}
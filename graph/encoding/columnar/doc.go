@@ -0,0 +1,19 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package columnar implements chunked streaming of graph edges as columnar
+// edge tables.
+//
+// An edge table holds a source node ID column, a destination node ID column,
+// an optional weight column and any number of string attribute columns. The
+// table is streamed as a sequence of batches so that large graphs do not need
+// to be held in an intermediate row-oriented form. The Reader and Writer
+// interfaces correspond directly to the record batch readers and writers of
+// columnar formats such as Apache Arrow and Parquet; adapting them to a
+// specific format implementation is left to the user so that this package
+// does not depend on those implementations.
+//
+// Only edges are held in an edge table, so nodes without edges are not
+// preserved by a round trip through Export and Import.
+package columnar // import "gonum.org/v1/gonum/graph/encoding/columnar"
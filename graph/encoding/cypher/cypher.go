@@ -0,0 +1,228 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cypher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Node is a Neo4j node.
+type Node struct {
+	ID         int64
+	Labels     []string
+	Properties map[string]interface{}
+}
+
+// Relationship is a Neo4j relationship from the node with ID Start
+// to the node with ID End.
+type Relationship struct {
+	ID         int64
+	Type       string
+	Start, End int64
+	Properties map[string]interface{}
+}
+
+// NodeSetter is a graph.Node that can record the Neo4j node it represents.
+type NodeSetter interface {
+	SetNeo4jNode(Node) error
+}
+
+// RelationshipSetter is a graph.Edge that can record the Neo4j relationship
+// it represents.
+type RelationshipSetter interface {
+	SetNeo4jRelationship(Relationship) error
+}
+
+// Build adds the given Neo4j nodes and relationships to dst. Nodes and
+// relationships may be repeated, as they are when they occur in more
+// than one row of a query result, and repeats are ignored.
+//
+// Nodes are created by dst.NewNode. If the node is a NodeSetter it is
+// passed the Neo4j node, otherwise a simple.Node with the Neo4j node ID
+// is added to dst in its place. Edges are created by dst.NewEdge and
+// passed the Neo4j relationship if they are a RelationshipSetter.
+//
+// Neo4j may hold more than one relationship between a pair of nodes. Since
+// dst holds at most one edge from a node to another, a later relationship
+// replaces an earlier one between the same nodes. It is an error for a
+// relationship to refer to a node that is not in nodes, or to start and
+// end at the same node, since graph.Builder implementations may not hold
+// self loops.
+func Build(dst encoding.Builder, nodes []Node, rels []Relationship) error {
+	b := builder{dst: dst, nodes: make(map[int64]graph.Node), rels: make(map[int64]bool)}
+	return b.add(nodes, rels)
+}
+
+// builder is a helper to add Neo4j nodes and relationships to a graph.
+type builder struct {
+	dst encoding.Builder
+
+	// nodes maps from Neo4j node IDs
+	// to graph nodes.
+	nodes map[int64]graph.Node
+
+	// rels is the set of added
+	// relationship IDs.
+	rels map[int64]bool
+}
+
+func (b *builder) add(nodes []Node, rels []Relationship) error {
+	for _, n := range nodes {
+		if _, exists := b.nodes[n.ID]; exists {
+			continue
+		}
+		gn := b.dst.NewNode()
+		if s, ok := gn.(NodeSetter); ok {
+			err := s.SetNeo4jNode(n)
+			if err != nil {
+				return err
+			}
+		} else {
+			gn = b.dst.Node(n.ID)
+			if gn == nil {
+				gn = simple.Node(n.ID)
+			}
+		}
+		if b.dst.Node(gn.ID()) == nil {
+			b.dst.AddNode(gn)
+		}
+		b.nodes[n.ID] = gn
+	}
+
+	for _, r := range rels {
+		if b.rels[r.ID] {
+			continue
+		}
+		u, ok := b.nodes[r.Start]
+		if !ok {
+			return fmt.Errorf("cypher: relationship %d from unknown node %d", r.ID, r.Start)
+		}
+		v, ok := b.nodes[r.End]
+		if !ok {
+			return fmt.Errorf("cypher: relationship %d to unknown node %d", r.ID, r.End)
+		}
+		if r.Start == r.End {
+			return fmt.Errorf("cypher: relationship %d is a self loop on node %d", r.ID, r.Start)
+		}
+		e := b.dst.NewEdge(u, v)
+		if s, ok := e.(RelationshipSetter); ok {
+			err := s.SetNeo4jRelationship(r)
+			if err != nil {
+				return err
+			}
+		}
+		b.dst.SetEdge(e)
+		b.rels[r.ID] = true
+	}
+	return nil
+}
+
+// Unmarshal adds the nodes and relationships held in the graph result format
+// of the Neo4j HTTP transactional API in data to dst. All the rows of all the
+// results are added as described for Build, with nodes and relationships
+// in a row able to refer to nodes in earlier rows. Numerical property values
+// are represented as json.Number.
+//
+// The graph result format is requested by including "graph" in the
+// resultDataContents of a statement. If the response holds errors, the
+// first is returned and dst is not modified.
+func Unmarshal(data []byte, dst encoding.Builder) error {
+	var resp response
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	err := dec.Decode(&resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.Errors) != 0 {
+		e := resp.Errors[0]
+		return fmt.Errorf("cypher: %s: %s", e.Code, e.Message)
+	}
+
+	b := builder{dst: dst, nodes: make(map[int64]graph.Node), rels: make(map[int64]bool)}
+	for _, res := range resp.Results {
+		for _, row := range res.Data {
+			if row.Graph == nil {
+				return errors.New("cypher: result row has no graph data")
+			}
+			nodes := make([]Node, len(row.Graph.Nodes))
+			for i, n := range row.Graph.Nodes {
+				id, err := parseID(n.ID)
+				if err != nil {
+					return err
+				}
+				nodes[i] = Node{ID: id, Labels: n.Labels, Properties: n.Properties}
+			}
+			rels := make([]Relationship, len(row.Graph.Relationships))
+			for i, r := range row.Graph.Relationships {
+				id, err := parseID(r.ID)
+				if err != nil {
+					return err
+				}
+				start, err := parseID(r.StartNode)
+				if err != nil {
+					return err
+				}
+				end, err := parseID(r.EndNode)
+				if err != nil {
+					return err
+				}
+				rels[i] = Relationship{ID: id, Type: r.Type, Start: start, End: end, Properties: r.Properties}
+			}
+			err = b.add(nodes, rels)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseID returns the Neo4j ID held in id. The HTTP API
+// writes IDs as JSON strings, but numbers are accepted.
+func parseID(id json.RawMessage) (int64, error) {
+	s := strings.Trim(string(id), `"`)
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cypher: invalid ID %s", id)
+	}
+	return v, nil
+}
+
+// response is a Neo4j HTTP transactional API response.
+type response struct {
+	Results []struct {
+		Columns []string `json:"columns"`
+		Data    []struct {
+			Graph *struct {
+				Nodes []struct {
+					ID         json.RawMessage        `json:"id"`
+					Labels     []string               `json:"labels"`
+					Properties map[string]interface{} `json:"properties"`
+				} `json:"nodes"`
+				Relationships []struct {
+					ID         json.RawMessage        `json:"id"`
+					Type       string                 `json:"type"`
+					StartNode  json.RawMessage        `json:"startNode"`
+					EndNode    json.RawMessage        `json:"endNode"`
+					Properties map[string]interface{} `json:"properties"`
+				} `json:"relationships"`
+			} `json:"graph"`
+		} `json:"data"`
+	} `json:"results"`
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
@@ -0,0 +1,196 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cypher
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// movies is a Neo4j HTTP API response holding graph result data
+// for a query matching people and the movies they are related to.
+// The last row repeats a relationship of the first.
+const movies = `{
+  "results": [{
+    "columns": ["p", "r", "m"],
+    "data": [
+      {
+        "graph": {
+          "nodes": [
+            {"id": "17", "labels": ["Person"], "properties": {"name": "Keanu Reeves", "born": 1964}},
+            {"id": "3", "labels": ["Movie"], "properties": {"title": "The Matrix", "released": 1999}}
+          ],
+          "relationships": [
+            {"id": "40", "type": "ACTED_IN", "startNode": "17", "endNode": "3", "properties": {"roles": ["Neo"]}}
+          ]
+        }
+      },
+      {
+        "graph": {
+          "nodes": [
+            {"id": "18", "labels": ["Person", "Director"], "properties": {"name": "Lana Wachowski"}},
+            {"id": "3", "labels": ["Movie"], "properties": {"title": "The Matrix", "released": 1999}}
+          ],
+          "relationships": [
+            {"id": "41", "type": "DIRECTED", "startNode": "18", "endNode": "3", "properties": {}}
+          ]
+        }
+      },
+      {
+        "graph": {
+          "nodes": [],
+          "relationships": [
+            {"id": "40", "type": "ACTED_IN", "startNode": "17", "endNode": "3", "properties": {"roles": ["Neo"]}}
+          ]
+        }
+      }
+    ]
+  }],
+  "errors": []
+}`
+
+func TestUnmarshal(t *testing.T) {
+	g := newDirectedGraph()
+	err := Unmarshal([]byte(movies), g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		n := n.(*node)
+		got = append(got, fmt.Sprintf("%d:%v:%v", n.neo4jID, n.labels, n.properties))
+	}
+	sort.Strings(got)
+	want := []string{
+		"17:[Person]:map[born:1964 name:Keanu Reeves]",
+		"18:[Person Director]:map[name:Lana Wachowski]",
+		"3:[Movie]:map[released:1999 title:The Matrix]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected nodes:\ngot: %v\nwant:%v", got, want)
+	}
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		n := n.(*node)
+		if n.neo4jID == 3 {
+			if _, ok := n.properties["released"].(json.Number); !ok {
+				t.Errorf("unexpected type for numerical property: %T", n.properties["released"])
+			}
+		}
+	}
+
+	got = got[:0]
+	for _, e := range graph.EdgesOf(g.Edges()) {
+		e := e.(*edge)
+		got = append(got, fmt.Sprintf("%d-[%s]->%d:%v",
+			e.F.(*node).neo4jID, e.rel.Type, e.T.(*node).neo4jID, e.rel.Properties))
+	}
+	sort.Strings(got)
+	want = []string{
+		"17-[ACTED_IN]->3:map[roles:[Neo]]",
+		"18-[DIRECTED]->3:map[]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected edges:\ngot: %v\nwant:%v", got, want)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	err := Build(g,
+		[]Node{{ID: 10}, {ID: 20}, {ID: 30}, {ID: 10}},
+		[]Relationship{
+			{ID: 1, Start: 10, End: 20},
+			{ID: 2, Start: 20, End: 30},
+			{ID: 3, Start: 30, End: 10},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := g.Nodes().Len(); n != 3 {
+		t.Errorf("unexpected number of nodes: got:%d want:3", n)
+	}
+	for _, e := range [][2]int64{{10, 20}, {20, 30}, {30, 10}} {
+		if !g.HasEdgeFromTo(e[0], e[1]) {
+			t.Errorf("missing edge %d->%d", e[0], e[1])
+		}
+	}
+
+	err = Build(g, nil, []Relationship{{ID: 4, Start: 10, End: 40}})
+	if err == nil {
+		t.Error("expected error for relationship to unknown node")
+	}
+
+	err = Build(g, nil, []Relationship{{ID: 5, Start: 10, End: 10}})
+	if err == nil {
+		t.Error("expected error for self loop relationship")
+	}
+	if g.HasEdgeFromTo(10, 10) {
+		t.Error("unexpected self loop edge")
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"results": [], "errors": [{"code": "Neo.ClientError.Statement.SyntaxError", "message": "Invalid input"}]}`,
+		`{"results": [{"columns": ["n"], "data": [{"row": [{}]}]}], "errors": []}`,
+		`{"results": [{"columns": ["n"], "data": [{"graph": {"nodes": [{"id": "x"}], "relationships": []}}]}], "errors": []}`,
+	} {
+		err := Unmarshal([]byte(data), simple.NewDirectedGraph())
+		if err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+}
+
+// directedGraph is a directed graph retaining Neo4j node and
+// relationship data.
+type directedGraph struct {
+	*simple.DirectedGraph
+}
+
+func newDirectedGraph() directedGraph {
+	return directedGraph{simple.NewDirectedGraph()}
+}
+
+func (g directedGraph) NewNode() graph.Node {
+	return &node{id: g.DirectedGraph.NewNode().ID()}
+}
+
+func (g directedGraph) NewEdge(from, to graph.Node) graph.Edge {
+	return &edge{Edge: simple.Edge{F: from, T: to}}
+}
+
+type node struct {
+	id         int64
+	neo4jID    int64
+	labels     []string
+	properties map[string]interface{}
+}
+
+func (n *node) ID() int64 { return n.id }
+func (n *node) SetNeo4jNode(nn Node) error {
+	n.neo4jID = nn.ID
+	n.labels = nn.Labels
+	n.properties = nn.Properties
+	return nil
+}
+
+type edge struct {
+	simple.Edge
+	rel Relationship
+}
+
+func (e *edge) SetNeo4jRelationship(r Relationship) error {
+	e.rel = r
+	return nil
+}
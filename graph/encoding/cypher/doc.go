@@ -0,0 +1,17 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cypher implements construction of graphs from the results of
+// Cypher queries made against a Neo4j graph database.
+//
+// Query results may be decoded from the graph result format of the Neo4j
+// HTTP transactional API with Unmarshal, or passed to Build as Node and
+// Relationship values. Build is intended to be used with values obtained
+// from a Bolt protocol driver, with the driver's node and relationship
+// types converted to the types of this package by the caller, so that
+// this package does not depend on a driver implementation.
+//
+// See https://neo4j.com/docs/http-api/current/ for a description of the
+// HTTP API result formats.
+package cypher // import "gonum.org/v1/gonum/graph/encoding/cypher"
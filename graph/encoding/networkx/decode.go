@@ -0,0 +1,235 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package networkx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// jsonGraph is the union of the NetworkX node-link and adjacency formats.
+type jsonGraph struct {
+	Directed   bool                           `json:"directed"`
+	Multigraph bool                           `json:"multigraph"`
+	Graph      map[string]json.RawMessage     `json:"graph"`
+	Nodes      []map[string]json.RawMessage   `json:"nodes"`
+	Links      []map[string]json.RawMessage   `json:"links"`
+	Edges      []map[string]json.RawMessage   `json:"edges"`
+	Adjacency  [][]map[string]json.RawMessage `json:"adjacency"`
+}
+
+// Unmarshal parses the NetworkX JSON encoded data in either the node-link
+// or the adjacency format and stores the result in dst. The format is
+// determined by the presence of an "adjacency" list. Node-link data may
+// hold its edges in either a "links" or an "edges" list.
+//
+// Nodes are created by dst.NewNode. If the node is an IDSetter its
+// NetworkX identifier is set, otherwise the NetworkX identifier must be
+// an integer and a simple.Node with that ID is added to dst in its place.
+// Graph, node and edge attributes are set on dst, nodes and edges that
+// implement AttributeSetter or encoding.AttributeSetter. If dst is a
+// graph.WeightedBuilder and an edge has a numerical "weight" attribute,
+// the edge is created by dst.NewWeightedEdge with that weight.
+//
+// Multigraph data and data with a directedness that does not match dst
+// are not supported.
+func Unmarshal(data []byte, dst encoding.Builder) error {
+	var src jsonGraph
+	err := json.Unmarshal(data, &src)
+	if err != nil {
+		return err
+	}
+	if src.Multigraph {
+		return errors.New("networkx: multigraphs are not supported")
+	}
+	switch dst.(type) {
+	case graph.Directed:
+		if !src.Directed {
+			return errors.New("networkx: cannot unmarshal undirected graph into directed graph")
+		}
+	case graph.Undirected:
+		if src.Directed {
+			return errors.New("networkx: cannot unmarshal directed graph into undirected graph")
+		}
+	}
+
+	gen := generator{dst: dst, nodes: make(map[string]graph.Node)}
+	if len(src.Graph) != 0 {
+		err = setAttributes(dst, src.Graph)
+		if err != nil {
+			return err
+		}
+	}
+	nodes := make([]graph.Node, len(src.Nodes))
+	for i, jn := range src.Nodes {
+		nodes[i], err = gen.addNode(jn)
+		if err != nil {
+			return err
+		}
+	}
+
+	if src.Adjacency != nil {
+		if len(src.Adjacency) != len(src.Nodes) {
+			return fmt.Errorf("networkx: mismatched adjacency length: %d lists for %d nodes", len(src.Adjacency), len(src.Nodes))
+		}
+		for i, adj := range src.Adjacency {
+			for _, je := range adj {
+				err = gen.addEdge(nodes[i], je, "id")
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	links := src.Links
+	if links == nil {
+		links = src.Edges
+	}
+	for _, je := range links {
+		u, err := gen.node(je["source"])
+		if err != nil {
+			return err
+		}
+		err = gen.addEdge(u, je, "source", "target")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generator is a helper to add NetworkX nodes and edges to a graph.
+type generator struct {
+	dst encoding.Builder
+
+	// nodes maps from canonical NetworkX
+	// identifiers to graph nodes.
+	nodes map[string]graph.Node
+}
+
+// canonical returns a canonical form of the JSON value id.
+func canonical(id json.RawMessage) (string, error) {
+	if id == nil {
+		return "", errors.New("networkx: missing identifier")
+	}
+	var v interface{}
+	err := json.Unmarshal(id, &v)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// addNode adds the NetworkX node jn to the graph.
+func (g *generator) addNode(jn map[string]json.RawMessage) (graph.Node, error) {
+	id := jn["id"]
+	key, err := canonical(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := g.nodes[key]; exists {
+		return nil, fmt.Errorf("networkx: duplicate node identifier %s", key)
+	}
+
+	n := g.dst.NewNode()
+	if s, ok := n.(IDSetter); ok {
+		err = s.SetNetworkXID(id)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var nid int64
+		err = json.Unmarshal(id, &nid)
+		if err != nil {
+			return nil, fmt.Errorf("networkx: cannot use identifier %s as node ID: %v", key, err)
+		}
+		if g.dst.Node(nid) != nil {
+			return nil, fmt.Errorf("networkx: node ID %d already in graph", nid)
+		}
+		n = simple.Node(nid)
+	}
+
+	attrs := make(map[string]json.RawMessage, len(jn))
+	for k, v := range jn {
+		if k != "id" {
+			attrs[k] = v
+		}
+	}
+	if len(attrs) != 0 {
+		err = setAttributes(n, attrs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	g.dst.AddNode(n)
+	g.nodes[key] = n
+	return n, nil
+}
+
+// node returns the node with the NetworkX identifier id.
+func (g *generator) node(id json.RawMessage) (graph.Node, error) {
+	key, err := canonical(id)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := g.nodes[key]
+	if !ok {
+		return nil, fmt.Errorf("networkx: unknown node identifier %s", key)
+	}
+	return n, nil
+}
+
+// addEdge adds the edge from u described by je to the graph. The
+// target of the edge is held by the last of the given keys. Keys
+// describing the edge's end points are not passed to the edge as
+// attributes.
+func (g *generator) addEdge(u graph.Node, je map[string]json.RawMessage, keys ...string) error {
+	v, err := g.node(je[keys[len(keys)-1]])
+	if err != nil {
+		return err
+	}
+	if _, ok := g.dst.(graph.Undirected); ok && g.dst.Edge(u.ID(), v.ID()) != nil {
+		// Undirected adjacency data holds
+		// each edge in both directions.
+		return nil
+	}
+
+	attrs := make(map[string]json.RawMessage, len(je))
+	for k, a := range je {
+		attrs[k] = a
+	}
+	for _, k := range keys {
+		delete(attrs, k)
+	}
+	delete(attrs, "key")
+
+	var e graph.Edge
+	var w float64
+	wb, isWeighted := g.dst.(graph.WeightedBuilder)
+	if isWeighted && json.Unmarshal(attrs["weight"], &w) == nil {
+		e = wb.NewWeightedEdge(u, v, w)
+	} else {
+		e = g.dst.NewEdge(u, v)
+	}
+	if len(attrs) != 0 {
+		err = setAttributes(e, attrs)
+		if err != nil {
+			return err
+		}
+	}
+	if we, ok := e.(graph.WeightedEdge); ok && isWeighted {
+		wb.SetWeightedEdge(we)
+		return nil
+	}
+	g.dst.SetEdge(e)
+	return nil
+}
@@ -0,0 +1,15 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package networkx implements JSON marshaling and unmarshaling of graphs in
+// the node-link and adjacency formats of the NetworkX Python package.
+//
+// The formats correspond to the node_link_data and adjacency_data functions
+// of networkx.readwrite.json_graph. NetworkX node identifiers and graph, node
+// and edge attributes are retained as raw JSON by types implementing the
+// interfaces of this package, so graphs can be exchanged without loss.
+//
+// See https://networkx.org/documentation/stable/reference/readwrite/json_graph.html
+// for a description of the formats.
+package networkx // import "gonum.org/v1/gonum/graph/encoding/networkx"
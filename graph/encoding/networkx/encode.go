@@ -0,0 +1,129 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package networkx
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Marshal returns the NetworkX JSON encoding of g in the given format.
+//
+// Node identifiers are taken from nodes implementing IDer, and are the
+// integer node IDs otherwise. Graph, node and edge attributes are taken
+// from values implementing Attributer or encoding.Attributer. If an edge
+// is a graph.WeightedEdge and has no "weight" attribute, its weight is
+// encoded as the "weight" attribute. Nodes and adjacency lists are
+// written in order of node ID.
+//
+// It is an error for an attribute to use a key that is reserved by the
+// format: "id" for nodes and adjacency edges, and "source", "target" and
+// "key" for links.
+func Marshal(g graph.Graph, format Format) ([]byte, error) {
+	if format != NodeLink && format != Adjacency {
+		return nil, fmt.Errorf("networkx: unknown format %d", format)
+	}
+	_, directed := g.(graph.Directed)
+
+	attrs, err := attributesOf(g)
+	if err != nil {
+		return nil, err
+	}
+	dst := map[string]interface{}{
+		"directed":   directed,
+		"multigraph": false,
+		"graph":      attrs,
+	}
+
+	nodes := sortedNodes(g.Nodes())
+	ids := make(map[int64]json.RawMessage, len(nodes))
+	jsonNodes := make([]map[string]json.RawMessage, len(nodes))
+	for i, n := range nodes {
+		id, err := idOf(n)
+		if err != nil {
+			return nil, err
+		}
+		ids[n.ID()] = id
+		jn, err := attributesOf(n)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := jn["id"]; ok {
+			return nil, reservedError("node", "id")
+		}
+		jn["id"] = id
+		jsonNodes[i] = jn
+	}
+	dst["nodes"] = jsonNodes
+
+	switch format {
+	case NodeLink:
+		links := []map[string]json.RawMessage{}
+		for _, u := range nodes {
+			uid := u.ID()
+			for _, v := range sortedNodes(g.From(uid)) {
+				vid := v.ID()
+				if !directed && vid < uid {
+					// Undirected edges are written once.
+					continue
+				}
+				link, err := edgeAttributesOf(g.Edge(uid, vid), "source", "target", "key")
+				if err != nil {
+					return nil, err
+				}
+				link["source"] = ids[uid]
+				link["target"] = ids[vid]
+				links = append(links, link)
+			}
+		}
+		dst["links"] = links
+	case Adjacency:
+		adjacency := make([][]map[string]json.RawMessage, len(nodes))
+		for i, u := range nodes {
+			uid := u.ID()
+			adj := []map[string]json.RawMessage{}
+			for _, v := range sortedNodes(g.From(uid)) {
+				vid := v.ID()
+				a, err := edgeAttributesOf(g.Edge(uid, vid), "id", "key")
+				if err != nil {
+					return nil, err
+				}
+				a["id"] = ids[vid]
+				adj = append(adj, a)
+			}
+			adjacency[i] = adj
+		}
+		dst["adjacency"] = adjacency
+	}
+
+	return json.Marshal(dst)
+}
+
+// edgeAttributesOf returns the attributes of e, including its weight if
+// e is a graph.WeightedEdge. It returns an error if any of the attribute
+// keys is in reserved.
+func edgeAttributesOf(e graph.Edge, reserved ...string) (map[string]json.RawMessage, error) {
+	attrs, err := attributesOf(e)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range reserved {
+		if _, ok := attrs[k]; ok {
+			return nil, reservedError("edge", k)
+		}
+	}
+	if we, ok := e.(graph.WeightedEdge); ok {
+		if _, ok := attrs["weight"]; !ok {
+			w, err := json.Marshal(we.Weight())
+			if err != nil {
+				return nil, err
+			}
+			attrs["weight"] = w
+		}
+	}
+	return attrs, nil
+}
@@ -0,0 +1,125 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package networkx
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// Format is a NetworkX JSON graph format.
+type Format int
+
+const (
+	// NodeLink is the format of the NetworkX
+	// node_link_data function, holding lists
+	// of nodes and links.
+	NodeLink Format = iota
+
+	// Adjacency is the format of the NetworkX
+	// adjacency_data function, holding a list
+	// of nodes and the adjacency list of each
+	// node.
+	Adjacency
+)
+
+// IDer is a graph.Node with a NetworkX node identifier, which may be any
+// JSON value.
+type IDer interface {
+	NetworkXID() json.RawMessage
+}
+
+// IDSetter is a graph.Node that can record its NetworkX node identifier.
+type IDSetter interface {
+	SetNetworkXID(json.RawMessage) error
+}
+
+// Attributer is a graph, graph.Node or graph.Edge with NetworkX
+// attributes. The attribute values may be any JSON value.
+type Attributer interface {
+	NetworkXAttributes() map[string]json.RawMessage
+}
+
+// AttributeSetter is a graph, graph.Node or graph.Edge that can
+// record its NetworkX attributes.
+type AttributeSetter interface {
+	SetNetworkXAttributes(map[string]json.RawMessage) error
+}
+
+// attributesOf returns the NetworkX attributes of v. If v is not an
+// Attributer but is an encoding.Attributer, the attribute values are
+// encoded as JSON strings. The returned map may be modified.
+func attributesOf(v interface{}) (map[string]json.RawMessage, error) {
+	attrs := make(map[string]json.RawMessage)
+	switch v := v.(type) {
+	case Attributer:
+		for k, a := range v.NetworkXAttributes() {
+			attrs[k] = a
+		}
+	case encoding.Attributer:
+		for _, a := range v.Attributes() {
+			b, err := json.Marshal(a.Value)
+			if err != nil {
+				return nil, err
+			}
+			attrs[a.Key] = b
+		}
+	}
+	return attrs, nil
+}
+
+// setAttributes sets the attributes of v if it is an AttributeSetter
+// or an encoding.AttributeSetter. JSON string values are passed to an
+// encoding.AttributeSetter unquoted, and other values as JSON text.
+func setAttributes(v interface{}, attrs map[string]json.RawMessage) error {
+	switch v := v.(type) {
+	case AttributeSetter:
+		return v.SetNetworkXAttributes(attrs)
+	case encoding.AttributeSetter:
+		keys := make([]string, 0, len(attrs))
+		for k := range attrs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			value := string(attrs[k])
+			var s string
+			if json.Unmarshal(attrs[k], &s) == nil {
+				value = s
+			}
+			err := v.SetAttribute(encoding.Attribute{Key: k, Value: value})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// idOf returns the NetworkX identifier of n.
+func idOf(n graph.Node) (json.RawMessage, error) {
+	if n, ok := n.(IDer); ok {
+		return n.NetworkXID(), nil
+	}
+	return json.Marshal(n.ID())
+}
+
+// sortedNodes returns the nodes of it sorted by ID.
+func sortedNodes(it graph.Nodes) []graph.Node {
+	nodes := graph.NodesOf(it)
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
+
+// reservedError returns an error for an attribute using a key that
+// is reserved by the format.
+func reservedError(kind, key string) error {
+	return fmt.Errorf("networkx: %s attribute uses reserved key %q", kind, key)
+}
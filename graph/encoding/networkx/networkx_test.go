@@ -0,0 +1,313 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package networkx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// The test data below are formatted as written by
+// networkx.readwrite.json_graph with json.dumps.
+var roundTripTests = []struct {
+	name     string
+	directed bool
+	format   Format
+	data     string
+	want     string
+}{
+	{
+		name:   "undirected node-link",
+		format: NodeLink,
+		data: `{"directed": false, "multigraph": false, "graph": {"name": "example"},
+	"nodes": [{"color": "red", "id": "a"}, {"id": "b"}, {"size": 3, "id": 1}],
+	"links": [{"weight": 2.5, "source": "a", "target": "b"}, {"source": "b", "target": 1}]}`,
+		want: `{"directed":false,"graph":{"name":"example"},"links":[{"source":"a","target":"b","weight":2.5},{"source":"b","target":1}],"multigraph":false,"nodes":[{"color":"red","id":"a"},{"id":"b"},{"id":1,"size":3}]}`,
+	},
+	{
+		name:   "undirected adjacency",
+		format: Adjacency,
+		data: `{"directed": false, "multigraph": false, "graph": {"name": "example"},
+	"nodes": [{"color": "red", "id": "a"}, {"id": "b"}, {"size": 3, "id": 1}],
+	"adjacency": [[{"weight": 2.5, "id": "b"}], [{"weight": 2.5, "id": "a"}, {"id": 1}], [{"id": "b"}]]}`,
+		want: `{"adjacency":[[{"id":"b","weight":2.5}],[{"id":"a","weight":2.5},{"id":1}],[{"id":"b"}]],"directed":false,"graph":{"name":"example"},"multigraph":false,"nodes":[{"color":"red","id":"a"},{"id":"b"},{"id":1,"size":3}]}`,
+	},
+	{
+		name:     "directed node-link with edges key",
+		directed: true,
+		format:   NodeLink,
+		data: `{"directed": true, "multigraph": false, "graph": {},
+	"nodes": [{"id": [0, 1]}, {"id": "x", "tags": ["p", "q"]}],
+	"edges": [{"source": [0, 1], "target": "x", "weight": 1}, {"source": "x", "target": [0, 1], "label": {"k": null}}]}`,
+		want: `{"directed":true,"graph":{},"links":[{"source":[0,1],"target":"x","weight":1},{"label":{"k":null},"source":"x","target":[0,1]}],"multigraph":false,"nodes":[{"id":[0,1]},{"id":"x","tags":["p","q"]}]}`,
+	},
+	{
+		name:     "directed adjacency",
+		directed: true,
+		format:   Adjacency,
+		data: `{"directed": true, "multigraph": false, "graph": {},
+	"nodes": [{"id": "a"}, {"id": "b"}, {"id": "c"}],
+	"adjacency": [[{"id": "b"}, {"id": "c", "weight": 0.5}], [{"id": "c"}], []]}`,
+		want: `{"adjacency":[[{"id":"b"},{"id":"c","weight":0.5}],[{"id":"c"}],[]],"directed":true,"graph":{},"multigraph":false,"nodes":[{"id":"a"},{"id":"b"},{"id":"c"}]}`,
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, test := range roundTripTests {
+		var dst encoding.Builder
+		if test.directed {
+			dst = newDirectedGraph()
+		} else {
+			dst = newUndirectedGraph()
+		}
+		err := Unmarshal([]byte(test.data), dst)
+		if err != nil {
+			t.Errorf("unexpected error unmarshaling %s: %v", test.name, err)
+			continue
+		}
+		got, err := Marshal(dst, test.format)
+		if err != nil {
+			t.Errorf("unexpected error marshaling %s: %v", test.name, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("unexpected round trip result for %s:\ngot: %s\nwant:%s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestUnmarshalIntegerIDs(t *testing.T) {
+	const data = `{"directed": true, "multigraph": false, "graph": {},
+	"nodes": [{"id": 4}, {"id": 7}, {"id": 2}],
+	"links": [{"source": 4, "target": 7}, {"source": 7, "target": 2}]}`
+	g := simple.NewDirectedGraph()
+	err := Unmarshal([]byte(data), g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, id := range []int64{2, 4, 7} {
+		if g.Node(id) == nil {
+			t.Errorf("missing node %d", id)
+		}
+	}
+	if !g.HasEdgeFromTo(4, 7) || !g.HasEdgeFromTo(7, 2) || g.Edges().Len() != 2 {
+		t.Errorf("unexpected edges: %v", graph.EdgesOf(g.Edges()))
+	}
+
+	got, err := Marshal(g, NodeLink)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"directed":true,"graph":{},"links":[{"source":4,"target":7},{"source":7,"target":2}],"multigraph":false,"nodes":[{"id":2},{"id":4},{"id":7}]}`
+	if string(got) != want {
+		t.Errorf("unexpected marshaled graph:\ngot: %s\nwant:%s", got, want)
+	}
+}
+
+func TestMarshalEncodingAttributes(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 3})
+	g.AddNode(dotNode{id: 2, attrs: []encoding.Attribute{{Key: "shape", Value: "box"}}})
+
+	got, err := Marshal(g, Adjacency)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"adjacency":[[{"id":1,"weight":3}],[{"id":0,"weight":3}],[]],"directed":false,"graph":{},"multigraph":false,"nodes":[{"id":0},{"id":1},{"id":2,"shape":"box"}]}`
+	if string(got) != want {
+		t.Errorf("unexpected marshaled graph:\ngot: %s\nwant:%s", got, want)
+	}
+}
+
+var unmarshalErrorTests = []struct {
+	name     string
+	directed bool
+	data     string
+}{
+	{
+		name:     "directedness mismatch",
+		directed: true,
+		data:     `{"directed": false, "multigraph": false, "graph": {}, "nodes": [], "links": []}`,
+	},
+	{
+		name: "multigraph",
+		data: `{"directed": false, "multigraph": true, "graph": {}, "nodes": [], "links": []}`,
+	},
+	{
+		name: "unknown node",
+		data: `{"directed": false, "multigraph": false, "graph": {}, "nodes": [{"id": "a"}], "links": [{"source": "a", "target": "b"}]}`,
+	},
+	{
+		name: "duplicate node",
+		data: `{"directed": false, "multigraph": false, "graph": {}, "nodes": [{"id": "a"}, {"id": "a"}], "links": []}`,
+	},
+	{
+		name: "adjacency length",
+		data: `{"directed": false, "multigraph": false, "graph": {}, "nodes": [{"id": "a"}], "adjacency": []}`,
+	},
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, test := range unmarshalErrorTests {
+		var dst encoding.Builder
+		if test.directed {
+			dst = newDirectedGraph()
+		} else {
+			dst = newUndirectedGraph()
+		}
+		err := Unmarshal([]byte(test.data), dst)
+		if err == nil {
+			t.Errorf("expected error for %s", test.name)
+		}
+	}
+
+	err := Unmarshal([]byte(`{"directed": false, "nodes": [{"id": "a"}]}`), simple.NewUndirectedGraph())
+	if err == nil {
+		t.Error("expected error for non-integer identifier")
+	}
+}
+
+func TestMarshalReservedKey(t *testing.T) {
+	g := newUndirectedGraph()
+	n := g.NewNode().(*node)
+	n.attrs = map[string]json.RawMessage{"id": json.RawMessage(`"x"`)}
+	g.AddNode(n)
+	_, err := Marshal(g, NodeLink)
+	if err == nil {
+		t.Error("expected error for reserved node attribute key")
+	}
+}
+
+// undirectedGraph is an undirected graph retaining NetworkX identifiers
+// and attributes.
+type undirectedGraph struct {
+	*simple.UndirectedGraph
+	attributes
+}
+
+func newUndirectedGraph() *undirectedGraph {
+	return &undirectedGraph{UndirectedGraph: simple.NewUndirectedGraph()}
+}
+
+func (g *undirectedGraph) NewNode() graph.Node {
+	return &node{id: g.UndirectedGraph.NewNode().ID()}
+}
+
+func (g *undirectedGraph) NewEdge(from, to graph.Node) graph.Edge {
+	return &edge{from: from, to: to}
+}
+
+func (g *undirectedGraph) NewWeightedEdge(from, to graph.Node, w float64) graph.WeightedEdge {
+	return &weightedEdge{edge: &edge{from: from, to: to}, w: w}
+}
+
+func (g *undirectedGraph) SetWeightedEdge(e graph.WeightedEdge) { g.SetEdge(e) }
+
+// directedGraph is a directed graph retaining NetworkX identifiers
+// and attributes.
+type directedGraph struct {
+	*simple.DirectedGraph
+	attributes
+}
+
+func newDirectedGraph() *directedGraph {
+	return &directedGraph{DirectedGraph: simple.NewDirectedGraph()}
+}
+
+func (g *directedGraph) NewNode() graph.Node {
+	return &node{id: g.DirectedGraph.NewNode().ID()}
+}
+
+func (g *directedGraph) NewEdge(from, to graph.Node) graph.Edge {
+	return &edge{from: from, to: to}
+}
+
+func (g *directedGraph) NewWeightedEdge(from, to graph.Node, w float64) graph.WeightedEdge {
+	return &weightedEdge{edge: &edge{from: from, to: to}, w: w}
+}
+
+func (g *directedGraph) SetWeightedEdge(e graph.WeightedEdge) { g.SetEdge(e) }
+
+type node struct {
+	id   int64
+	nxID json.RawMessage
+	attributes
+}
+
+func (n *node) ID() int64                   { return n.id }
+func (n *node) NetworkXID() json.RawMessage { return n.nxID }
+func (n *node) SetNetworkXID(id json.RawMessage) error {
+	n.nxID = id
+	return nil
+}
+
+type edge struct {
+	from, to graph.Node
+	attributes
+}
+
+func (e *edge) From() graph.Node { return e.from }
+func (e *edge) To() graph.Node   { return e.to }
+func (e *edge) ReversedEdge() graph.Edge {
+	return &edge{from: e.to, to: e.from, attributes: e.attributes}
+}
+
+// weightedEdge is an edge holding its NetworkX weight both as
+// an attribute and as its graph.WeightedEdge weight.
+type weightedEdge struct {
+	*edge
+	w float64
+}
+
+func (e *weightedEdge) Weight() float64 { return e.w }
+func (e *weightedEdge) ReversedEdge() graph.Edge {
+	return &weightedEdge{edge: e.edge.ReversedEdge().(*edge), w: e.w}
+}
+
+type attributes struct {
+	attrs map[string]json.RawMessage
+}
+
+func (a *attributes) NetworkXAttributes() map[string]json.RawMessage { return a.attrs }
+func (a *attributes) SetNetworkXAttributes(attrs map[string]json.RawMessage) error {
+	a.attrs = attrs
+	return nil
+}
+
+// dotNode is a node with encoding attributes.
+type dotNode struct {
+	id    int64
+	attrs []encoding.Attribute
+}
+
+func (n dotNode) ID() int64                        { return n.id }
+func (n dotNode) Attributes() []encoding.Attribute { return n.attrs }
+
+func TestSetEncodingAttributes(t *testing.T) {
+	var got encodingAttributes
+	err := setAttributes(&got, map[string]json.RawMessage{
+		"name": json.RawMessage(`"x"`),
+		"size": json.RawMessage(`3`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := encodingAttributes{{Key: "name", Value: "x"}, {Key: "size", Value: "3"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected attributes: got:%v want:%v", got, want)
+	}
+}
+
+type encodingAttributes []encoding.Attribute
+
+func (a *encodingAttributes) SetAttribute(attr encoding.Attribute) error {
+	*a = append(*a, attr)
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// LocalEdgeConnectivity returns the local edge connectivity of s and t in g,
+// the least number of edges that must be removed from g to leave no path from
+// s to t, and a minimum cut with those edges. Edge weights are ignored. If s
+// or t is not in g, LocalEdgeConnectivity returns zero and an empty cut.
+// LocalEdgeConnectivity will panic if s and t are the same node.
+func LocalEdgeConnectivity(s, t graph.Node, g graph.Graph) (k int, cut Cut) {
+	cut = MinCut(s, t, unitCapacity(g))
+	return int(cut.Capacity), cut
+}
+
+// EdgeConnectivity returns the edge connectivity of g, the least number of
+// edges that must be removed from g to leave it disconnected, and a minimum
+// cut with those edges. Edge weights are ignored. For directed graphs, the
+// connectivity is the least number of edges that must be removed to leave
+// g not strongly connected. If g has fewer than two nodes, EdgeConnectivity
+// returns zero and an empty cut.
+//
+// The edge connectivity is found from the local edge connectivities between
+// one node and every other node, taking |V|-1 maximum flow computations for
+// undirected graphs and 2(|V|-1) for directed graphs.
+func EdgeConnectivity(g graph.Graph) (k int, cut Cut) {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) < 2 {
+		return 0, Cut{}
+	}
+	sort.Sort(ordered.ByID(nodes))
+	_, undirected := g.(graph.Undirected)
+	unit := unitCapacity(g)
+
+	k = -1
+	s := nodes[0]
+	for _, t := range nodes[1:] {
+		c := MinCut(s, t, unit)
+		if k < 0 || int(c.Capacity) < k {
+			k, cut = int(c.Capacity), c
+		}
+		if undirected {
+			continue
+		}
+		c = MinCut(t, s, unit)
+		if int(c.Capacity) < k {
+			k, cut = int(c.Capacity), c
+		}
+	}
+	return k, cut
+}
+
+// LocalVertexConnectivity returns the local vertex connectivity of s and t in
+// g, the least number of nodes other than s and t that must be removed from g
+// to leave no path from s to t, and a minimum set of such nodes sorted by ID.
+// If s or t is not in g, LocalVertexConnectivity returns zero and a nil
+// separator. LocalVertexConnectivity will panic if s and t are the same node
+// or if there is an edge from s to t, since no set of other nodes separates
+// them.
+func LocalVertexConnectivity(s, t graph.Node, g graph.Graph) (k int, separator []graph.Node) {
+	if g.Edge(s.ID(), t.ID()) != nil {
+		panic("flow: no vertex separator for adjacent nodes")
+	}
+	return localVertexConnectivity(s, t, SplitNodes(infiniteCapacity(g), unitNode))
+}
+
+// localVertexConnectivity returns the local vertex connectivity of s and
+// t in the graph that has been split with unit node capacities.
+func localVertexConnectivity(s, t graph.Node, split *NodeSplit) (k int, separator []graph.Node) {
+	out := split.Out(s.ID())
+	in := split.In(t.ID())
+	if out == nil || in == nil {
+		return 0, nil
+	}
+	c := Dinic(out, in, split).MinCut()
+	for _, e := range c.Edges {
+		separator = append(separator, split.Original(e.From().ID()))
+	}
+	sort.Sort(ordered.ByID(separator))
+	return len(separator), separator
+}
+
+// VertexConnectivity returns the vertex connectivity of g, the least number of
+// nodes that must be removed from g to leave it disconnected, and a minimum set
+// of such nodes sorted by ID. For directed graphs, the connectivity is the least
+// number of nodes that must be removed to leave g not strongly connected. If g
+// is disconnected, VertexConnectivity returns zero and a nil separator. If no
+// set of nodes disconnects g, either because g is complete or has fewer than
+// two nodes, VertexConnectivity returns |V|-1, or zero for an empty graph, and
+// a nil separator.
+//
+// The vertex connectivity is found with Even's algorithm, computing the local
+// vertex connectivities of non-adjacent pairs of nodes from the first k+1
+// nodes, where k is the least connectivity found so far.
+//
+// See Even, "An algorithm for determining whether the connectivity of a graph
+// is at least k", SIAM J. Comput. 4(3):393-396 (1975) doi:10.1137/0204034.
+func VertexConnectivity(g graph.Graph) (k int, separator []graph.Node) {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) < 2 {
+		return 0, nil
+	}
+	sort.Sort(ordered.ByID(nodes))
+	_, undirected := g.(graph.Undirected)
+	split := SplitNodes(infiniteCapacity(g), unitNode)
+
+	k = len(nodes) - 1
+	for i := 0; i <= k && i < len(nodes); i++ {
+		u := nodes[i]
+		for _, v := range nodes[i+1:] {
+			if g.Edge(u.ID(), v.ID()) == nil {
+				if c, sep := localVertexConnectivity(u, v, split); c < k {
+					k, separator = c, sep
+				}
+			}
+			if undirected || g.Edge(v.ID(), u.ID()) != nil {
+				continue
+			}
+			if c, sep := localVertexConnectivity(v, u, split); c < k {
+				k, separator = c, sep
+			}
+		}
+	}
+	return k, separator
+}
+
+// unitNode returns a unit node capacity.
+func unitNode(int64) float64 { return 1 }
+
+// unitCapacity returns a view of g without edge weights,
+// so that each edge has unit capacity.
+func unitCapacity(g graph.Graph) graph.Graph {
+	if u, ok := g.(graph.Undirected); ok {
+		return struct{ graph.Undirected }{u}
+	}
+	return struct{ graph.Graph }{g}
+}
+
+// infiniteCapacity returns a view of g where each
+// edge has an infinite capacity.
+func infiniteCapacity(g graph.Graph) graph.Graph {
+	if u, ok := g.(graph.Undirected); ok {
+		return infiniteUndirected{u}
+	}
+	return infiniteGraph{g}
+}
+
+type infiniteGraph struct{ graph.Graph }
+
+func (infiniteGraph) Weight(xid, yid int64) (w float64, ok bool) { return math.Inf(1), true }
+func (g infiniteGraph) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	return weightedEdgeOf(g.Edge(uid, vid))
+}
+
+type infiniteUndirected struct{ graph.Undirected }
+
+func (infiniteUndirected) Weight(xid, yid int64) (w float64, ok bool) { return math.Inf(1), true }
+func (g infiniteUndirected) WeightedEdge(uid, vid int64) graph.WeightedEdge {
+	return weightedEdgeOf(g.Edge(uid, vid))
+}
+
+// weightedEdgeOf returns e as an infinite weight
+// graph.WeightedEdge, or nil if e is nil.
+func weightedEdgeOf(e graph.Edge) graph.WeightedEdge {
+	if e == nil {
+		return nil
+	}
+	return infiniteEdge{e}
+}
+
+type infiniteEdge struct{ graph.Edge }
+
+func (infiniteEdge) Weight() float64 { return math.Inf(1) }
@@ -0,0 +1,245 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+var connectivityTests = []struct {
+	name     string
+	directed bool
+	nodes    []int64
+	edges    [][2]int64
+
+	edge, vertex int
+}{
+	{name: "empty"},
+	{name: "single", nodes: []int64{0}},
+	{
+		name:  "disconnected",
+		edges: [][2]int64{{0, 1}, {2, 3}},
+		edge:  0, vertex: 0,
+	},
+	{
+		name:  "path",
+		edges: [][2]int64{{0, 1}, {1, 2}, {2, 3}},
+		edge:  1, vertex: 1,
+	},
+	{
+		name:  "cycle",
+		edges: [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}},
+		edge:  2, vertex: 2,
+	},
+	{
+		name:  "complete",
+		edges: [][2]int64{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}},
+		edge:  3, vertex: 3,
+	},
+	{
+		name: "bowtie",
+		edges: [][2]int64{
+			{0, 1}, {1, 2}, {2, 0},
+			{2, 3}, {3, 4}, {4, 2},
+		},
+		edge: 2, vertex: 1,
+	},
+	{
+		name: "bridged cliques",
+		edges: [][2]int64{
+			{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3},
+			{4, 5}, {4, 6}, {4, 7}, {5, 6}, {5, 7}, {6, 7},
+			{3, 4},
+		},
+		edge: 1, vertex: 1,
+	},
+	{
+		name: "petersen",
+		edges: [][2]int64{
+			{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+			{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+			{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5},
+		},
+		edge: 3, vertex: 3,
+	},
+	{
+		name:     "directed cycle",
+		directed: true,
+		edges:    [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 0}},
+		edge:     1, vertex: 1,
+	},
+	{
+		name:     "directed not strongly connected",
+		directed: true,
+		edges:    [][2]int64{{0, 1}, {1, 2}, {2, 0}, {2, 3}},
+		edge:     0, vertex: 0,
+	},
+	{
+		name:     "directed double cycle",
+		directed: true,
+		edges: [][2]int64{
+			{0, 1}, {1, 2}, {2, 3}, {3, 0},
+			{1, 0}, {2, 1}, {3, 2}, {0, 3},
+		},
+		edge: 2, vertex: 2,
+	},
+}
+
+func connectivityGraph(directed bool, nodes []int64, edges [][2]int64) graph.Graph {
+	var g interface {
+		graph.Graph
+		graph.NodeAdder
+		graph.EdgeAdder
+	}
+	if directed {
+		g = simple.NewDirectedGraph()
+	} else {
+		g = simple.NewUndirectedGraph()
+	}
+	for _, id := range nodes {
+		g.AddNode(simple.Node(id))
+	}
+	for _, e := range edges {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	return g
+}
+
+func TestEdgeConnectivity(t *testing.T) {
+	t.Parallel()
+	for _, test := range connectivityTests {
+		g := connectivityGraph(test.directed, test.nodes, test.edges)
+		k, cut := EdgeConnectivity(g)
+		if k != test.edge {
+			t.Errorf("%s: unexpected edge connectivity: got:%d want:%d", test.name, k, test.edge)
+		}
+		if g.Nodes().Len() < 2 {
+			continue
+		}
+		if len(cut.Edges) != k {
+			t.Errorf("%s: unexpected number of cut edges: got:%d want:%d", test.name, len(cut.Edges), k)
+		}
+		if connected(removeEdges(g, cut.Edges), test.directed) {
+			t.Errorf("%s: graph connected after removing cut edges %v", test.name, cut.Edges)
+		}
+	}
+}
+
+func TestVertexConnectivity(t *testing.T) {
+	t.Parallel()
+	for _, test := range connectivityTests {
+		g := connectivityGraph(test.directed, test.nodes, test.edges)
+		k, sep := VertexConnectivity(g)
+		if k != test.vertex {
+			t.Errorf("%s: unexpected vertex connectivity: got:%d want:%d", test.name, k, test.vertex)
+		}
+		if sep == nil {
+			continue
+		}
+		if len(sep) != k {
+			t.Errorf("%s: unexpected separator size: got:%d want:%d", test.name, len(sep), k)
+		}
+		if connected(removeNodes(g, sep), test.directed) {
+			t.Errorf("%s: graph connected after removing separator %v", test.name, sep)
+		}
+	}
+}
+
+func TestLocalConnectivity(t *testing.T) {
+	t.Parallel()
+	// Two paths of length two and one of length
+	// three join 0 and 5 through distinct nodes,
+	// and node 1 has an extra edge to 5.
+	g := connectivityGraph(false, nil, [][2]int64{
+		{0, 1}, {1, 5},
+		{0, 2}, {2, 5},
+		{0, 3}, {3, 4}, {4, 5},
+		{1, 4},
+	})
+	k, cut := LocalEdgeConnectivity(simple.Node(0), simple.Node(5), g)
+	if k != 3 || cut.Capacity != 3 {
+		t.Errorf("unexpected local edge connectivity: got:%d want:3", k)
+	}
+	k, sep := LocalVertexConnectivity(simple.Node(0), simple.Node(5), g)
+	if k != 3 || len(sep) != 3 {
+		t.Errorf("unexpected local vertex connectivity: got:%d %v want:3", k, sep)
+	}
+	h := removeNodes(g, sep)
+	if topo.PathExistsIn(h, simple.Node(0), simple.Node(5)) {
+		t.Errorf("path remains after removing separator %v", sep)
+	}
+
+	k, sep = LocalVertexConnectivity(simple.Node(0), simple.Node(10), g)
+	if k != 0 || sep != nil {
+		t.Errorf("unexpected local vertex connectivity for missing node: got:%d %v", k, sep)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for adjacent nodes")
+		}
+	}()
+	LocalVertexConnectivity(simple.Node(0), simple.Node(1), g)
+}
+
+// removeEdges returns a copy of g without the given edges.
+func removeEdges(g graph.Graph, edges []graph.Edge) graph.Graph {
+	_, undirected := g.(graph.Undirected)
+	removed := make(map[[2]int64]bool)
+	for _, e := range edges {
+		removed[[2]int64{e.From().ID(), e.To().ID()}] = true
+		if undirected {
+			removed[[2]int64{e.To().ID(), e.From().ID()}] = true
+		}
+	}
+	var kept [][2]int64
+	var nodes []int64
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		nodes = append(nodes, u.ID())
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			e := [2]int64{u.ID(), v.ID()}
+			if !removed[e] {
+				kept = append(kept, e)
+			}
+		}
+	}
+	return connectivityGraph(!undirected, nodes, kept)
+}
+
+// removeNodes returns a copy of g without the given nodes.
+func removeNodes(g graph.Graph, nodes []graph.Node) graph.Graph {
+	_, undirected := g.(graph.Undirected)
+	removed := make(map[int64]bool)
+	for _, n := range nodes {
+		removed[n.ID()] = true
+	}
+	var kept [][2]int64
+	var ids []int64
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		if removed[u.ID()] {
+			continue
+		}
+		ids = append(ids, u.ID())
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if !removed[v.ID()] {
+				kept = append(kept, [2]int64{u.ID(), v.ID()})
+			}
+		}
+	}
+	return connectivityGraph(!undirected, ids, kept)
+}
+
+// connected returns whether g is connected, or
+// strongly connected if directed is true.
+func connected(g graph.Graph, directed bool) bool {
+	if directed {
+		return len(topo.TarjanSCC(g.(graph.Directed))) <= 1
+	}
+	return len(topo.ConnectedComponents(g.(graph.Undirected))) <= 1
+}
@@ -0,0 +1,104 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// Cut is an s-t cut of a capacitated graph.
+type Cut struct {
+	// Source and Sink hold the nodes on the
+	// source and sink sides of the cut, sorted
+	// by ID.
+	Source, Sink []graph.Node
+
+	// Edges holds the edges leading from the
+	// source side of the cut to the sink side,
+	// sorted by the IDs of their end nodes.
+	Edges []graph.Edge
+
+	// Capacity is the sum of the capacities
+	// of the cut edges.
+	Capacity float64
+}
+
+// MinCut returns a minimum s-t cut in g, using Dinic to find a maximum flow from
+// s to t. See Dinic for the interpretation of edge capacities and the conditions
+// under which MinCut will panic.
+func MinCut(s, t graph.Node, g graph.Graph) Cut {
+	return Dinic(s, t, g).MinCut()
+}
+
+// MinCut returns the minimum s-t cut corresponding to the maximum flow f. The
+// source side of the cut holds the nodes reachable from the source node through
+// edges with remaining capacity, and the capacity of the cut is equal to the
+// value of the flow. The cut edges are obtained from the Edge method of the
+// analysed graph, called with the ID of the source side node first.
+//
+// If f has an infinite value, there is no finite cut and the returned cut is not
+// a minimum cut. If the source or sink of f was not in the analysed graph, the
+// returned cut is empty.
+func (f Flow) MinCut() Cut {
+	if f.res == nil {
+		return Cut{}
+	}
+	r := f.res
+
+	inSource := make([]bool, len(r.nodes))
+	s := r.indexOf[f.source.ID()]
+	inSource[s] = true
+	queue := []int{s}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, i := range r.adj[u] {
+			v := r.arcs[i].to
+			if !inSource[v] && r.remaining(i) > 0 {
+				inSource[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+
+	var c Cut
+	for i, n := range r.nodes {
+		if inSource[i] {
+			c.Source = append(c.Source, n)
+		} else {
+			c.Sink = append(c.Sink, n)
+		}
+	}
+	sort.Sort(ordered.ByID(c.Source))
+	sort.Sort(ordered.ByID(c.Sink))
+
+	for e, i := range r.arcOf {
+		if !inSource[r.indexOf[e[0]]] || inSource[r.arcs[i].to] {
+			continue
+		}
+		c.Edges = append(c.Edges, r.g.Edge(e[0], e[1]))
+		c.Capacity += r.arcs[i].cap
+	}
+	sort.Sort(byEndIDs(c.Edges))
+
+	return c
+}
+
+// byEndIDs sorts a slice of graph.Edge by the
+// IDs of their from and then to nodes.
+type byEndIDs []graph.Edge
+
+func (e byEndIDs) Len() int { return len(e) }
+func (e byEndIDs) Less(i, j int) bool {
+	a, b := e[i].From().ID(), e[j].From().ID()
+	if a != b {
+		return a < b
+	}
+	return e[i].To().ID() < e[j].To().ID()
+}
+func (e byEndIDs) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
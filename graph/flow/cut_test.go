@@ -0,0 +1,133 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMinCut(t *testing.T) {
+	t.Parallel()
+	for _, test := range maxFlowTests {
+		if math.IsInf(test.want, 1) {
+			continue
+		}
+		var g interface {
+			graph.Graph
+			graph.WeightedBuilder
+		}
+		if test.undirected {
+			g = simple.NewWeightedUndirectedGraph(0, 0)
+		} else {
+			g = simple.NewWeightedDirectedGraph(0, 0)
+		}
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+
+		c := MinCut(simple.Node(test.s), simple.Node(test.t), g)
+		if c.Capacity != test.want {
+			t.Errorf("%q: unexpected cut capacity: got:%v want:%v", test.name, c.Capacity, test.want)
+		}
+		checkCut(t, test.name, c, g, test.s, test.t)
+	}
+}
+
+func TestMinCutRandom(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		const n = 9
+		var g interface {
+			graph.Graph
+			graph.WeightedBuilder
+		}
+		if trial%2 == 1 {
+			g = simple.NewWeightedUndirectedGraph(0, 0)
+		} else {
+			g = simple.NewWeightedDirectedGraph(0, 0)
+		}
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 3*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(10))})
+		}
+
+		c := MinCut(simple.Node(0), simple.Node(n-1), g)
+		if want := bruteForceMinCut(g, 0, n-1, n); c.Capacity != want {
+			t.Errorf("trial %d: unexpected cut capacity: got:%v want:%v", trial, c.Capacity, want)
+		}
+		checkCut(t, "random", c, g, 0, n-1)
+	}
+}
+
+func TestMinCutMissing(t *testing.T) {
+	t.Parallel()
+	g := simple.NewWeightedDirectedGraph(0, 0)
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	c := MinCut(simple.Node(0), simple.Node(2), g)
+	if c.Source != nil || c.Sink != nil || c.Edges != nil || c.Capacity != 0 {
+		t.Errorf("unexpected cut for missing sink: %+v", c)
+	}
+}
+
+// checkCut checks that c is a partition of the nodes of g separating
+// s from t, and that the cut edges are the edges crossing the cut.
+func checkCut(t *testing.T, name string, c Cut, g graph.Graph, s, tid int64) {
+	t.Helper()
+	side := make(map[int64]bool)
+	for _, n := range c.Source {
+		side[n.ID()] = true
+	}
+	for _, n := range c.Sink {
+		if side[n.ID()] {
+			t.Errorf("%q: node %d on both sides of cut", name, n.ID())
+		}
+	}
+	if len(c.Source)+len(c.Sink) != g.Nodes().Len() {
+		t.Errorf("%q: cut is not a partition: got %d+%d nodes, want %d",
+			name, len(c.Source), len(c.Sink), g.Nodes().Len())
+	}
+	if !side[s] || side[tid] {
+		t.Errorf("%q: cut does not separate %d from %d", name, s, tid)
+	}
+
+	var want int
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		for _, v := range graph.NodesOf(g.From(u.ID())) {
+			if side[u.ID()] && !side[v.ID()] {
+				want++
+			}
+		}
+	}
+	if len(c.Edges) != want {
+		t.Errorf("%q: unexpected number of cut edges: got:%d want:%d", name, len(c.Edges), want)
+	}
+	var capacity float64
+	for i, e := range c.Edges {
+		if !side[e.From().ID()] || side[e.To().ID()] {
+			t.Errorf("%q: cut edge %d->%d does not cross the cut", name, e.From().ID(), e.To().ID())
+		}
+		if i > 0 && !byEndIDs(c.Edges).Less(i-1, i) {
+			t.Errorf("%q: cut edges not sorted", name)
+		}
+		w, _ := g.(graph.Weighted).Weight(e.From().ID(), e.To().ID())
+		capacity += w
+	}
+	if capacity != c.Capacity {
+		t.Errorf("%q: cut edge capacities do not sum to cut capacity: got:%v want:%v", name, capacity, c.Capacity)
+	}
+}
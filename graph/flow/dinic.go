@@ -0,0 +1,121 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Dinic returns a maximum flow from s to t in g. If g implements graph.Weighted,
+// edge weights are used as edge capacities, otherwise each edge has unit capacity.
+// Each edge of an undirected graph may carry flow in either direction up to its
+// capacity. If s or t is not in g, the returned flow has zero value. If there is a
+// path of infinite capacity edges from s to t, the returned flow has an infinite
+// value and the flows along edges are not valid. Dinic will panic if s and t are
+// the same node or g has a negative or NaN capacity.
+//
+// Dinic's algorithm repeatedly builds a level graph of the shortest paths from s
+// to t in the residual network and saturates it with a blocking flow. The time
+// complexity of Dinic is O(|V|^2.|E|), and O(|E|.sqrt(|V|)) for unit capacity
+// graphs.
+//
+// See Dinitz, "Algorithm for solution of a problem of maximum flow in a network
+// with power estimation", Soviet Math. Doklady 11:1277-1280 (1970).
+func Dinic(s, t graph.Node, g graph.Graph) Flow {
+	sid := s.ID()
+	tid := t.ID()
+	if sid == tid {
+		panic("flow: source and sink are the same node")
+	}
+	f := Flow{source: s, sink: t}
+	if g.Node(sid) == nil || g.Node(tid) == nil {
+		return f
+	}
+	f.res = newResidual(g)
+	d := dinic{
+		residual: f.res,
+		level:    make([]int, len(f.res.nodes)),
+		next:     make([]int, len(f.res.nodes)),
+		s:        f.res.indexOf[sid],
+		t:        f.res.indexOf[tid],
+	}
+	for d.levels() {
+		for i := range d.next {
+			d.next[i] = 0
+		}
+		for {
+			pushed := d.augment(d.s, math.Inf(1))
+			if pushed == 0 {
+				break
+			}
+			f.value += pushed
+			if math.IsInf(pushed, 1) {
+				return f
+			}
+		}
+	}
+	return f
+}
+
+// dinic holds the state of Dinic's algorithm.
+type dinic struct {
+	*residual
+
+	// level holds the distance from s to each
+	// node in the residual network, or -1 for
+	// nodes not reachable from s.
+	level []int
+	// next holds the index into adj of the
+	// next arc to try for each node.
+	next []int
+
+	s, t int
+}
+
+// levels finds the level of each node in the residual network
+// and returns whether t is reachable from s.
+func (d *dinic) levels() bool {
+	for i := range d.level {
+		d.level[i] = -1
+	}
+	d.level[d.s] = 0
+	queue := []int{d.s}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, i := range d.adj[u] {
+			v := d.arcs[i].to
+			if d.level[v] < 0 && d.remaining(i) > 0 {
+				d.level[v] = d.level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return d.level[d.t] >= 0
+}
+
+// augment pushes up to limit flow from u to t along level graph
+// arcs and returns the amount of flow that was pushed.
+func (d *dinic) augment(u int, limit float64) float64 {
+	if u == d.t {
+		return limit
+	}
+	for ; d.next[u] < len(d.adj[u]); d.next[u]++ {
+		i := d.adj[u][d.next[u]]
+		v := d.arcs[i].to
+		c := d.remaining(i)
+		if d.level[v] != d.level[u]+1 || c <= 0 {
+			continue
+		}
+		pushed := d.augment(v, math.Min(limit, c))
+		if pushed > 0 {
+			d.push(i, pushed)
+			return pushed
+		}
+	}
+	return 0
+}
@@ -0,0 +1,220 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var maxFlowTests = []struct {
+	name       string
+	undirected bool
+	edges      []simple.WeightedEdge
+	s, t       int64
+	want       float64
+}{
+	{
+		// Figure 26.6 from Cormen et al. Introduction to Algorithms.
+		name: "clrs",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 16},
+			{F: simple.Node(0), T: simple.Node(2), W: 13},
+			{F: simple.Node(2), T: simple.Node(1), W: 4},
+			{F: simple.Node(1), T: simple.Node(3), W: 12},
+			{F: simple.Node(3), T: simple.Node(2), W: 9},
+			{F: simple.Node(2), T: simple.Node(4), W: 14},
+			{F: simple.Node(4), T: simple.Node(3), W: 7},
+			{F: simple.Node(3), T: simple.Node(5), W: 20},
+			{F: simple.Node(4), T: simple.Node(5), W: 4},
+		},
+		s: 0, t: 5,
+		want: 23,
+	},
+	{
+		name: "antiparallel",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(1), T: simple.Node(0), W: 5},
+			{F: simple.Node(1), T: simple.Node(2), W: 2},
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+		},
+		s: 0, t: 2,
+		want: 3,
+	},
+	{
+		name: "no path",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(2), T: simple.Node(1), W: 5},
+		},
+		s: 0, t: 2,
+		want: 0,
+	},
+	{
+		name:       "undirected",
+		undirected: true,
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 2},
+			{F: simple.Node(0), T: simple.Node(2), W: 4},
+			{F: simple.Node(1), T: simple.Node(2), W: 3},
+			{F: simple.Node(1), T: simple.Node(3), W: 4},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		},
+		s: 0, t: 3,
+		want: 5,
+	},
+	{
+		name: "infinite",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: math.Inf(1)},
+			{F: simple.Node(1), T: simple.Node(2), W: math.Inf(1)},
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+		},
+		s: 0, t: 2,
+		want: math.Inf(1),
+	},
+}
+
+func TestDinic(t *testing.T) {
+	t.Parallel()
+	for _, test := range maxFlowTests {
+		var g interface {
+			graph.Graph
+			graph.WeightedBuilder
+		}
+		if test.undirected {
+			g = simple.NewWeightedUndirectedGraph(0, 0)
+		} else {
+			g = simple.NewWeightedDirectedGraph(0, 0)
+		}
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+
+		f := Dinic(simple.Node(test.s), simple.Node(test.t), g)
+		if f.Source().ID() != test.s || f.Sink().ID() != test.t {
+			t.Errorf("%q: unexpected terminals: got:%d->%d want:%d->%d",
+				test.name, f.Source().ID(), f.Sink().ID(), test.s, test.t)
+		}
+		if f.Value() != test.want {
+			t.Errorf("%q: unexpected flow value: got:%v want:%v", test.name, f.Value(), test.want)
+		}
+		if !math.IsInf(test.want, 1) {
+			checkFlow(t, test.name, f, g)
+		}
+	}
+}
+
+func TestDinicRandom(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		const n = 9
+		var g interface {
+			graph.Graph
+			graph.WeightedBuilder
+		}
+		undirected := trial%2 == 1
+		if undirected {
+			g = simple.NewWeightedUndirectedGraph(0, 0)
+		} else {
+			g = simple.NewWeightedDirectedGraph(0, 0)
+		}
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 3*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(10))})
+		}
+
+		f := Dinic(simple.Node(0), simple.Node(n-1), g)
+		if want := bruteForceMinCut(g, 0, n-1, n); f.Value() != want {
+			t.Errorf("trial %d: unexpected flow value: got:%v want:%v", trial, f.Value(), want)
+		}
+		checkFlow(t, "random", f, g)
+	}
+}
+
+// bruteForceMinCut returns the capacity of the minimum s-t cut in g,
+// which must have nodes with IDs in [0, n), by trying every cut.
+func bruteForceMinCut(g graph.Graph, s, t, n int) float64 {
+	min := math.Inf(1)
+	for set := 0; set < 1<<uint(n); set++ {
+		if set&(1<<uint(s)) == 0 || set&(1<<uint(t)) != 0 {
+			continue
+		}
+		var c float64
+		for u := 0; u < n; u++ {
+			if set&(1<<uint(u)) == 0 {
+				continue
+			}
+			for _, v := range graph.NodesOf(g.From(int64(u))) {
+				if set&(1<<uint(v.ID())) != 0 {
+					continue
+				}
+				w, _ := g.(graph.Weighted).Weight(int64(u), v.ID())
+				c += w
+			}
+		}
+		if c < min {
+			min = c
+		}
+	}
+	return min
+}
+
+// checkFlow checks that f satisfies the capacity
+// and conservation constraints of g.
+func checkFlow(t *testing.T, name string, f Flow, g graph.Graph) {
+	t.Helper()
+	net := make(map[int64]float64)
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			vid := v.ID()
+			flow := f.Flow(uid, vid)
+			c, _ := g.(graph.Weighted).Weight(uid, vid)
+			if flow < 0 || flow > c {
+				t.Errorf("%q: flow along %d->%d outside capacity: %v not in [0, %v]", name, uid, vid, flow, c)
+			}
+			net[uid] -= flow
+			net[vid] += flow
+		}
+	}
+	for id, v := range net {
+		switch id {
+		case f.Source().ID():
+			v = -v
+			fallthrough
+		case f.Sink().ID():
+			if v != f.Value() {
+				t.Errorf("%q: unexpected net flow at terminal %d: got:%v want:%v", name, id, v, f.Value())
+			}
+		default:
+			if v != 0 {
+				t.Errorf("%q: flow not conserved at %d: %v", name, id, v)
+			}
+		}
+	}
+
+	fg := simple.NewWeightedDirectedGraph(0, 0)
+	f.Graph(fg)
+	for _, e := range graph.WeightedEdgesOf(fg.WeightedEdges()) {
+		if got := f.Flow(e.From().ID(), e.To().ID()); e.Weight() != got {
+			t.Errorf("%q: unexpected flow graph edge weight for %d->%d: got:%v want:%v",
+				name, e.From().ID(), e.To().ID(), e.Weight(), got)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// EdgeDisjointPaths returns a maximum set of edge-disjoint paths from s to t
+// in g. Each path is returned as the sequence of nodes from s to t, and the
+// paths are sorted by length and then by the IDs of their nodes. By Menger's
+// theorem, the number of paths is the local edge connectivity of s and t. Edge
+// weights are ignored. Each edge of an undirected graph is used by at most one
+// path in either direction. If s or t is not in g, EdgeDisjointPaths returns
+// nil. EdgeDisjointPaths will panic if s and t are the same node.
+//
+// The paths are obtained by decomposing a maximum unit capacity flow found
+// by Dinic.
+func EdgeDisjointPaths(s, t graph.Node, g graph.Graph) [][]graph.Node {
+	f := Dinic(s, t, unitCapacity(g))
+	if f.res == nil {
+		return nil
+	}
+	var paths [][]graph.Node
+	for _, p := range f.paths() {
+		path := make([]graph.Node, len(p))
+		for i, u := range p {
+			path[i] = f.res.nodes[u]
+		}
+		paths = append(paths, path)
+	}
+	sortPaths(paths)
+	return paths
+}
+
+// VertexDisjointPaths returns a maximum set of paths from s to t in g that
+// share no nodes other than s and t. Each path is returned as the sequence
+// of nodes from s to t, and the paths are sorted by length and then by the
+// IDs of their nodes. By Menger's theorem, if s and t are not adjacent the
+// number of paths is the local vertex connectivity of s and t. If s and t
+// are adjacent, the path holding only s and t is included. Edge weights are
+// ignored. If s or t is not in g, VertexDisjointPaths returns nil.
+// VertexDisjointPaths will panic if s and t are the same node.
+//
+// The paths are obtained by decomposing a maximum flow found by Dinic in the
+// node split of g with unit node and edge capacities.
+func VertexDisjointPaths(s, t graph.Node, g graph.Graph) [][]graph.Node {
+	if s.ID() == t.ID() {
+		panic("flow: source and sink are the same node")
+	}
+	split := SplitNodes(unitCapacity(g), unitNode)
+	out := split.Out(s.ID())
+	in := split.In(t.ID())
+	if out == nil || in == nil {
+		return nil
+	}
+	f := Dinic(out, in, split)
+	var paths [][]graph.Node
+	for _, p := range f.paths() {
+		// Each node of g other than s and t appears
+		// on the path as its in node followed by its
+		// out node.
+		var path []graph.Node
+		for _, u := range p {
+			n := split.Original(f.res.nodes[u].ID())
+			if len(path) == 0 || path[len(path)-1].ID() != n.ID() {
+				path = append(path, n)
+			}
+		}
+		paths = append(paths, path)
+	}
+	sortPaths(paths)
+	return paths
+}
+
+// paths returns a decomposition of the integral flow f into paths from
+// its source to its sink, each path held as the indices of its nodes in
+// the residual network. Flow around cycles is discarded.
+func (f Flow) paths() [][]int {
+	r := f.res
+	s := r.indexOf[f.source.ID()]
+	t := r.indexOf[f.sink.ID()]
+
+	flow := make([]int, len(r.arcs))
+	for i, a := range r.arcs {
+		if a.flow > 0 {
+			flow[i] = int(math.Round(a.flow))
+		}
+	}
+	// next holds the position in adj of the next
+	// arc leaving each node that may carry flow.
+	next := make([]int, len(r.nodes))
+	// pos holds the position of each node
+	// on the current path, or -1.
+	pos := make([]int, len(r.nodes))
+	for i := range pos {
+		pos[i] = -1
+	}
+
+	var paths [][]int
+	for {
+		path := []int{s}
+		var used []int
+		pos[s] = 0
+		for u := s; u != t; {
+			for next[u] < len(r.adj[u]) && flow[r.adj[u][next[u]]] == 0 {
+				next[u]++
+			}
+			if next[u] == len(r.adj[u]) {
+				// By flow conservation, this only
+				// happens when the source is exhausted.
+				return paths
+			}
+			i := r.adj[u][next[u]]
+			v := r.arcs[i].to
+			if p := pos[v]; p >= 0 {
+				// Cancel the flow around the cycle
+				// closed by the arc and continue
+				// from its start.
+				flow[i]--
+				for _, j := range used[p:] {
+					flow[j]--
+				}
+				for _, w := range path[p+1:] {
+					pos[w] = -1
+				}
+				path = path[:p+1]
+				used = used[:p]
+				u = v
+				continue
+			}
+			pos[v] = len(path)
+			path = append(path, v)
+			used = append(used, i)
+			u = v
+		}
+		for _, j := range used {
+			flow[j]--
+		}
+		for _, u := range path {
+			pos[u] = -1
+		}
+		paths = append(paths, path)
+	}
+}
+
+// sortPaths sorts paths by length and then by the IDs of their nodes.
+func sortPaths(paths [][]graph.Node) {
+	sort.Slice(paths, func(i, j int) bool {
+		a, b := paths[i], paths[j]
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		for k := range a {
+			if a[k].ID() != b[k].ID() {
+				return a[k].ID() < b[k].ID()
+			}
+		}
+		return false
+	})
+}
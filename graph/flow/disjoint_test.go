@@ -0,0 +1,153 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var disjointPathsTests = []struct {
+	name     string
+	directed bool
+	edges    [][2]int64
+	s, t     int64
+
+	edge, vertex int
+}{
+	{
+		name:  "path",
+		edges: [][2]int64{{0, 1}, {1, 2}, {2, 3}},
+		s:     0, t: 3,
+		edge: 1, vertex: 1,
+	},
+	{
+		name:  "disconnected",
+		edges: [][2]int64{{0, 1}, {2, 3}},
+		s:     0, t: 3,
+		edge: 0, vertex: 0,
+	},
+	{
+		name: "bowtie",
+		edges: [][2]int64{
+			{0, 1}, {1, 2}, {2, 0},
+			{2, 3}, {3, 4}, {4, 2},
+		},
+		s: 0, t: 4,
+		edge: 2, vertex: 1,
+	},
+	{
+		name: "shared middle",
+		edges: [][2]int64{
+			{0, 1}, {1, 5},
+			{0, 2}, {2, 5},
+			{0, 3}, {3, 4}, {4, 5},
+			{1, 4},
+		},
+		s: 0, t: 5,
+		edge: 3, vertex: 3,
+	},
+	{
+		name:  "adjacent",
+		edges: [][2]int64{{0, 1}, {0, 2}, {2, 1}, {0, 3}, {3, 2}},
+		s:     0, t: 1,
+		edge: 2, vertex: 2,
+	},
+	{
+		name: "petersen",
+		edges: [][2]int64{
+			{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+			{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+			{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5},
+		},
+		s: 0, t: 7,
+		edge: 3, vertex: 3,
+	},
+	{
+		name:     "directed",
+		directed: true,
+		edges: [][2]int64{
+			{0, 1}, {0, 2}, {1, 3}, {2, 3},
+			{3, 4}, {3, 5}, {4, 6}, {5, 6},
+			{2, 1}, {6, 0},
+		},
+		s: 0, t: 6,
+		edge: 2, vertex: 1,
+	},
+}
+
+func TestDisjointPaths(t *testing.T) {
+	t.Parallel()
+	for _, test := range disjointPathsTests {
+		g := connectivityGraph(test.directed, nil, test.edges)
+		s, tn := simple.Node(test.s), simple.Node(test.t)
+
+		paths := EdgeDisjointPaths(s, tn, g)
+		if len(paths) != test.edge {
+			t.Errorf("%s: unexpected number of edge-disjoint paths: got:%d want:%d", test.name, len(paths), test.edge)
+		}
+		checkPaths(t, test.name, g, paths, test.s, test.t)
+		used := make(map[[2]int64]bool)
+		for _, p := range paths {
+			for i := 1; i < len(p); i++ {
+				e := [2]int64{p[i-1].ID(), p[i].ID()}
+				if !test.directed && e[0] > e[1] {
+					e[0], e[1] = e[1], e[0]
+				}
+				if used[e] {
+					t.Errorf("%s: edge %v used by more than one path", test.name, e)
+				}
+				used[e] = true
+			}
+		}
+
+		paths = VertexDisjointPaths(s, tn, g)
+		if len(paths) != test.vertex {
+			t.Errorf("%s: unexpected number of vertex-disjoint paths: got:%d want:%d", test.name, len(paths), test.vertex)
+		}
+		checkPaths(t, test.name, g, paths, test.s, test.t)
+		seen := make(map[int64]bool)
+		for _, p := range paths {
+			for _, n := range p[1 : len(p)-1] {
+				if seen[n.ID()] {
+					t.Errorf("%s: node %d used by more than one path", test.name, n.ID())
+				}
+				seen[n.ID()] = true
+			}
+		}
+	}
+}
+
+func TestDisjointPathsMissing(t *testing.T) {
+	t.Parallel()
+	g := connectivityGraph(false, nil, [][2]int64{{0, 1}})
+	if paths := EdgeDisjointPaths(simple.Node(0), simple.Node(2), g); paths != nil {
+		t.Errorf("unexpected edge-disjoint paths for missing node: %v", paths)
+	}
+	if paths := VertexDisjointPaths(simple.Node(2), simple.Node(0), g); paths != nil {
+		t.Errorf("unexpected vertex-disjoint paths for missing node: %v", paths)
+	}
+}
+
+// checkPaths checks that each path leads from s to t along edges of g
+// and that the paths are sorted.
+func checkPaths(t *testing.T, name string, g graph.Graph, paths [][]graph.Node, s, tid int64) {
+	t.Helper()
+	for k, p := range paths {
+		if p[0].ID() != s || p[len(p)-1].ID() != tid {
+			t.Errorf("%s: path does not join terminals: %v", name, p)
+		}
+		for i := 1; i < len(p); i++ {
+			if g.Edge(p[i-1].ID(), p[i].ID()) == nil {
+				t.Errorf("%s: path has no edge %d--%d", name, p[i-1].ID(), p[i].ID())
+			}
+		}
+		if k > 0 && len(paths[k-1]) > len(p) {
+			t.Errorf("%s: paths not sorted by length", name)
+		}
+	}
+}
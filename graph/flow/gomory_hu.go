@@ -0,0 +1,234 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/mat"
+)
+
+// CutTree is a Gomory-Hu cut tree of an undirected capacitated graph. Each
+// edge of the tree corresponds to a minimum cut between its end nodes in the
+// graph, and the minimum cut between any pair of nodes in the graph is the
+// lightest edge on the path between them in the tree.
+type CutTree struct {
+	nodes   []graph.Node
+	indexOf map[int64]int
+
+	// parent holds the parent of each node
+	// in the tree, with -1 for the root, and
+	// weight holds the capacity of the cut
+	// between each node and its parent.
+	parent []int
+	weight []float64
+
+	// cut holds the minimum cut
+	// capacity between each pair
+	// of nodes.
+	cut *mat.SymDense
+}
+
+// GomoryHu returns a Gomory-Hu cut tree of the undirected graph g. If g
+// implements graph.Weighted, edge weights are used as edge capacities,
+// otherwise each edge has unit capacity. The tree is constructed using
+// |V|-1 maximum flow computations, after which the minimum cut between any
+// pair of nodes can be found in constant time. The returned CutTree holds
+// O(|V|^2) values. If g has an infinite capacity edge the returned tree is
+// not valid. GomoryHu will panic if g has a negative or NaN capacity.
+//
+// GomoryHu uses Gusfield's method to construct the tree without contracting
+// nodes of g.
+//
+// See Gomory and Hu, "Multi-terminal network flows", J. SIAM 9(4):551-570
+// (1961) doi:10.1137/0109047 and Gusfield, "Very simple methods for all pairs
+// network flow analysis", SIAM J. Comput. 19(1):143-155 (1990)
+// doi:10.1137/0219009.
+func GomoryHu(g graph.Undirected) *CutTree {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	t := &CutTree{
+		nodes:   nodes,
+		indexOf: make(map[int64]int, len(nodes)),
+		parent:  make([]int, len(nodes)),
+		weight:  make([]float64, len(nodes)),
+	}
+	if len(nodes) == 0 {
+		return t
+	}
+	for i, n := range nodes {
+		t.indexOf[n.ID()] = i
+	}
+	t.parent[0] = -1
+
+	for i := 1; i < len(nodes); i++ {
+		p := t.parent[i]
+		c := Dinic(nodes[i], nodes[p], g).MinCut()
+		side := make([]bool, len(nodes))
+		for _, n := range c.Source {
+			side[t.indexOf[n.ID()]] = true
+		}
+		t.weight[i] = c.Capacity
+		for j := range nodes {
+			if j != i && side[j] && t.parent[j] == p {
+				t.parent[j] = i
+			}
+		}
+		if pp := t.parent[p]; pp >= 0 && side[pp] {
+			t.parent[i] = pp
+			t.parent[p] = i
+			t.weight[i] = t.weight[p]
+			t.weight[p] = c.Capacity
+		}
+	}
+
+	t.cut = mat.NewSymDense(len(nodes), nil)
+	adj := make([][]int, len(nodes))
+	for i, p := range t.parent {
+		if p >= 0 {
+			adj[i] = append(adj[i], p)
+			adj[p] = append(adj[p], i)
+		}
+	}
+	min := make([]float64, len(nodes))
+	for u := range nodes {
+		t.cut.SetSym(u, u, math.Inf(1))
+		for i := range min {
+			min[i] = math.NaN()
+		}
+		min[u] = math.Inf(1)
+		stack := []int{u}
+		for len(stack) != 0 {
+			v := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, w := range adj[v] {
+				if !math.IsNaN(min[w]) {
+					continue
+				}
+				min[w] = math.Min(min[v], t.edgeWeight(v, w))
+				if w > u {
+					t.cut.SetSym(u, w, min[w])
+				}
+				stack = append(stack, w)
+			}
+		}
+	}
+
+	return t
+}
+
+// edgeWeight returns the weight of the tree edge between the
+// nodes indexed by u and v.
+func (t *CutTree) edgeWeight(u, v int) float64 {
+	if t.parent[u] == v {
+		return t.weight[u]
+	}
+	return t.weight[v]
+}
+
+// MinCut returns the capacity of a minimum cut between the nodes with IDs
+// uid and vid. If uid and vid are the same node, MinCut returns positive
+// infinity. If either node is not in the tree, MinCut returns zero.
+func (t *CutTree) MinCut(uid, vid int64) float64 {
+	u, ok := t.indexOf[uid]
+	if !ok {
+		return 0
+	}
+	v, ok := t.indexOf[vid]
+	if !ok {
+		return 0
+	}
+	return t.cut.At(u, v)
+}
+
+// Cut returns a minimum cut between the nodes with IDs uid and vid. The
+// returned nodes are the nodes on the side of the cut holding uid, sorted
+// by ID, and capacity is the capacity of the cut. If uid and vid are the
+// same node or either node is not in the tree, Cut returns nil and the
+// value returned by MinCut.
+func (t *CutTree) Cut(uid, vid int64) (side []graph.Node, capacity float64) {
+	u, ok := t.indexOf[uid]
+	if !ok {
+		return nil, 0
+	}
+	v, ok := t.indexOf[vid]
+	if !ok {
+		return nil, 0
+	}
+	if u == v {
+		return nil, math.Inf(1)
+	}
+
+	// Find the lightest edge on the tree path
+	// between u and v. The path is the union of
+	// the paths from u and v to the root less
+	// their common part.
+	depth := func(i int) int {
+		var d int
+		for ; t.parent[i] >= 0; i = t.parent[i] {
+			d++
+		}
+		return d
+	}
+	lightest := -1
+	consider := func(i int) {
+		if lightest < 0 || t.weight[i] < t.weight[lightest] {
+			lightest = i
+		}
+	}
+	a, b := u, v
+	da, db := depth(a), depth(b)
+	for ; da > db; da-- {
+		consider(a)
+		a = t.parent[a]
+	}
+	for ; db > da; db-- {
+		consider(b)
+		b = t.parent[b]
+	}
+	for a != b {
+		consider(a)
+		consider(b)
+		a, b = t.parent[a], t.parent[b]
+	}
+
+	// Removing the lightest edge, which joins the
+	// node indexed by lightest to its parent, divides
+	// the tree into the subtree rooted at lightest
+	// and the remaining nodes.
+	inSubtree := make([]bool, len(t.nodes))
+	for i := range t.nodes {
+		for j := i; j >= 0; j = t.parent[j] {
+			if j == lightest {
+				inSubtree[i] = true
+				break
+			}
+		}
+	}
+	want := inSubtree[u]
+	for i, n := range t.nodes {
+		if inSubtree[i] == want {
+			side = append(side, n)
+		}
+	}
+	return side, t.weight[lightest]
+}
+
+// Tree adds the nodes and edges of the cut tree to dst. The weight of each
+// edge is the capacity of the minimum cut between its end nodes.
+func (t *CutTree) Tree(dst graph.WeightedBuilder) {
+	for _, n := range t.nodes {
+		dst.AddNode(n)
+	}
+	for i, p := range t.parent {
+		if p < 0 {
+			continue
+		}
+		dst.SetWeightedEdge(dst.NewWeightedEdge(t.nodes[p], t.nodes[i], t.weight[i]))
+	}
+}
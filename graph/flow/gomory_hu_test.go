@@ -0,0 +1,91 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestGomoryHu(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		n := 2 + rnd.Intn(9)
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 2*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(10))})
+		}
+
+		tree := GomoryHu(g)
+		dst := simple.NewWeightedUndirectedGraph(0, 0)
+		tree.Tree(dst)
+		if got := len(graph.EdgesOf(dst.Edges())); got != n-1 {
+			t.Errorf("trial %d: unexpected number of tree edges: got:%d want:%d", trial, got, n-1)
+		}
+
+		for u := 0; u < n; u++ {
+			if got := tree.MinCut(int64(u), int64(u)); !math.IsInf(got, 1) {
+				t.Errorf("trial %d: unexpected min cut for %d with itself: got:%v", trial, u, got)
+			}
+			for v := 0; v < n; v++ {
+				if u == v {
+					continue
+				}
+				want := Dinic(simple.Node(u), simple.Node(v), g).Value()
+				if got := tree.MinCut(int64(u), int64(v)); got != want {
+					t.Errorf("trial %d: unexpected min cut between %d and %d: got:%v want:%v",
+						trial, u, v, got, want)
+				}
+
+				side, capacity := tree.Cut(int64(u), int64(v))
+				if capacity != want {
+					t.Errorf("trial %d: unexpected cut capacity between %d and %d: got:%v want:%v",
+						trial, u, v, capacity, want)
+				}
+				in := make(map[int64]bool)
+				for _, n := range side {
+					in[n.ID()] = true
+				}
+				if !in[int64(u)] || in[int64(v)] {
+					t.Errorf("trial %d: cut does not separate %d from %d: %v", trial, u, v, side)
+				}
+				var c float64
+				for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+					if in[e.From().ID()] != in[e.To().ID()] {
+						c += e.Weight()
+					}
+				}
+				if c != want {
+					t.Errorf("trial %d: cut between %d and %d is not minimum: got:%v want:%v",
+						trial, u, v, c, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGomoryHuMissing(t *testing.T) {
+	t.Parallel()
+	tree := GomoryHu(simple.NewUndirectedGraph())
+	if got := tree.MinCut(0, 1); got != 0 {
+		t.Errorf("unexpected min cut in empty tree: got:%v want:0", got)
+	}
+	if side, c := tree.Cut(0, 1); side != nil || c != 0 {
+		t.Errorf("unexpected cut in empty tree: got:%v,%v want:nil,0", side, c)
+	}
+}
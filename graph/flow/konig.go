@@ -0,0 +1,84 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// MinVertexCover returns a minimum vertex cover and a maximum independent set
+// of the bipartite graph g, each sorted by ID. Every edge of g has at least one
+// end in cover, no edge of g has both ends in independent, and the two sets
+// partition the nodes of g. The nodes in part form one side of the bipartition
+// of g, so every edge of g must join a node in part to a node not in part.
+// Edge weights are ignored. MinVertexCover will panic if g has an edge that
+// does not join a node in part to a node not in part.
+//
+// The cover is derived from a maximum cardinality matching, found with
+// MaxWeightMatching, by König's theorem. If Z is the set of nodes reachable
+// from the unmatched nodes in part by paths alternating between unmatched
+// and matched edges, the cover holds the nodes in part that are not in Z and
+// the nodes not in part that are in Z. The size of the cover is equal to the
+// size of the matching.
+func MinVertexCover(g graph.Undirected, part []graph.Node) (cover, independent []graph.Node) {
+	matching, _ := MaxWeightMatching(g, part, func(uid, vid int64) (float64, bool) { return 1, true })
+
+	inPart := make(set.Int64s, len(part))
+	for _, n := range part {
+		inPart.Add(n.ID())
+	}
+	mate := make(map[int64]graph.Node, 2*len(matching))
+	for _, e := range matching {
+		u, v := e.From(), e.To()
+		mate[u.ID()] = v
+		mate[v.ID()] = u
+	}
+
+	// Find the nodes reachable by alternating
+	// paths from unmatched nodes in part.
+	z := make(set.Int64s)
+	var queue []graph.Node
+	for _, n := range part {
+		if _, ok := mate[n.ID()]; !ok && g.Node(n.ID()) != nil {
+			z.Add(n.ID())
+			queue = append(queue, n)
+		}
+	}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		to := g.From(u.ID())
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if z.Has(vid) {
+				continue
+			}
+			z.Add(vid)
+			// v is not in part, and since the matching
+			// is maximum, v is matched.
+			if m, ok := mate[vid]; ok && !z.Has(m.ID()) {
+				z.Add(m.ID())
+				queue = append(queue, m)
+			}
+		}
+	}
+
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		id := n.ID()
+		if inPart.Has(id) != z.Has(id) {
+			cover = append(cover, n)
+		} else {
+			independent = append(independent, n)
+		}
+	}
+	sort.Sort(ordered.ByID(cover))
+	sort.Sort(ordered.ByID(independent))
+	return cover, independent
+}
@@ -0,0 +1,98 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestMinVertexCover(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 50; n++ {
+		left, right := 1+rnd.Intn(6), 1+rnd.Intn(6)
+		g := simple.NewUndirectedGraph()
+		var part []graph.Node
+		for i := 0; i < left; i++ {
+			part = append(part, simple.Node(i))
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < right; i++ {
+			g.AddNode(simple.Node(left + i))
+		}
+		for i := 0; i < left; i++ {
+			for j := 0; j < right; j++ {
+				if rnd.Float64() < 0.3 {
+					g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(left + j)})
+				}
+			}
+		}
+
+		cover, independent := MinVertexCover(g, part)
+		if len(cover)+len(independent) != g.Nodes().Len() {
+			t.Errorf("cover and independent set do not partition nodes: %d+%d != %d",
+				len(cover), len(independent), g.Nodes().Len())
+		}
+		inCover := make(map[int64]bool)
+		for _, n := range cover {
+			inCover[n.ID()] = true
+		}
+		for _, n := range independent {
+			if inCover[n.ID()] {
+				t.Errorf("node %d in both cover and independent set", n.ID())
+			}
+		}
+		for _, e := range graph.EdgesOf(g.Edges()) {
+			if !inCover[e.From().ID()] && !inCover[e.To().ID()] {
+				t.Errorf("edge %d--%d not covered", e.From().ID(), e.To().ID())
+			}
+		}
+
+		matching, _ := MaxWeightMatching(g, part, nil)
+		if len(cover) != len(matching) {
+			t.Errorf("cover size not equal to matching size: %d != %d", len(cover), len(matching))
+		}
+		if want := minCoverSize(g); len(cover) != want {
+			t.Errorf("cover not minimum: got:%d want:%d", len(cover), want)
+		}
+	}
+}
+
+// minCoverSize returns the size of a minimum vertex
+// cover of g by exhaustive search.
+func minCoverSize(g *simple.UndirectedGraph) int {
+	nodes := graph.NodesOf(g.Nodes())
+	edges := graph.EdgesOf(g.Edges())
+	best := len(nodes)
+	for mask := 0; mask < 1<<uint(len(nodes)); mask++ {
+		in := make(map[int64]bool)
+		var size int
+		for i, n := range nodes {
+			if mask&(1<<uint(i)) != 0 {
+				in[n.ID()] = true
+				size++
+			}
+		}
+		if size >= best {
+			continue
+		}
+		covered := true
+		for _, e := range edges {
+			if !in[e.From().ID()] && !in[e.To().ID()] {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			best = size
+		}
+	}
+	return best
+}
@@ -0,0 +1,114 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"encoding"
+	"errors"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/codec"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = DominatorTree{}
+	_ encoding.BinaryUnmarshaler = (*DominatorTree)(nil)
+)
+
+// Encoding kind tag and current version of dominator trees.
+const (
+	dominatorKind    = "DOMT"
+	dominatorVersion = 1
+)
+
+// MarshalBinary encodes the dominator tree into a versioned binary form
+// holding the IDs of the tree's nodes.
+func (d DominatorTree) MarshalBinary() ([]byte, error) {
+	enc := codec.NewEncoder(dominatorKind, dominatorVersion)
+	if d.root == nil {
+		enc.Int(0)
+		return enc.Bytes(), nil
+	}
+	enc.Int(1)
+	enc.Int64(d.root.ID())
+
+	ids := make([]int64, 0, len(d.dominatorOf))
+	for id := range d.dominatorOf {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	enc.Int(len(ids))
+	for _, id := range ids {
+		enc.Int64(id)
+		enc.Int64(d.dominatorOf[id].ID())
+	}
+
+	ids = ids[:0]
+	for id := range d.dominatedBy {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	enc.Int(len(ids))
+	for _, id := range ids {
+		enc.Int64(id)
+		dominated := d.dominatedBy[id]
+		enc.Int(len(dominated))
+		for _, n := range dominated {
+			enc.Int64(n.ID())
+		}
+	}
+	return enc.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the binary form of a dominator tree into the
+// receiver. The nodes of the restored tree are simple.Node values holding
+// the IDs of the encoded nodes.
+func (d *DominatorTree) UnmarshalBinary(data []byte) error {
+	dec, _, err := codec.NewDecoder(data, dominatorKind, dominatorVersion)
+	if err != nil {
+		return err
+	}
+	var t DominatorTree
+	switch dec.Int() {
+	case 0:
+		if err := dec.Err(); err != nil {
+			return err
+		}
+		*d = t
+		return nil
+	case 1:
+	default:
+		if err := dec.Err(); err != nil {
+			return err
+		}
+		return errors.New("flow: invalid dominator tree encoding")
+	}
+	t.root = simple.Node(dec.Int64())
+
+	n := dec.Len(2)
+	t.dominatorOf = make(map[int64]graph.Node, n)
+	for i := 0; i < n; i++ {
+		id := dec.Int64()
+		t.dominatorOf[id] = simple.Node(dec.Int64())
+	}
+	n = dec.Len(2)
+	t.dominatedBy = make(map[int64][]graph.Node, n)
+	for i := 0; i < n; i++ {
+		id := dec.Int64()
+		m := dec.Len(1)
+		dominated := make([]graph.Node, m)
+		for j := range dominated {
+			dominated[j] = simple.Node(dec.Int64())
+		}
+		t.dominatedBy[id] = dominated
+	}
+	if err := dec.Err(); err != nil {
+		return err
+	}
+	*d = t
+	return nil
+}
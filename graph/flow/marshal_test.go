@@ -0,0 +1,83 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDominatorTreeMarshal(t *testing.T) {
+	for i, test := range dominatorsTests {
+		g := simple.NewDirectedGraph()
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+		want := Dominators(test.n, g)
+		data, err := want.MarshalBinary()
+		if err != nil {
+			t.Fatalf("test %d: unexpected error marshaling dominator tree: %v", i, err)
+		}
+		var got DominatorTree
+		err = got.UnmarshalBinary(data)
+		if err != nil {
+			t.Fatalf("test %d: unexpected error unmarshaling dominator tree: %v", i, err)
+		}
+
+		if got.Root().ID() != want.Root().ID() {
+			t.Errorf("test %d: unexpected root after round trip: got:%d want:%d", i, got.Root().ID(), want.Root().ID())
+		}
+		if !reflect.DeepEqual(idsOfDominators(got), idsOfDominators(want)) {
+			t.Errorf("test %d: unexpected dominators after round trip", i)
+		}
+		for id, nodes := range want.dominatedBy {
+			if !reflect.DeepEqual(idsOf(got.DominatedBy(id)), idsOf(nodes)) {
+				t.Errorf("test %d: unexpected nodes dominated by %d after round trip: got:%v want:%v",
+					i, id, got.DominatedBy(id), nodes)
+			}
+		}
+		if len(got.dominatedBy) != len(want.dominatedBy) {
+			t.Errorf("test %d: unexpected number of dominating nodes after round trip", i)
+		}
+	}
+
+	var empty DominatorTree
+	data, err := empty.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling empty dominator tree: %v", err)
+	}
+	got := DominatorTree{root: simple.Node(1)}
+	err = got.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling empty dominator tree: %v", err)
+	}
+	if got.Root() != nil {
+		t.Errorf("unexpected root for empty dominator tree: %v", got.Root())
+	}
+
+	err = got.UnmarshalBinary(data[:len(data)-1])
+	if err == nil {
+		t.Error("expected error unmarshaling truncated dominator tree")
+	}
+}
+
+func idsOfDominators(d DominatorTree) map[int64]int64 {
+	ids := make(map[int64]int64)
+	for id, n := range d.dominatorOf {
+		ids[id] = n.ID()
+	}
+	return ids
+}
+
+func idsOf(nodes []graph.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
@@ -0,0 +1,224 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// BMatching returns a maximum weight b-matching of the bipartite graph g and
+// its weight. A b-matching is a set of edges of g, a degree-constrained
+// subgraph, such that each node n is an end of at most b(n.ID()) of the edges.
+// The nodes in part form one side of the bipartition of g, so every edge of g
+// must join a node in part to a node not in part. If g implements
+// graph.Weighted, edge weights are used, otherwise each edge has unit weight
+// and a maximum cardinality b-matching is returned. Edges with a non-positive
+// weight are not matched. The returned edges are obtained from g.Edge, called
+// with the ID of the node in part first, and are sorted by the IDs of their
+// end nodes. BMatching will panic if g has an edge that does not join a node
+// in part to a node not in part, or if b returns a negative value.
+//
+// BMatching finds a minimum cost flow in the network with arcs from a source
+// to each node in part with capacity b(n.ID()), from each node in part to its
+// neighbors with unit capacity and a cost of the negated edge weight, and from
+// each node not in part to a sink with capacity b(n.ID()), augmenting along
+// shortest paths while they have a negative cost. The time complexity of
+// BMatching is O(B.|V|.|E|) where B is the sum of b over part.
+func BMatching(g graph.Undirected, part []graph.Node, b func(id int64) int) (edges []graph.Edge, weight float64) {
+	return bMatching(g, part, b, nil)
+}
+
+// CostFunc returns the weight of matching the nodes with IDs uid and vid, and
+// whether the nodes may be matched.
+type CostFunc func(uid, vid int64) (w float64, ok bool)
+
+// MaxWeightMatching returns a maximum weight matching of the bipartite graph g
+// and its weight. The nodes in part form one side of the bipartition of g, so
+// every edge of g must join a node in part to a node not in part. The sides of
+// the bipartition need not have the same number of nodes, and nodes that are
+// left unmatched are not included in the matching.
+//
+// The weight of matching the ends of an edge of g is given by weight, called
+// with the ID of the node in part first. If weight is nil, edge weights are
+// obtained from g if it implements graph.Weighted, otherwise each edge has unit
+// weight and a maximum cardinality matching is returned. Edges for which weight
+// returns false, and edges with a non-positive weight, are not matched. The
+// returned edges are obtained from g.Edge, called with the ID of the node in
+// part first, and are sorted by the IDs of their end nodes. MaxWeightMatching
+// will panic if g has an edge that does not join a node in part to a node not
+// in part.
+//
+// MaxWeightMatching is the b-matching returned by BMatching with a capacity of
+// one for every node.
+func MaxWeightMatching(g graph.Undirected, part []graph.Node, weight CostFunc) (edges []graph.Edge, w float64) {
+	return bMatching(g, part, func(int64) int { return 1 }, weight)
+}
+
+// bMatching returns a maximum weight b-matching of the bipartite graph g.
+// If weight is nil, edge weights are obtained as described for BMatching.
+func bMatching(g graph.Undirected, part []graph.Node, b func(id int64) int, weight CostFunc) (edges []graph.Edge, w float64) {
+	if weight == nil {
+		weight = func(uid, vid int64) (float64, bool) { return 1, true }
+		if wg, ok := g.(graph.Weighted); ok {
+			weight = wg.Weight
+		}
+	}
+	inPart := make(set.Int64s, len(part))
+	for _, n := range part {
+		inPart.Add(n.ID())
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	// Node indices in the network are offset by
+	// two to make room for the source and sink.
+	const source, sink = 0, 1
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i + 2
+	}
+	net := newCostNetwork(len(nodes) + 2)
+	for _, u := range nodes {
+		uid := u.ID()
+		capacity := b(uid)
+		if capacity < 0 {
+			panic("flow: negative b-matching capacity")
+		}
+		if !inPart.Has(uid) {
+			net.addArc(indexOf[uid], sink, float64(capacity), 0)
+			continue
+		}
+		net.addArc(source, indexOf[uid], float64(capacity), 0)
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if inPart.Has(vid) {
+				panic("flow: graph not bipartite with respect to part")
+			}
+			w, ok := weight(uid, vid)
+			if ok && w > 0 {
+				net.addArc(indexOf[uid], indexOf[vid], 1, -w)
+			}
+		}
+	}
+	for _, u := range nodes {
+		if inPart.Has(u.ID()) {
+			continue
+		}
+		to := g.From(u.ID())
+		for to.Next() {
+			if !inPart.Has(to.Node().ID()) {
+				panic("flow: graph not bipartite with respect to part")
+			}
+		}
+	}
+
+	net.minCostFlow(source, sink, true)
+
+	for i := 0; i < len(net.arcs); i += 2 {
+		a := net.arcs[i]
+		from := net.arcs[i+1].to
+		if from < 2 || a.to < 2 || a.flow <= 0 {
+			continue
+		}
+		uid := nodes[from-2].ID()
+		vid := nodes[a.to-2].ID()
+		edges = append(edges, g.Edge(uid, vid))
+		w -= a.cost
+	}
+	sort.Sort(byEndIDs(edges))
+	return edges, w
+}
+
+// costNetwork is a residual network with arc costs for minimum cost
+// flow computations. Arcs with indices 2i and 2i+1 are each the reverse
+// of the other, with the reverse of an arc having zero capacity and the
+// negated cost of the arc.
+type costNetwork struct {
+	arcs []costArc
+	adj  [][]int
+}
+
+// costArc is an arc in a costNetwork.
+type costArc struct {
+	to   int
+	cap  float64
+	cost float64
+	flow float64
+}
+
+func newCostNetwork(n int) *costNetwork {
+	return &costNetwork{adj: make([][]int, n)}
+}
+
+// addArc adds an arc from u to v with the given capacity and cost.
+func (n *costNetwork) addArc(u, v int, capacity, cost float64) {
+	n.adj[u] = append(n.adj[u], len(n.arcs))
+	n.adj[v] = append(n.adj[v], len(n.arcs)+1)
+	n.arcs = append(n.arcs,
+		costArc{to: v, cap: capacity, cost: cost},
+		costArc{to: u, cost: -cost},
+	)
+}
+
+// minCostFlow augments flow from s to t along shortest paths by cost
+// in the residual network until t is not reachable or, if negativeOnly
+// is true, until the shortest path does not have a negative cost. It
+// returns the value and cost of the flow. The network must not have a
+// cycle of negative cost.
+func (n *costNetwork) minCostFlow(s, t int, negativeOnly bool) (value, cost float64) {
+	dist := make([]float64, len(n.adj))
+	via := make([]int, len(n.adj))
+	onQueue := make([]bool, len(n.adj))
+	for {
+		// Find shortest paths from s with the queue-based
+		// Bellman-Ford algorithm, which allows negative
+		// costs in the residual network.
+		for i := range dist {
+			dist[i] = math.Inf(1)
+			via[i] = -1
+		}
+		dist[s] = 0
+		queue := []int{s}
+		onQueue[s] = true
+		for len(queue) != 0 {
+			u := queue[0]
+			queue = queue[1:]
+			onQueue[u] = false
+			for _, i := range n.adj[u] {
+				a := n.arcs[i]
+				if a.cap-a.flow <= 0 {
+					continue
+				}
+				if d := dist[u] + a.cost; d < dist[a.to] {
+					dist[a.to] = d
+					via[a.to] = i
+					if !onQueue[a.to] {
+						onQueue[a.to] = true
+						queue = append(queue, a.to)
+					}
+				}
+			}
+		}
+		if math.IsInf(dist[t], 1) || (negativeOnly && dist[t] >= 0) {
+			return value, cost
+		}
+
+		f := math.Inf(1)
+		for v := t; v != s; v = n.arcs[via[v]^1].to {
+			a := n.arcs[via[v]]
+			f = math.Min(f, a.cap-a.flow)
+		}
+		for v := t; v != s; v = n.arcs[via[v]^1].to {
+			n.arcs[via[v]].flow += f
+			n.arcs[via[v]^1].flow -= f
+		}
+		value += f
+		cost += f * dist[t]
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestBMatching(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		const left, right = 4, 5
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		var part []graph.Node
+		for i := 0; i < left+right; i++ {
+			g.AddNode(simple.Node(i))
+			if i < left {
+				part = append(part, simple.Node(i))
+			}
+		}
+		for i := 0; i < 12; i++ {
+			u, v := rnd.Intn(left), left+rnd.Intn(right)
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(10) - 2)})
+		}
+		caps := make([]int, left+right)
+		for i := range caps {
+			caps[i] = rnd.Intn(3)
+		}
+		b := func(id int64) int { return caps[id] }
+
+		edges, w := BMatching(g, part, b)
+		if want := bruteForceBMatching(g, caps); w != want {
+			t.Errorf("trial %d: unexpected matching weight: got:%v want:%v", trial, w, want)
+		}
+
+		deg := make([]int, left+right)
+		var sum float64
+		for i, e := range edges {
+			uid, vid := e.From().ID(), e.To().ID()
+			if uid >= left || vid < left {
+				t.Errorf("trial %d: unexpected matched edge orientation: %d--%d", trial, uid, vid)
+			}
+			if i > 0 && !byEndIDs(edges).Less(i-1, i) {
+				t.Errorf("trial %d: matched edges not sorted", trial)
+			}
+			deg[uid]++
+			deg[vid]++
+			ew, _ := g.Weight(uid, vid)
+			sum += ew
+		}
+		for id, d := range deg {
+			if d > caps[id] {
+				t.Errorf("trial %d: degree of node %d exceeds capacity: %d > %d", trial, id, d, caps[id])
+			}
+		}
+		if sum != w {
+			t.Errorf("trial %d: matched edge weights do not sum to matching weight: got:%v want:%v", trial, sum, w)
+		}
+	}
+}
+
+func TestBMatchingUnweighted(t *testing.T) {
+	t.Parallel()
+	// A complete bipartite graph K_{2,3} with capacity 3 on
+	// the small side and 1 on the large side has a maximum
+	// b-matching of 3 edges.
+	g := simple.NewUndirectedGraph()
+	for u := 0; u < 2; u++ {
+		for v := 2; v < 5; v++ {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	b := func(id int64) int {
+		if id < 2 {
+			return 3
+		}
+		return 1
+	}
+	edges, w := BMatching(g, []graph.Node{simple.Node(0), simple.Node(1)}, b)
+	if len(edges) != 3 || w != 3 {
+		t.Errorf("unexpected matching: got:%v weight %v want 3 edges", edges, w)
+	}
+}
+
+func TestBMatchingNotBipartite(t *testing.T) {
+	t.Parallel()
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(3)})
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for graph that is not bipartite with respect to part")
+		}
+	}()
+	BMatching(g, []graph.Node{simple.Node(0), simple.Node(1)}, func(int64) int { return 1 })
+}
+
+func TestMaxWeightMatching(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		// The sides of the bipartition are unbalanced
+		// and not all pairs of nodes are joined.
+		const left, right = 3, 6
+		g := simple.NewUndirectedGraph()
+		allowed := simple.NewWeightedUndirectedGraph(0, 0)
+		var part []graph.Node
+		for i := 0; i < left+right; i++ {
+			g.AddNode(simple.Node(i))
+			allowed.AddNode(simple.Node(i))
+			if i < left {
+				part = append(part, simple.Node(i))
+			}
+		}
+		weights := make(map[[2]int64]float64)
+		for i := 0; i < 10; i++ {
+			u, v := int64(rnd.Intn(left)), int64(left+rnd.Intn(right))
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			if rnd.Intn(4) == 0 {
+				// Leave the pair without a cost.
+				continue
+			}
+			w := float64(rnd.Intn(10) - 2)
+			weights[[2]int64{u, v}] = w
+			allowed.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+		}
+		cost := func(uid, vid int64) (float64, bool) {
+			if uid >= left || vid < left {
+				t.Errorf("trial %d: unexpected cost query orientation: %d--%d", trial, uid, vid)
+			}
+			w, ok := weights[[2]int64{uid, vid}]
+			return w, ok
+		}
+
+		edges, w := MaxWeightMatching(g, part, cost)
+		caps := make([]int, left+right)
+		for i := range caps {
+			caps[i] = 1
+		}
+		if want := bruteForceBMatching(allowed, caps); w != want {
+			t.Errorf("trial %d: unexpected matching weight: got:%v want:%v", trial, w, want)
+		}
+
+		matched := make(map[int64]bool)
+		var sum float64
+		for _, e := range edges {
+			uid, vid := e.From().ID(), e.To().ID()
+			if matched[uid] || matched[vid] {
+				t.Errorf("trial %d: node matched more than once in %v", trial, edges)
+			}
+			matched[uid] = true
+			matched[vid] = true
+			ew, ok := weights[[2]int64{uid, vid}]
+			if !ok {
+				t.Errorf("trial %d: matched pair without cost: %d--%d", trial, uid, vid)
+			}
+			sum += ew
+		}
+		if sum != w {
+			t.Errorf("trial %d: matched edge weights do not sum to matching weight: got:%v want:%v", trial, sum, w)
+		}
+	}
+}
+
+func TestMaxWeightMatchingUnweighted(t *testing.T) {
+	t.Parallel()
+	// A path 0--3--1--4 with part {0, 1} and an isolated
+	// node 2 in part has a maximum matching of 2 edges.
+	g := simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(2))
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(3)})
+	g.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(4)})
+	edges, w := MaxWeightMatching(g, []graph.Node{simple.Node(0), simple.Node(1), simple.Node(2)}, nil)
+	if len(edges) != 2 || w != 2 {
+		t.Errorf("unexpected matching: got:%v weight %v want 2 edges", edges, w)
+	}
+}
+
+// bruteForceBMatching returns the weight of the maximum weight b-matching
+// of g by trying every subset of edges.
+func bruteForceBMatching(g *simple.WeightedUndirectedGraph, caps []int) float64 {
+	edges := graph.WeightedEdgesOf(g.WeightedEdges())
+	best := math.Inf(-1)
+	deg := make([]int, len(caps))
+	for set := 0; set < 1<<uint(len(edges)); set++ {
+		for i := range deg {
+			deg[i] = 0
+		}
+		var w float64
+		ok := true
+		for i, e := range edges {
+			if set&(1<<uint(i)) == 0 {
+				continue
+			}
+			deg[e.From().ID()]++
+			deg[e.To().ID()]++
+			if deg[e.From().ID()] > caps[e.From().ID()] || deg[e.To().ID()] > caps[e.To().ID()] {
+				ok = false
+				break
+			}
+			w += e.Weight()
+		}
+		if ok && w > best {
+			best = w
+		}
+	}
+	return best
+}
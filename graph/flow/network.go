@@ -0,0 +1,152 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Flow is a flow from a source node to a sink node in a capacitated graph,
+// as returned by the maximum flow functions.
+type Flow struct {
+	source, sink graph.Node
+
+	value float64
+
+	res *residual
+}
+
+// Source returns the source node of the flow.
+func (f Flow) Source() graph.Node { return f.source }
+
+// Sink returns the sink node of the flow.
+func (f Flow) Sink() graph.Node { return f.sink }
+
+// Value returns the value of the flow, the net flow leaving the source.
+func (f Flow) Value() float64 { return f.value }
+
+// Flow returns the flow along the edge from the node with ID uid to the node
+// with ID vid. If there is no such edge, Flow returns zero.
+func (f Flow) Flow(uid, vid int64) float64 {
+	if f.res == nil {
+		return 0
+	}
+	i, ok := f.res.arcOf[[2]int64{uid, vid}]
+	if !ok {
+		return 0
+	}
+	return math.Max(f.res.arcs[i].flow, 0)
+}
+
+// Graph adds the nodes of the flow network and the edges carrying a positive
+// flow to dst, with edge weights holding the flow along each edge.
+func (f Flow) Graph(dst graph.WeightedBuilder) {
+	if f.res == nil {
+		return
+	}
+	for _, n := range f.res.nodes {
+		dst.AddNode(n)
+	}
+	for e, i := range f.res.arcOf {
+		a := f.res.arcs[i]
+		if a.flow > 0 {
+			u := f.res.nodes[f.res.indexOf[e[0]]]
+			v := f.res.nodes[a.to]
+			dst.SetWeightedEdge(dst.NewWeightedEdge(u, v, a.flow))
+		}
+	}
+}
+
+// residual is a residual network for flow computations. Each graph edge
+// is represented by a pair of arcs with indices 2i and 2i+1 in arcs, each
+// the reverse of the other. For a directed edge the reverse arc has zero
+// capacity, and for an undirected edge both arcs have the capacity of the
+// edge. The flow along an arc is the negation of the flow along its reverse.
+type residual struct {
+	g graph.Graph
+
+	nodes   []graph.Node
+	indexOf map[int64]int
+
+	arcs []arc
+	// adj holds the indices of the arcs
+	// leaving each node.
+	adj [][]int
+
+	// arcOf maps the ends of each graph
+	// edge to the index of its arc.
+	arcOf map[[2]int64]int
+}
+
+// arc is an arc in a residual network.
+type arc struct {
+	to   int
+	cap  float64
+	flow float64
+}
+
+// newResidual returns a residual network with zero flow for g. If g implements
+// graph.Weighted, edge weights are used as capacities, otherwise each edge has
+// unit capacity. newResidual will panic if g has a negative or NaN capacity.
+func newResidual(g graph.Graph) *residual {
+	capacity := func(uid, vid int64) float64 { return 1 }
+	if wg, ok := g.(graph.Weighted); ok {
+		capacity = func(uid, vid int64) float64 {
+			w, _ := wg.Weight(uid, vid)
+			return w
+		}
+	}
+	_, undirected := g.(graph.Undirected)
+
+	nodes := graph.NodesOf(g.Nodes())
+	r := &residual{
+		g:       g,
+		nodes:   nodes,
+		indexOf: make(map[int64]int, len(nodes)),
+		adj:     make([][]int, len(nodes)),
+		arcOf:   make(map[[2]int64]int),
+	}
+	for i, n := range nodes {
+		r.indexOf[n.ID()] = i
+	}
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if uid == vid || (undirected && vid < uid) {
+				continue
+			}
+			c := capacity(uid, vid)
+			if !(c >= 0) {
+				panic("flow: invalid capacity")
+			}
+			back := 0.0
+			if undirected {
+				back = c
+				r.arcOf[[2]int64{vid, uid}] = len(r.arcs) + 1
+			}
+			r.arcOf[[2]int64{uid, vid}] = len(r.arcs)
+			j := r.indexOf[vid]
+			r.adj[i] = append(r.adj[i], len(r.arcs))
+			r.adj[j] = append(r.adj[j], len(r.arcs)+1)
+			r.arcs = append(r.arcs, arc{to: j, cap: c}, arc{to: i, cap: back})
+		}
+	}
+	return r
+}
+
+// push adds f to the flow along the arc with index i.
+func (r *residual) push(i int, f float64) {
+	r.arcs[i].flow += f
+	r.arcs[i^1].flow -= f
+}
+
+// remaining returns the residual capacity of the arc with index i.
+func (r *residual) remaining(i int) float64 {
+	return r.arcs[i].cap - r.arcs[i].flow
+}
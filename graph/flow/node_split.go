@@ -0,0 +1,200 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// NodeSplit is a capacitated directed graph obtained by splitting each node of
+// a graph into an in node and an out node joined by an edge holding the
+// capacity of the original node. Each edge of the original graph leads from
+// the out node of its from node to the in node of its to node, and each edge
+// of an undirected graph is represented by an edge in each direction.
+//
+// In nodes hold the original nodes and keep their IDs, and out nodes are
+// allocated new IDs.
+type NodeSplit struct {
+	*simple.WeightedDirectedGraph
+
+	undirected bool
+
+	// out holds the out node of each
+	// original node and orig holds the
+	// original node of each out node.
+	out  map[int64]graph.Node
+	orig map[int64]graph.Node
+}
+
+// SplitNodes returns the node split of g with the capacity of each node given
+// by capacity, called with the ID of the original node. If g implements
+// graph.Weighted, edge weights are used as edge capacities, otherwise each edge
+// has unit capacity. Node and edge capacities may be infinite.
+func SplitNodes(g graph.Graph, capacity func(id int64) float64) *NodeSplit {
+	edgeCapacity := func(uid, vid int64) float64 { return 1 }
+	if wg, ok := g.(graph.Weighted); ok {
+		edgeCapacity = func(uid, vid int64) float64 {
+			w, _ := wg.Weight(uid, vid)
+			return w
+		}
+	}
+	_, undirected := g.(graph.Undirected)
+
+	nodes := graph.NodesOf(g.Nodes())
+	s := &NodeSplit{
+		WeightedDirectedGraph: simple.NewWeightedDirectedGraph(0, 0),
+		undirected:            undirected,
+		out:                   make(map[int64]graph.Node, len(nodes)),
+		orig:                  make(map[int64]graph.Node, len(nodes)),
+	}
+	for _, n := range nodes {
+		s.AddNode(n)
+	}
+	for _, n := range nodes {
+		out := s.NewNode()
+		s.AddNode(out)
+		s.out[n.ID()] = out
+		s.orig[out.ID()] = n
+		s.SetWeightedEdge(s.NewWeightedEdge(n, out, capacity(n.ID())))
+	}
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if uid == vid {
+				continue
+			}
+			s.SetWeightedEdge(s.NewWeightedEdge(s.out[uid], v, edgeCapacity(uid, vid)))
+		}
+	}
+	return s
+}
+
+// In returns the in node of the original node with the given ID,
+// or nil if the node was not in the original graph.
+func (s *NodeSplit) In(id int64) graph.Node {
+	if _, ok := s.out[id]; !ok {
+		return nil
+	}
+	return s.Node(id)
+}
+
+// Out returns the out node of the original node with the given ID,
+// or nil if the node was not in the original graph.
+func (s *NodeSplit) Out(id int64) graph.Node {
+	return s.out[id]
+}
+
+// Original returns the original node of the in or out node with the
+// given ID, or nil if there is no such node.
+func (s *NodeSplit) Original(id int64) graph.Node {
+	if n, ok := s.orig[id]; ok {
+		return n
+	}
+	if _, ok := s.out[id]; ok {
+		return s.Node(id)
+	}
+	return nil
+}
+
+// NodeFlow is a flow from a source node to a sink node in a graph with
+// capacitated nodes, as returned by NodeCapacitatedFlow. Flows are reported
+// in terms of the nodes and edges of the analysed graph.
+type NodeFlow struct {
+	source, sink graph.Node
+
+	split *NodeSplit
+	flow  Flow
+}
+
+// NodeCapacitatedFlow returns a maximum flow from s to t in g where the flow
+// passing through each node is limited by its capacity, given by capacity
+// called with the node ID. The capacities of s and t are not used. Edge
+// capacities are obtained as described for Dinic. The flow is found with Dinic
+// in the node split of g returned by SplitNodes, from the out node of s to the
+// in node of t. NodeCapacitatedFlow will panic if s and t are the same node or
+// g has a negative or NaN node or edge capacity.
+func NodeCapacitatedFlow(s, t graph.Node, g graph.Graph, capacity func(id int64) float64) NodeFlow {
+	if s.ID() == t.ID() {
+		panic("flow: source and sink are the same node")
+	}
+	split := SplitNodes(g, capacity)
+	f := NodeFlow{source: s, sink: t, split: split}
+	out := split.Out(s.ID())
+	in := split.In(t.ID())
+	if out == nil || in == nil {
+		return f
+	}
+	f.flow = Dinic(out, in, split)
+	return f
+}
+
+// Source returns the source node of the flow.
+func (f NodeFlow) Source() graph.Node { return f.source }
+
+// Sink returns the sink node of the flow.
+func (f NodeFlow) Sink() graph.Node { return f.sink }
+
+// Value returns the value of the flow, the net flow leaving the source.
+func (f NodeFlow) Value() float64 { return f.flow.value }
+
+// Flow returns the flow along the edge from the node with ID uid to the node
+// with ID vid in the analysed graph. If the graph is undirected, the flow is
+// the net flow from uid to vid. If there is no such edge, Flow returns zero.
+func (f NodeFlow) Flow(uid, vid int64) float64 {
+	out := f.split.Out(uid)
+	if out == nil {
+		return 0
+	}
+	flow := f.flow.Flow(out.ID(), vid)
+	if f.split.undirected {
+		if back := f.split.Out(vid); back != nil {
+			flow -= f.flow.Flow(back.ID(), uid)
+		}
+	}
+	return math.Max(flow, 0)
+}
+
+// Through returns the flow passing through the node with the given ID in the
+// analysed graph. For the source and sink nodes, Through returns the value of
+// the flow.
+func (f NodeFlow) Through(id int64) float64 {
+	if f.flow.res == nil {
+		return 0
+	}
+	if id == f.source.ID() || id == f.sink.ID() {
+		return f.flow.value
+	}
+	out := f.split.Out(id)
+	if out == nil {
+		return 0
+	}
+	return f.flow.Flow(id, out.ID())
+}
+
+// Graph adds the nodes of the analysed graph and its edges carrying a positive
+// flow to dst, with edge weights holding the flow along each edge.
+func (f NodeFlow) Graph(dst graph.WeightedBuilder) {
+	if f.flow.res == nil {
+		return
+	}
+	for id := range f.split.out {
+		dst.AddNode(f.split.Node(id))
+	}
+	for uid, out := range f.split.out {
+		to := f.split.From(out.ID())
+		for to.Next() {
+			v := to.Node()
+			if w := f.Flow(uid, v.ID()); w > 0 {
+				dst.SetWeightedEdge(dst.NewWeightedEdge(f.split.Node(uid), v, w))
+			}
+		}
+	}
+}
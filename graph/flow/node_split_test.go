@@ -0,0 +1,196 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestNodeCapacitatedFlow(t *testing.T) {
+	t.Parallel()
+	// Two paths from 0 to 4 join at node 3,
+	// and a third path avoids it.
+	g := simple.NewWeightedDirectedGraph(0, 0)
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 10},
+		{F: simple.Node(0), T: simple.Node(2), W: 10},
+		{F: simple.Node(1), T: simple.Node(3), W: 10},
+		{F: simple.Node(2), T: simple.Node(3), W: 10},
+		{F: simple.Node(3), T: simple.Node(4), W: 10},
+		{F: simple.Node(0), T: simple.Node(5), W: 2},
+		{F: simple.Node(5), T: simple.Node(4), W: 10},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	capacity := func(id int64) float64 {
+		switch id {
+		case 0, 4:
+			// The source and sink capacities
+			// must be ignored.
+			return 0
+		case 3:
+			return 5
+		case 5:
+			return 1
+		}
+		return math.Inf(1)
+	}
+	for _, test := range []struct {
+		capacity func(int64) float64
+		want     float64
+		through  map[int64]float64
+	}{
+		{capacity: capacity, want: 6, through: map[int64]float64{0: 6, 3: 5, 4: 6, 5: 1}},
+		{
+			capacity: func(int64) float64 { return math.Inf(1) },
+			want:     12,
+			through:  map[int64]float64{0: 12, 3: 10, 4: 12, 5: 2},
+		},
+	} {
+		f := NodeCapacitatedFlow(simple.Node(0), simple.Node(4), g, test.capacity)
+		if f.Value() != test.want {
+			t.Errorf("unexpected flow value: got:%v want:%v", f.Value(), test.want)
+		}
+		if f.Source().ID() != 0 || f.Sink().ID() != 4 {
+			t.Errorf("unexpected flow terminals: got:%d->%d want:0->4", f.Source().ID(), f.Sink().ID())
+		}
+		for id, want := range test.through {
+			if got := f.Through(id); got != want {
+				t.Errorf("unexpected flow through node %d: got:%v want:%v", id, got, want)
+			}
+		}
+		checkNodeFlow(t, g, f, test.capacity)
+	}
+}
+
+func TestNodeCapacitatedFlowUndirected(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for i := 0; i < 10; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 25; i++ {
+			u, v := rnd.Int63n(10), rnd.Int63n(10)
+			if u == v {
+				continue
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(5))})
+		}
+		s, t9 := simple.Node(0), simple.Node(9)
+
+		// With unconstrained nodes the flow is
+		// the edge capacitated maximum flow.
+		inf := func(int64) float64 { return math.Inf(1) }
+		f := NodeCapacitatedFlow(s, t9, g, inf)
+		if want := Dinic(s, t9, g).Value(); f.Value() != want {
+			t.Errorf("trial %d: unexpected unconstrained flow value: got:%v want:%v", trial, f.Value(), want)
+		}
+		checkNodeFlow(t, g, f, inf)
+
+		caps := make([]float64, 10)
+		for i := range caps {
+			caps[i] = float64(rnd.Intn(4))
+		}
+		capacity := func(id int64) float64 { return caps[id] }
+		f = NodeCapacitatedFlow(s, t9, g, capacity)
+		checkNodeFlow(t, g, f, capacity)
+	}
+}
+
+func TestSplitNodes(t *testing.T) {
+	t.Parallel()
+	g := simple.NewDirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(5), T: simple.Node(7)})
+	s := SplitNodes(g, func(id int64) float64 { return float64(id) })
+
+	if n := s.Nodes().Len(); n != 4 {
+		t.Errorf("unexpected number of split nodes: got:%d want:4", n)
+	}
+	for _, id := range []int64{5, 7} {
+		in, out := s.In(id), s.Out(id)
+		if in == nil || in.ID() != id {
+			t.Errorf("unexpected in node for %d: %v", id, in)
+			continue
+		}
+		if out == nil || out.ID() == 5 || out.ID() == 7 {
+			t.Errorf("unexpected out node for %d: %v", id, out)
+			continue
+		}
+		if w, ok := s.Weight(id, out.ID()); !ok || w != float64(id) {
+			t.Errorf("unexpected capacity for node %d: got:%v want:%v", id, w, id)
+		}
+		if o := s.Original(out.ID()); o == nil || o.ID() != id {
+			t.Errorf("unexpected original of out node for %d: %v", id, o)
+		}
+		if o := s.Original(id); o == nil || o.ID() != id {
+			t.Errorf("unexpected original of in node for %d: %v", id, o)
+		}
+	}
+	if w, ok := s.Weight(s.Out(5).ID(), 7); !ok || w != 1 {
+		t.Errorf("unexpected split edge capacity: got:%v,%t want:1,true", w, ok)
+	}
+	if s.In(6) != nil || s.Out(6) != nil || s.Original(100) != nil {
+		t.Error("unexpected split node for missing node")
+	}
+}
+
+// checkNodeFlow checks that the flow f in g respects edge and node
+// capacities and is conserved at every node except the terminals.
+func checkNodeFlow(t *testing.T, g graph.Graph, f NodeFlow, capacity func(int64) float64) {
+	t.Helper()
+	const tol = 1e-9
+	_, undirected := g.(graph.Undirected)
+	wg := g.(graph.Weighted)
+	net := make(map[int64]float64)
+	through := make(map[int64]float64)
+	dst := simple.NewWeightedDirectedGraph(0, 0)
+	f.Graph(dst)
+	for _, e := range graph.WeightedEdgesOf(dst.WeightedEdges()) {
+		uid, vid := e.From().ID(), e.To().ID()
+		w, ok := wg.Weight(uid, vid)
+		if !ok {
+			t.Errorf("flow along missing edge %d->%d", uid, vid)
+		}
+		if e.Weight() > w+tol {
+			t.Errorf("flow exceeds edge capacity on %d->%d: %v > %v", uid, vid, e.Weight(), w)
+		}
+		if e.Weight() != f.Flow(uid, vid) {
+			t.Errorf("graph flow does not match edge flow on %d->%d", uid, vid)
+		}
+		if undirected && f.Flow(vid, uid) != 0 {
+			t.Errorf("flow in both directions on %d--%d", uid, vid)
+		}
+		net[uid] -= e.Weight()
+		net[vid] += e.Weight()
+		through[vid] += e.Weight()
+	}
+	sid, tid := f.Source().ID(), f.Sink().ID()
+	if math.Abs(net[tid]-f.Value()) > tol || math.Abs(net[sid]+f.Value()) > tol {
+		t.Errorf("terminal flows do not match value %v: source:%v sink:%v", f.Value(), net[sid], net[tid])
+	}
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		id := n.ID()
+		if id == sid || id == tid {
+			continue
+		}
+		if math.Abs(net[id]) > tol {
+			t.Errorf("flow not conserved at node %d: %v", id, net[id])
+		}
+		if through[id] > capacity(id)+tol {
+			t.Errorf("flow exceeds capacity of node %d: %v > %v", id, through[id], capacity(id))
+		}
+		if math.Abs(f.Through(id)-through[id]) > tol {
+			t.Errorf("unexpected flow through node %d: got:%v want:%v", id, f.Through(id), through[id])
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// StoerWagner returns a global minimum cut of the undirected graph g, a division
+// of the nodes of g into two non-empty sets such that the sum of the capacities of
+// edges between the sets is minimised. If g implements graph.Weighted, edge weights
+// are used as edge capacities, otherwise each edge has unit capacity. The Source
+// side of the returned cut holds the node of g with the lowest ID. If g has fewer
+// than two nodes, there is no cut and the returned cut has all the nodes of g on
+// the Source side and an infinite capacity. StoerWagner will panic if g has a
+// negative or NaN capacity.
+//
+// The time complexity of StoerWagner is O(|V|.|E| log |V|).
+//
+// See Stoer and Wagner, "A simple min-cut algorithm", J. ACM 44(4):585-591 (1997)
+// doi:10.1145/263867.263872.
+func StoerWagner(g graph.Undirected) Cut {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	if len(nodes) < 2 {
+		return Cut{Source: nodes, Capacity: math.Inf(1)}
+	}
+
+	capacity := func(uid, vid int64) float64 { return 1 }
+	if wg, ok := g.(graph.Weighted); ok {
+		capacity = func(uid, vid int64) float64 {
+			w, _ := wg.Weight(uid, vid)
+			return w
+		}
+	}
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	// adj holds the capacities between the merged
+	// nodes, and members holds the nodes of g in each
+	// merged node.
+	adj := make([]map[int]float64, len(nodes))
+	members := make([][]int, len(nodes))
+	for i, u := range nodes {
+		adj[i] = make(map[int]float64)
+		members[i] = []int{i}
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			c := capacity(uid, vid)
+			if !(c >= 0) {
+				panic("flow: invalid capacity")
+			}
+			adj[i][indexOf[vid]] = c
+		}
+	}
+
+	active := make([]bool, len(nodes))
+	for i := range active {
+		active[i] = true
+	}
+	added := make([]bool, len(nodes))
+	key := make([]float64, len(nodes))
+	best := math.Inf(1)
+	var side []int
+	for remaining := len(nodes); remaining > 1; remaining-- {
+		// Find the most tightly connected ordering of
+		// the active nodes starting from node 0, which
+		// is never merged into another node.
+		for i := range key {
+			key[i] = 0
+			added[i] = false
+		}
+		q := swQueue{{node: 0}}
+		s, t := -1, -1
+		var cut float64
+		for q.Len() != 0 {
+			u := heap.Pop(&q).(swItem)
+			if added[u.node] || u.key != key[u.node] {
+				continue
+			}
+			added[u.node] = true
+			s, t = t, u.node
+			cut = u.key
+			for v, c := range adj[u.node] {
+				if added[v] {
+					continue
+				}
+				key[v] += c
+				heap.Push(&q, swItem{node: v, key: key[v]})
+			}
+		}
+		var disconnected bool
+		for i, ok := range active {
+			if ok && !added[i] {
+				// The active nodes that were not added are
+				// disconnected from the nodes that were, so
+				// they form a side of a zero capacity cut.
+				if !disconnected {
+					side = side[:0]
+					disconnected = true
+				}
+				side = append(side, members[i]...)
+			}
+		}
+		if disconnected {
+			break
+		}
+
+		if cut < best {
+			best = cut
+			side = append(side[:0], members[t]...)
+		}
+		if best == 0 {
+			break
+		}
+
+		// Merge t into s.
+		for v, c := range adj[t] {
+			delete(adj[v], t)
+			if v == s {
+				continue
+			}
+			adj[s][v] += c
+			adj[v][s] += c
+		}
+		adj[t] = nil
+		members[s] = append(members[s], members[t]...)
+		members[t] = nil
+		active[t] = false
+	}
+
+	inSink := make([]bool, len(nodes))
+	for _, i := range side {
+		inSink[i] = true
+	}
+	var c Cut
+	for i, n := range nodes {
+		if inSink[i] {
+			c.Sink = append(c.Sink, n)
+		} else {
+			c.Source = append(c.Source, n)
+		}
+	}
+	for _, u := range c.Source {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if inSink[indexOf[vid]] {
+				c.Edges = append(c.Edges, g.Edge(uid, vid))
+				c.Capacity += capacity(uid, vid)
+			}
+		}
+	}
+	sort.Sort(byEndIDs(c.Edges))
+	return c
+}
+
+// swItem is a merged node and its connectivity
+// to the set of added nodes in a Stoer-Wagner phase.
+type swItem struct {
+	node int
+	key  float64
+}
+
+// swQueue is a max-priority queue of swItems,
+// ordered by key and then by lowest node index.
+type swQueue []swItem
+
+func (q swQueue) Len() int { return len(q) }
+func (q swQueue) Less(i, j int) bool {
+	if q[i].key != q[j].key {
+		return q[i].key > q[j].key
+	}
+	return q[i].node < q[j].node
+}
+func (q swQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *swQueue) Push(x interface{}) { *q = append(*q, x.(swItem)) }
+func (q *swQueue) Pop() interface{} {
+	old := *q
+	n := len(old) - 1
+	x := old[n]
+	*q = old[:n]
+	return x
+}
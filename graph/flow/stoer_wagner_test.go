@@ -0,0 +1,138 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flow
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var stoerWagnerTests = []struct {
+	name     string
+	edges    []simple.WeightedEdge
+	nodes    int
+	want     float64
+	wantSink []int64
+}{
+	{
+		// Figure 1 from Stoer and Wagner doi:10.1145/263867.263872.
+		name: "stoer-wagner",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(1), T: simple.Node(2), W: 2},
+			{F: simple.Node(1), T: simple.Node(5), W: 3},
+			{F: simple.Node(2), T: simple.Node(3), W: 3},
+			{F: simple.Node(2), T: simple.Node(5), W: 2},
+			{F: simple.Node(2), T: simple.Node(6), W: 2},
+			{F: simple.Node(3), T: simple.Node(4), W: 4},
+			{F: simple.Node(3), T: simple.Node(7), W: 2},
+			{F: simple.Node(4), T: simple.Node(7), W: 2},
+			{F: simple.Node(4), T: simple.Node(8), W: 2},
+			{F: simple.Node(5), T: simple.Node(6), W: 3},
+			{F: simple.Node(6), T: simple.Node(7), W: 1},
+			{F: simple.Node(7), T: simple.Node(8), W: 3},
+		},
+		want:     4,
+		wantSink: []int64{3, 4, 7, 8},
+	},
+	{
+		name: "disconnected",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 2},
+			{F: simple.Node(2), T: simple.Node(3), W: 2},
+		},
+		want:     0,
+		wantSink: []int64{2, 3},
+	},
+	{
+		name:  "single",
+		nodes: 1,
+		want:  math.Inf(1),
+	},
+	{
+		name: "empty",
+		want: math.Inf(1),
+	},
+}
+
+func TestStoerWagner(t *testing.T) {
+	t.Parallel()
+	for _, test := range stoerWagnerTests {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for i := 0; i < test.nodes; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+
+		c := StoerWagner(g)
+		if c.Capacity != test.want {
+			t.Errorf("%q: unexpected cut capacity: got:%v want:%v", test.name, c.Capacity, test.want)
+		}
+		if len(c.Sink) != len(test.wantSink) {
+			t.Errorf("%q: unexpected sink side: got:%v want:%v", test.name, c.Sink, test.wantSink)
+			continue
+		}
+		for i, n := range c.Sink {
+			if n.ID() != test.wantSink[i] {
+				t.Errorf("%q: unexpected sink side: got:%v want:%v", test.name, c.Sink, test.wantSink)
+				break
+			}
+		}
+	}
+}
+
+func TestStoerWagnerRandom(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		const n = 9
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 3*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(10))})
+		}
+
+		c := StoerWagner(g)
+		want := math.Inf(1)
+		for tid := 1; tid < n; tid++ {
+			want = math.Min(want, bruteForceMinCut(g, 0, tid, n))
+		}
+		if c.Capacity != want {
+			t.Errorf("trial %d: unexpected cut capacity: got:%v want:%v", trial, c.Capacity, want)
+		}
+		if len(c.Source) == 0 || len(c.Sink) == 0 {
+			t.Errorf("trial %d: cut has empty side", trial)
+			continue
+		}
+		checkCut(t, "random", c, g, c.Source[0].ID(), c.Sink[0].ID())
+	}
+}
+
+func TestStoerWagnerUnweighted(t *testing.T) {
+	t.Parallel()
+	// Two triangles joined by a single edge.
+	g := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}, {2, 3}} {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	c := StoerWagner(g)
+	if c.Capacity != 1 {
+		t.Errorf("unexpected cut capacity: got:%v want:1", c.Capacity)
+	}
+	if len(c.Edges) != 1 || c.Edges[0].From().ID() != 2 || c.Edges[0].To().ID() != 3 {
+		t.Errorf("unexpected cut edges: got:%v want:[2--3]", c.Edges)
+	}
+}
@@ -0,0 +1,674 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Decoder is an RDF statement decoder for the N-Triples and Turtle
+// formats. Since N-Triples is a subset of Turtle, a single Decoder
+// reads both.
+type Decoder struct {
+	r    *bufio.Reader
+	back []rune
+	line int
+
+	prefixes map[string]string
+	base     *url.URL
+
+	// pending holds the statements of
+	// the current triples block that
+	// have not yet been returned.
+	pending []*Statement
+	err     error
+}
+
+// NewDecoder returns a new Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		r:        bufio.NewReader(r),
+		line:     1,
+		prefixes: make(map[string]string),
+	}
+}
+
+// Unmarshal returns the next statement from the input. At the end of
+// the input Unmarshal returns io.EOF. After an error other than io.EOF,
+// all subsequent calls return the same error.
+func (d *Decoder) Unmarshal() (*Statement, error) {
+	for len(d.pending) == 0 {
+		if d.err != nil {
+			return nil, d.err
+		}
+		d.err = d.statement()
+	}
+	s := d.pending[0]
+	d.pending = d.pending[1:]
+	return s, nil
+}
+
+// statement reads a directive or a block of triples.
+func (d *Decoder) statement() error {
+	err := d.skipSpace()
+	if err != nil {
+		return err
+	}
+	r, err := d.read()
+	if err != nil {
+		return err
+	}
+	if r == '@' {
+		name, err := d.name()
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "prefix":
+			err = d.prefix()
+		case "base":
+			err = d.setBase()
+		default:
+			return d.errorf("unknown directive @%s", name)
+		}
+		if err != nil {
+			return err
+		}
+		return d.expect('.')
+	}
+	d.unread(r)
+	if unicode.IsLetter(r) {
+		name, err := d.name()
+		if err != nil {
+			return err
+		}
+		switch {
+		case strings.EqualFold(name, "prefix"):
+			return d.prefix()
+		case strings.EqualFold(name, "base"):
+			return d.setBase()
+		}
+		d.unreadString(name)
+	}
+	return d.triples()
+}
+
+// prefix reads the body of a prefix directive.
+func (d *Decoder) prefix() error {
+	err := d.skipSpace()
+	if err != nil {
+		return err
+	}
+	name, err := d.name()
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(name, ":") || strings.Count(name, ":") != 1 {
+		return d.errorf("invalid prefix name %q", name)
+	}
+	iri, err := d.term(false)
+	if err != nil {
+		return err
+	}
+	if iri.Kind != IRI {
+		return d.errorf("invalid prefix IRI %s", iri)
+	}
+	d.prefixes[strings.TrimSuffix(name, ":")] = iri.Value
+	return nil
+}
+
+// setBase reads the body of a base directive.
+func (d *Decoder) setBase() error {
+	iri, err := d.term(false)
+	if err != nil {
+		return err
+	}
+	if iri.Kind != IRI {
+		return d.errorf("invalid base IRI %s", iri)
+	}
+	d.base, err = url.Parse(iri.Value)
+	if err != nil {
+		return d.errorf("invalid base IRI: %v", err)
+	}
+	return nil
+}
+
+// triples reads a subject with its predicate and object lists
+// and the terminating period, adding the statements to d.pending.
+func (d *Decoder) triples() error {
+	subj, err := d.term(false)
+	if err != nil {
+		return err
+	}
+	if subj.Kind != IRI && subj.Kind != Blank {
+		return d.errorf("invalid subject %s", subj)
+	}
+	var stmts []*Statement
+	for {
+		pred, err := d.term(true)
+		if err != nil {
+			return err
+		}
+		if pred.Kind != IRI {
+			return d.errorf("invalid predicate %s", pred)
+		}
+		for {
+			obj, err := d.term(false)
+			if err != nil {
+				return err
+			}
+			stmts = append(stmts, &Statement{Subject: subj, Predicate: pred, Object: obj})
+			more, err := d.accept(',')
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+		}
+
+		more, err := d.accept(';')
+		if err != nil {
+			return err
+		}
+		if !more {
+			break
+		}
+		// Repeated semicolons and a trailing
+		// semicolon are allowed.
+		for more {
+			more, err = d.accept(';')
+			if err != nil {
+				return err
+			}
+		}
+		end, err := d.accept('.')
+		if err != nil {
+			return err
+		}
+		if end {
+			d.pending = stmts
+			return nil
+		}
+	}
+	err = d.expect('.')
+	if err != nil {
+		return err
+	}
+	d.pending = stmts
+	return nil
+}
+
+// term reads an RDF term. If verb is true the term is in predicate
+// position and the keyword "a" is accepted for rdf:type.
+func (d *Decoder) term(verb bool) (Term, error) {
+	err := d.skipSpace()
+	if err == io.EOF {
+		return Term{}, d.errorf("unexpected end of input")
+	}
+	if err != nil {
+		return Term{}, err
+	}
+	r, err := d.read()
+	if err != nil {
+		return Term{}, err
+	}
+	switch {
+	case r == '<':
+		iri, err := d.iriRef()
+		return Term{Kind: IRI, Value: iri}, err
+	case r == '_':
+		r, err = d.read()
+		if err != nil || r != ':' {
+			return Term{}, d.errorf("invalid blank node label")
+		}
+		label, err := d.name()
+		if err != nil {
+			return Term{}, err
+		}
+		if label == "" {
+			return Term{}, d.errorf("empty blank node label")
+		}
+		return Term{Kind: Blank, Value: label}, nil
+	case r == '"' || r == '\'':
+		return d.literal(r)
+	case r == '[' || r == '(':
+		return Term{}, d.errorf("unsupported syntax %q", r)
+	case r == '+' || r == '-' || r == '.' || ('0' <= r && r <= '9'):
+		d.unread(r)
+		return d.number()
+	}
+
+	d.unread(r)
+	name, err := d.name()
+	if err != nil {
+		return Term{}, err
+	}
+	switch {
+	case name == "":
+		return Term{}, d.errorf("unexpected %q", r)
+	case verb && name == "a":
+		return Term{Kind: IRI, Value: rdfNS + "type"}, nil
+	case !verb && (name == "true" || name == "false"):
+		return Term{Kind: Literal, Value: name, Datatype: xsdNS + "boolean"}, nil
+	}
+	i := strings.Index(name, ":")
+	if i < 0 {
+		return Term{}, d.errorf("invalid name %q", name)
+	}
+	ns, ok := d.prefixes[name[:i]]
+	if !ok {
+		return Term{}, d.errorf("undefined prefix %q", name[:i])
+	}
+	return Term{Kind: IRI, Value: ns + unescapeLocal(name[i+1:])}, nil
+}
+
+// iriRef reads the remainder of an IRI reference after the opening
+// angle bracket, resolving it against the base IRI if it is relative.
+func (d *Decoder) iriRef() (string, error) {
+	var b strings.Builder
+	for {
+		r, err := d.read()
+		if err != nil {
+			return "", d.errorf("unterminated IRI")
+		}
+		switch r {
+		case '>':
+			iri := b.String()
+			if d.base == nil {
+				return iri, nil
+			}
+			u, err := url.Parse(iri)
+			if err != nil {
+				return "", d.errorf("invalid IRI: %v", err)
+			}
+			if u.IsAbs() {
+				return iri, nil
+			}
+			resolved := d.base.ResolveReference(u).String()
+			if strings.HasSuffix(iri, "#") && !strings.HasSuffix(resolved, "#") {
+				// Retain empty fragments which are
+				// dropped by the url package.
+				resolved += "#"
+			}
+			return resolved, nil
+		case '\\':
+			r, err = d.escape(false)
+			if err != nil {
+				return "", err
+			}
+		case ' ', '\n', '<', '"', '{', '}', '|', '^', '`':
+			return "", d.errorf("invalid character %q in IRI", r)
+		}
+		b.WriteRune(r)
+	}
+}
+
+// literal reads the remainder of a string literal opened by the quote
+// q and any following language tag or datatype.
+func (d *Decoder) literal(q rune) (Term, error) {
+	long := false
+	r, err := d.read()
+	if err != nil {
+		return Term{}, d.errorf("unterminated string")
+	}
+	if r == q {
+		r, err = d.read()
+		if err == nil && r == q {
+			long = true
+		} else {
+			if err == nil {
+				d.unread(r)
+			}
+			return d.annotate("")
+		}
+	} else {
+		d.unread(r)
+	}
+
+	var b strings.Builder
+	for {
+		r, err := d.read()
+		if err != nil {
+			return Term{}, d.errorf("unterminated string")
+		}
+		switch {
+		case r == '\\':
+			r, err = d.escape(true)
+			if err != nil {
+				return Term{}, err
+			}
+		case r == q && !long:
+			return d.annotate(b.String())
+		case r == q:
+			// Up to two quotes may precede the
+			// closing quotes of a long string.
+			n := 1
+			for n < 5 {
+				r, err = d.read()
+				if err != nil || r != q {
+					break
+				}
+				n++
+			}
+			if n >= 3 {
+				if err == nil && r != q {
+					d.unread(r)
+				}
+				b.WriteString(strings.Repeat(string(q), n-3))
+				return d.annotate(b.String())
+			}
+			if err != nil {
+				return Term{}, d.errorf("unterminated string")
+			}
+			b.WriteString(strings.Repeat(string(q), n))
+			d.unread(r)
+			continue
+		case (r == '\n' || r == '\r') && !long:
+			return Term{}, d.errorf("newline in string")
+		}
+		b.WriteRune(r)
+	}
+}
+
+// annotate reads the language tag or datatype following a string
+// literal with the lexical form s.
+func (d *Decoder) annotate(s string) (Term, error) {
+	t := Term{Kind: Literal, Value: s}
+	r, err := d.read()
+	if err == io.EOF {
+		return t, nil
+	}
+	if err != nil {
+		return Term{}, err
+	}
+	switch r {
+	case '@':
+		var b strings.Builder
+		for {
+			r, err = d.read()
+			if err != nil {
+				break
+			}
+			if !isLangRune(r) {
+				d.unread(r)
+				break
+			}
+			b.WriteRune(r)
+		}
+		t.Lang = strings.TrimRight(b.String(), "-")
+		if t.Lang == "" {
+			return Term{}, d.errorf("empty language tag")
+		}
+	case '^':
+		r, err = d.read()
+		if err != nil || r != '^' {
+			return Term{}, d.errorf("invalid datatype")
+		}
+		dt, err := d.term(false)
+		if err != nil {
+			return Term{}, err
+		}
+		if dt.Kind != IRI {
+			return Term{}, d.errorf("invalid datatype %s", dt)
+		}
+		if dt.Value != xsdNS+"string" {
+			t.Datatype = dt.Value
+		}
+	default:
+		d.unread(r)
+	}
+	return t, nil
+}
+
+// number reads a numeric literal.
+func (d *Decoder) number() (Term, error) {
+	var b strings.Builder
+	for {
+		r, err := d.read()
+		if err != nil {
+			break
+		}
+		if !strings.ContainsRune("0123456789+-.eE", r) {
+			d.unread(r)
+			break
+		}
+		b.WriteRune(r)
+	}
+	s := b.String()
+	for strings.HasSuffix(s, ".") {
+		// A trailing period ends the statement.
+		s = s[:len(s)-1]
+		d.unread('.')
+	}
+	var dt string
+	switch {
+	case strings.ContainsAny(s, "eE"):
+		dt = "double"
+		_, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return Term{}, d.errorf("invalid double %q", s)
+		}
+	case strings.Contains(s, "."):
+		dt = "decimal"
+		if _, err := strconv.ParseFloat(s, 64); err != nil || strings.HasSuffix(s, ".") {
+			return Term{}, d.errorf("invalid decimal %q", s)
+		}
+	default:
+		dt = "integer"
+		if s == "" || strings.Trim(s, "+-") == "" || strings.LastIndexAny(s, "+-") > 0 {
+			return Term{}, d.errorf("invalid integer %q", s)
+		}
+	}
+	return Term{Kind: Literal, Value: s, Datatype: xsdNS + dt}, nil
+}
+
+// escape reads the remainder of an escape sequence after a backslash.
+// If str is false only numeric escapes are permitted.
+func (d *Decoder) escape(str bool) (rune, error) {
+	r, err := d.read()
+	if err != nil {
+		return 0, d.errorf("invalid escape")
+	}
+	var n int
+	switch r {
+	case 'u':
+		n = 4
+	case 'U':
+		n = 8
+	default:
+		if !str {
+			return 0, d.errorf("invalid escape \\%c", r)
+		}
+		switch r {
+		case 't':
+			return '\t', nil
+		case 'b':
+			return '\b', nil
+		case 'n':
+			return '\n', nil
+		case 'r':
+			return '\r', nil
+		case 'f':
+			return '\f', nil
+		case '"', '\'', '\\':
+			return r, nil
+		}
+		return 0, d.errorf("invalid escape \\%c", r)
+	}
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		r, err = d.read()
+		if err != nil {
+			return 0, d.errorf("invalid escape")
+		}
+		b.WriteRune(r)
+	}
+	v, err := strconv.ParseUint(b.String(), 16, 32)
+	if err != nil {
+		return 0, d.errorf("invalid escape \\u%s", b.String())
+	}
+	return rune(v), nil
+}
+
+// name reads a keyword, prefixed name or blank node label. A trailing
+// period is not included in the name.
+func (d *Decoder) name() (string, error) {
+	var b strings.Builder
+	for {
+		r, err := d.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if r == '\\' {
+			// Retain local name escapes for unescapeLocal.
+			b.WriteRune(r)
+			r, err = d.read()
+			if err != nil {
+				return "", d.errorf("invalid escape")
+			}
+			b.WriteRune(r)
+			continue
+		}
+		if !isNameRune(r) {
+			d.unread(r)
+			break
+		}
+		b.WriteRune(r)
+	}
+	s := b.String()
+	for strings.HasSuffix(s, ".") && !strings.HasSuffix(s, `\.`) {
+		s = s[:len(s)-1]
+		d.unread('.')
+	}
+	return s, nil
+}
+
+// unescapeLocal returns the local part of a prefixed name with
+// backslash escapes removed.
+func unescapeLocal(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.:%", r) || r > unicode.MaxASCII
+}
+
+func isLangRune(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r == '-'
+}
+
+// accept skips white space and reports whether the next rune is r,
+// consuming it if it is.
+func (d *Decoder) accept(r rune) (bool, error) {
+	err := d.skipSpace()
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	c, err := d.read()
+	if err != nil {
+		return false, err
+	}
+	if c != r {
+		d.unread(c)
+		return false, nil
+	}
+	return true, nil
+}
+
+// expect skips white space and returns an error if the next rune is not r.
+func (d *Decoder) expect(r rune) error {
+	ok, err := d.accept(r)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return d.errorf("expected %q", r)
+	}
+	return nil
+}
+
+// skipSpace skips white space and comments.
+func (d *Decoder) skipSpace() error {
+	for {
+		r, err := d.read()
+		if err != nil {
+			return err
+		}
+		switch {
+		case r == '#':
+			for r != '\n' {
+				r, err = d.read()
+				if err != nil {
+					return err
+				}
+			}
+		case !unicode.IsSpace(r):
+			d.unread(r)
+			return nil
+		}
+	}
+}
+
+func (d *Decoder) read() (rune, error) {
+	var r rune
+	if n := len(d.back); n != 0 {
+		r = d.back[n-1]
+		d.back = d.back[:n-1]
+	} else {
+		var err error
+		r, _, err = d.r.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+	}
+	if r == '\n' {
+		d.line++
+	}
+	return r, nil
+}
+
+func (d *Decoder) unread(r rune) {
+	if r == '\n' {
+		d.line--
+	}
+	d.back = append(d.back, r)
+}
+
+func (d *Decoder) unreadString(s string) {
+	r := []rune(s)
+	for i := len(r) - 1; i >= 0; i-- {
+		d.unread(r[i])
+	}
+}
+
+func (d *Decoder) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("rdf: line %d: %s", d.line, fmt.Sprintf(format, args...))
+}
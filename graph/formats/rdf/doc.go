@@ -0,0 +1,16 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rdf implements decoding of RDF statements in the N-Triples and
+// Turtle formats, and a labeled directed multigraph representation of RDF
+// graphs.
+//
+// The Turtle decoder handles prefix and base directives, prefixed names,
+// predicate and object lists, blank node labels and string, numeric and
+// boolean literals. Anonymous blank node property lists and collections
+// are not supported.
+//
+// For details of the formats see https://www.w3.org/TR/n-triples/ and
+// https://www.w3.org/TR/turtle/.
+package rdf // import "gonum.org/v1/gonum/graph/formats/rdf"
@@ -0,0 +1,136 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"io"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/multi"
+)
+
+// Graph is an RDF graph represented as a directed multigraph. The nodes
+// of the graph are the subject and object terms of the RDF statements, and
+// each statement is a line from its subject to its object labeled with
+// its predicate. Each distinct term is mapped to a single node.
+type Graph struct {
+	*multi.DirectedGraph
+
+	ids map[Term]int64
+}
+
+var (
+	rg *Graph
+
+	_ graph.Directed           = rg
+	_ graph.DirectedMultigraph = rg
+)
+
+// NewGraph returns a new empty Graph.
+func NewGraph() *Graph {
+	return &Graph{
+		DirectedGraph: multi.NewDirectedGraph(),
+		ids:           make(map[Term]int64),
+	}
+}
+
+// ReadGraph returns the Graph holding the N-Triples or Turtle encoded
+// statements read from r.
+func ReadGraph(r io.Reader) (*Graph, error) {
+	g := NewGraph()
+	dec := NewDecoder(r)
+	for {
+		s, err := dec.Unmarshal()
+		if err == io.EOF {
+			return g, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		g.AddStatement(s)
+	}
+}
+
+// Node is an RDF term node.
+type Node struct {
+	id   int64
+	Term Term
+}
+
+// ID returns the ID of the node.
+func (n Node) ID() int64 { return n.id }
+
+// Line is an RDF statement line from the subject node to the object
+// node, labeled with the statement's predicate.
+type Line struct {
+	F, T      Node
+	Predicate Term
+	UID       int64
+}
+
+// From returns the subject node of the line.
+func (l Line) From() graph.Node { return l.F }
+
+// To returns the object node of the line.
+func (l Line) To() graph.Node { return l.T }
+
+// ReversedLine returns a new Line with the F and T fields swapped.
+func (l Line) ReversedLine() graph.Line { l.F, l.T = l.T, l.F; return l }
+
+// ID returns the ID of the line.
+func (l Line) ID() int64 { return l.UID }
+
+// Statement returns the RDF statement represented by the line.
+func (l Line) Statement() *Statement {
+	return &Statement{Subject: l.F.Term, Predicate: l.Predicate, Object: l.T.Term}
+}
+
+// AddStatement adds the statement s to the graph, adding nodes for
+// its subject and object if they do not exist. Statements already in
+// the graph are not added again.
+func (g *Graph) AddStatement(s *Statement) {
+	u := g.nodeFor(s.Subject)
+	v := g.nodeFor(s.Object)
+	lines := g.Lines(u.ID(), v.ID())
+	for lines.Next() {
+		if lines.Line().(Line).Predicate == s.Predicate {
+			return
+		}
+	}
+	g.SetLine(Line{F: u, T: v, Predicate: s.Predicate, UID: g.NewLine(u, v).ID()})
+}
+
+// nodeFor returns the node for the term t, adding it to the
+// graph if it does not exist.
+func (g *Graph) nodeFor(t Term) Node {
+	id, ok := g.ids[t]
+	if ok {
+		return g.Node(id).(Node)
+	}
+	n := Node{id: g.NewNode().ID(), Term: t}
+	g.AddNode(n)
+	g.ids[t] = n.id
+	return n
+}
+
+// TermNode returns the node for the term t if it is in the graph.
+func (g *Graph) TermNode(t Term) (n Node, ok bool) {
+	id, ok := g.ids[t]
+	if !ok {
+		return Node{}, false
+	}
+	return g.Node(id).(Node), true
+}
+
+// RemoveNode removes the node with the given ID and its statements
+// from the graph.
+func (g *Graph) RemoveNode(id int64) {
+	n, ok := g.Node(id).(Node)
+	if !ok {
+		return
+	}
+	delete(g.ids, n.Term)
+	g.DirectedGraph.RemoveNode(id)
+}
@@ -0,0 +1,89 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	rdfNS = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	xsdNS = "http://www.w3.org/2001/XMLSchema#"
+)
+
+// Kind is the kind of an RDF term.
+type Kind int
+
+const (
+	// Invalid is an invalid term.
+	Invalid Kind = iota
+
+	// IRI is an IRI term.
+	IRI
+
+	// Blank is a blank node term.
+	Blank
+
+	// Literal is a literal term.
+	Literal
+)
+
+// Term is an RDF term.
+type Term struct {
+	Kind Kind
+
+	// Value is the IRI of an IRI term,
+	// the label of a blank node term and
+	// the lexical form of a literal term.
+	Value string
+
+	// Datatype is the datatype IRI of a
+	// literal term. It is empty for simple
+	// and language-tagged string literals.
+	Datatype string
+
+	// Lang is the language tag of a
+	// language-tagged string literal.
+	Lang string
+}
+
+// String returns the N-Triples representation of the term.
+func (t Term) String() string {
+	switch t.Kind {
+	case IRI:
+		return "<" + t.Value + ">"
+	case Blank:
+		return "_:" + t.Value
+	case Literal:
+		s := `"` + escaper.Replace(t.Value) + `"`
+		switch {
+		case t.Lang != "":
+			s += "@" + t.Lang
+		case t.Datatype != "":
+			s += "^^<" + t.Datatype + ">"
+		}
+		return s
+	default:
+		return fmt.Sprintf("<invalid term kind %d>", t.Kind)
+	}
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+)
+
+// Statement is an RDF statement.
+type Statement struct {
+	Subject, Predicate, Object Term
+}
+
+// String returns the N-Triples representation of the statement.
+func (s *Statement) String() string {
+	return fmt.Sprintf("%s %s %s .", s.Subject, s.Predicate, s.Object)
+}
@@ -0,0 +1,198 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdf
+
+import (
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+var decodeTests = []struct {
+	name string
+	data string
+	want []string
+}{
+	{
+		name: "n-triples",
+		data: `# A comment.
+<http://example.org/a> <http://example.org/p> <http://example.org/b> .
+<http://example.org/a> <http://example.org/p> "x\ty\u00e9" .
+_:b0 <http://example.org/q> "chat"@fr .
+_:b0 <http://example.org/q> "1"^^<http://www.w3.org/2001/XMLSchema#integer> . # Trailing comment.
+<http://example.org/b> <http://example.org/r> "s"^^<http://www.w3.org/2001/XMLSchema#string> .
+`,
+		want: []string{
+			`<http://example.org/a> <http://example.org/p> <http://example.org/b> .`,
+			`<http://example.org/a> <http://example.org/p> "x	yé" .`,
+			`_:b0 <http://example.org/q> "chat"@fr .`,
+			`_:b0 <http://example.org/q> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+			`<http://example.org/b> <http://example.org/r> "s" .`,
+		},
+	},
+	{
+		name: "turtle",
+		data: `@base <http://example.org/> .
+@prefix ex: <http://example.org/ns#> .
+PREFIX : <http://example.org/default#>
+
+<alice> a ex:Person ;
+	ex:name "Alice", 'Alicia'@es ;
+	ex:knows <bob>, _:carol ;
+	ex:age 42 ;
+	ex:height 1.75 ;
+	ex:mass 6.5e1 ;
+	ex:member true ;
+.
+_:carol ex:note """line one
+line "two" ""done""" ; :tag ex:a\-b.
+:x ex:rel -3.
+`,
+		want: []string{
+			`<http://example.org/alice> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.org/ns#Person> .`,
+			`<http://example.org/alice> <http://example.org/ns#name> "Alice" .`,
+			`<http://example.org/alice> <http://example.org/ns#name> "Alicia"@es .`,
+			`<http://example.org/alice> <http://example.org/ns#knows> <http://example.org/bob> .`,
+			`<http://example.org/alice> <http://example.org/ns#knows> _:carol .`,
+			`<http://example.org/alice> <http://example.org/ns#age> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+			`<http://example.org/alice> <http://example.org/ns#height> "1.75"^^<http://www.w3.org/2001/XMLSchema#decimal> .`,
+			`<http://example.org/alice> <http://example.org/ns#mass> "6.5e1"^^<http://www.w3.org/2001/XMLSchema#double> .`,
+			`<http://example.org/alice> <http://example.org/ns#member> "true"^^<http://www.w3.org/2001/XMLSchema#boolean> .`,
+			`_:carol <http://example.org/ns#note> "line one\nline \"two\" \"\"done" .`,
+			`_:carol <http://example.org/default#tag> <http://example.org/ns#a-b> .`,
+			`<http://example.org/default#x> <http://example.org/ns#rel> "-3"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+		},
+	},
+	{
+		name: "long string trailing quotes",
+		data: `<s> <p> """a"""" .
+<s> <p> """b""""" .
+<s> <p> '''c'''' .
+`,
+		want: []string{
+			`<s> <p> "a\"" .`,
+			`<s> <p> "b\"\"" .`,
+			`<s> <p> "c'" .`,
+		},
+	},
+}
+
+func TestDecode(t *testing.T) {
+	for _, test := range decodeTests {
+		dec := NewDecoder(strings.NewReader(test.data))
+		var got []string
+		for {
+			s, err := dec.Unmarshal()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error for %s: %v", test.name, err)
+			}
+			got = append(got, s.String())
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected statements for %s:\ngot:\n%s\nwant:\n%s",
+				test.name, strings.Join(got, "\n"), strings.Join(test.want, "\n"))
+		}
+	}
+}
+
+var decodeErrorTests = []string{
+	`<a> <p> <b>`,
+	`<a> <p> .`,
+	`<a> "p" <b> .`,
+	`"a" <p> <b> .`,
+	`<a> <p> "unterminated .`,
+	`<a> <p> "new
+line" .`,
+	`<a> <p> undefined:b .`,
+	`<a> <p> [ <q> <r> ] .`,
+	`@unknown <a> .`,
+	`<a> <p> "x"^^"y" .`,
+	`<a b> <p> <c> .`,
+	`<a> <p> """x"""""" .`,
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, data := range decodeErrorTests {
+		dec := NewDecoder(strings.NewReader(data))
+		var err error
+		for err == nil {
+			_, err = dec.Unmarshal()
+		}
+		if err == io.EOF {
+			t.Errorf("expected error for %q", data)
+		}
+	}
+}
+
+const knows = `@prefix ex: <http://example.org/> .
+ex:a ex:knows ex:b ; ex:likes ex:b .
+ex:b ex:knows ex:c .
+ex:c ex:knows ex:a .
+ex:c ex:knows ex:d .
+ex:a ex:knows ex:b .
+`
+
+func TestGraph(t *testing.T) {
+	g, err := ReadGraph(strings.NewReader(knows))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := g.Nodes().Len(); n != 4 {
+		t.Errorf("unexpected number of nodes: got:%d want:4", n)
+	}
+
+	a, ok := g.TermNode(Term{Kind: IRI, Value: "http://example.org/a"})
+	if !ok {
+		t.Fatal("missing node for ex:a")
+	}
+	b, ok := g.TermNode(Term{Kind: IRI, Value: "http://example.org/b"})
+	if !ok {
+		t.Fatal("missing node for ex:b")
+	}
+	var preds []string
+	lines := g.Lines(a.ID(), b.ID())
+	for lines.Next() {
+		preds = append(preds, lines.Line().(Line).Predicate.Value)
+	}
+	sort.Strings(preds)
+	want := []string{"http://example.org/knows", "http://example.org/likes"}
+	if !reflect.DeepEqual(preds, want) {
+		t.Errorf("unexpected predicates between ex:a and ex:b: got:%v want:%v", preds, want)
+	}
+
+	var sccs [][]string
+	for _, c := range topo.TarjanSCC(g) {
+		var terms []string
+		for _, n := range c {
+			terms = append(terms, n.(Node).Term.Value)
+		}
+		sort.Strings(terms)
+		sccs = append(sccs, terms)
+	}
+	sort.Slice(sccs, func(i, j int) bool { return len(sccs[i]) > len(sccs[j]) })
+	wantSCCs := [][]string{
+		{"http://example.org/a", "http://example.org/b", "http://example.org/c"},
+		{"http://example.org/d"},
+	}
+	if !reflect.DeepEqual(sccs, wantSCCs) {
+		t.Errorf("unexpected strongly connected components: got:%v want:%v", sccs, wantSCCs)
+	}
+
+	g.RemoveNode(b.ID())
+	if _, ok := g.TermNode(b.Term); ok {
+		t.Error("unexpected node for removed term")
+	}
+	if n := len(graph.NodesOf(g.Nodes())); n != 3 {
+		t.Errorf("unexpected number of nodes after removal: got:%d want:3", n)
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package geo provides functions for relating geographic coordinates to
+// the nodes of graphs with geographically located nodes, such as road
+// networks.
+package geo // import "gonum.org/v1/gonum/graph/geo"
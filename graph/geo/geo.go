@@ -0,0 +1,47 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import "math"
+
+// EarthRadius is the mean radius of the Earth in metres.
+const EarthRadius = 6371008.8
+
+// Distance returns the great-circle distance in metres between two points
+// given by their latitude and longitude in degrees. The distance is
+// calculated with the haversine formula on a spherical Earth with radius
+// EarthRadius.
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	sdLat := math.Sin(radians(lat2-lat1) / 2)
+	sdLon := math.Sin(radians(lon2-lon1) / 2)
+	a := sdLat*sdLat + math.Cos(radians(lat1))*math.Cos(radians(lat2))*sdLon*sdLon
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func radians(d float64) float64 { return d * math.Pi / 180 }
+
+// cartesian returns the position of the point with the given latitude and
+// longitude in degrees on the unit sphere.
+func cartesian(lat, lon float64) [3]float64 {
+	sinLat, cosLat := math.Sincos(radians(lat))
+	sinLon, cosLon := math.Sincos(radians(lon))
+	return [3]float64{cosLat * cosLon, cosLat * sinLon, sinLat}
+}
+
+// chordToDistance returns the great-circle distance in metres corresponding
+// to the given squared chord length on the unit sphere.
+func chordToDistance(chord2 float64) float64 {
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(chord2)/2))
+}
+
+// distanceToChord returns the squared chord length on the unit sphere
+// corresponding to the given great-circle distance in metres.
+func distanceToChord(dist float64) float64 {
+	if dist >= math.Pi*EarthRadius {
+		return math.Inf(1)
+	}
+	c := 2 * math.Sin(dist/(2*EarthRadius))
+	return c * c
+}
@@ -0,0 +1,192 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/spatial/kdtree"
+)
+
+// Locator returns the latitude and longitude in degrees of a node.
+type Locator func(graph.Node) (lat, lon float64)
+
+// Box is a latitude and longitude bounding box in degrees. Boxes crossing
+// the antimeridian are not supported.
+type Box struct {
+	MinLat, MinLon float64
+	MaxLat, MaxLon float64
+}
+
+// Contains returns whether the point at lat and lon is within the box.
+func (b Box) Contains(lat, lon float64) bool {
+	return b.MinLat <= lat && lat <= b.MaxLat && b.MinLon <= lon && lon <= b.MaxLon
+}
+
+// Index is a spatial index of graph nodes, allowing geographic coordinates
+// to be mapped onto the nodes of a graph before performing a search.
+type Index struct {
+	tree *kdtree.Tree
+}
+
+// NewIndex returns a spatial index of the given nodes located by loc.
+func NewIndex(nodes graph.Nodes, loc Locator) *Index {
+	var pts locatedNodes
+	for nodes.Next() {
+		n := nodes.Node()
+		lat, lon := loc(n)
+		pts = append(pts, locatedNode{node: n, lat: lat, lon: lon, p: cartesian(lat, lon)})
+	}
+	return &Index{tree: kdtree.New(pts, false)}
+}
+
+// Len returns the number of nodes held by the index.
+func (idx *Index) Len() int { return idx.tree.Len() }
+
+// NearestNode returns the indexed node nearest to the point at lat and lon in
+// degrees and its great-circle distance from the point in metres. If the index
+// is empty, NearestNode returns nil and +Inf.
+func (idx *Index) NearestNode(lat, lon float64) (n graph.Node, dist float64) {
+	if idx.tree.Len() == 0 {
+		return nil, math.Inf(1)
+	}
+	c, d := idx.tree.Nearest(locatedNode{p: cartesian(lat, lon)})
+	return c.(locatedNode).node, chordToDistance(d)
+}
+
+// NodesNear returns the indexed nodes within the great-circle distance radius
+// in metres of the point at lat and lon in degrees, ordered by increasing
+// distance from the point.
+func (idx *Index) NodesNear(lat, lon, radius float64) []graph.Node {
+	if idx.tree.Len() == 0 || radius < 0 {
+		return nil
+	}
+	keep := kdtree.NewDistKeeper(distanceToChord(radius))
+	idx.tree.NearestSet(keep, locatedNode{p: cartesian(lat, lon)})
+	nodes := make([]graph.Node, len(keep.Heap))
+	for i, c := range keep.Heap {
+		nodes[i] = c.Comparable.(locatedNode).node
+	}
+	return nodes
+}
+
+// NodesWithin returns the indexed nodes located within the bounding box b.
+// The order of the returned nodes is not specified.
+func (idx *Index) NodesWithin(b Box) []graph.Node {
+	if b.MinLat > b.MaxLat || b.MinLon > b.MaxLon {
+		return nil
+	}
+
+	// Find a Cartesian bounding box for the
+	// region and filter points in the box
+	// by their geographic coordinates.
+	zMin, zMax := math.Sin(radians(b.MinLat)), math.Sin(radians(b.MaxLat))
+	cMin, cMax := extrema(b.MinLat, b.MaxLat, math.Cos, 0)
+	cosMin, cosMax := extrema(b.MinLon, b.MaxLon, math.Cos, 0)
+	sinMin, sinMax := extrema(b.MinLon, b.MaxLon, math.Sin, 90)
+	xMin, xMax := productRange(cMin, cMax, cosMin, cosMax)
+	yMin, yMax := productRange(cMin, cMax, sinMin, sinMax)
+
+	const eps = 1e-9
+	bound := &kdtree.Bounding{
+		Min: locatedNode{p: [3]float64{xMin - eps, yMin - eps, zMin - eps}},
+		Max: locatedNode{p: [3]float64{xMax + eps, yMax + eps, zMax + eps}},
+	}
+	var nodes []graph.Node
+	idx.tree.DoBounded(bound, func(c kdtree.Comparable, _ *kdtree.Bounding, _ int) (done bool) {
+		n := c.(locatedNode)
+		if b.Contains(n.lat, n.lon) {
+			nodes = append(nodes, n.node)
+		}
+		return false
+	})
+	return nodes
+}
+
+// extrema returns the minimum and maximum values of f over the angles
+// in degrees from lo to hi, where f has its turning points at phase and
+// every 180 degrees from phase.
+func extrema(lo, hi float64, f func(float64) float64, phase float64) (min, max float64) {
+	min, max = f(radians(lo)), f(radians(lo))
+	update := func(v float64) {
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	update(f(radians(hi)))
+	for k := math.Ceil((lo - phase) / 180); k*180+phase <= hi; k++ {
+		update(f(radians(k*180 + phase)))
+	}
+	return min, max
+}
+
+// productRange returns the range of products of values in [aMin, aMax]
+// and [bMin, bMax].
+func productRange(aMin, aMax, bMin, bMax float64) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, a := range []float64{aMin, aMax} {
+		for _, b := range []float64{bMin, bMax} {
+			min = math.Min(min, a*b)
+			max = math.Max(max, a*b)
+		}
+	}
+	return min, max
+}
+
+// locatedNode is a graph node with its geographic location and
+// position on the unit sphere. It satisfies kdtree.Comparable.
+type locatedNode struct {
+	node     graph.Node
+	lat, lon float64
+	p        [3]float64
+}
+
+func (n locatedNode) Compare(c kdtree.Comparable, d kdtree.Dim) float64 {
+	return n.p[d] - c.(locatedNode).p[d]
+}
+
+func (n locatedNode) Dims() int { return 3 }
+
+func (n locatedNode) Distance(c kdtree.Comparable) float64 {
+	q := c.(locatedNode)
+	var sum float64
+	for d, v := range n.p {
+		v -= q.p[d]
+		sum += v * v
+	}
+	return sum
+}
+
+// locatedNodes is a collection of locatedNode that satisfies kdtree.Interface.
+type locatedNodes []locatedNode
+
+func (p locatedNodes) Index(i int) kdtree.Comparable         { return p[i] }
+func (p locatedNodes) Len() int                              { return len(p) }
+func (p locatedNodes) Pivot(d kdtree.Dim) int                { return locatedPlane{locatedNodes: p, Dim: d}.Pivot() }
+func (p locatedNodes) Slice(start, end int) kdtree.Interface { return p[start:end] }
+
+// randoms is the maximum number of random values to sample for calculation of
+// median of random elements.
+const randoms = 100
+
+// locatedPlane allows locatedNodes to be pivoted on a dimension.
+type locatedPlane struct {
+	kdtree.Dim
+	locatedNodes
+}
+
+func (p locatedPlane) Pivot() int {
+	return kdtree.Partition(p, kdtree.MedianOfRandoms(p, randoms))
+}
+func (p locatedPlane) Less(i, j int) bool {
+	return p.locatedNodes[i].p[p.Dim] < p.locatedNodes[j].p[p.Dim]
+}
+func (p locatedPlane) Slice(start, end int) kdtree.SortSlicer {
+	p.locatedNodes = p.locatedNodes[start:end]
+	return p
+}
+func (p locatedPlane) Swap(i, j int) {
+	p.locatedNodes[i], p.locatedNodes[j] = p.locatedNodes[j], p.locatedNodes[i]
+}
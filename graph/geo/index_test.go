@@ -0,0 +1,122 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDistance(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		lat1, lon1, lat2, lon2 float64
+		want, tol              float64
+	}{
+		{lat1: 0, lon1: 0, lat2: 0, lon2: 0, want: 0},
+		{lat1: 0, lon1: 0, lat2: 0, lon2: 180, want: math.Pi * EarthRadius, tol: 1e-6},
+		{lat1: 90, lon1: 0, lat2: -90, lon2: 0, want: math.Pi * EarthRadius, tol: 1e-6},
+		// London to Paris.
+		{lat1: 51.5074, lon1: -0.1278, lat2: 48.8566, lon2: 2.3522, want: 343.56e3, tol: 0.1e3},
+	} {
+		got := Distance(test.lat1, test.lon1, test.lat2, test.lon2)
+		if math.Abs(got-test.want) > test.tol {
+			t.Errorf("unexpected distance between (%v,%v) and (%v,%v): got:%v want:%v",
+				test.lat1, test.lon1, test.lat2, test.lon2, got, test.want)
+		}
+	}
+}
+
+func randomIndex(n int, src rand.Source) (*Index, []graph.Node, map[int64][2]float64) {
+	rnd := rand.New(src)
+	loc := make(map[int64][2]float64)
+	nodes := make([]graph.Node, n)
+	for i := range nodes {
+		nodes[i] = simple.Node(i)
+		loc[int64(i)] = [2]float64{180*rnd.Float64() - 90, 360*rnd.Float64() - 180}
+	}
+	idx := NewIndex(iterator.NewOrderedNodes(nodes), func(n graph.Node) (lat, lon float64) {
+		l := loc[n.ID()]
+		return l[0], l[1]
+	})
+	return idx, nodes, loc
+}
+
+func TestNearestNode(t *testing.T) {
+	t.Parallel()
+	idx, nodes, loc := randomIndex(1000, rand.NewSource(1))
+	if idx.Len() != len(nodes) {
+		t.Fatalf("unexpected index length: got:%d want:%d", idx.Len(), len(nodes))
+	}
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		lat, lon := 180*rnd.Float64()-90, 360*rnd.Float64()-180
+		var (
+			want     graph.Node
+			wantDist = math.Inf(1)
+		)
+		for _, n := range nodes {
+			l := loc[n.ID()]
+			d := Distance(lat, lon, l[0], l[1])
+			if d < wantDist {
+				want, wantDist = n, d
+			}
+		}
+		got, dist := idx.NearestNode(lat, lon)
+		if got.ID() != want.ID() {
+			t.Errorf("unexpected nearest node to (%v,%v): got:%d want:%d", lat, lon, got.ID(), want.ID())
+		}
+		if math.Abs(dist-wantDist) > 1e-3 {
+			t.Errorf("unexpected distance to nearest node to (%v,%v): got:%v want:%v", lat, lon, dist, wantDist)
+		}
+	}
+
+	empty := NewIndex(graph.Empty, nil)
+	n, d := empty.NearestNode(0, 0)
+	if n != nil || !math.IsInf(d, 1) {
+		t.Errorf("unexpected result for empty index: got:%v,%v want:<nil>,+Inf", n, d)
+	}
+}
+
+func TestNodesWithin(t *testing.T) {
+	t.Parallel()
+	idx, nodes, loc := randomIndex(1000, rand.NewSource(1))
+	for _, b := range []Box{
+		{MinLat: -10, MinLon: -10, MaxLat: 10, MaxLon: 10},
+		{MinLat: 30, MinLon: 100, MaxLat: 80, MaxLon: 180},
+		{MinLat: -90, MinLon: -180, MaxLat: -60, MaxLon: 180},
+		{MinLat: -45, MinLon: -135, MaxLat: 45, MaxLon: 135},
+		{MinLat: 10, MinLon: -170, MaxLat: 20, MaxLon: -90},
+		{MinLat: 10, MinLon: 10, MaxLat: -10, MaxLon: 20},
+	} {
+		var want []graph.Node
+		for _, n := range nodes {
+			l := loc[n.ID()]
+			if b.Contains(l[0], l[1]) {
+				want = append(want, n)
+			}
+		}
+		got := idx.NodesWithin(b)
+		sort.Sort(ordered.ByID(got))
+		if len(got) != len(want) {
+			t.Errorf("unexpected number of nodes within %+v: got:%d want:%d", b, len(got), len(want))
+			continue
+		}
+		for i := range got {
+			if got[i].ID() != want[i].ID() {
+				t.Errorf("unexpected nodes within %+v: got:%v want:%v", b, got, want)
+				break
+			}
+		}
+	}
+}
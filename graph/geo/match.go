@@ -0,0 +1,346 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// Point is a geographic location given by its latitude and longitude
+// in degrees.
+type Point struct {
+	Lat, Lon float64
+}
+
+// Matcher performs hidden Markov model map-matching of GPS traces onto a
+// graph with located nodes, such as a road network. Candidate matches for
+// each GPS point are positions on edges near the point. The emission
+// probability of a candidate is Gaussian in the distance between the point
+// and the candidate, and the transition probability between candidates for
+// consecutive points decays exponentially with the difference between the
+// route distance between the candidates and the great-circle distance
+// between the points, as described in
+//
+// Newson P. and Krumm J. "Hidden Markov map matching through noise and
+// sparseness." Proceedings of the 17th ACM SIGSPATIAL International
+// Conference on Advances in Geographic Information Systems (2009):336–343.
+//
+// Route distances are calculated from the great-circle lengths of edges
+// between node locations; edge weights of the graph are not used.
+type Matcher struct {
+	// Sigma is the standard deviation of
+	// GPS measurement noise in metres.
+	Sigma float64
+	// Beta is the scale in metres of the
+	// exponential distribution of route
+	// and great-circle distance differences.
+	Beta float64
+	// Radius is the distance in metres from
+	// each GPS point within which an end node
+	// of an edge must be for the edge to be
+	// considered as a candidate match.
+	Radius float64
+
+	g     graph.Graph
+	loc   Locator
+	index *Index
+}
+
+// NewMatcher returns a Matcher for the graph g with node locations given by
+// loc. The returned Matcher has Sigma set to 4.07 m, Beta set to 3 m and Radius
+// set to 200 m, following Newson and Krumm.
+func NewMatcher(g graph.Graph, loc Locator) *Matcher {
+	return &Matcher{
+		Sigma:  4.07,
+		Beta:   3,
+		Radius: 200,
+
+		g:     g,
+		loc:   loc,
+		index: NewIndex(g.Nodes(), loc),
+	}
+}
+
+// Match returns the most likely path through the graph taken by a traveller
+// recording the GPS trace. Points in the trace with no candidate edges are
+// ignored. If no path is consistent with the trace, Match returns nil.
+//
+// Routes between candidates for consecutive points are only considered if
+// they are shorter than twice the sum of the great-circle distance between
+// the points and the Radius of the Matcher.
+func (m *Matcher) Match(trace []Point) []graph.Node {
+	var (
+		steps  [][]candidate
+		back   [][]int
+		limits []float64
+		logP   []float64
+		last   Point
+	)
+	for _, p := range trace {
+		cands := m.candidates(p)
+		if len(cands) == 0 {
+			continue
+		}
+		next := make([]float64, len(cands))
+		if steps == nil {
+			for i, c := range cands {
+				next[i] = m.emission(c)
+			}
+			back = append(back, nil)
+			limits = append(limits, 0)
+		} else {
+			gc := Distance(last.Lat, last.Lon, p.Lat, p.Lon)
+			limit := 2 * (gc + m.Radius)
+			prev := steps[len(steps)-1]
+			bk := make([]int, len(cands))
+			for i := range next {
+				next[i] = math.Inf(-1)
+				bk[i] = -1
+			}
+			for j, a := range prev {
+				if math.IsInf(logP[j], -1) {
+					continue
+				}
+				routes := m.routesFrom(a.to, limit)
+				for i, b := range cands {
+					d := a.routeTo(b, routes.dist)
+					if d > limit {
+						continue
+					}
+					lp := logP[j] - math.Abs(d-gc)/m.Beta + m.emission(b)
+					if lp > next[i] {
+						next[i] = lp
+						bk[i] = j
+					}
+				}
+			}
+			var ok bool
+			for _, lp := range next {
+				if !math.IsInf(lp, -1) {
+					ok = true
+					break
+				}
+			}
+			if !ok {
+				return nil
+			}
+			back = append(back, bk)
+			limits = append(limits, limit)
+		}
+		steps = append(steps, cands)
+		logP = next
+		last = p
+	}
+	if steps == nil {
+		return nil
+	}
+
+	// Find the most likely sequence of candidates.
+	best := 0
+	for i, lp := range logP {
+		if lp > logP[best] {
+			best = i
+		}
+	}
+	seq := make([]candidate, len(steps))
+	for t := len(steps) - 1; t >= 0; t-- {
+		seq[t] = steps[t][best]
+		if t != 0 {
+			best = back[t][best]
+		}
+	}
+
+	// Construct the path through the candidate edges.
+	path := []graph.Node{seq[0].from, seq[0].to}
+	for t, b := range seq[1:] {
+		a := seq[t]
+		if a.sameEdge(b) && a.frac <= b.frac {
+			continue
+		}
+		route := m.routesFrom(a.to, limits[t+1]).to(b.from.ID())
+		path = append(path, route[1:]...)
+		path = append(path, b.to)
+	}
+	return path
+}
+
+// emission returns the log emission probability of c, omitting the
+// normalization constant.
+func (m *Matcher) emission(c candidate) float64 {
+	z := c.dist / m.Sigma
+	return -0.5 * z * z
+}
+
+// candidates returns the candidate positions on edges of the graph for
+// the GPS point p.
+func (m *Matcher) candidates(p Point) []candidate {
+	var cands []candidate
+	seen := make(map[[2]int64]bool)
+	add := func(u, v graph.Node) {
+		e := [2]int64{u.ID(), v.ID()}
+		if seen[e] {
+			return
+		}
+		seen[e] = true
+		cands = append(cands, m.project(p, u, v))
+	}
+	d, isDirected := m.g.(graph.Directed)
+	for _, u := range m.index.NodesNear(p.Lat, p.Lon, m.Radius) {
+		to := m.g.From(u.ID())
+		for to.Next() {
+			v := to.Node()
+			add(u, v)
+			if !isDirected {
+				add(v, u)
+			}
+		}
+		if isDirected {
+			from := d.To(u.ID())
+			for from.Next() {
+				add(from.Node(), u)
+			}
+		}
+	}
+	return cands
+}
+
+// project returns the candidate position on the edge from u to v nearest
+// to p. The projection is made on a local equirectangular approximation
+// centred on p.
+func (m *Matcher) project(p Point, u, v graph.Node) candidate {
+	uLat, uLon := m.loc(u)
+	vLat, vLon := m.loc(v)
+	scale := math.Cos(radians(p.Lat))
+	ax, ay := EarthRadius*radians(uLon-p.Lon)*scale, EarthRadius*radians(uLat-p.Lat)
+	bx, by := EarthRadius*radians(vLon-p.Lon)*scale, EarthRadius*radians(vLat-p.Lat)
+	dx, dy := bx-ax, by-ay
+	var f float64
+	if l2 := dx*dx + dy*dy; l2 != 0 {
+		f = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l2))
+	}
+	return candidate{
+		from:   u,
+		to:     v,
+		frac:   f,
+		dist:   math.Hypot(ax+f*dx, ay+f*dy),
+		length: Distance(uLat, uLon, vLat, vLon),
+	}
+}
+
+// candidate is a candidate match position on an edge.
+type candidate struct {
+	from, to graph.Node
+	// frac is the fraction of the
+	// distance along the edge.
+	frac float64
+	// dist is the distance from the
+	// GPS point to the position.
+	dist float64
+	// length is the length of the edge.
+	length float64
+}
+
+// sameEdge returns whether c and o are positions on the same edge.
+func (c candidate) sameEdge(o candidate) bool {
+	return c.from.ID() == o.from.ID() && c.to.ID() == o.to.ID()
+}
+
+// routeTo returns the route distance from c to o given the route distances
+// from the to node of c.
+func (c candidate) routeTo(o candidate, dist map[int64]float64) float64 {
+	if c.sameEdge(o) && c.frac <= o.frac {
+		return (o.frac - c.frac) * c.length
+	}
+	d, ok := dist[o.from.ID()]
+	if !ok {
+		return math.Inf(1)
+	}
+	return (1-c.frac)*c.length + d + o.frac*o.length
+}
+
+// routesFrom returns the shortest routes from u to all nodes within the
+// route distance limit of u.
+func (m *Matcher) routesFrom(u graph.Node, limit float64) routes {
+	r := routes{
+		dist: map[int64]float64{u.ID(): 0},
+		prev: make(map[int64]graph.Node),
+		node: map[int64]graph.Node{u.ID(): u},
+	}
+	q := routeQueue{{node: u}}
+	for q.Len() != 0 {
+		mid := heap.Pop(&q).(routeNode)
+		if mid.dist > r.dist[mid.node.ID()] {
+			continue
+		}
+		uLat, uLon := m.loc(mid.node)
+		to := m.g.From(mid.node.ID())
+		for to.Next() {
+			v := to.Node()
+			vLat, vLon := m.loc(v)
+			joint := mid.dist + Distance(uLat, uLon, vLat, vLon)
+			if joint > limit {
+				continue
+			}
+			if d, ok := r.dist[v.ID()]; ok && d <= joint {
+				continue
+			}
+			r.dist[v.ID()] = joint
+			r.prev[v.ID()] = mid.node
+			r.node[v.ID()] = v
+			heap.Push(&q, routeNode{node: v, dist: joint})
+		}
+	}
+	return r
+}
+
+// routes is a shortest route tree.
+type routes struct {
+	dist map[int64]float64
+	prev map[int64]graph.Node
+	node map[int64]graph.Node
+}
+
+// to returns the route to the node with the given ID, including the
+// start of the route. If the node was not reached, to returns nil.
+func (r routes) to(id int64) []graph.Node {
+	n, ok := r.node[id]
+	if !ok {
+		return nil
+	}
+	path := []graph.Node{n}
+	for {
+		p, ok := r.prev[n.ID()]
+		if !ok {
+			break
+		}
+		path = append(path, p)
+		n = p
+	}
+	ordered.Reverse(path)
+	return path
+}
+
+type routeNode struct {
+	node graph.Node
+	dist float64
+}
+
+// routeQueue implements a no-dec priority queue.
+type routeQueue []routeNode
+
+func (q routeQueue) Len() int            { return len(q) }
+func (q routeQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q routeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *routeQueue) Push(n interface{}) { *q = append(*q, n.(routeNode)) }
+func (q *routeQueue) Pop() interface{} {
+	t := *q
+	var n interface{}
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
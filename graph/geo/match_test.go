@@ -0,0 +1,108 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// grid returns a size×size grid road network with nodes spaced by
+// spacing degrees starting at lat and lon, and a Locator for the nodes.
+func grid(size int, lat, lon, spacing float64, g graph.Builder) Locator {
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			id := simple.Node(r*size + c)
+			if c+1 < size {
+				g.SetEdge(g.NewEdge(id, simple.Node(r*size+c+1)))
+			}
+			if r+1 < size {
+				g.SetEdge(g.NewEdge(id, simple.Node((r+1)*size+c)))
+			}
+		}
+	}
+	return func(n graph.Node) (float64, float64) {
+		id := int(n.ID())
+		return lat + float64(id/size)*spacing, lon + float64(id%size)*spacing
+	}
+}
+
+var matchTests = []struct {
+	name     string
+	directed bool
+	trace    []Point
+	want     []int64
+}{
+	{
+		name: "along bottom and up right",
+		trace: []Point{
+			{Lat: 51.50005, Lon: -0.0997},
+			{Lat: 51.49995, Lon: -0.0993},
+			{Lat: 51.50004, Lon: -0.0990},
+			{Lat: 51.49996, Lon: -0.0985},
+			{Lat: 51.50006, Lon: -0.0980},
+			{Lat: 51.5005, Lon: -0.09806},
+			{Lat: 51.5010, Lon: -0.09795},
+			{Lat: 51.5015, Lon: -0.09804},
+		},
+		want: []int64{0, 1, 2, 7, 12},
+	},
+	{
+		name: "staircase",
+		trace: []Point{
+			{Lat: 51.50002, Lon: -0.09970},
+			{Lat: 51.50004, Lon: -0.09930},
+			{Lat: 51.50050, Lon: -0.09896},
+			{Lat: 51.50100, Lon: -0.09903},
+			{Lat: 51.50096, Lon: -0.09850},
+		},
+		want: []int64{0, 1, 6, 7},
+	},
+	{
+		name:     "directed one way",
+		directed: true,
+		trace: []Point{
+			{Lat: 51.50005, Lon: -0.0997},
+			{Lat: 51.49995, Lon: -0.0993},
+			{Lat: 51.50004, Lon: -0.0988},
+		},
+		want: []int64{0, 1, 2},
+	},
+	{
+		name: "no candidates",
+		trace: []Point{
+			{Lat: 10, Lon: 10},
+		},
+		want: nil,
+	},
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+	for _, test := range matchTests {
+		var g graph.Builder
+		if test.directed {
+			g = simple.NewDirectedGraph()
+		} else {
+			g = simple.NewUndirectedGraph()
+		}
+		loc := grid(5, 51.5, -0.1, 0.001, g)
+		m := NewMatcher(g.(graph.Graph), loc)
+		m.Sigma = 10
+		m.Beta = 20
+
+		p := m.Match(test.trace)
+		var got []int64
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected match for %q: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
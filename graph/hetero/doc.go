@@ -0,0 +1,10 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hetero provides functions for the analysis of heterogeneous
+// graphs, graphs whose nodes are labeled with a type.
+//
+// Node types are provided by a Labeling function, so any graph
+// implementation can be analyzed without adaptation.
+package hetero // import "gonum.org/v1/gonum/graph/hetero"
@@ -0,0 +1,97 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hetero
+
+import (
+	"errors"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Labeling is a mapping from nodes to their type label.
+type Labeling func(n graph.Node) string
+
+// Builder is a graph that can have nodes and weighted edges added.
+type Builder interface {
+	graph.Graph
+	graph.WeightedBuilder
+}
+
+// MetaPathProjection adds the homogeneous projection of g along the given
+// meta-path to dst. A meta-path is a sequence of node type labels, for
+// example Author, Paper, Author, and an instance of the meta-path is a
+// walk in g through nodes with the labels of the meta-path in order.
+//
+// The nodes of g labeled with the first or last type of the meta-path are
+// added to dst if they are not already present, and for each pair of
+// distinct nodes u and v joined by at least one instance of the meta-path,
+// an edge from u to v is set in dst with a weight equal to the number of
+// instances joining them. Edges in g are followed as returned by From.
+//
+// If dst is a graph.Undirected, the meta-path must be symmetric so that
+// the number of instances from u to v equals the number from v to u.
+// MetaPathProjection returns an error if the meta-path has fewer than two
+// types or if dst is undirected and the meta-path is not symmetric.
+func MetaPathProjection(dst Builder, g graph.Graph, label Labeling, metaPath []string) error {
+	if len(metaPath) < 2 {
+		return errors.New("hetero: meta-path too short")
+	}
+	if _, ok := dst.(graph.Undirected); ok {
+		for i, j := 0, len(metaPath)-1; i < j; i, j = i+1, j-1 {
+			if metaPath[i] != metaPath[j] {
+				return errors.New("hetero: asymmetric meta-path for undirected projection")
+			}
+		}
+	}
+
+	first, last := metaPath[0], metaPath[len(metaPath)-1]
+	var starts []graph.Node
+	nodes := g.Nodes()
+	for nodes.Next() {
+		n := nodes.Node()
+		l := label(n)
+		if l != first && l != last {
+			continue
+		}
+		if dst.Node(n.ID()) == nil {
+			dst.AddNode(n)
+		}
+		if l == first {
+			starts = append(starts, n)
+		}
+	}
+
+	for _, u := range starts {
+		uid := u.ID()
+		// counts holds the number of meta-path
+		// instance prefixes from u ending at
+		// each node.
+		counts := map[int64]float64{uid: 1}
+		for _, typ := range metaPath[1:] {
+			next := make(map[int64]float64)
+			for xid, c := range counts {
+				to := g.From(xid)
+				for to.Next() {
+					y := to.Node()
+					if label(y) != typ {
+						continue
+					}
+					next[y.ID()] += c
+				}
+			}
+			counts = next
+			if len(counts) == 0 {
+				break
+			}
+		}
+		for vid, c := range counts {
+			if vid == uid {
+				continue
+			}
+			dst.SetWeightedEdge(dst.NewWeightedEdge(dst.Node(uid), dst.Node(vid), c))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hetero
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// labeledEdges is a heterogeneous graph description.
+type labeledEdges struct {
+	labels map[int64]string
+	edges  [][2]int64
+}
+
+func (b labeledEdges) label(n graph.Node) string { return b.labels[n.ID()] }
+
+// bibliography is a small heterogeneous graph of authors,
+// papers and venues.
+var bibliography = labeledEdges{
+	labels: map[int64]string{
+		0: "author", 1: "author", 2: "author", 3: "author",
+		10: "paper", 11: "paper", 12: "paper",
+		20: "venue", 21: "venue",
+	},
+	edges: [][2]int64{
+		// Authorship.
+		{0, 10}, {1, 10},
+		{0, 11}, {1, 11},
+		{1, 12}, {2, 12},
+		// Publication.
+		{10, 20}, {11, 20}, {12, 21},
+	},
+}
+
+var projectionTests = []struct {
+	name     string
+	directed bool
+	metaPath []string
+	want     map[[2]int64]float64
+	wantN    int
+}{
+	{
+		name:     "author-paper-author",
+		metaPath: []string{"author", "paper", "author"},
+		want: map[[2]int64]float64{
+			{0, 1}: 2,
+			{1, 2}: 1,
+		},
+		wantN: 4,
+	},
+	{
+		name:     "author-paper-venue-paper-author",
+		metaPath: []string{"author", "paper", "venue", "paper", "author"},
+		want: map[[2]int64]float64{
+			// Authors 0 and 1 share 2 papers in venue 20,
+			// each of which reaches both papers in the venue.
+			{0, 1}: 4,
+			{1, 2}: 1,
+		},
+		wantN: 4,
+	},
+	{
+		name:     "author-paper-venue",
+		directed: true,
+		metaPath: []string{"author", "paper", "venue"},
+		want: map[[2]int64]float64{
+			{0, 20}: 2,
+			{1, 20}: 2,
+			{1, 21}: 1,
+			{2, 21}: 1,
+		},
+		wantN: 6,
+	},
+}
+
+func TestMetaPathProjection(t *testing.T) {
+	for _, test := range projectionTests {
+		var g interface {
+			graph.Graph
+			SetEdge(graph.Edge)
+			AddNode(graph.Node)
+		}
+		var dst interface {
+			Builder
+			Edges() graph.Edges
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph()
+			dst = simple.NewWeightedDirectedGraph(0, 0)
+		} else {
+			g = simple.NewUndirectedGraph()
+			dst = simple.NewWeightedUndirectedGraph(0, 0)
+		}
+		for id := range bibliography.labels {
+			g.AddNode(simple.Node(id))
+		}
+		for _, e := range bibliography.edges {
+			g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+		}
+
+		err := MetaPathProjection(dst, g, bibliography.label, test.metaPath)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+			continue
+		}
+		if n := dst.Nodes().Len(); n != test.wantN {
+			t.Errorf("unexpected number of nodes for %s: got:%d want:%d", test.name, n, test.wantN)
+		}
+		got := make(map[[2]int64]float64)
+		for _, e := range graph.EdgesOf(dst.Edges()) {
+			u, v := e.From().ID(), e.To().ID()
+			if !test.directed && u > v {
+				u, v = v, u
+			}
+			got[[2]int64{u, v}] = e.(graph.WeightedEdge).Weight()
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected projection for %s:\ngot: %v\nwant:%v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestMetaPathProjectionErrors(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for _, metaPath := range [][]string{
+		nil,
+		{"author"},
+		{"author", "paper"},
+	} {
+		err := MetaPathProjection(simple.NewWeightedUndirectedGraph(0, 0), g, bibliography.label, metaPath)
+		if err == nil {
+			t.Errorf("expected error for meta-path %v", metaPath)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hetero
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// EdgeLabeling is a mapping from edges to their type label.
+type EdgeLabeling func(e graph.Edge) string
+
+// EdgeType is the type of an edge, given by the labels of its end
+// nodes and its own label.
+type EdgeType struct {
+	From, Label, To string
+}
+
+// Statistics holds cardinality statistics of a labeled graph for use in
+// estimating the selectivity of steps of a graph pattern match.
+type Statistics struct {
+	// Nodes holds the number of nodes
+	// with each node label.
+	Nodes map[string]int
+
+	// Edges holds the number of edges
+	// of each edge type.
+	Edges map[EdgeType]int
+
+	// OutDegree and InDegree hold the
+	// out and in degree histograms of the
+	// nodes with each node label. The kth
+	// element of a histogram is the number
+	// of nodes with degree k.
+	OutDegree, InDegree map[string][]int
+
+	// CoOccurrence holds the number of
+	// nodes incident to edges with both
+	// edge labels of the key, ordered
+	// lexically. The count for a label
+	// paired with itself is the number of
+	// nodes incident to an edge with that
+	// label.
+	CoOccurrence map[[2]string]int
+}
+
+// PatternStatistics returns the cardinality statistics of g with node labels
+// given by nodeLabel and edge labels given by edgeLabel. Edges are obtained
+// from g with the Edge method. If g is undirected, each edge is counted in
+// both directions, so its type is recorded from both of its ends and it
+// contributes to both the in and out degree of each of its nodes.
+func PatternStatistics(g graph.Graph, nodeLabel Labeling, edgeLabel EdgeLabeling) Statistics {
+	s := Statistics{
+		Nodes:        make(map[string]int),
+		Edges:        make(map[EdgeType]int),
+		OutDegree:    make(map[string][]int),
+		InDegree:     make(map[string][]int),
+		CoOccurrence: make(map[[2]string]int),
+	}
+	var to func(int64) graph.Nodes
+	if d, ok := g.(graph.Directed); ok {
+		to = d.To
+	} else {
+		to = g.From
+	}
+
+	nodes := g.Nodes()
+	for nodes.Next() {
+		u := nodes.Node()
+		uid := u.ID()
+		ul := nodeLabel(u)
+		s.Nodes[ul]++
+
+		incident := make(map[string]bool)
+		out := g.From(uid)
+		var outDeg int
+		for out.Next() {
+			v := out.Node()
+			l := edgeLabel(g.Edge(uid, v.ID()))
+			s.Edges[EdgeType{From: ul, Label: l, To: nodeLabel(v)}]++
+			incident[l] = true
+			outDeg++
+		}
+		in := to(uid)
+		var inDeg int
+		for in.Next() {
+			incident[edgeLabel(g.Edge(in.Node().ID(), uid))] = true
+			inDeg++
+		}
+		s.OutDegree[ul] = addToHistogram(s.OutDegree[ul], outDeg)
+		s.InDegree[ul] = addToHistogram(s.InDegree[ul], inDeg)
+
+		labels := make([]string, 0, len(incident))
+		for l := range incident {
+			labels = append(labels, l)
+		}
+		sort.Strings(labels)
+		for i, a := range labels {
+			for _, b := range labels[i:] {
+				s.CoOccurrence[[2]string{a, b}]++
+			}
+		}
+	}
+	return s
+}
+
+// addToHistogram returns the histogram h with the count for k incremented.
+func addToHistogram(h []int, k int) []int {
+	if k >= len(h) {
+		h = append(h, make([]int, k+1-len(h))...)
+	}
+	h[k]++
+	return h
+}
+
+// FanOut returns the mean number of edges of type t leaving a node labeled
+// t.From. FanOut returns zero if there are no nodes labeled t.From.
+func (s Statistics) FanOut(t EdgeType) float64 {
+	n := s.Nodes[t.From]
+	if n == 0 {
+		return 0
+	}
+	return float64(s.Edges[t]) / float64(n)
+}
+
+// FanIn returns the mean number of edges of type t entering a node labeled
+// t.To. FanIn returns zero if there are no nodes labeled t.To.
+func (s Statistics) FanIn(t EdgeType) float64 {
+	n := s.Nodes[t.To]
+	if n == 0 {
+		return 0
+	}
+	return float64(s.Edges[t]) / float64(n)
+}
+
+// Selectivity returns the fraction of pairs of nodes labeled t.From and t.To
+// that are joined by an edge of type t. Lower selectivity steps of a pattern
+// match produce fewer candidate bindings. Selectivity returns zero if there
+// are no such pairs.
+func (s Statistics) Selectivity(t EdgeType) float64 {
+	pairs := float64(s.Nodes[t.From]) * float64(s.Nodes[t.To])
+	if pairs == 0 {
+		return 0
+	}
+	return float64(s.Edges[t]) / pairs
+}
+
+// CoOccurring returns the number of nodes incident to edges with both
+// the edge labels a and b.
+func (s Statistics) CoOccurring(a, b string) int {
+	if b < a {
+		a, b = b, a
+	}
+	return s.CoOccurrence[[2]string{a, b}]
+}
@@ -0,0 +1,88 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hetero
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestPatternStatistics(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for id := range bibliography.labels {
+		g.AddNode(simple.Node(id))
+	}
+	for _, e := range bibliography.edges {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	// Add a citation.
+	g.SetEdge(simple.Edge{F: simple.Node(11), T: simple.Node(12)})
+
+	edgeLabel := func(e graph.Edge) string {
+		switch bibliography.label(e.To()) {
+		case "paper":
+			if bibliography.label(e.From()) == "paper" {
+				return "cites"
+			}
+			return "wrote"
+		case "venue":
+			return "in"
+		default:
+			panic("unexpected edge")
+		}
+	}
+	s := PatternStatistics(g, bibliography.label, edgeLabel)
+
+	want := Statistics{
+		Nodes: map[string]int{"author": 4, "paper": 3, "venue": 2},
+		Edges: map[EdgeType]int{
+			{From: "author", Label: "wrote", To: "paper"}: 6,
+			{From: "paper", Label: "in", To: "venue"}:     3,
+			{From: "paper", Label: "cites", To: "paper"}:  1,
+		},
+		OutDegree: map[string][]int{
+			"author": {1, 1, 1, 1},
+			"paper":  {0, 2, 1},
+			"venue":  {2},
+		},
+		InDegree: map[string][]int{
+			"author": {4},
+			"paper":  {0, 0, 2, 1},
+			"venue":  {0, 1, 1},
+		},
+		CoOccurrence: map[[2]string]int{
+			{"wrote", "wrote"}: 6,
+			{"in", "in"}:       5,
+			{"in", "wrote"}:    3,
+			{"cites", "cites"}: 2,
+			{"cites", "in"}:    2,
+			{"cites", "wrote"}: 2,
+		},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("unexpected statistics:\ngot: %+v\nwant:%+v", s, want)
+	}
+
+	wrote := EdgeType{From: "author", Label: "wrote", To: "paper"}
+	if got := s.FanOut(wrote); got != 1.5 {
+		t.Errorf("unexpected fan out: got:%v want:1.5", got)
+	}
+	if got := s.FanIn(wrote); got != 2 {
+		t.Errorf("unexpected fan in: got:%v want:2", got)
+	}
+	if got := s.Selectivity(wrote); got != 0.5 {
+		t.Errorf("unexpected selectivity: got:%v want:0.5", got)
+	}
+	if got := s.CoOccurring("wrote", "in"); got != 3 {
+		t.Errorf("unexpected co-occurrence: got:%d want:3", got)
+	}
+	missing := EdgeType{From: "editor", Label: "edited", To: "paper"}
+	if s.FanOut(missing) != 0 || s.Selectivity(missing) != 0 {
+		t.Error("unexpected non-zero estimate for missing edge type")
+	}
+}
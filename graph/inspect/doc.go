@@ -0,0 +1,10 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package inspect provides an HTTP handler for interactive inspection of
+// graphs. The handler serves a laid out graph, with any encoding attributes
+// of its nodes and edges, as Cytoscape.js JSON and as SVG, allowing graphs
+// to be examined from a browser during a computation without writing them
+// to disk and switching tools.
+package inspect // import "gonum.org/v1/gonum/graph/inspect"
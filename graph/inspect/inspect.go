@@ -0,0 +1,309 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/encoding/dot"
+	"gonum.org/v1/gonum/graph/formats/cytoscapejs"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/layout"
+	"gonum.org/v1/gonum/spatial/r2"
+)
+
+// Handler is an http.Handler serving a laid out graph for inspection.
+// Handler serves the following paths:
+//
+//	/           an HTML page showing the graph
+//	/graph.svg  an SVG rendering of the graph
+//	/graph.json the graph in Cytoscape.js JSON format
+//
+// Node and edge attributes provided by encoding.Attributer implementations,
+// for example those of a graph returned by annotate.Graph, are included in
+// the JSON data and as tooltips in the SVG rendering. The DOT attributes
+// "fillcolor", "width" and "penwidth" are used to style node fill colors,
+// node sizes and edge widths in the SVG rendering, so a graph annotated with
+// network metrics may be inspected by serving
+//
+//	annotate.Graph(g, annotate.Metrics(g, annotate.All, nil))
+//
+// The layout of the graph is calculated when the Handler is created, so
+// changes made to the graph after the Handler has been created will not be
+// reflected in the served graph.
+type Handler struct {
+	g      graph.Graph
+	nodes  []graph.Node
+	coords map[int64]r2.Vec
+	mux    *http.ServeMux
+}
+
+// NewHandler returns a new Handler serving g laid out by the layout update
+// function. If update is nil, an Eades layout with 100 updates is used.
+func NewHandler(g graph.Graph, update func(graph.Graph, layout.LayoutR2) bool) *Handler {
+	if update == nil {
+		update = (&layout.EadesR2{
+			Updates:   100,
+			Repulsion: 1,
+			Rate:      0.05,
+			Theta:     0.2,
+			Src:       rand.NewSource(1),
+		}).Update
+	}
+	o := layout.NewOptimizerR2(g, update)
+	for o.Update() {
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	h := &Handler{
+		g:      g,
+		nodes:  nodes,
+		coords: make(map[int64]r2.Vec, len(nodes)),
+		mux:    http.NewServeMux(),
+	}
+	for _, n := range nodes {
+		h.coords[n.ID()] = o.Coord2(n.ID())
+	}
+	h.mux.HandleFunc("/", h.serveIndex)
+	h.mux.HandleFunc("/graph.svg", h.serveSVG)
+	h.mux.HandleFunc("/graph.json", h.serveJSON)
+	return h
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Serve serves g for inspection on the TCP network address addr using
+// the default layout. Serve always returns a non-nil error.
+func Serve(addr string, g graph.Graph) error {
+	return http.ListenAndServe(addr, NewHandler(g, nil))
+}
+
+// edge is an edge of the inspected graph with its end node IDs.
+type edge struct {
+	graph.Edge
+	uid, vid int64
+}
+
+// edges returns the edges of the graph in a deterministic order.
+// Each undirected edge is returned once.
+func (h *Handler) edges() []edge {
+	_, isDirected := h.g.(graph.Directed)
+	var edges []edge
+	for _, u := range h.nodes {
+		uid := u.ID()
+		var to []int64
+		for _, v := range graph.NodesOf(h.g.From(uid)) {
+			if vid := v.ID(); isDirected || uid <= vid {
+				to = append(to, vid)
+			}
+		}
+		sort.Slice(to, func(i, j int) bool { return to[i] < to[j] })
+		for _, vid := range to {
+			edges = append(edges, edge{Edge: h.g.Edge(uid, vid), uid: uid, vid: vid})
+		}
+	}
+	return edges
+}
+
+func (h *Handler) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Graph</title></head>
+<body>
+<p><a href="graph.json">JSON</a> <a href="graph.svg">SVG</a></p>
+`)
+	h.writeSVG(w)
+	fmt.Fprint(w, "\n</body>\n</html>\n")
+}
+
+func (h *Handler) serveSVG(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	h.writeSVG(w)
+}
+
+// Dimensions of the SVG rendering in pixels.
+const (
+	size       = 800
+	margin     = 40
+	nodeRadius = 10
+)
+
+// writeSVG writes an SVG rendering of the graph to w.
+func (h *Handler) writeSVG(w io.Writer) {
+	min := r2.Vec{X: math.Inf(1), Y: math.Inf(1)}
+	max := r2.Vec{X: math.Inf(-1), Y: math.Inf(-1)}
+	for _, c := range h.coords {
+		min = r2.Vec{X: math.Min(min.X, c.X), Y: math.Min(min.Y, c.Y)}
+		max = r2.Vec{X: math.Max(max.X, c.X), Y: math.Max(max.Y, c.Y)}
+	}
+	scale := (size - 2*margin) / math.Max(math.Max(max.X-min.X, max.Y-min.Y), 1e-12)
+	pos := func(id int64) r2.Vec {
+		c := h.coords[id]
+		if len(h.coords) == 1 {
+			return r2.Vec{X: size / 2, Y: size / 2}
+		}
+		return r2.Vec{X: margin + (c.X-min.X)*scale, Y: margin + (c.Y-min.Y)*scale}
+	}
+
+	_, isDirected := h.g.(graph.Directed)
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="%[1]d" viewBox="0 0 %[1]d %[1]d">`+"\n", size)
+	if isDirected {
+		fmt.Fprint(w, `<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto"><path d="M0,0 L10,5 L0,10 z"/></marker></defs>`+"\n")
+	}
+
+	for _, e := range h.edges() {
+		attrs := attributes(e.Edge)
+		width := 1.0
+		if v, err := strconv.ParseFloat(attrs["penwidth"], 64); err == nil {
+			width = v
+		}
+		u, v := pos(e.uid), pos(e.vid)
+		if isDirected {
+			// Stop the line at the edge of the
+			// target node so the arrow is visible.
+			d := v.Sub(u)
+			if l := r2.Norm(d); l != 0 {
+				v = v.Sub(d.Scale(radius(h.g.Node(e.vid)) / l))
+			}
+		}
+		fmt.Fprintf(w, `<g class="edge"><title>%s</title><line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="black" stroke-width="%g"`,
+			html.EscapeString(tooltip(fmt.Sprintf("%d–%d", e.uid, e.vid), attrs)), u.X, u.Y, v.X, v.Y, width)
+		if isDirected {
+			fmt.Fprint(w, ` marker-end="url(#arrow)"`)
+		}
+		fmt.Fprint(w, "/></g>\n")
+	}
+
+	for _, n := range h.nodes {
+		attrs := attributes(n)
+		fill := "white"
+		if c, ok := attrs["fillcolor"]; ok {
+			fill = strings.Trim(c, `"`)
+		}
+		p := pos(n.ID())
+		label := nodeID(n)
+		if l, ok := attrs["label"]; ok {
+			label = strings.Trim(l, `"`)
+		}
+		fmt.Fprintf(w, `<g class="node" id="node-%d"><title>%s</title><circle cx="%.2f" cy="%.2f" r="%.2f" fill="%s" stroke="black"/><text x="%.2f" y="%.2f" text-anchor="middle" dominant-baseline="central" font-size="10">%s</text></g>`+"\n",
+			n.ID(), html.EscapeString(tooltip(nodeID(n), attrs)), p.X, p.Y, radius(n), html.EscapeString(fill), p.X, p.Y, html.EscapeString(label))
+	}
+	fmt.Fprint(w, "</svg>\n")
+}
+
+// radius returns the SVG radius of n.
+func radius(n graph.Node) float64 {
+	if v, err := strconv.ParseFloat(attributes(n)["width"], 64); err == nil {
+		return nodeRadius * v
+	}
+	return nodeRadius
+}
+
+func (h *Handler) serveJSON(w http.ResponseWriter, r *http.Request) {
+	var g cytoscapejs.GraphNodeEdge
+	for _, n := range h.nodes {
+		c := h.coords[n.ID()]
+		g.Elements.Nodes = append(g.Elements.Nodes, cytoscapejs.Node{
+			Data: cytoscapejs.NodeData{
+				ID:         nodeID(n),
+				Attributes: jsonAttributes(attributes(n)),
+			},
+			Position: &cytoscapejs.Position{X: c.X, Y: c.Y},
+		})
+	}
+	for _, e := range h.edges() {
+		g.Elements.Edges = append(g.Elements.Edges, cytoscapejs.Edge{
+			Data: cytoscapejs.EdgeData{
+				ID:         fmt.Sprintf("%d-%d", e.uid, e.vid),
+				Source:     nodeID(h.g.Node(e.uid)),
+				Target:     nodeID(h.g.Node(e.vid)),
+				Attributes: jsonAttributes(attributes(e.Edge)),
+			},
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(&g)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// nodeID returns the DOT ID of n if it is a dot.Node, and its
+// decimal ID otherwise.
+func nodeID(n graph.Node) string {
+	if d, ok := n.(dot.Node); ok {
+		if id := d.DOTID(); id != "" {
+			return id
+		}
+	}
+	return strconv.FormatInt(n.ID(), 10)
+}
+
+// attributes returns the encoding attributes of v as a map. Later
+// attributes take precedence over earlier attributes with the same key.
+func attributes(v interface{}) map[string]string {
+	a, ok := v.(encoding.Attributer)
+	if !ok {
+		return nil
+	}
+	attrs := a.Attributes()
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		m[attr.Key] = attr.Value
+	}
+	return m
+}
+
+// jsonAttributes returns attrs as Cytoscape.js element data attributes.
+func jsonAttributes(attrs map[string]string) map[string]interface{} {
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, len(attrs))
+	for k, v := range attrs {
+		m[k] = strings.Trim(v, `"`)
+	}
+	return m
+}
+
+// tooltip returns a description of the element with the given
+// name and attributes, with attributes sorted by key.
+func tooltip(name string, attrs map[string]string) string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n%s=%s", k, attrs[k])
+	}
+	return b.String()
+}
@@ -0,0 +1,157 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inspect
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/encoding/annotate"
+	"gonum.org/v1/gonum/graph/formats/cytoscapejs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func get(t *testing.T, srv *httptest.Server, path string) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatalf("unexpected error getting %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error reading %s: %v", path, err)
+	}
+	return resp, b
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name string
+		g    interface {
+			graph.Graph
+			SetEdge(graph.Edge)
+		}
+		wantEdges int
+		wantArrow bool
+	}{
+		{name: "undirected", g: simple.NewUndirectedGraph(), wantEdges: 4},
+		{name: "directed", g: simple.NewDirectedGraph(), wantEdges: 4, wantArrow: true},
+	} {
+		for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 0}, {2, 3}} {
+			test.g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+		}
+		test.g.(graph.NodeAdder).AddNode(simple.Node(4))
+
+		a := annotate.NewAnnotations()
+		a.AddNode(3, encoding.Attribute{Key: "fillcolor", Value: `"#ff0000"`}, encoding.Attribute{Key: "width", Value: "2"})
+		a.AddEdge(2, 3, encoding.Attribute{Key: "penwidth", Value: "3"})
+
+		srv := httptest.NewServer(NewHandler(annotate.Graph(test.g, a), nil))
+
+		resp, b := get(t, srv, "/graph.json")
+		if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: unexpected JSON content type: %q", test.name, ct)
+		}
+		var cy cytoscapejs.GraphNodeEdge
+		err := json.Unmarshal(b, &cy)
+		if err != nil {
+			t.Fatalf("%s: unexpected error decoding JSON: %v", test.name, err)
+		}
+		if len(cy.Elements.Nodes) != 5 {
+			t.Errorf("%s: unexpected number of JSON nodes: got:%d want:5", test.name, len(cy.Elements.Nodes))
+		}
+		if len(cy.Elements.Edges) != test.wantEdges {
+			t.Errorf("%s: unexpected number of JSON edges: got:%d want:%d", test.name, len(cy.Elements.Edges), test.wantEdges)
+		}
+		for _, n := range cy.Elements.Nodes {
+			if n.Position == nil {
+				t.Errorf("%s: missing position for node %s", test.name, n.Data.ID)
+			}
+			if n.Data.ID == "3" && n.Data.Attributes["fillcolor"] != "#ff0000" {
+				t.Errorf("%s: unexpected fillcolor for node 3: got:%v", test.name, n.Data.Attributes["fillcolor"])
+			}
+		}
+		for _, e := range cy.Elements.Edges {
+			if e.Data.Source == "2" && e.Data.Target == "3" && e.Data.Attributes["penwidth"] != "3" {
+				t.Errorf("%s: unexpected penwidth for edge 2-3: got:%v", test.name, e.Data.Attributes["penwidth"])
+			}
+		}
+
+		resp, b = get(t, srv, "/graph.svg")
+		if ct := resp.Header.Get("Content-Type"); ct != "image/svg+xml" {
+			t.Errorf("%s: unexpected SVG content type: %q", test.name, ct)
+		}
+		var svg struct {
+			Groups []struct {
+				Class  string `xml:"class,attr"`
+				Circle *struct {
+					Fill string `xml:"fill,attr"`
+					R    string `xml:"r,attr"`
+				} `xml:"circle"`
+				Line *struct {
+					Width string `xml:"stroke-width,attr"`
+				} `xml:"line"`
+			} `xml:"g"`
+			Defs *struct{} `xml:"defs"`
+		}
+		err = xml.Unmarshal(b, &svg)
+		if err != nil {
+			t.Fatalf("%s: unexpected error decoding SVG: %v", test.name, err)
+		}
+		var nodes, edges int
+		var sawFill, sawWidth bool
+		for _, g := range svg.Groups {
+			switch g.Class {
+			case "node":
+				nodes++
+				if g.Circle.Fill == "#ff0000" && g.Circle.R == "20.00" {
+					sawFill = true
+				}
+			case "edge":
+				edges++
+				if g.Line.Width == "3" {
+					sawWidth = true
+				}
+			}
+		}
+		if nodes != 5 || edges != test.wantEdges {
+			t.Errorf("%s: unexpected SVG element counts: got:%d nodes %d edges want:5 nodes %d edges",
+				test.name, nodes, edges, test.wantEdges)
+		}
+		if !sawFill {
+			t.Errorf("%s: missing styled node in SVG", test.name)
+		}
+		if !sawWidth {
+			t.Errorf("%s: missing styled edge in SVG", test.name)
+		}
+		if (svg.Defs != nil) != test.wantArrow {
+			t.Errorf("%s: unexpected arrow definition: got:%t want:%t", test.name, svg.Defs != nil, test.wantArrow)
+		}
+
+		resp, b = get(t, srv, "/")
+		if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+			t.Errorf("%s: unexpected index content type: %q", test.name, ct)
+		}
+		if !strings.Contains(string(b), "<svg") {
+			t.Errorf("%s: index does not contain SVG", test.name)
+		}
+
+		resp, _ = get(t, srv, "/missing")
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("%s: unexpected status for missing path: got:%d want:%d", test.name, resp.StatusCode, http.StatusNotFound)
+		}
+
+		srv.Close()
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package codec provides a versioned binary encoding for graph analysis
+// preprocessing results.
+//
+// An encoding starts with a header holding the magic bytes "GGPP", a four
+// byte kind tag identifying the encoded type, and a uint32 version number,
+// followed by the encoded values. All values are little-endian encoded as
+// eight bytes.
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const magic = "GGPP"
+
+// headerSize is the size of the magic bytes, kind tag and version.
+const headerSize = 4 + 4 + 4
+
+var errShort = errors.New("graph: encoding too short")
+
+// Encoder is a binary encoder.
+type Encoder struct {
+	buf []byte
+}
+
+// NewEncoder returns an encoder that has written a header for the given
+// kind and version. NewEncoder will panic if kind is not four bytes long.
+func NewEncoder(kind string, version uint32) *Encoder {
+	if len(kind) != 4 {
+		panic("codec: invalid kind tag")
+	}
+	e := &Encoder{buf: make([]byte, headerSize)}
+	copy(e.buf, magic)
+	copy(e.buf[4:], kind)
+	binary.LittleEndian.PutUint32(e.buf[8:], version)
+	return e
+}
+
+// Uint64 encodes v.
+func (e *Encoder) Uint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.buf = append(e.buf, b[:]...)
+}
+
+// Int64 encodes v.
+func (e *Encoder) Int64(v int64) { e.Uint64(uint64(v)) }
+
+// Int encodes v.
+func (e *Encoder) Int(v int) { e.Uint64(uint64(int64(v))) }
+
+// Float64 encodes v.
+func (e *Encoder) Float64(v float64) { e.Uint64(math.Float64bits(v)) }
+
+// Bytes returns the encoded data.
+func (e *Encoder) Bytes() []byte { return e.buf }
+
+// Decoder is a binary decoder. Decoding errors are sticky; after the
+// first error all decoded values are zero and Err returns the error.
+type Decoder struct {
+	buf []byte
+	err error
+}
+
+// NewDecoder returns a decoder for data and the version of the encoding.
+// An error is returned if data does not start with a header for the given
+// kind or the version is greater than max.
+func NewDecoder(data []byte, kind string, max uint32) (d *Decoder, version uint32, err error) {
+	if len(data) < headerSize || string(data[:4]) != magic {
+		return nil, 0, errors.New("graph: invalid preprocessing encoding")
+	}
+	if got := string(data[4:8]); got != kind {
+		return nil, 0, fmt.Errorf("graph: mismatched preprocessing kind: got:%q want:%q", got, kind)
+	}
+	version = binary.LittleEndian.Uint32(data[8:])
+	if version == 0 || version > max {
+		return nil, 0, fmt.Errorf("graph: unsupported %s encoding version: %d", kind, version)
+	}
+	return &Decoder{buf: data[headerSize:]}, version, nil
+}
+
+// Uint64 decodes a uint64.
+func (d *Decoder) Uint64() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) < 8 {
+		d.err = errShort
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(d.buf)
+	d.buf = d.buf[8:]
+	return v
+}
+
+// Int64 decodes an int64.
+func (d *Decoder) Int64() int64 { return int64(d.Uint64()) }
+
+// Int decodes an int.
+func (d *Decoder) Int() int {
+	v := d.Int64()
+	if int64(int(v)) != v {
+		d.fail(errors.New("graph: encoded value overflows int"))
+		return 0
+	}
+	return int(v)
+}
+
+// Float64 decodes a float64.
+func (d *Decoder) Float64() float64 { return math.Float64frombits(d.Uint64()) }
+
+// Len decodes the length of a sequence of elements that are each encoded
+// with the given number of values. An error is recorded if the length is
+// negative or the remaining data is too short to hold the sequence.
+func (d *Decoder) Len(values int) int {
+	n := d.Int64()
+	if d.err != nil {
+		return 0
+	}
+	if n < 0 || (values > 0 && n > int64(len(d.buf)/(8*values))) {
+		d.fail(errShort)
+		return 0
+	}
+	return int(n)
+}
+
+// Err returns the first error encountered during decoding, or an error
+// if not all of the data was decoded.
+func (d *Decoder) Err() error {
+	if d.err == nil && len(d.buf) != 0 {
+		return errors.New("graph: trailing data in encoding")
+	}
+	return d.err
+}
+
+func (d *Decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
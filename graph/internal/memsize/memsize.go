@@ -0,0 +1,56 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package memsize provides estimates of the memory held by Go values.
+package memsize // import "gonum.org/v1/gonum/graph/internal/memsize"
+
+import (
+	"reflect"
+	"strconv"
+)
+
+const (
+	// Word is the size of a pointer or int.
+	Word = strconv.IntSize / 8
+	// Interface is the size of an interface value.
+	Interface = 2 * Word
+	// Slice is the size of a slice header.
+	Slice = 3 * Word
+
+	// mapHeader is the size of the runtime map header.
+	mapHeader = 6 * Word
+	// bucketLen is the number of entries held
+	// by a map bucket.
+	bucketLen = 8
+	// loadFactor is the mean number of entries per
+	// bucket at which a map is grown.
+	loadFactor = 6.5
+)
+
+// Map returns an estimate of the number of bytes held by a map with n entries
+// with the given key and element sizes, excluding the memory referenced by the
+// keys and elements.
+func Map(n int, key, elem uintptr) int64 {
+	buckets := 1
+	for float64(n) > loadFactor*float64(buckets) {
+		buckets *= 2
+	}
+	bucket := int64(bucketLen + bucketLen*(key+elem) + Word)
+	return mapHeader + int64(buckets)*bucket
+}
+
+// Dynamic returns the number of bytes held by the value boxed in the
+// interface value v, or zero if v is nil or the value is held directly
+// in the interface.
+func Dynamic(v interface{}) int64 {
+	if v == nil {
+		return 0
+	}
+	t := reflect.TypeOf(v)
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return 0
+	}
+	return int64(t.Size())
+}
@@ -0,0 +1,15 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package interval provides construction of interval graphs and scheduling
+// algorithms that exploit their structure.
+//
+// An interval graph has a node for each of a set of intervals and an edge
+// between each pair of overlapping intervals. Problems that are hard on
+// general graphs, such as coloring and finding maximum cliques and maximum
+// independent sets, are solved directly from the intervals in O(n log n)
+// time. In scheduling terms, a coloring assigns jobs to the least number of
+// machines, and a maximum independent set is the largest set of jobs that
+// can be run on a single machine.
+package interval // import "gonum.org/v1/gonum/graph/interval"
@@ -0,0 +1,228 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"container/heap"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Interval is a half-open interval [Start, End) that is a graph node.
+// An interval with End equal to Start is empty and overlaps no other
+// interval.
+type Interval struct {
+	UID int64
+
+	Start, End float64
+}
+
+// ID returns the ID of the interval.
+func (i Interval) ID() int64 { return i.UID }
+
+// Overlaps returns whether i and j have a point in common.
+func (i Interval) Overlaps(j Interval) bool {
+	return i.Start < j.End && j.Start < i.End && !i.empty() && !j.empty()
+}
+
+func (i Interval) empty() bool { return i.End == i.Start }
+
+// Graph adds a node for each of the intervals and an edge between each pair
+// of overlapping intervals to dst. The intervals are the nodes of the graph.
+// Graph will panic if an interval has End less than Start or if two intervals
+// have the same ID.
+//
+// The edges are found by sweeping over the intervals in order of their start,
+// taking O(n log n + m) time for n intervals with m overlapping pairs.
+func Graph(dst graph.Builder, intervals []Interval) {
+	sorted := byStart(intervals)
+	var active []Interval
+	for _, iv := range sorted {
+		dst.AddNode(iv)
+		if iv.empty() {
+			continue
+		}
+		n := 0
+		for _, a := range active {
+			if a.End > iv.Start {
+				active[n] = a
+				n++
+			}
+		}
+		active = active[:n]
+		for _, a := range active {
+			dst.SetEdge(dst.NewEdge(a, iv))
+		}
+		active = append(active, iv)
+	}
+}
+
+// Color returns an optimal coloring of the interval graph of the intervals,
+// mapping interval IDs to colors from zero, and the number of colors used.
+// The number of colors is equal to the size of the largest set of mutually
+// overlapping intervals, returned by MaxOverlap. Empty intervals overlap no
+// other interval and are given color zero without counting toward the number
+// of colors. In scheduling terms, the colors are the machines that jobs are
+// assigned to. Color will panic if an interval has End less than Start.
+//
+// Intervals are colored in order of their start with the least color not in
+// use by an overlapping interval.
+func Color(intervals []Interval) (colors map[int64]int, k int) {
+	colors = make(map[int64]int, len(intervals))
+	var (
+		active ends
+		free   ints
+	)
+	for _, iv := range byStart(intervals) {
+		if iv.empty() {
+			colors[iv.UID] = 0
+			continue
+		}
+		for len(active) != 0 && active[0].end <= iv.Start {
+			heap.Push(&free, heap.Pop(&active).(colorEnd).color)
+		}
+		c := k
+		if len(free) != 0 {
+			c = heap.Pop(&free).(int)
+		} else {
+			k++
+		}
+		colors[iv.UID] = c
+		heap.Push(&active, colorEnd{end: iv.End, color: c})
+	}
+	return colors, k
+}
+
+// MaxOverlap returns a largest set of mutually overlapping intervals, a
+// maximum clique of their interval graph, sorted by start and then by ID.
+// The returned intervals all contain the returned point. If there are no
+// non-empty intervals, MaxOverlap returns a nil set and zero. MaxOverlap
+// will panic if an interval has End less than Start.
+func MaxOverlap(intervals []Interval) (clique []Interval, at float64) {
+	type event struct {
+		at    float64
+		delta int
+	}
+	var events []event
+	for _, iv := range intervals {
+		if iv.End < iv.Start {
+			panic("interval: invalid interval")
+		}
+		if iv.empty() {
+			continue
+		}
+		events = append(events, event{at: iv.Start, delta: 1}, event{at: iv.End, delta: -1})
+	}
+	if len(events) == 0 {
+		return nil, 0
+	}
+	// Ends sort before starts at the same point
+	// since the intervals are half-open.
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].at != events[j].at {
+			return events[i].at < events[j].at
+		}
+		return events[i].delta < events[j].delta
+	})
+	var n, max int
+	for _, e := range events {
+		n += e.delta
+		if n > max {
+			max, at = n, e.at
+		}
+	}
+	for _, iv := range byStart(intervals) {
+		if iv.Start <= at && at < iv.End {
+			clique = append(clique, iv)
+		}
+	}
+	return clique, at
+}
+
+// MaxIndependentSet returns a largest set of pairwise non-overlapping
+// intervals, a maximum independent set of their interval graph, sorted by
+// start and then by ID. In scheduling terms, this is the largest set of jobs
+// that can be run on a single machine. Empty intervals overlap no other
+// interval and are always included. MaxIndependentSet will panic if an
+// interval has End less than Start.
+//
+// The set is found by the greedy earliest-finish algorithm.
+func MaxIndependentSet(intervals []Interval) []Interval {
+	sorted := byStart(intervals)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].End < sorted[j].End })
+	var set []Interval
+	var last *Interval
+	for i, iv := range sorted {
+		if iv.empty() {
+			set = append(set, iv)
+			continue
+		}
+		if last == nil || last.End <= iv.Start {
+			set = append(set, iv)
+			last = &sorted[i]
+		}
+	}
+	return byStart(set)
+}
+
+// byStart returns a copy of intervals sorted by start, then end and then
+// ID. byStart will panic if an interval has End less than Start.
+func byStart(intervals []Interval) []Interval {
+	sorted := make([]Interval, len(intervals))
+	for i, iv := range intervals {
+		if iv.End < iv.Start {
+			panic("interval: invalid interval")
+		}
+		sorted[i] = iv
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		if a.End != b.End {
+			return a.End < b.End
+		}
+		return a.UID < b.UID
+	})
+	return sorted
+}
+
+// colorEnd is the end of an interval and its color.
+type colorEnd struct {
+	end   float64
+	color int
+}
+
+// ends is a min-heap of interval ends.
+type ends []colorEnd
+
+func (h ends) Len() int            { return len(h) }
+func (h ends) Less(i, j int) bool  { return h[i].end < h[j].end }
+func (h ends) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *ends) Push(x interface{}) { *h = append(*h, x.(colorEnd)) }
+func (h *ends) Pop() interface{} {
+	old := *h
+	n := len(old) - 1
+	x := old[n]
+	*h = old[:n]
+	return x
+}
+
+// ints is a min-heap of ints.
+type ints []int
+
+func (h ints) Len() int            { return len(h) }
+func (h ints) Less(i, j int) bool  { return h[i] < h[j] }
+func (h ints) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *ints) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *ints) Pop() interface{} {
+	old := *h
+	n := len(old) - 1
+	x := old[n]
+	*h = old[:n]
+	return x
+}
@@ -0,0 +1,220 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package interval
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+var intervalTests = []struct {
+	name      string
+	intervals []Interval
+
+	edges       int
+	colors      int
+	independent int
+}{
+	{name: "empty"},
+	{
+		name:      "single",
+		intervals: []Interval{{UID: 0, Start: 0, End: 1}},
+		colors:    1, independent: 1,
+	},
+	{
+		name: "touching",
+		intervals: []Interval{
+			{UID: 0, Start: 0, End: 1},
+			{UID: 1, Start: 1, End: 2},
+			{UID: 2, Start: 2, End: 3},
+		},
+		edges: 0, colors: 1, independent: 3,
+	},
+	{
+		name: "nested",
+		intervals: []Interval{
+			{UID: 0, Start: 0, End: 10},
+			{UID: 1, Start: 1, End: 9},
+			{UID: 2, Start: 2, End: 3},
+			{UID: 3, Start: 4, End: 5},
+		},
+		edges: 5, colors: 3, independent: 2,
+	},
+	{
+		name: "schedule",
+		intervals: []Interval{
+			{UID: 0, Start: 9, End: 10.5},
+			{UID: 1, Start: 9, End: 12.5},
+			{UID: 2, Start: 9, End: 10.5},
+			{UID: 3, Start: 11, End: 12.5},
+			{UID: 4, Start: 11, End: 14},
+			{UID: 5, Start: 13, End: 14.5},
+			{UID: 6, Start: 13, End: 14.5},
+			{UID: 7, Start: 14, End: 16.5},
+			{UID: 8, Start: 15, End: 16.5},
+			{UID: 9, Start: 15, End: 16.5},
+		},
+		edges: 14, colors: 3, independent: 4,
+	},
+	{
+		name: "with empty",
+		intervals: []Interval{
+			{UID: 0, Start: 0, End: 2},
+			{UID: 1, Start: 1, End: 1},
+			{UID: 2, Start: 1, End: 3},
+		},
+		edges: 1, colors: 2, independent: 2,
+	},
+	{
+		name: "all empty",
+		intervals: []Interval{
+			{UID: 0, Start: 0, End: 0},
+			{UID: 1, Start: 1, End: 1},
+		},
+		edges: 0, colors: 0, independent: 2,
+	},
+}
+
+func TestIntervals(t *testing.T) {
+	t.Parallel()
+	for _, test := range intervalTests {
+		checkIntervals(t, test.name, test.intervals, test.edges, test.colors, test.independent)
+	}
+}
+
+func TestIntervalsRandom(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 20; n++ {
+		intervals := make([]Interval, 30)
+		for i := range intervals {
+			s := float64(rnd.Intn(50))
+			intervals[i] = Interval{UID: int64(i), Start: s, End: s + float64(rnd.Intn(10))}
+		}
+		checkIntervals(t, "random", intervals, -1, -1, -1)
+	}
+}
+
+func checkIntervals(t *testing.T, name string, intervals []Interval, edges, colors, independent int) {
+	t.Helper()
+	g := simple.NewUndirectedGraph()
+	Graph(g, intervals)
+	if g.Nodes().Len() != len(intervals) {
+		t.Errorf("%s: unexpected number of nodes: got:%d want:%d", name, g.Nodes().Len(), len(intervals))
+	}
+	var m int
+	for i, a := range intervals {
+		for _, b := range intervals[i+1:] {
+			if g.HasEdgeBetween(a.ID(), b.ID()) != a.Overlaps(b) {
+				t.Errorf("%s: unexpected edge state between %v and %v", name, a, b)
+			}
+			if a.Overlaps(b) {
+				m++
+			}
+		}
+	}
+	if edges >= 0 && m != edges {
+		t.Errorf("%s: unexpected number of edges: got:%d want:%d", name, m, edges)
+	}
+
+	// The coloring is proper and uses as many
+	// colors as the size of a maximum clique.
+	c, k := Color(intervals)
+	for _, e := range graph.EdgesOf(g.Edges()) {
+		if c[e.From().ID()] == c[e.To().ID()] {
+			t.Errorf("%s: overlapping intervals %d and %d have the same color", name, e.From().ID(), e.To().ID())
+		}
+	}
+	empty := make(map[int64]bool)
+	for _, iv := range intervals {
+		if iv.End == iv.Start {
+			empty[iv.UID] = true
+		}
+	}
+	for id, col := range c {
+		if empty[id] {
+			if col != 0 {
+				t.Errorf("%s: unexpected color %d of empty interval %d", name, col, id)
+			}
+			continue
+		}
+		if col < 0 || col >= k {
+			t.Errorf("%s: color %d of interval %d out of range [0, %d)", name, col, id, k)
+		}
+	}
+	var want int
+	for _, clique := range topo.BronKerbosch(g) {
+		if len(clique) == 1 && empty[clique[0].ID()] {
+			// Empty intervals are not colored.
+			continue
+		}
+		if len(clique) > want {
+			want = len(clique)
+		}
+	}
+	if k != want {
+		t.Errorf("%s: unexpected number of colors: got:%d want:%d", name, k, want)
+	}
+	if colors >= 0 && k != colors {
+		t.Errorf("%s: unexpected number of colors: got:%d want:%d", name, k, colors)
+	}
+
+	clique, at := MaxOverlap(intervals)
+	if len(clique) != k {
+		t.Errorf("%s: unexpected maximum overlap: got:%d want:%d", name, len(clique), k)
+	}
+	for _, iv := range clique {
+		if at < iv.Start || iv.End <= at {
+			t.Errorf("%s: interval %v does not contain %v", name, iv, at)
+		}
+	}
+
+	set := MaxIndependentSet(intervals)
+	for i, a := range set {
+		for _, b := range set[i+1:] {
+			if a.Overlaps(b) {
+				t.Errorf("%s: independent set has overlapping intervals %v and %v", name, a, b)
+			}
+		}
+	}
+	if independent >= 0 && len(set) != independent {
+		t.Errorf("%s: unexpected independent set size: got:%d want:%d", name, len(set), independent)
+	}
+	if len(intervals) <= 12 {
+		if max := maxIndependent(intervals); len(set) != max {
+			t.Errorf("%s: independent set not maximum: got:%d want:%d", name, len(set), max)
+		}
+	}
+}
+
+// maxIndependent returns the size of a maximum independent
+// set of the intervals by exhaustive search.
+func maxIndependent(intervals []Interval) int {
+	var max int
+	for mask := 0; mask < 1<<uint(len(intervals)); mask++ {
+		var set []Interval
+		ok := true
+		for i, iv := range intervals {
+			if mask&(1<<uint(i)) == 0 {
+				continue
+			}
+			for _, s := range set {
+				if s.Overlaps(iv) {
+					ok = false
+				}
+			}
+			set = append(set, iv)
+		}
+		if ok && len(set) > max {
+			max = len(set)
+		}
+	}
+	return max
+}
@@ -0,0 +1,7 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package memory provides estimates of the memory used by graphs and
+// graph analysis results.
+package memory // import "gonum.org/v1/gonum/graph/memory"
@@ -0,0 +1,153 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"unsafe"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/memsize"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Sizer is a type that can estimate the memory it holds.
+type Sizer interface {
+	// SizeOf returns an estimate of the
+	// number of bytes held by the receiver.
+	SizeOf() int64
+}
+
+// SizeOf returns an estimate of the number of bytes held by v and whether v is
+// a type that SizeOf can estimate. SizeOf can estimate the sizes of the graph
+// types of the simple package, of values implementing Sizer, including the
+// shortest path types of the path package, and of the map[int64]float64 and
+// map[[2]int64]float64 centrality values returned by the network package.
+//
+// The estimate includes the memory held by maps and slices and the values of
+// nodes and edges held by graphs. It does not include memory referenced by
+// pointers held in node and edge values, or allocator overhead.
+func SizeOf(v interface{}) (bytes int64, ok bool) {
+	switch v := v.(type) {
+	case *simple.DirectedGraph:
+		return int64(unsafe.Sizeof(*v)) + listSize(v, true, edgeOf(v)), true
+	case *simple.UndirectedGraph:
+		return int64(unsafe.Sizeof(*v)) + listSize(v, false, edgeOf(v)), true
+	case *simple.WeightedDirectedGraph:
+		return int64(unsafe.Sizeof(*v)) + listSize(v, true, weightedEdgeOf(v)), true
+	case *simple.WeightedUndirectedGraph:
+		return int64(unsafe.Sizeof(*v)) + listSize(v, false, weightedEdgeOf(v)), true
+	case *simple.DirectedMatrix:
+		return int64(unsafe.Sizeof(*v)) + matrixSize(v, v.Matrix()), true
+	case *simple.UndirectedMatrix:
+		return int64(unsafe.Sizeof(*v)) + matrixSize(v, v.Matrix()), true
+	case Sizer:
+		return v.SizeOf(), true
+	case map[int64]float64:
+		return memsize.Map(len(v), unsafe.Sizeof(int64(0)), unsafe.Sizeof(float64(0))), true
+	case map[[2]int64]float64:
+		return memsize.Map(len(v), unsafe.Sizeof([2]int64{}), unsafe.Sizeof(float64(0))), true
+	}
+	return 0, false
+}
+
+// edgeOf returns a function returning the edge between
+// the nodes with the given IDs in g.
+func edgeOf(g graph.Graph) func(uid, vid int64) interface{} {
+	return func(uid, vid int64) interface{} { return g.Edge(uid, vid) }
+}
+
+// weightedEdgeOf returns a function returning the weighted
+// edge between the nodes with the given IDs in g.
+func weightedEdgeOf(g graph.Weighted) func(uid, vid int64) interface{} {
+	return func(uid, vid int64) interface{} { return g.WeightedEdge(uid, vid) }
+}
+
+// listSize returns an estimate of the number of bytes referenced by an
+// adjacency list graph of the simple package. Directed graphs hold a map of
+// edges from and to each node, and undirected graphs hold a map of edges for
+// each node with each edge value held by the maps of both its nodes.
+func listSize(g graph.Graph, directed bool, edge func(uid, vid int64) interface{}) int64 {
+	nodes := g.Nodes()
+	n := nodes.Len()
+
+	// The node map and the set of used node IDs.
+	size := memsize.Map(n, unsafe.Sizeof(int64(0)), memsize.Interface) +
+		memsize.Map(n, unsafe.Sizeof(int64(0)), 0)
+
+	// The outer maps of edge lists.
+	lists := int64(1)
+	if directed {
+		lists = 2
+	}
+	size += lists * memsize.Map(n, unsafe.Sizeof(int64(0)), memsize.Word)
+
+	for nodes.Next() {
+		u := nodes.Node()
+		uid := u.ID()
+		size += memsize.Dynamic(u)
+
+		var deg int
+		to := g.From(uid)
+		for to.Next() {
+			deg++
+			vid := to.Node().ID()
+			if directed || uid <= vid {
+				size += memsize.Dynamic(edge(uid, vid))
+			}
+		}
+		size += memsize.Map(deg, unsafe.Sizeof(int64(0)), memsize.Interface)
+		if directed {
+			size += memsize.Map(g.(graph.Directed).To(uid).Len(), unsafe.Sizeof(int64(0)), memsize.Interface)
+		}
+	}
+	return size
+}
+
+// matrixSize returns an estimate of the number of bytes referenced
+// by an adjacency matrix graph of the simple package.
+func matrixSize(g graph.Graph, m mat.Matrix) int64 {
+	r, c := m.Dims()
+	size := int64(unsafe.Sizeof(mat.Dense{})) + int64(r*c)*int64(unsafe.Sizeof(float64(0)))
+	nodes := g.Nodes()
+	size += int64(nodes.Len()) * memsize.Interface
+	for nodes.Next() {
+		size += memsize.Dynamic(nodes.Node())
+	}
+	return size
+}
+
+// Budget is a memory budget for graph analyses.
+type Budget struct {
+	// Bytes is the number of bytes available to an
+	// analysis. If Bytes is not positive, the budget
+	// is unlimited.
+	Bytes int64
+
+	// Warn is called with the name of an analysis
+	// and its estimated size when the analysis would
+	// exceed the budget. Warn may be nil.
+	Warn func(analysis string, need, budget int64)
+}
+
+// AllPairs returns whether the result of an all-pairs shortest path analysis of
+// g, such as path.DijkstraAllPaths or path.FloydWarshall, is estimated to fit
+// within the budget. If it does not, b.Warn is called before AllPairs returns.
+func (b Budget) AllPairs(g graph.Graph) bool {
+	return b.check("all pairs shortest paths", path.AllShortestSize(g.Nodes().Len()))
+}
+
+// check returns whether need bytes fit within the budget,
+// calling b.Warn for the named analysis if they do not.
+func (b Budget) check(analysis string, need int64) bool {
+	if b.Bytes <= 0 || need <= b.Bytes {
+		return true
+	}
+	if b.Warn != nil {
+		b.Warn(analysis, need, b.Bytes)
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package memory
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/network"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// line adds a path of n nodes to g.
+func line(g graph.NodeAdder, n int) {
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for i := 1; i < n; i++ {
+		switch g := g.(type) {
+		case graph.WeightedEdgeAdder:
+			g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(i-1), simple.Node(i), 1))
+		case graph.EdgeAdder:
+			g.SetEdge(g.NewEdge(simple.Node(i-1), simple.Node(i)))
+		}
+	}
+}
+
+func TestSizeOfGraphs(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		new  func() graph.NodeAdder
+	}{
+		{name: "directed", new: func() graph.NodeAdder { return simple.NewDirectedGraph() }},
+		{name: "undirected", new: func() graph.NodeAdder { return simple.NewUndirectedGraph() }},
+		{name: "weighted directed", new: func() graph.NodeAdder { return simple.NewWeightedDirectedGraph(0, 0) }},
+		{name: "weighted undirected", new: func() graph.NodeAdder { return simple.NewWeightedUndirectedGraph(0, 0) }},
+	} {
+		var last int64
+		for _, n := range []int{0, 10, 100, 1000} {
+			g := test.new()
+			line(g, n)
+			size, ok := SizeOf(g)
+			if !ok {
+				t.Fatalf("unexpected failure to estimate size of %s graph", test.name)
+			}
+			if size <= last {
+				t.Errorf("unexpected size of %s graph with %d nodes: got:%d want >%d", test.name, n, size, last)
+			}
+			last = size
+		}
+	}
+
+	small, _ := SizeOf(simple.NewDirectedMatrix(10, 0, 0, 0))
+	large, _ := SizeOf(simple.NewDirectedMatrix(100, 0, 0, 0))
+	if large-small < 100*100*8-10*10*8 {
+		t.Errorf("unexpected size difference for matrix graphs: got:%d want>=%d", large-small, 100*100*8-10*10*8)
+	}
+}
+
+func TestSizeOfResults(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	line(g, 100)
+
+	sizes := make(map[string]int64)
+	for name, v := range map[string]interface{}{
+		"shortest":    path.DijkstraFrom(simple.Node(0), g),
+		"allShortest": path.DijkstraAllPaths(g),
+		"betweenness": network.Betweenness(g),
+		"edges":       network.EdgeBetweenness(g),
+	} {
+		size, ok := SizeOf(v)
+		if !ok {
+			t.Fatalf("unexpected failure to estimate size of %s", name)
+		}
+		if size <= 0 {
+			t.Errorf("unexpected size of %s: got:%d", name, size)
+		}
+		sizes[name] = size
+	}
+	if sizes["allShortest"] < 100*100*12 {
+		t.Errorf("unexpected size of all shortest paths: got:%d want>=%d", sizes["allShortest"], 100*100*12)
+	}
+	if sizes["shortest"] >= sizes["allShortest"] {
+		t.Errorf("unexpected size of shortest paths relative to all shortest paths: %d >= %d",
+			sizes["shortest"], sizes["allShortest"])
+	}
+
+	if _, ok := SizeOf(struct{}{}); ok {
+		t.Error("unexpected estimate for unknown type")
+	}
+}
+
+func TestBudgetAllPairs(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	line(g, 100)
+	need := path.AllShortestSize(100)
+
+	for _, test := range []struct {
+		bytes int64
+		want  bool
+	}{
+		{bytes: 0, want: true},
+		{bytes: need, want: true},
+		{bytes: need - 1, want: false},
+	} {
+		var warned bool
+		b := Budget{
+			Bytes: test.bytes,
+			Warn: func(analysis string, gotNeed, budget int64) {
+				warned = true
+				if gotNeed != need || budget != test.bytes {
+					t.Errorf("unexpected warning values: got:(%d, %d) want:(%d, %d)", gotNeed, budget, need, test.bytes)
+				}
+			},
+		}
+		got := b.AllPairs(g)
+		if got != test.want {
+			t.Errorf("unexpected result for budget %d: got:%t want:%t", test.bytes, got, test.want)
+		}
+		if warned == got {
+			t.Errorf("unexpected warning state for budget %d: got:%t want:%t", test.bytes, warned, !got)
+		}
+	}
+}
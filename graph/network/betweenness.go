@@ -85,9 +85,122 @@ func EdgeBetweenness(g graph.Graph) map[[2]int64]float64 {
 	return cb
 }
 
-// brandes is the common code for Betweenness and EdgeBetweenness. It corresponds
-// to algorithm 1 in http://algo.uni-konstanz.de/publications/b-vspbc-08.pdf with
-// the accumulation loop provided by the accumulate closure.
+// Stress returns the non-zero stress centrality for nodes in the unweighted graph g.
+//
+//  C_S(v) = \sum_{s ≠ v ≠ t ∈ V} \sigma_{st}(v)
+//
+// where \sigma_{st}(v) is the number of shortest paths from s to t that contain v.
+func Stress(g graph.Graph) map[int64]float64 {
+	// Brandes' algorithm modified for stress centrality as
+	// described in section 3.3 of
+	//
+	// http://algo.uni-konstanz.de/publications/b-vspbc-08.pdf
+
+	cs := make(map[int64]float64)
+	brandes(g, func(s graph.Node, stack linear.NodeStack, p map[int64][]graph.Node, delta, sigma map[int64]float64) {
+		for stack.Len() != 0 {
+			w := stack.Pop()
+			for _, v := range p[w.ID()] {
+				delta[v.ID()] += 1 + delta[w.ID()]
+			}
+			if w.ID() != s.ID() {
+				if d := delta[w.ID()]; d != 0 {
+					cs[w.ID()] += sigma[w.ID()] * d
+				}
+			}
+		}
+	})
+	return cs
+}
+
+// Load returns the non-zero load centrality for nodes in the unweighted graph g.
+// The load of a node is the total amount of the unit commodities exchanged between
+// each ordered pair of nodes, s and t, that passes through the node when each
+// commodity is routed from t to s along shortest paths, being divided equally at
+// each node among its predecessors on shortest paths from s. It is computed with
+// the recurrence
+//
+//  δ_{s•}(v) = \sum_{w : v ∈ P_s(w)} (1 + δ_{s•}(w)) / |P_s(w)|
+//  C_L(v) = \sum_{s ≠ v ∈ V} δ_{s•}(v)
+//
+// where P_s(w) is the set of predecessors of w on shortest paths from s.
+// In graphs where all shortest paths are unique, load centrality is equal to
+// betweenness centrality.
+func Load(g graph.Graph) map[int64]float64 {
+	// Brandes' algorithm modified for load centrality as
+	// described in section 3.4 of
+	//
+	// http://algo.uni-konstanz.de/publications/b-vspbc-08.pdf
+
+	cl := make(map[int64]float64)
+	brandes(g, func(s graph.Node, stack linear.NodeStack, p map[int64][]graph.Node, delta, _ map[int64]float64) {
+		for stack.Len() != 0 {
+			w := stack.Pop()
+			preds := p[w.ID()]
+			for _, v := range preds {
+				delta[v.ID()] += (1 + delta[w.ID()]) / float64(len(preds))
+			}
+			if w.ID() != s.ID() {
+				if d := delta[w.ID()]; d != 0 {
+					cl[w.ID()] += d
+				}
+			}
+		}
+	})
+	return cl
+}
+
+// Percolation returns the non-zero percolation centrality for nodes in the unweighted
+// graph g with the given node percolation states. Percolation states are expected
+// to be in [0, 1] and nodes of g without a state in states have a state of zero.
+//
+//  C_P(v) = 1/(N-2) \sum_{s ≠ v ≠ t ∈ V} (\sigma_{st}(v) / \sigma_{st}) (x_s / (\sum_i x_i - x_v))
+//
+// where \sigma_{st} and \sigma_{st}(v) are the number of shortest paths from s to t,
+// and the subset of those paths containing v respectively, x_i is the percolation
+// state of node i and N is the number of nodes in g.
+//
+// See Piraveenan, Prokopenko and Hossain, "Percolation Centrality: Quantifying
+// Graph-Theoretic Impact of Nodes during Percolation in Networks", PLoS ONE
+// 8(1):e53095 (2013) doi:10.1371/journal.pone.0053095
+func Percolation(g graph.Graph, states map[int64]float64) map[int64]float64 {
+	n := g.Nodes().Len()
+	if n < 3 {
+		return map[int64]float64{}
+	}
+	var sum float64
+	nodes := g.Nodes()
+	for nodes.Next() {
+		sum += states[nodes.Node().ID()]
+	}
+
+	cp := make(map[int64]float64)
+	brandes(g, func(s graph.Node, stack linear.NodeStack, p map[int64][]graph.Node, delta, sigma map[int64]float64) {
+		xs := states[s.ID()]
+		for stack.Len() != 0 {
+			w := stack.Pop()
+			for _, v := range p[w.ID()] {
+				delta[v.ID()] += sigma[v.ID()] / sigma[w.ID()] * (1 + delta[w.ID()])
+			}
+			if w.ID() == s.ID() || xs == 0 {
+				continue
+			}
+			rest := sum - states[w.ID()]
+			if d := delta[w.ID()]; d != 0 && rest != 0 {
+				cp[w.ID()] += d * xs / rest
+			}
+		}
+	})
+	for id := range cp {
+		cp[id] /= float64(n - 2)
+	}
+	return cp
+}
+
+// brandes is the common code for Betweenness, EdgeBetweenness, Stress, Load and
+// Percolation. It corresponds to algorithm 1 in
+// http://algo.uni-konstanz.de/publications/b-vspbc-08.pdf with the accumulation
+// loop provided by the accumulate closure.
 func brandes(g graph.Graph, accumulate func(s graph.Node, stack linear.NodeStack, p map[int64][]graph.Node, delta, sigma map[int64]float64)) {
 	var (
 		nodes = graph.NodesOf(g.Nodes())
@@ -0,0 +1,252 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// deterministicBlock is the number of sources whose dependencies
+// are summed together before being added to the result in the
+// deterministic mode of BetweennessParallel.
+const deterministicBlock = 64
+
+// BetweennessParallel returns the non-zero betweenness centrality for nodes in the
+// unweighted graph g. It is equivalent to Betweenness, but the single-source
+// dependency accumulations are performed concurrently by workers goroutines. If
+// workers is less than one, runtime.GOMAXPROCS(0) workers are used. The graph g
+// must be safe for concurrent reads.
+//
+// Floating point addition is not associative, so the order in which the
+// dependencies of each source are summed affects the last bits of the result.
+// If deterministic is true, sources are taken in order of node ID in blocks of a
+// fixed size, the dependencies of the sources in each block are summed in order
+// and the block sums are added to the result in order, so the returned
+// centralities are bitwise identical for any number of workers. Otherwise each
+// worker sums the dependencies of the sources it is given, and the result may
+// vary between calls in the last bits.
+//
+// See Edmonds, Hoefler and Lumsdaine, "A space-efficient parallel algorithm for
+// computing betweenness centrality in distributed memory", HiPC 2010
+// doi:10.1109/HIPC.2010.5713180.
+func BetweennessParallel(g graph.Graph, workers int, deterministic bool) map[int64]float64 {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	// Neighbors are held in order of node ID so that the
+	// dependencies of each source do not depend on the
+	// iteration order of g.
+	adj := make([][]int, len(nodes))
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			adj[i] = append(adj[i], indexOf[to.Node().ID()])
+		}
+		sort.Ints(adj[i])
+	}
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var sum []float64
+	if deterministic {
+		sum = deterministicBetweenness(adj, workers)
+	} else {
+		sum = concurrentBetweenness(adj, workers)
+	}
+
+	cb := make(map[int64]float64)
+	for i, c := range sum {
+		if c != 0 {
+			cb[nodes[i].ID()] = c
+		}
+	}
+	return cb
+}
+
+// concurrentBetweenness returns the betweenness centrality for nodes in the graph
+// represented by adj, with each worker summing dependencies for the sources it
+// is given.
+func concurrentBetweenness(adj [][]int, workers int) []float64 {
+	if workers > len(adj) {
+		workers = len(adj)
+	}
+	sources := make(chan int)
+	sums := make([][]float64, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := range sums {
+		go func(w int) {
+			defer wg.Done()
+			b := newBrandesState(len(adj))
+			sum := make([]float64, len(adj))
+			for s := range sources {
+				b.dependencies(s, adj)
+				for i, d := range b.delta {
+					if i != s {
+						sum[i] += d
+					}
+				}
+			}
+			sums[w] = sum
+		}(w)
+	}
+	for s := range adj {
+		sources <- s
+	}
+	close(sources)
+	wg.Wait()
+
+	sum := make([]float64, len(adj))
+	for _, part := range sums {
+		for i, c := range part {
+			sum[i] += c
+		}
+	}
+	return sum
+}
+
+// deterministicBetweenness returns the betweenness centrality for nodes in the
+// graph represented by adj, summing the dependencies of sources in a fixed order.
+func deterministicBetweenness(adj [][]int, workers int) []float64 {
+	blocks := (len(adj) + deterministicBlock - 1) / deterministicBlock
+	if workers > blocks {
+		workers = blocks
+	}
+
+	type blockSum struct {
+		block int
+		sum   []float64
+	}
+	var (
+		jobs    = make(chan int)
+		results = make(chan blockSum)
+		// slots bounds the number of block sums
+		// held while waiting to be added in order.
+		slots = make(chan struct{}, 2*workers)
+		wg    sync.WaitGroup
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			b := newBrandesState(len(adj))
+			for block := range jobs {
+				sum := make([]float64, len(adj))
+				end := (block + 1) * deterministicBlock
+				if end > len(adj) {
+					end = len(adj)
+				}
+				for s := block * deterministicBlock; s < end; s++ {
+					b.dependencies(s, adj)
+					for i, d := range b.delta {
+						if i != s {
+							sum[i] += d
+						}
+					}
+				}
+				results <- blockSum{block: block, sum: sum}
+			}
+		}()
+	}
+	go func() {
+		for block := 0; block < blocks; block++ {
+			slots <- struct{}{}
+			jobs <- block
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	sum := make([]float64, len(adj))
+	pending := make(map[int][]float64)
+	next := 0
+	for r := range results {
+		pending[r.block] = r.sum
+		for {
+			part, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			for i, c := range part {
+				sum[i] += c
+			}
+			next++
+			<-slots
+		}
+	}
+	return sum
+}
+
+// brandesState holds the working state for single-source
+// dependency accumulation in Brandes' algorithm.
+type brandesState struct {
+	stack []int
+	queue []int
+	p     [][]int
+	sigma []float64
+	d     []int
+	delta []float64
+}
+
+func newBrandesState(n int) *brandesState {
+	return &brandesState{
+		p:     make([][]int, n),
+		sigma: make([]float64, n),
+		d:     make([]int, n),
+		delta: make([]float64, n),
+	}
+}
+
+// dependencies sets b.delta to the dependencies of the
+// source s on each node of the graph represented by adj.
+func (b *brandesState) dependencies(s int, adj [][]int) {
+	b.stack = b.stack[:0]
+	for i := range adj {
+		b.p[i] = b.p[i][:0]
+		b.sigma[i] = 0
+		b.d[i] = -1
+		b.delta[i] = 0
+	}
+	b.sigma[s] = 1
+	b.d[s] = 0
+
+	b.queue = append(b.queue[:0], s)
+	for len(b.queue) != 0 {
+		v := b.queue[0]
+		b.queue = b.queue[1:]
+		b.stack = append(b.stack, v)
+		for _, w := range adj[v] {
+			// w found for the first time?
+			if b.d[w] < 0 {
+				b.queue = append(b.queue, w)
+				b.d[w] = b.d[v] + 1
+			}
+			// shortest path to w via v?
+			if b.d[w] == b.d[v]+1 {
+				b.sigma[w] += b.sigma[v]
+				b.p[w] = append(b.p[w], v)
+			}
+		}
+	}
+
+	for i := len(b.stack) - 1; i >= 0; i-- {
+		w := b.stack[i]
+		for _, v := range b.p[w] {
+			b.delta[v] += b.sigma[v] / b.sigma[w] * (1 + b.delta[w])
+		}
+	}
+}
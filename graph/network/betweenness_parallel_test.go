@@ -0,0 +1,97 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestBetweennessParallel(t *testing.T) {
+	for i, test := range betweennessTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		for _, deterministic := range []bool{false, true} {
+			for _, workers := range []int{0, 1, 3} {
+				got := BetweennessParallel(g, workers, deterministic)
+				prec := 1 - int(math.Log10(test.wantTol))
+				for n := range test.g {
+					gotN, gotOK := got[int64(n)]
+					wantN, wantOK := test.want[int64(n)]
+					if gotOK != wantOK || !scalar.EqualWithinAbsOrRel(gotN, wantN, test.wantTol, test.wantTol) {
+						t.Errorf("unexpected betweenness result for test %d with %d workers deterministic=%t:\ngot: %v\nwant:%v",
+							i, workers, deterministic, orderedFloats(got, prec), orderedFloats(test.want, prec))
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBetweennessParallelDeterministic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, directed := range []bool{false, true} {
+		var g interface {
+			graph.Graph
+			graph.Builder
+		}
+		if directed {
+			g = simple.NewDirectedGraph()
+		} else {
+			g = simple.NewUndirectedGraph()
+		}
+		const n = 300
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 4*n; i++ {
+			u := rnd.Int63n(n)
+			v := rnd.Int63n(n)
+			if u == v {
+				continue
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+
+		serial := Betweenness(g)
+		want := BetweennessParallel(g, 1, true)
+		if len(want) != len(serial) {
+			t.Errorf("unexpected number of results for directed=%t: got:%d want:%d", directed, len(want), len(serial))
+		}
+		for id, c := range serial {
+			if !scalar.EqualWithinAbsOrRel(want[id], c, 1e-9, 1e-9) {
+				t.Errorf("unexpected betweenness for node %d directed=%t: got:%v want:%v", id, directed, want[id], c)
+			}
+		}
+		for _, workers := range []int{2, 3, 8} {
+			got := BetweennessParallel(g, workers, true)
+			if len(got) != len(want) {
+				t.Errorf("unexpected number of results for directed=%t with %d workers: got:%d want:%d",
+					directed, workers, len(got), len(want))
+			}
+			for id, c := range want {
+				if math.Float64bits(got[id]) != math.Float64bits(c) {
+					t.Errorf("result not bitwise identical for node %d directed=%t with %d workers: got:%v want:%v",
+						id, directed, workers, got[id], c)
+				}
+			}
+		}
+	}
+}
@@ -10,7 +10,10 @@ import (
 	"sort"
 	"testing"
 
+	"golang.org/x/exp/rand"
+
 	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/path"
 	"gonum.org/v1/gonum/graph/simple"
 )
@@ -336,3 +339,215 @@ func (o orderedPairFloatsMap) Less(i, j int) bool {
 	return o[i].key[0] < o[j].key[0] || (o[i].key[0] == o[j].key[0] && o[i].key[1] < o[j].key[1])
 }
 func (o orderedPairFloatsMap) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+
+var stressLoadTests = []struct {
+	g        []set
+	directed bool
+
+	want map[string]map[int64]float64
+}{
+	{
+		// A directed graph with paths of different multiplicity
+		// joining at G so that stress, load and betweenness differ.
+		g: []set{
+			A: linksTo(B, C, E),
+			B: linksTo(D),
+			C: linksTo(D),
+			D: linksTo(G),
+			E: linksTo(F),
+			F: linksTo(G),
+			G: nil,
+		},
+		directed: true,
+
+		want: map[string]map[int64]float64{
+			"betweenness": {B: 5.0 / 6, C: 5.0 / 6, D: 8.0 / 3, E: 4.0 / 3, F: 4.0 / 3},
+			"stress":      {B: 2, C: 2, D: 4, E: 2, F: 2},
+			"load":        {B: 0.75, C: 0.75, D: 2.5, E: 1.5, F: 1.5},
+		},
+	},
+	{
+		// A tree has unique shortest paths so all the measures agree.
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(D, E),
+			C: nil,
+			D: nil,
+			E: nil,
+		},
+
+		want: map[string]map[int64]float64{
+			"betweenness": {A: 6, B: 10},
+			"stress":      {A: 6, B: 10},
+			"load":        {A: 6, B: 10},
+		},
+	},
+}
+
+func TestStressLoad(t *testing.T) {
+	for i, test := range stressLoadTests {
+		var g interface {
+			graph.Graph
+			graph.Builder
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph()
+		} else {
+			g = simple.NewUndirectedGraph()
+		}
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		for _, measure := range []struct {
+			name string
+			fn   func(graph.Graph) map[int64]float64
+		}{
+			{name: "betweenness", fn: Betweenness},
+			{name: "stress", fn: Stress},
+			{name: "load", fn: Load},
+		} {
+			got := measure.fn(g)
+			want := test.want[measure.name]
+			if len(got) != len(want) {
+				t.Errorf("unexpected number of %s results for test %d: got:%d want:%d",
+					measure.name, i, len(got), len(want))
+			}
+			for n, w := range want {
+				if !scalar.EqualWithinAbsOrRel(got[n], w, 1e-10, 1e-10) {
+					t.Errorf("unexpected %s result for test %d:\ngot: %v\nwant:%v",
+						measure.name, i, orderedFloats(got, 3), orderedFloats(want, 3))
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestPercolation(t *testing.T) {
+	for i, test := range betweennessTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		// With uniform percolation states, percolation
+		// centrality is normalised betweenness.
+		n := float64(len(test.g))
+		states := make(map[int64]float64)
+		for u := range test.g {
+			states[int64(u)] = 1
+		}
+		got := Percolation(g, states)
+		want := Betweenness(g)
+		for id := range want {
+			want[id] /= (n - 1) * (n - 2)
+		}
+		if len(got) != len(want) {
+			t.Errorf("unexpected number of results for test %d: got:%d want:%d", i, len(got), len(want))
+		}
+		for id, w := range want {
+			if !scalar.EqualWithinAbsOrRel(got[id], w, 1e-10, 1e-10) {
+				t.Errorf("unexpected percolation result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(got, 4), orderedFloats(want, 4))
+				break
+			}
+		}
+	}
+
+	// Only paths from percolated sources contribute.
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(A), T: simple.Node(B)})
+	g.SetEdge(simple.Edge{F: simple.Node(B), T: simple.Node(C)})
+	g.SetEdge(simple.Edge{F: simple.Node(C), T: simple.Node(D)})
+	got := Percolation(g, map[int64]float64{A: 1})
+	// B lies on paths from A to C and D, C on the path from A to D.
+	want := map[int64]float64{B: 2.0 / 2, C: 1.0 / 2}
+	if len(got) != len(want) || !scalar.EqualWithinAbsOrRel(got[B], want[B], 1e-10, 1e-10) ||
+		!scalar.EqualWithinAbsOrRel(got[C], want[C], 1e-10, 1e-10) {
+		t.Errorf("unexpected percolation result for path graph:\ngot: %v\nwant:%v",
+			orderedFloats(got, 4), orderedFloats(want, 4))
+	}
+}
+
+func TestBetweennessDijkstra(t *testing.T) {
+	for i, test := range betweennessTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got := BetweennessDijkstra(g, nil)
+		prec := 1 - int(math.Log10(test.wantTol))
+		for n := range test.g {
+			gotN, gotOK := got[int64(n)]
+			wantN, wantOK := test.want[int64(n)]
+			if gotOK != wantOK {
+				t.Errorf("unexpected betweenness existence for test %d, node %c", i, n+'A')
+			}
+			if !scalar.EqualWithinAbsOrRel(gotN, wantN, test.wantTol, test.wantTol) {
+				t.Errorf("unexpected betweenness result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(got, prec), orderedFloats(test.want, prec))
+				break
+			}
+		}
+	}
+}
+
+func TestBetweennessDijkstraWeighted(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, directed := range []bool{false, true} {
+		for n := 0; n < 10; n++ {
+			var g interface {
+				graph.Weighted
+				graph.WeightedBuilder
+			}
+			if directed {
+				g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+			} else {
+				g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+			}
+			for i := 0; i < 60; i++ {
+				u, v := rnd.Int63n(20), rnd.Int63n(20)
+				if u == v {
+					continue
+				}
+				// Small integer weights give ties
+				// between shortest paths.
+				w := float64(1 + rnd.Intn(3))
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+			}
+
+			p, ok := path.FloydWarshall(g)
+			if !ok {
+				t.Fatal("unexpected negative cycle")
+			}
+			want := BetweennessWeighted(g, p)
+			got := BetweennessDijkstra(g, nil)
+			if len(got) != len(want) {
+				t.Errorf("unexpected number of nodes with non-zero betweenness: got:%d want:%d", len(got), len(want))
+			}
+			for id, w := range want {
+				if !scalar.EqualWithinAbsOrRel(got[id], w, 1e-10, 1e-10) {
+					t.Errorf("unexpected betweenness for node %d directed=%t: got:%v want:%v", id, directed, got[id], w)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import "gonum.org/v1/gonum/graph"
+
+// LocalClustering returns the local clustering coefficient of each node
+// of the undirected graph g, keyed on node ID. The local clustering
+// coefficient of a node v is
+//
+//  C(v) = 2 T(v) / (d(v) (d(v)-1))
+//
+// where T(v) is the number of triangles that include v and d(v) is the
+// degree of v. Nodes with fewer than two neighbors have a clustering
+// coefficient of zero. Self loops are ignored.
+//
+// Triangles are counted as described for Triangles.
+//
+// See https://en.wikipedia.org/wiki/Clustering_coefficient#Local_clustering_coefficient
+// for details.
+func LocalClustering(g graph.Undirected) map[int64]float64 {
+	nodes, tri, deg := triangleCounts(g)
+	c := make(map[int64]float64, len(nodes))
+	for i, n := range nodes {
+		if deg[i] < 2 {
+			c[n.ID()] = 0
+			continue
+		}
+		c[n.ID()] = 2 * float64(tri[i]) / float64(deg[i]*(deg[i]-1))
+	}
+	return c
+}
+
+// Transitivity returns the global clustering coefficient of the undirected
+// graph g, the ratio of three times the number of triangles in g to the
+// number of connected triples of nodes in g,
+//
+//  T = 3 × triangles / triples.
+//
+// Transitivity returns zero if g has no connected triples. Self loops are
+// ignored.
+//
+// See https://en.wikipedia.org/wiki/Clustering_coefficient#Global_clustering_coefficient
+// for details.
+func Transitivity(g graph.Undirected) float64 {
+	_, tri, deg := triangleCounts(g)
+	var triangles, triples float64
+	for i, d := range deg {
+		// Each triangle is counted at each of its
+		// three nodes, giving three times the number
+		// of triangles.
+		triangles += float64(tri[i])
+		triples += float64(d) * float64(d-1) / 2
+	}
+	if triples == 0 {
+		return 0
+	}
+	return triangles / triples
+}
@@ -0,0 +1,128 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var clusteringTests = []struct {
+	name string
+	g    []set
+
+	wantLocal        map[int64]float64
+	wantTransitivity float64
+}{
+	{
+		name:             "empty",
+		wantLocal:        map[int64]float64{},
+		wantTransitivity: 0,
+	},
+	{
+		name: "paw",
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(C),
+			C: linksTo(D),
+			D: nil,
+		},
+		wantLocal:        map[int64]float64{A: 1, B: 1, C: 1.0 / 3, D: 0},
+		wantTransitivity: 0.6,
+	},
+	{
+		name: "K4",
+		g: []set{
+			A: linksTo(B, C, D),
+			B: linksTo(C, D),
+			C: linksTo(D),
+		},
+		wantLocal:        map[int64]float64{A: 1, B: 1, C: 1, D: 1},
+		wantTransitivity: 1,
+	},
+	{
+		name: "star",
+		g: []set{
+			A: linksTo(B, C, D),
+		},
+		wantLocal:        map[int64]float64{A: 0, B: 0, C: 0, D: 0},
+		wantTransitivity: 0,
+	},
+}
+
+func TestClustering(t *testing.T) {
+	const tol = 1e-14
+	for _, test := range clusteringTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got := LocalClustering(g)
+		if len(got) != len(test.wantLocal) {
+			t.Errorf("unexpected number of local clustering coefficients for %s: got:%d want:%d",
+				test.name, len(got), len(test.wantLocal))
+		}
+		for id, want := range test.wantLocal {
+			if !scalar.EqualWithinAbsOrRel(got[id], want, tol, tol) {
+				t.Errorf("unexpected local clustering coefficient for %s node %d: got:%v want:%v",
+					test.name, id, got[id], want)
+			}
+		}
+		if tr := Transitivity(g); !scalar.EqualWithinAbsOrRel(tr, test.wantTransitivity, tol, tol) {
+			t.Errorf("unexpected transitivity for %s: got:%v want:%v", test.name, tr, test.wantTransitivity)
+		}
+	}
+}
+
+func TestClusteringRandom(t *testing.T) {
+	const tol = 1e-14
+	for seed := uint64(0); seed < 5; seed++ {
+		g := simple.NewUndirectedGraph()
+		err := gen.Gnp(g, 50, 0.2, rand.NewSource(seed))
+		if err != nil {
+			t.Fatalf("unexpected error generating graph: %v", err)
+		}
+		got := LocalClustering(g)
+
+		var triangles, triples float64
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			nbrs := graph.NodesOf(g.From(u.ID()))
+			var links float64
+			for i, v := range nbrs {
+				for _, w := range nbrs[i+1:] {
+					if g.HasEdgeBetween(v.ID(), w.ID()) {
+						links++
+					}
+				}
+			}
+			d := float64(len(nbrs))
+			var want float64
+			if d > 1 {
+				want = 2 * links / (d * (d - 1))
+			}
+			if !scalar.EqualWithinAbsOrRel(got[u.ID()], want, tol, tol) {
+				t.Errorf("unexpected local clustering coefficient for seed %d node %d: got:%v want:%v",
+					seed, u.ID(), got[u.ID()], want)
+			}
+			triangles += links
+			triples += d * (d - 1) / 2
+		}
+		want := triangles / triples
+		if tr := Transitivity(g); !scalar.EqualWithinAbsOrRel(tr, want, tol, tol) {
+			t.Errorf("unexpected transitivity for seed %d: got:%v want:%v", seed, tr, want)
+		}
+	}
+}
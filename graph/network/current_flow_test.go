@@ -0,0 +1,218 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+)
+
+var currentFlowTreeTests = []struct {
+	name string
+	g    []set
+}{
+	{
+		name: "path",
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: linksTo(D),
+			D: nil,
+		},
+	},
+	{
+		name: "star",
+		g: []set{
+			A: linksTo(B, C, D, E),
+			B: nil,
+			C: nil,
+			D: nil,
+			E: nil,
+		},
+	},
+	{
+		name: "forest",
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(D, E),
+			C: linksTo(F),
+			D: nil,
+			E: nil,
+			F: nil,
+			G: linksTo(H),
+			H: linksTo(I),
+			I: nil,
+			J: nil,
+		},
+	},
+}
+
+func TestCurrentFlowTree(t *testing.T) {
+	const tol = 1e-10
+	for _, test := range currentFlowTreeTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		// Current-flow measures equal shortest-path
+		// measures on trees.
+		got := CurrentFlowBetweenness(g, nil)
+		want := Betweenness(g)
+		if len(got) != g.Nodes().Len() {
+			t.Errorf("unexpected number of nodes for %s: got:%d want:%d",
+				test.name, len(got), g.Nodes().Len())
+		}
+		for id := range got {
+			w := want[id]
+			if !scalar.EqualWithinAbsOrRel(got[id], w, tol, tol) {
+				t.Errorf("unexpected betweenness for %s node %d: got:%v want:%v", test.name, id, got[id], w)
+			}
+		}
+
+		gotC := CurrentFlowCloseness(g, nil)
+		wantC := Closeness(g, path.DijkstraAllPaths(g))
+		for id, w := range wantC {
+			if !scalar.EqualWithinAbsOrRel(gotC[id], w, tol, tol) {
+				t.Errorf("unexpected closeness for %s node %d: got:%v want:%v", test.name, id, gotC[id], w)
+			}
+		}
+	}
+}
+
+func TestCurrentFlowCycle(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < 4; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node((i + 1) % 4)})
+	}
+	// The effective resistances from each node are
+	// 3/4 to its neighbors and 1 to the opposite node,
+	// and the pairs of neighbors pass 1/2 and the other
+	// pairs 1/4 of their current through the node.
+	const (
+		wantBetweenness = 2
+		wantCloseness   = 1 / 2.5
+	)
+	cb := CurrentFlowBetweenness(g, nil)
+	cc := CurrentFlowCloseness(g, nil)
+	for i := int64(0); i < 4; i++ {
+		if !scalar.EqualWithinAbsOrRel(cb[i], wantBetweenness, 1e-10, 1e-10) {
+			t.Errorf("unexpected betweenness for node %d: got:%v want:%v", i, cb[i], wantBetweenness)
+		}
+		if !scalar.EqualWithinAbsOrRel(cc[i], wantCloseness, 1e-10, 1e-10) {
+			t.Errorf("unexpected closeness for node %d: got:%v want:%v", i, cc[i], wantCloseness)
+		}
+	}
+}
+
+func TestCurrentFlowBetweennessWeighted(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 10; n++ {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		// Ensure the graph is connected.
+		for i := 0; i < 11; i++ {
+			w := 0.5 + rnd.Float64()
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(i + 1), W: w})
+		}
+		for i := 0; i < 20; i++ {
+			u, v := rnd.Int63n(12), rnd.Int63n(12)
+			if u == v {
+				continue
+			}
+			w := 0.5 + rnd.Float64()
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+		}
+		got := CurrentFlowBetweenness(g, nil)
+		want := currentFlowBetweennessBruteForce(g)
+		for id, c := range got {
+			if !scalar.EqualWithinAbsOrRel(c, want[id], 1e-8, 1e-8) {
+				t.Errorf("unexpected betweenness for node %d: got:%v want:%v", id, c, want[id])
+			}
+		}
+	}
+}
+
+// currentFlowBetweennessBruteForce returns the current-flow betweenness
+// of nodes in the connected graph g by calculating the currents for each
+// pair of nodes with the sink grounded.
+func currentFlowBetweennessBruteForce(g *simple.WeightedUndirectedGraph) map[int64]float64 {
+	nodes := graph.NodesOf(g.Nodes())
+	n := len(nodes)
+	indexOf := make(map[int64]int, n)
+	for i, u := range nodes {
+		indexOf[u.ID()] = i
+	}
+	cb := make(map[int64]float64)
+	for s := range nodes {
+		for t := range nodes {
+			if s == t {
+				continue
+			}
+			// Build the Laplacian with the row and
+			// column of the sink removed.
+			reduced := func(i int) int {
+				if i > t {
+					return i - 1
+				}
+				return i
+			}
+			l := mat.NewDense(n-1, n-1, nil)
+			b := mat.NewVecDense(n-1, nil)
+			for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+				u, v := indexOf[e.From().ID()], indexOf[e.To().ID()]
+				w := e.Weight()
+				if u != t {
+					l.Set(reduced(u), reduced(u), l.At(reduced(u), reduced(u))+w)
+				}
+				if v != t {
+					l.Set(reduced(v), reduced(v), l.At(reduced(v), reduced(v))+w)
+				}
+				if u != t && v != t {
+					l.Set(reduced(u), reduced(v), l.At(reduced(u), reduced(v))-w)
+					l.Set(reduced(v), reduced(u), l.At(reduced(v), reduced(u))-w)
+				}
+			}
+			b.SetVec(reduced(s), 1)
+			var p mat.VecDense
+			err := p.SolveVec(l, b)
+			if err != nil {
+				panic(err)
+			}
+			potential := func(i int) float64 {
+				if i == t {
+					return 0
+				}
+				return p.AtVec(reduced(i))
+			}
+
+			through := make([]float64, n)
+			for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+				u, v := indexOf[e.From().ID()], indexOf[e.To().ID()]
+				i := e.Weight() * math.Abs(potential(u)-potential(v))
+				through[u] += i / 2
+				through[v] += i / 2
+			}
+			for v, c := range through {
+				if v != s && v != t && c != 0 {
+					cb[nodes[v].ID()] += c
+				}
+			}
+		}
+	}
+	return cb
+}
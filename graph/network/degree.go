@@ -0,0 +1,111 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import "gonum.org/v1/gonum/graph"
+
+// Degrees returns the degree of each node of g, keyed on node ID. If g
+// is a graph.Directed, the degree of a node is the sum of its in and out
+// degrees. A self loop contributes two to the degree of its node, so the
+// sum of the degrees is twice the number of edges.
+func Degrees(g graph.Graph) map[int64]int {
+	nodes := g.Nodes()
+	deg := make(map[int64]int, nodes.Len())
+	d, isDirected := g.(graph.Directed)
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		var k int
+		to := g.From(uid)
+		for to.Next() {
+			k++
+			if !isDirected && to.Node().ID() == uid {
+				k++
+			}
+		}
+		if isDirected {
+			k += d.To(uid).Len()
+		}
+		deg[uid] = k
+	}
+	return deg
+}
+
+// InDegrees returns the in degree of each node of g, keyed on node ID.
+func InDegrees(g graph.Directed) map[int64]int {
+	nodes := g.Nodes()
+	deg := make(map[int64]int, nodes.Len())
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		deg[uid] = g.To(uid).Len()
+	}
+	return deg
+}
+
+// OutDegrees returns the out degree of each node of g, keyed on node ID.
+func OutDegrees(g graph.Directed) map[int64]int {
+	nodes := g.Nodes()
+	deg := make(map[int64]int, nodes.Len())
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		deg[uid] = g.From(uid).Len()
+	}
+	return deg
+}
+
+// DegreeHistogram returns the degree histogram of the given degrees. The
+// kth element of the returned slice holds the number of nodes with degree
+// k, and the last element holds the count for the maximum degree.
+// DegreeHistogram returns nil if degrees is empty.
+func DegreeHistogram(degrees map[int64]int) []int {
+	if len(degrees) == 0 {
+		return nil
+	}
+	var max int
+	for _, k := range degrees {
+		if k > max {
+			max = k
+		}
+	}
+	hist := make([]int, max+1)
+	for _, k := range degrees {
+		hist[k]++
+	}
+	return hist
+}
+
+// AverageDegree returns the mean degree of the nodes of g as defined by
+// Degrees. AverageDegree returns zero if g has no nodes.
+func AverageDegree(g graph.Graph) float64 {
+	n, m := nodesAndEdges(g)
+	if n == 0 {
+		return 0
+	}
+	return 2 * float64(m) / float64(n)
+}
+
+// Density returns the density of g, the ratio of the number of edges in
+// g to the number of edges in a complete graph with the same nodes. Self
+// loops are counted as edges, so the density of a graph with self loops
+// may exceed one. Density returns zero if g has fewer than two nodes.
+func Density(g graph.Graph) float64 {
+	n, m := nodesAndEdges(g)
+	if n < 2 {
+		return 0
+	}
+	possible := float64(n) * float64(n-1)
+	if _, isDirected := g.(graph.Directed); !isDirected {
+		possible /= 2
+	}
+	return float64(m) / possible
+}
+
+// nodesAndEdges returns the number of nodes and edges in g.
+func nodesAndEdges(g graph.Graph) (nodes, edges int) {
+	deg := Degrees(g)
+	for _, k := range deg {
+		edges += k
+	}
+	return len(deg), edges / 2
+}
@@ -0,0 +1,111 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var degreeTests = []struct {
+	name     string
+	g        []set
+	directed bool
+
+	wantDegree    map[int64]int
+	wantIn        map[int64]int
+	wantOut       map[int64]int
+	wantHistogram []int
+	wantAverage   float64
+	wantDensity   float64
+}{
+	{
+		name: "empty",
+		g:    nil,
+
+		wantDegree: map[int64]int{},
+	},
+	{
+		name: "undirected",
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(C),
+			C: linksTo(D),
+			D: nil,
+			E: nil,
+		},
+
+		wantDegree:    map[int64]int{A: 2, B: 2, C: 3, D: 1, E: 0},
+		wantHistogram: []int{1, 1, 2, 1},
+		wantAverage:   1.6,
+		wantDensity:   0.4,
+	},
+	{
+		name: "directed",
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(A),
+			C: linksTo(D),
+			D: nil,
+			E: nil,
+		},
+		directed: true,
+
+		wantDegree:    map[int64]int{A: 3, B: 2, C: 2, D: 1, E: 0},
+		wantIn:        map[int64]int{A: 1, B: 1, C: 1, D: 1, E: 0},
+		wantOut:       map[int64]int{A: 2, B: 1, C: 1, D: 0, E: 0},
+		wantHistogram: []int{1, 1, 2, 1},
+		wantAverage:   1.6,
+		wantDensity:   0.2,
+	},
+}
+
+func TestDegreeStatistics(t *testing.T) {
+	for _, test := range degreeTests {
+		var g interface {
+			graph.Graph
+			AddNode(graph.Node)
+			SetEdge(graph.Edge)
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph()
+		} else {
+			g = simple.NewUndirectedGraph()
+		}
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		deg := Degrees(g)
+		if !reflect.DeepEqual(deg, test.wantDegree) {
+			t.Errorf("unexpected degrees for %s: got:%v want:%v", test.name, deg, test.wantDegree)
+		}
+		if hist := DegreeHistogram(deg); !reflect.DeepEqual(hist, test.wantHistogram) {
+			t.Errorf("unexpected degree histogram for %s: got:%v want:%v", test.name, hist, test.wantHistogram)
+		}
+		if avg := AverageDegree(g); avg != test.wantAverage {
+			t.Errorf("unexpected average degree for %s: got:%v want:%v", test.name, avg, test.wantAverage)
+		}
+		if d := Density(g); d != test.wantDensity {
+			t.Errorf("unexpected density for %s: got:%v want:%v", test.name, d, test.wantDensity)
+		}
+		if dg, ok := g.(graph.Directed); ok {
+			if in := InDegrees(dg); !reflect.DeepEqual(in, test.wantIn) {
+				t.Errorf("unexpected in degrees for %s: got:%v want:%v", test.name, in, test.wantIn)
+			}
+			if out := OutDegrees(dg); !reflect.DeepEqual(out, test.wantOut) {
+				t.Errorf("unexpected out degrees for %s: got:%v want:%v", test.name, out, test.wantOut)
+			}
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/path"
+)
+
+// Eccentricities returns the eccentricity of each node of g using the edge
+// weights given by weight, keyed on node ID. The eccentricity of a node v is
+//
+//  e(v) = max_u d(v,u)
+//
+// where d(v,u) is the length of the shortest path from v to u. For directed
+// graphs the outgoing paths are used. A node that cannot reach every other
+// node has an infinite eccentricity.
+//
+// If weight is nil, edge weights are obtained from g if it implements
+// graph.Weighted, otherwise each edge has unit weight. Self loops are
+// ignored. Eccentricities will panic if g has a negative edge weight.
+//
+// Eccentricities performs a Dijkstra search from each node, taking
+// O(|V||E| + |V|^2 log |V|) time.
+func Eccentricities(g graph.Graph, weight path.Weighting) map[int64]float64 {
+	nodes, ecc := eccentricities(g, weight)
+	e := make(map[int64]float64, len(nodes))
+	for i, n := range nodes {
+		e[n.ID()] = ecc[i]
+	}
+	return e
+}
+
+// Diameter returns the diameter of g, the maximum eccentricity of its
+// nodes. The diameter of a graph that is not strongly connected is
+// infinite. Diameter returns NaN if g has no nodes. Edge weights are
+// interpreted as described for Eccentricities.
+//
+// For large undirected unweighted graphs, DiameterIFUB will usually be
+// much faster.
+func Diameter(g graph.Graph, weight path.Weighting) float64 {
+	_, ecc := eccentricities(g, weight)
+	if len(ecc) == 0 {
+		return math.NaN()
+	}
+	d := ecc[0]
+	for _, e := range ecc[1:] {
+		d = math.Max(d, e)
+	}
+	return d
+}
+
+// Radius returns the radius of g, the minimum eccentricity of its nodes.
+// Radius returns NaN if g has no nodes. Edge weights are interpreted as
+// described for Eccentricities.
+func Radius(g graph.Graph, weight path.Weighting) float64 {
+	_, ecc := eccentricities(g, weight)
+	if len(ecc) == 0 {
+		return math.NaN()
+	}
+	r := ecc[0]
+	for _, e := range ecc[1:] {
+		r = math.Min(r, e)
+	}
+	return r
+}
+
+// Center returns the nodes of g with eccentricity equal to the radius of
+// g, sorted by ID. Edge weights are interpreted as described for
+// Eccentricities.
+func Center(g graph.Graph, weight path.Weighting) []graph.Node {
+	return extremeEccentricity(g, weight, math.Min)
+}
+
+// Periphery returns the nodes of g with eccentricity equal to the
+// diameter of g, sorted by ID. Edge weights are interpreted as described
+// for Eccentricities.
+func Periphery(g graph.Graph, weight path.Weighting) []graph.Node {
+	return extremeEccentricity(g, weight, math.Max)
+}
+
+// extremeEccentricity returns the nodes of g with the eccentricity
+// selected by the extreme function, sorted by ID.
+func extremeEccentricity(g graph.Graph, weight path.Weighting, extreme func(a, b float64) float64) []graph.Node {
+	nodes, ecc := eccentricities(g, weight)
+	if len(nodes) == 0 {
+		return nil
+	}
+	x := ecc[0]
+	for _, e := range ecc[1:] {
+		x = extreme(x, e)
+	}
+	var selected []graph.Node
+	for i, e := range ecc {
+		if e == x {
+			selected = append(selected, nodes[i])
+		}
+	}
+	sort.Sort(ordered.ByID(selected))
+	return selected
+}
+
+// eccentricities returns the nodes of g and their eccentricities.
+func eccentricities(g graph.Graph, weight path.Weighting) ([]graph.Node, []float64) {
+	d := newShortestDistances(g, weight)
+	ecc := make([]float64, len(d.nodes))
+	for s := range d.nodes {
+		var reached int
+		d.from(s, func(_ int, dist float64) {
+			reached++
+			ecc[s] = dist
+		})
+		if reached < len(d.nodes) {
+			ecc[s] = math.Inf(1)
+		}
+	}
+	return d.nodes, ecc
+}
+
+// shortestDistances performs repeated single source
+// shortest path searches over a weighted graph.
+type shortestDistances struct {
+	nodes []graph.Node
+	adj   [][]weightedArc
+
+	dist  []float64
+	queue distQueue
+}
+
+// weightedArc is a weighted edge to the
+// node with index to.
+type weightedArc struct {
+	to int
+	w  float64
+}
+
+// newShortestDistances returns a shortestDistances for g using the edge
+// weights given by weight, interpreted as described for Eccentricities.
+func newShortestDistances(g graph.Graph, weight path.Weighting) *shortestDistances {
+	weight = weightingOf(g, weight)
+
+	nodes := graph.NodesOf(g.Nodes())
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]weightedArc, len(nodes))
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			w, ok := weight(uid, vid)
+			if !ok {
+				continue
+			}
+			if w < 0 {
+				panic("network: negative edge weight")
+			}
+			adj[i] = append(adj[i], weightedArc{to: indexOf[vid], w: w})
+		}
+	}
+	return &shortestDistances{
+		nodes: nodes,
+		adj:   adj,
+		dist:  make([]float64, len(nodes)),
+	}
+}
+
+// from performs a Dijkstra search from the node with index s, calling fn
+// with the index and distance of each node reachable from s, including s,
+// in order of non-decreasing distance.
+func (d *shortestDistances) from(s int, fn func(v int, dist float64)) {
+	d.search(s, fn, nil)
+}
+
+// search performs a Dijkstra search from the node with index s, calling
+// settle as described for from. If relax is not nil, it is called with the
+// indices of u and v whenever the arc from u to v gives a path to v that is
+// shorter than, or as short as, the shortest path to v found so far.
+func (d *shortestDistances) search(s int, settle func(v int, dist float64), relax func(u, v int, shorter bool)) {
+	for i := range d.dist {
+		d.dist[i] = math.Inf(1)
+	}
+	d.dist[s] = 0
+	d.queue = append(d.queue[:0], distItem{node: s})
+	for d.queue.Len() != 0 {
+		it := heap.Pop(&d.queue).(distItem)
+		v := it.node
+		if it.dist > d.dist[v] {
+			continue
+		}
+		settle(v, it.dist)
+		for _, a := range d.adj[v] {
+			dist := d.dist[v] + a.w
+			switch {
+			case dist < d.dist[a.to]:
+				d.dist[a.to] = dist
+				heap.Push(&d.queue, distItem{node: a.to, dist: dist})
+				if relax != nil {
+					relax(v, a.to, true)
+				}
+			case dist == d.dist[a.to] && relax != nil:
+				relax(v, a.to, false)
+			}
+		}
+	}
+}
+
+// DiameterIFUB returns the diameter of the undirected graph g, treating
+// each edge as having unit weight. The diameter of a graph that is not
+// connected is infinite. DiameterIFUB returns NaN if g has no nodes. Self
+// loops are ignored.
+//
+// DiameterIFUB uses the iterative fringe upper bound algorithm, starting
+// from a node of highest degree. In the worst case it performs a breadth
+// first search from every node, but on real-world graphs it typically
+// needs only a small number of searches.
+//
+// See Crescenzi et al. "On computing the diameter of real-world undirected
+// graphs", Theor. Comput. Sci. 514:84-95 (2013) doi:10.1016/j.tcs.2012.09.018.
+func DiameterIFUB(g graph.Undirected) float64 {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return math.NaN()
+	}
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	var u int
+	for i, n := range nodes {
+		nid := n.ID()
+		to := g.From(nid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == nid {
+				continue
+			}
+			adj[i] = append(adj[i], indexOf[vid])
+		}
+		if len(adj[i]) > len(adj[u]) {
+			u = i
+		}
+	}
+
+	depth := make([]int, len(nodes))
+	var queue []int
+	// bfs fills depth with the distances from s and returns
+	// the nodes in order of non-decreasing distance.
+	bfs := func(s int) []int {
+		for i := range depth {
+			depth[i] = -1
+		}
+		depth[s] = 0
+		queue = append(queue[:0], s)
+		for i := 0; i < len(queue); i++ {
+			v := queue[i]
+			for _, w := range adj[v] {
+				if depth[w] < 0 {
+					depth[w] = depth[v] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return queue
+	}
+
+	order := bfs(u)
+	if len(order) < len(nodes) {
+		return math.Inf(1)
+	}
+	// fringe holds the nodes of each level
+	// of the breadth first search from u.
+	i := depth[order[len(order)-1]]
+	fringe := make([][]int, i+1)
+	for _, v := range order {
+		fringe[depth[v]] = append(fringe[depth[v]], v)
+	}
+
+	lb, ub := i, 2*i
+	for ; ub > lb; i-- {
+		for _, v := range fringe[i] {
+			q := bfs(v)
+			if e := depth[q[len(q)-1]]; e > lb {
+				lb = e
+			}
+		}
+		if lb > 2*(i-1) {
+			break
+		}
+		ub = 2 * (i - 1)
+	}
+	return float64(lb)
+}
@@ -0,0 +1,196 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var inf = math.Inf(1)
+
+var eccentricityTests = []struct {
+	name     string
+	g        []set
+	directed bool
+	weight   path.Weighting
+
+	want          map[int64]float64
+	wantCenter    []int64
+	wantPeriphery []int64
+}{
+	{
+		name: "empty",
+		want: map[int64]float64{},
+	},
+	{
+		name: "path",
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: linksTo(D),
+			D: linksTo(E),
+			E: nil,
+		},
+		want:          map[int64]float64{A: 4, B: 3, C: 2, D: 3, E: 4},
+		wantCenter:    []int64{C},
+		wantPeriphery: []int64{A, E},
+	},
+	{
+		name: "star",
+		g: []set{
+			A: linksTo(B, C, D),
+		},
+		want:          map[int64]float64{A: 1, B: 2, C: 2, D: 2},
+		wantCenter:    []int64{A},
+		wantPeriphery: []int64{B, C, D},
+	},
+	{
+		name: "disconnected",
+		g: []set{
+			A: linksTo(B),
+			B: nil,
+			C: nil,
+		},
+		want:          map[int64]float64{A: inf, B: inf, C: inf},
+		wantCenter:    []int64{A, B, C},
+		wantPeriphery: []int64{A, B, C},
+	},
+	{
+		name: "directed cycle",
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: linksTo(A),
+		},
+		directed:      true,
+		want:          map[int64]float64{A: 2, B: 2, C: 2},
+		wantCenter:    []int64{A, B, C},
+		wantPeriphery: []int64{A, B, C},
+	},
+	{
+		name: "directed path",
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: nil,
+		},
+		directed:      true,
+		want:          map[int64]float64{A: 2, B: inf, C: inf},
+		wantCenter:    []int64{A},
+		wantPeriphery: []int64{B, C},
+	},
+	{
+		name: "weighted triangle",
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(C),
+		},
+		weight: func(xid, yid int64) (float64, bool) {
+			if xid == yid {
+				return 0, true
+			}
+			if (xid == A && yid == C) || (xid == C && yid == A) {
+				return 5, true
+			}
+			return 1, true
+		},
+		want:          map[int64]float64{A: 2, B: 1, C: 2},
+		wantCenter:    []int64{B},
+		wantPeriphery: []int64{A, C},
+	},
+}
+
+func TestEccentricities(t *testing.T) {
+	for _, test := range eccentricityTests {
+		var g interface {
+			graph.Graph
+			graph.Builder
+			SetEdge(graph.Edge)
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph()
+		} else {
+			g = simple.NewUndirectedGraph()
+		}
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		got := Eccentricities(g, test.weight)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected eccentricities for %s: got:%v want:%v", test.name, got, test.want)
+		}
+
+		wantDiameter, wantRadius := math.NaN(), math.NaN()
+		for _, e := range test.want {
+			if math.IsNaN(wantDiameter) {
+				wantDiameter, wantRadius = e, e
+			}
+			wantDiameter = math.Max(wantDiameter, e)
+			wantRadius = math.Min(wantRadius, e)
+		}
+		if d := Diameter(g, test.weight); !sameFloat(d, wantDiameter) {
+			t.Errorf("unexpected diameter for %s: got:%v want:%v", test.name, d, wantDiameter)
+		}
+		if r := Radius(g, test.weight); !sameFloat(r, wantRadius) {
+			t.Errorf("unexpected radius for %s: got:%v want:%v", test.name, r, wantRadius)
+		}
+		if c := ids(Center(g, test.weight)); !reflect.DeepEqual(c, test.wantCenter) {
+			t.Errorf("unexpected center for %s: got:%v want:%v", test.name, c, test.wantCenter)
+		}
+		if p := ids(Periphery(g, test.weight)); !reflect.DeepEqual(p, test.wantPeriphery) {
+			t.Errorf("unexpected periphery for %s: got:%v want:%v", test.name, p, test.wantPeriphery)
+		}
+
+		if ug, ok := g.(graph.Undirected); ok && test.weight == nil {
+			if d := DiameterIFUB(ug); !sameFloat(d, wantDiameter) {
+				t.Errorf("unexpected iFUB diameter for %s: got:%v want:%v", test.name, d, wantDiameter)
+			}
+		}
+	}
+}
+
+func TestDiameterIFUBRandom(t *testing.T) {
+	for _, p := range []float64{0.02, 0.05, 0.1, 0.5} {
+		for seed := uint64(0); seed < 5; seed++ {
+			g := simple.NewUndirectedGraph()
+			err := gen.Gnp(g, 100, p, rand.NewSource(seed))
+			if err != nil {
+				t.Fatalf("unexpected error generating graph: %v", err)
+			}
+			got := DiameterIFUB(g)
+			want := Diameter(g, nil)
+			if got != want {
+				t.Errorf("unexpected iFUB diameter for p=%v seed %d: got:%v want:%v", p, seed, got, want)
+			}
+		}
+	}
+}
+
+// ids returns the IDs of the nodes.
+func ids(nodes []graph.Node) []int64 {
+	if nodes == nil {
+		return nil
+	}
+	id := make([]int64, len(nodes))
+	for i, n := range nodes {
+		id[i] = n.ID()
+	}
+	return id
+}
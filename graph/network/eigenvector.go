@@ -0,0 +1,104 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// EigenvectorCentrality returns the eigenvector centrality for nodes of the
+// undirected graph g. The centrality of a node is proportional to the sum of
+// the centralities of its neighbors,
+//
+//  λ C_E(v) = \sum_{u ∈ N(v)} w_{uv} C_E(u)
+//
+// so C_E is the principal eigenvector of the adjacency matrix of g. If g is a
+// graph.Weighted, the edge weights are used and must be positive; otherwise
+// each edge has unit weight. Self loops are included. The returned map is
+// keyed on the graph node IDs.
+//
+// The centralities of each connected component of g are calculated separately
+// by power iteration, since the principal eigenvector of a disconnected graph
+// is zero on all but one component. The centralities within a component are
+// scaled so that their sum of squares is the fraction of the nodes of g in the
+// component, so the returned centralities have unit 2-norm and are the usual
+// normalized eigenvector when g is connected. The iteration uses the shifted
+// matrix A+I, which has the same eigenvectors, so that it converges for
+// bipartite components.
+//
+// Iteration for each component terminates when the 2-norm of the vector
+// difference between iterations is below tol, or after maxIter iterations if
+// maxIter is positive. EigenvectorCentrality returns false if any component
+// did not converge, in which case the last iterates are returned.
+func EigenvectorCentrality(g graph.Undirected, tol float64, maxIter int) (centrality map[int64]float64, ok bool) {
+	var weight func(uid, vid int64) float64
+	if wg, isWeighted := g.(graph.Weighted); isWeighted {
+		weight = func(uid, vid int64) float64 {
+			w, _ := wg.Weight(uid, vid)
+			if !(w > 0) {
+				panic("network: non-positive edge weight")
+			}
+			return w
+		}
+	} else {
+		weight = func(_, _ int64) float64 { return 1 }
+	}
+
+	n := float64(g.Nodes().Len())
+	centrality = make(map[int64]float64)
+	ok = true
+	for _, c := range topo.ConnectedComponents(g) {
+		indexOf := make(map[int64]int, len(c))
+		for i, u := range c {
+			indexOf[u.ID()] = i
+		}
+		type arc struct {
+			to int
+			w  float64
+		}
+		adj := make([][]arc, len(c))
+		for i, u := range c {
+			uid := u.ID()
+			to := g.From(uid)
+			for to.Next() {
+				vid := to.Node().ID()
+				adj[i] = append(adj[i], arc{to: indexOf[vid], w: weight(uid, vid)})
+			}
+		}
+
+		x := make([]float64, len(c))
+		next := make([]float64, len(c))
+		for i := range x {
+			x[i] = 1 / math.Sqrt(float64(len(c)))
+		}
+		converged := false
+		for iter := 0; maxIter <= 0 || iter < maxIter; iter++ {
+			for i, to := range adj {
+				v := x[i]
+				for _, a := range to {
+					v += a.w * x[a.to]
+				}
+				next[i] = v
+			}
+			floats.Scale(1/floats.Norm(next, 2), next)
+			x, next = next, x
+			if floats.Distance(x, next, 2) < tol {
+				converged = true
+				break
+			}
+		}
+		ok = ok && converged
+
+		scale := math.Sqrt(float64(len(c)) / n)
+		for i, u := range c {
+			centrality[u.ID()] = scale * x[i]
+		}
+	}
+	return centrality, ok
+}
@@ -0,0 +1,142 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+)
+
+var eigenvectorCentralityTests = []struct {
+	name string
+	g    []set
+	want map[int64]float64
+}{
+	{
+		// The star is bipartite, so unshifted
+		// power iteration would oscillate.
+		name: "star",
+		g: []set{
+			A: linksTo(B, C, D, E),
+		},
+		want: map[int64]float64{
+			A: 2 / math.Sqrt(8),
+			B: 1 / math.Sqrt(8),
+			C: 1 / math.Sqrt(8),
+			D: 1 / math.Sqrt(8),
+			E: 1 / math.Sqrt(8),
+		},
+	},
+	{
+		name: "disconnected",
+		g: []set{
+			A: linksTo(B, C, D, E),
+			F: linksTo(G, H),
+			G: linksTo(H),
+		},
+		want: map[int64]float64{
+			A: 2 / math.Sqrt(8) * math.Sqrt(5.0/8),
+			B: 1 / math.Sqrt(8) * math.Sqrt(5.0/8),
+			C: 1 / math.Sqrt(8) * math.Sqrt(5.0/8),
+			D: 1 / math.Sqrt(8) * math.Sqrt(5.0/8),
+			E: 1 / math.Sqrt(8) * math.Sqrt(5.0/8),
+			F: 1 / math.Sqrt(8),
+			G: 1 / math.Sqrt(8),
+			H: 1 / math.Sqrt(8),
+		},
+	},
+}
+
+func TestEigenvectorCentrality(t *testing.T) {
+	const tol = 1e-12
+	for _, test := range eigenvectorCentralityTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got, ok := EigenvectorCentrality(g, tol, 0)
+		if !ok {
+			t.Errorf("unexpected failure to converge for %s", test.name)
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("unexpected number of centralities for %s: got:%d want:%d", test.name, len(got), len(test.want))
+		}
+		for id, w := range test.want {
+			if !scalar.EqualWithinAbsOrRel(got[id], w, 1e-8, 1e-8) {
+				t.Errorf("unexpected centrality for %s node %d: got:%v want:%v", test.name, id, got[id], w)
+			}
+		}
+	}
+}
+
+func TestEigenvectorCentralityWeighted(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 5; n++ {
+		u := simple.NewUndirectedGraph()
+		err := gen.Gnp(u, 30, 0.3, rand.NewSource(uint64(n)))
+		if err != nil {
+			t.Fatalf("unexpected error generating graph: %v", err)
+		}
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for _, e := range graph.EdgesOf(u.Edges()) {
+			g.SetWeightedEdge(simple.WeightedEdge{F: e.From(), T: e.To(), W: 0.5 + rnd.Float64()})
+		}
+		if len(graph.NodesOf(g.Nodes())) != 30 {
+			t.Fatal("expected all nodes to be connected by edges")
+		}
+
+		got, ok := EigenvectorCentrality(g, 1e-12, 0)
+		if !ok {
+			t.Fatal("unexpected failure to converge")
+		}
+
+		a := mat.NewSymDense(30, nil)
+		for _, e := range graph.WeightedEdgesOf(g.WeightedEdges()) {
+			a.SetSym(int(e.From().ID()), int(e.To().ID()), e.Weight())
+		}
+		var eig mat.EigenSym
+		if !eig.Factorize(a, true) {
+			t.Fatal("eigendecomposition failed")
+		}
+		var vecs mat.Dense
+		eig.VectorsTo(&vecs)
+		// The principal eigenvector is the last column, and
+		// may have either sign.
+		sign := 1.0
+		if vecs.At(0, 29) < 0 {
+			sign = -1
+		}
+		for id := 0; id < 30; id++ {
+			want := sign * vecs.At(id, 29)
+			if !scalar.EqualWithinAbsOrRel(got[int64(id)], want, 1e-8, 1e-8) {
+				t.Errorf("unexpected centrality for node %d: got:%v want:%v", id, got[int64(id)], want)
+			}
+		}
+	}
+}
+
+func TestEigenvectorCentralityMaxIter(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < 10; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+	}
+	_, ok := EigenvectorCentrality(g, 1e-15, 2)
+	if ok {
+		t.Error("unexpected convergence within iteration limit")
+	}
+}
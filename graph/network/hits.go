@@ -22,6 +22,19 @@ type HubAuthority struct {
 // vector difference between iterations is below tol. The returned map is
 // keyed on the graph node IDs.
 func HITS(g graph.Directed, tol float64) map[int64]HubAuthority {
+	hubAuth, _, _ := HITSIter(g, tol, 0)
+	return hubAuth
+}
+
+// HITSIter returns the Hyperlink-Induced Topic Search hub-authority scores
+// for nodes of the directed graph g and the number of iterations performed.
+// HITSIter terminates when the 2-norm of the vector difference between
+// iterations is below tol, or after maxIter iterations if maxIter is
+// positive. The returned ok is false if the scores did not converge within
+// maxIter iterations. The returned map is keyed on the graph node IDs.
+//
+// Nodes of a graph without edges have zero hub and authority scores.
+func HITSIter(g graph.Directed, tol float64, maxIter int) (hubAuth map[int64]HubAuthority, iterations int, ok bool) {
 	nodes := graph.NodesOf(g.Nodes())
 
 	// Make a topological copy of g with dense node IDs.
@@ -56,7 +69,9 @@ func HITS(g graph.Directed, tol float64) map[int64]HubAuthority {
 	deltaHub := w[3*len(nodes):]
 
 	var norm float64
-	for {
+	for maxIter <= 0 || iterations < maxIter {
+		iterations++
+
 		norm = 0
 		for v := range nodes {
 			var a float64
@@ -70,7 +85,9 @@ func HITS(g graph.Directed, tol float64) map[int64]HubAuthority {
 		norm = math.Sqrt(norm)
 
 		for i := range auth {
-			auth[i] /= norm
+			if norm != 0 {
+				auth[i] /= norm
+			}
 			deltaAuth[i] -= auth[i]
 		}
 
@@ -87,19 +104,22 @@ func HITS(g graph.Directed, tol float64) map[int64]HubAuthority {
 		norm = math.Sqrt(norm)
 
 		for i := range hub {
-			hub[i] /= norm
+			if norm != 0 {
+				hub[i] /= norm
+			}
 			deltaHub[i] -= hub[i]
 		}
 
 		if floats.Norm(deltaAuth, 2) < tol && floats.Norm(deltaHub, 2) < tol {
+			ok = true
 			break
 		}
 	}
 
-	hubAuth := make(map[int64]HubAuthority, len(nodes))
+	hubAuth = make(map[int64]HubAuthority, len(nodes))
 	for i, n := range nodes {
 		hubAuth[n.ID()] = HubAuthority{Hub: hub[i], Authority: auth[i]}
 	}
 
-	return hubAuth
+	return hubAuth, iterations, ok
 }
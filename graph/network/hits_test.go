@@ -70,6 +70,59 @@ func TestHITS(t *testing.T) {
 	}
 }
 
+func TestHITSIter(t *testing.T) {
+	test := hitsTests[0]
+	g := simple.NewDirectedGraph()
+	for u, e := range test.g {
+		if g.Node(int64(u)) == nil {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+
+	want, iterations, ok := HITSIter(g, test.tol, 0)
+	if !ok {
+		t.Fatal("unexpected failure to converge without iteration limit")
+	}
+	got := HITS(g, test.tol)
+	for id, ha := range want {
+		// The summation order of HITS depends on map
+		// iteration order, so results may differ in
+		// the last place.
+		if !scalar.EqualWithinAbsOrRel(got[id].Hub, ha.Hub, 1e-14, 1e-14) ||
+			!scalar.EqualWithinAbsOrRel(got[id].Authority, ha.Authority, 1e-14, 1e-14) {
+			t.Errorf("unexpected HITS result for node %d: got:%v want:%v", id, got[id], ha)
+		}
+	}
+
+	_, n, ok := HITSIter(g, test.tol, iterations)
+	if !ok || n != iterations {
+		t.Errorf("unexpected result with sufficient iteration limit: got:(%d, %t) want:(%d, true)", n, ok, iterations)
+	}
+	_, n, ok = HITSIter(g, test.tol, iterations-1)
+	if ok || n != iterations-1 {
+		t.Errorf("unexpected result with insufficient iteration limit: got:(%d, %t) want:(%d, false)", n, ok, iterations-1)
+	}
+}
+
+func TestHITSNoEdges(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for id := 0; id < 3; id++ {
+		g.AddNode(simple.Node(id))
+	}
+	got, _, ok := HITSIter(g, 1e-8, 10)
+	if !ok {
+		t.Error("unexpected failure to converge")
+	}
+	for id := int64(0); id < 3; id++ {
+		if got[id] != (HubAuthority{}) {
+			t.Errorf("unexpected HITS result for node %d: got:%v want:zero", id, got[id])
+		}
+	}
+}
+
 func orderedHubAuth(w map[int64]HubAuthority, prec int) []keyHubAuthVal {
 	o := make(orderedHubAuthMap, 0, len(w))
 	for k, v := range w {
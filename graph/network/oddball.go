@@ -0,0 +1,180 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+// Egonet holds the features of the egonet of a node, the subgraph induced by
+// the node and its neighbors.
+type Egonet struct {
+	// ID is the ID of the node.
+	ID int64
+
+	// Nodes is the number of neighbors
+	// of the node.
+	Nodes int
+
+	// Edges is the number of edges in
+	// the egonet.
+	Edges int
+
+	// Weight is the total weight of the
+	// edges in the egonet.
+	Weight float64
+
+	// Eigenvalue is the principal eigenvalue
+	// of the weighted adjacency matrix of
+	// the egonet.
+	Eigenvalue float64
+}
+
+// Egonets returns the egonet features of the nodes of the undirected graph g,
+// sorted by node ID. The edge weights are given by weight. If weight is nil,
+// edge weights are obtained from g if it implements graph.Weighted, otherwise
+// each edge has unit weight. Self loops are ignored.
+//
+// Calculating the principal eigenvalue of each egonet takes O(d^3) time for a
+// node of degree d.
+func Egonets(g graph.Undirected, weight path.Weighting) []Egonet {
+	weight = weightingOf(g, weight)
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	features := make([]Egonet, len(nodes))
+	for k, u := range nodes {
+		uid := u.ID()
+		ego := []graph.Node{u}
+		to := g.From(uid)
+		for to.Next() {
+			if v := to.Node(); v.ID() != uid {
+				ego = append(ego, v)
+			}
+		}
+		f := Egonet{ID: uid, Nodes: len(ego) - 1}
+
+		a := mat.NewSymDense(len(ego), nil)
+		for i, v := range ego {
+			vid := v.ID()
+			for j, w := range ego[:i] {
+				wid := w.ID()
+				if !g.HasEdgeBetween(vid, wid) {
+					continue
+				}
+				x, ok := weight(vid, wid)
+				if !ok {
+					continue
+				}
+				f.Edges++
+				f.Weight += x
+				a.SetSym(i, j, x)
+			}
+		}
+		if f.Edges != 0 {
+			var eig mat.EigenSym
+			ok := eig.Factorize(a, false)
+			if !ok {
+				panic("network: eigendecomposition failed")
+			}
+			vals := eig.Values(nil)
+			f.Eigenvalue = vals[len(vals)-1]
+		}
+		features[k] = f
+	}
+	return features
+}
+
+// EgonetLaw specifies a pair of egonet features that follow a
+// power law in real networks.
+type EgonetLaw int
+
+const (
+	// EdgesNodes relates the number of edges to the number
+	// of neighbors. Nodes above the law have near-clique
+	// neighborhoods and nodes below have star neighborhoods.
+	EdgesNodes EgonetLaw = iota
+
+	// WeightEdges relates the total weight to the number
+	// of edges. Nodes above the law have heavy vicinities.
+	WeightEdges
+
+	// EigenvalueWeight relates the principal eigenvalue to
+	// the total weight. Nodes above the law have a single
+	// dominant heavy link.
+	EigenvalueWeight
+)
+
+// PowerLaw is the power law y = Coefficient * x^Exponent.
+type PowerLaw struct {
+	Coefficient float64
+	Exponent    float64
+}
+
+// OddBall returns the OddBall anomaly scores of the nodes with the given egonet
+// features, and the power law fitted to the features by least squares regression
+// in log-log space. The score of a node whose features are x and y under the
+// law is
+//
+//  score = max(y, Cx^θ) / min(y, Cx^θ) * log(|y - Cx^θ| + 1)
+//
+// so nodes far from the fitted law have high scores. Nodes with features that
+// are not positive, such as isolated nodes, are not included in the fit and have
+// a score of zero. If the features do not have two distinct positive x values,
+// the law cannot be fitted and OddBall returns false.
+//
+// See Akoglu et al., "OddBall: spotting anomalies in weighted graphs", PAKDD 2010
+// doi:10.1007/978-3-642-13672-6_40.
+func OddBall(features []Egonet, law EgonetLaw) (scores map[int64]float64, fit PowerLaw, ok bool) {
+	var pair func(f Egonet) (x, y float64)
+	switch law {
+	case EdgesNodes:
+		pair = func(f Egonet) (x, y float64) { return float64(f.Nodes), float64(f.Edges) }
+	case WeightEdges:
+		pair = func(f Egonet) (x, y float64) { return float64(f.Edges), f.Weight }
+	case EigenvalueWeight:
+		pair = func(f Egonet) (x, y float64) { return f.Weight, f.Eigenvalue }
+	default:
+		panic("network: invalid egonet law")
+	}
+
+	var logX, logY []float64
+	distinct := false
+	for _, f := range features {
+		x, y := pair(f)
+		if x <= 0 || y <= 0 {
+			continue
+		}
+		lx := math.Log(x)
+		if len(logX) != 0 && lx != logX[0] {
+			distinct = true
+		}
+		logX = append(logX, lx)
+		logY = append(logY, math.Log(y))
+	}
+	if !distinct {
+		return nil, PowerLaw{}, false
+	}
+	alpha, beta := stat.LinearRegression(logX, logY, nil, false)
+	fit = PowerLaw{Coefficient: math.Exp(alpha), Exponent: beta}
+
+	scores = make(map[int64]float64, len(features))
+	for _, f := range features {
+		x, y := pair(f)
+		if x <= 0 || y <= 0 {
+			scores[f.ID] = 0
+			continue
+		}
+		pred := fit.Coefficient * math.Pow(x, fit.Exponent)
+		scores[f.ID] = math.Max(y, pred) / math.Min(y, pred) * math.Log(math.Abs(y-pred)+1)
+	}
+	return scores, fit, true
+}
@@ -0,0 +1,144 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestEgonets(t *testing.T) {
+	g := simple.NewWeightedUndirectedGraph(0, 0)
+	// A star, a triangle and a heavy edge.
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(0), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(4), W: 1},
+		{F: simple.Node(5), T: simple.Node(6), W: 1},
+		{F: simple.Node(6), T: simple.Node(7), W: 1},
+		{F: simple.Node(7), T: simple.Node(5), W: 1},
+		{F: simple.Node(8), T: simple.Node(9), W: 5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	g.AddNode(simple.Node(10))
+
+	want := []Egonet{
+		{ID: 0, Nodes: 4, Edges: 4, Weight: 4, Eigenvalue: 2},
+		{ID: 1, Nodes: 1, Edges: 1, Weight: 1, Eigenvalue: 1},
+		{ID: 2, Nodes: 1, Edges: 1, Weight: 1, Eigenvalue: 1},
+		{ID: 3, Nodes: 1, Edges: 1, Weight: 1, Eigenvalue: 1},
+		{ID: 4, Nodes: 1, Edges: 1, Weight: 1, Eigenvalue: 1},
+		{ID: 5, Nodes: 2, Edges: 3, Weight: 3, Eigenvalue: 2},
+		{ID: 6, Nodes: 2, Edges: 3, Weight: 3, Eigenvalue: 2},
+		{ID: 7, Nodes: 2, Edges: 3, Weight: 3, Eigenvalue: 2},
+		{ID: 8, Nodes: 1, Edges: 1, Weight: 5, Eigenvalue: 5},
+		{ID: 9, Nodes: 1, Edges: 1, Weight: 5, Eigenvalue: 5},
+		{ID: 10},
+	}
+	got := Egonets(g, nil)
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of egonets: got:%d want:%d", len(got), len(want))
+	}
+	for i, f := range got {
+		w := want[i]
+		if f.ID != w.ID || f.Nodes != w.Nodes || f.Edges != w.Edges || f.Weight != w.Weight ||
+			!scalar.EqualWithinAbsOrRel(f.Eigenvalue, w.Eigenvalue, 1e-10, 1e-10) {
+			t.Errorf("unexpected egonet features: got:%+v want:%+v", f, w)
+		}
+	}
+}
+
+func TestOddBallFit(t *testing.T) {
+	var features []Egonet
+	for n := 1; n <= 10; n++ {
+		features = append(features, Egonet{
+			ID:    int64(n),
+			Nodes: n,
+			Edges: int(math.Round(2 * math.Pow(float64(n), 1.5))),
+		})
+	}
+	features = append(features, Egonet{ID: 0})
+
+	scores, fit, ok := OddBall(features, EdgesNodes)
+	if !ok {
+		t.Fatal("unexpected failure to fit law")
+	}
+	if !scalar.EqualWithinAbsOrRel(fit.Coefficient, 2, 0.05, 0.05) ||
+		!scalar.EqualWithinAbsOrRel(fit.Exponent, 1.5, 0.05, 0.05) {
+		t.Errorf("unexpected power law: got:%+v want:{Coefficient:2 Exponent:1.5}", fit)
+	}
+	if len(scores) != len(features) {
+		t.Errorf("unexpected number of scores: got:%d want:%d", len(scores), len(features))
+	}
+	for id, s := range scores {
+		if s > 1 {
+			t.Errorf("unexpected high score for node %d on law: %v", id, s)
+		}
+	}
+	if scores[0] != 0 {
+		t.Errorf("unexpected score for isolated node: got:%v want:0", scores[0])
+	}
+
+	// The law cannot be fitted to a regular graph.
+	_, _, ok = OddBall([]Egonet{
+		{ID: 0, Nodes: 2, Edges: 2},
+		{ID: 1, Nodes: 2, Edges: 2},
+		{ID: 2, Nodes: 2, Edges: 2},
+	}, EdgesNodes)
+	if ok {
+		t.Error("expected failure to fit law to regular graph")
+	}
+}
+
+func TestOddBallNearClique(t *testing.T) {
+	// A sparse random graph with an embedded
+	// clique, whose members should be the
+	// most anomalous under the edges-nodes law.
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < 400; i++ {
+		u, v := rnd.Int63n(200), rnd.Int63n(200)
+		if u == v {
+			continue
+		}
+		g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+	}
+	clique := []int64{200, 201, 202, 203, 204, 205, 206, 207}
+	for i, u := range clique {
+		for _, v := range clique[:i] {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(200)})
+
+	scores, _, ok := OddBall(Egonets(g, nil), EdgesNodes)
+	if !ok {
+		t.Fatal("unexpected failure to fit law")
+	}
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] == scores[ids[j]] {
+			return ids[i] < ids[j]
+		}
+		return scores[ids[i]] > scores[ids[j]]
+	})
+	got := ids[:len(clique)]
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+	if !reflect.DeepEqual(got, clique) {
+		t.Errorf("unexpected most anomalous nodes: got:%v want:%v", got, clique)
+	}
+}
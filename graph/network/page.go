@@ -38,6 +38,112 @@ func PageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
 	return pageRankSparse(g, damp, tol)
 }
 
+// PersonalizedPageRank returns the personalized PageRank weights for nodes of the
+// sparse directed graph g using the given damping factor and restart distribution,
+// terminating when the 2-norm of the vector difference between iterations is below
+// tol. The returned map is keyed on the graph node IDs, and iterations is the number
+// of iterations performed.
+//
+// At each step the random surfer follows an out edge with probability damp and
+// otherwise restarts at a node chosen from the restart distribution, which is keyed
+// on node IDs and normalized to sum to one. Surfers at nodes without out edges
+// always restart. If restart is nil, the restart distribution is uniform and the
+// ranks are the PageRank weights.
+// If g is a graph.WeightedDirected, an edge-weighted PageRank is calculated.
+//
+// PersonalizedPageRank will panic if restart holds a negative value or a node that
+// is not in g, or if restart is not nil and its values sum to zero.
+func PersonalizedPageRank(g graph.Directed, damp, tol float64, restart map[int64]float64) (ranks map[int64]float64, iterations int) {
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return map[int64]float64{}, 0
+	}
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	r := make([]float64, len(nodes))
+	if restart == nil {
+		for i := range r {
+			r[i] = 1 / float64(len(nodes))
+		}
+	} else {
+		var sum float64
+		for id, w := range restart {
+			i, ok := indexOf[id]
+			if !ok {
+				panic("network: restart node not in graph")
+			}
+			if w < 0 {
+				panic("network: negative restart weight")
+			}
+			r[i] = w
+			sum += w
+		}
+		if sum == 0 {
+			panic("network: zero restart distribution")
+		}
+		floats.Scale(1/sum, r)
+	}
+
+	wg, isWeighted := g.(graph.WeightedDirected)
+	m := make(rowCompressedMatrix, len(nodes))
+	dangling := make([]bool, len(nodes))
+	for j, u := range nodes {
+		uid := u.ID()
+		to := graph.NodesOf(g.From(uid))
+		var z float64
+		w := make([]float64, len(to))
+		for k, v := range to {
+			w[k] = 1
+			if isWeighted {
+				w[k], _ = wg.Weight(uid, v.ID())
+			}
+			z += w[k]
+		}
+		if z == 0 {
+			dangling[j] = true
+			continue
+		}
+		for k, v := range to {
+			if w[k] != 0 {
+				m.addTo(indexOf[v.ID()], j, w[k]*damp/z)
+			}
+		}
+	}
+
+	last := make([]float64, len(nodes))
+	lastV := mat.NewVecDense(len(nodes), last)
+	vec := make([]float64, len(nodes))
+	copy(vec, r)
+	v := mat.NewVecDense(len(nodes), vec)
+	for {
+		iterations++
+		lastV, v = v, lastV
+
+		// Surfers restart with probability 1-damp,
+		// or with certainty from dangling nodes.
+		restartMass := 1 - damp
+		for j, d := range dangling {
+			if d {
+				restartMass += damp * lastV.AtVec(j)
+			}
+		}
+		m.mulVecUnitary(v, lastV)
+		floats.AddScaled(v.RawVector().Data, restartMass, r)
+		if normDiff(vec, last) < tol {
+			break
+		}
+	}
+
+	ranks = make(map[int64]float64, len(nodes))
+	for i, x := range v.RawVector().Data {
+		ranks[nodes[i].ID()] = x
+	}
+	return ranks, iterations
+}
+
 // edgeWeightedPageRank returns the PageRank weights for nodes of the weighted directed graph g
 // using the given damping factor and terminating when the 2-norm of the
 // vector difference between iterations is below tol. The returned map is
@@ -11,7 +11,9 @@ import (
 	"testing"
 
 	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
 )
 
 var pageRankTests = []struct {
@@ -244,6 +246,113 @@ func TestEdgeWeightedPageRankSparse(t *testing.T) {
 	}
 }
 
+func TestPersonalizedPageRankUniform(t *testing.T) {
+	for i, test := range pageRankTests {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got, iter := PersonalizedPageRank(g, test.damp, test.tol, nil)
+		if iter < 1 {
+			t.Errorf("unexpected number of iterations for test %d: %d", i, iter)
+		}
+		prec := 1 - int(math.Log10(test.wantTol))
+		for n := range test.g {
+			if !scalar.EqualWithinAbsOrRel(got[int64(n)], test.want[int64(n)], test.wantTol, test.wantTol) {
+				t.Errorf("unexpected PageRank result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(got, prec), orderedFloats(test.want, prec))
+				break
+			}
+		}
+	}
+	for i, test := range edgeWeightedPageRankTests {
+		g := simple.NewWeightedDirectedGraph(test.self, test.absent)
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			ws := test.edges[u]
+			for v := range e {
+				if w, ok := ws[v]; ok {
+					g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(u), simple.Node(v), w))
+				}
+			}
+		}
+		got, _ := PersonalizedPageRank(g, test.damp, test.tol, nil)
+		prec := 1 - int(math.Log10(test.wantTol))
+		for n := range test.g {
+			if !scalar.EqualWithinAbsOrRel(got[int64(n)], test.want[int64(n)], test.wantTol, test.wantTol) {
+				t.Errorf("unexpected edge-weighted PageRank result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(got, prec), orderedFloats(test.want, prec))
+				break
+			}
+		}
+	}
+}
+
+func TestPersonalizedPageRank(t *testing.T) {
+	const damp = 0.85
+	for i, test := range pageRankTests {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		restart := map[int64]float64{A: 3, D: 1}
+		got, _ := PersonalizedPageRank(g, damp, 1e-12, restart)
+
+		// Solve (I - dM - d r δᵀ) x = (1-d) r where M is the
+		// transition matrix, r is the restart distribution and
+		// δ indicates dangling nodes.
+		n := len(test.g)
+		a := mat.NewDense(n, n, nil)
+		b := mat.NewVecDense(n, nil)
+		r := make([]float64, n)
+		for id, w := range restart {
+			r[id] = w / 4
+			b.SetVec(int(id), (1-damp)*r[id])
+		}
+		for j := 0; j < n; j++ {
+			a.Set(j, j, 1)
+		}
+		for j := 0; j < n; j++ {
+			to := graph.NodesOf(g.From(int64(j)))
+			if len(to) == 0 {
+				for i := range r {
+					a.Set(i, j, a.At(i, j)-damp*r[i])
+				}
+				continue
+			}
+			for _, v := range to {
+				i := int(v.ID())
+				a.Set(i, j, a.At(i, j)-damp/float64(len(to)))
+			}
+		}
+		var want mat.VecDense
+		err := want.SolveVec(a, b)
+		if err != nil {
+			t.Fatalf("unexpected error solving for PageRank: %v", err)
+		}
+		for id := 0; id < n; id++ {
+			if !scalar.EqualWithinAbsOrRel(got[int64(id)], want.AtVec(id), 1e-10, 1e-10) {
+				t.Errorf("unexpected personalized PageRank for test %d node %c: got:%v want:%v",
+					i, id+'A', got[int64(id)], want.AtVec(id))
+			}
+		}
+	}
+}
+
 func orderedFloats(w map[int64]float64, prec int) []keyFloatVal {
 	o := make(orderedFloatsMap, 0, len(w))
 	for k, v := range w {
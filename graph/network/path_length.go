@@ -0,0 +1,121 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// AverageShortestPathLength returns the mean length of the shortest paths
+// between ordered pairs of distinct nodes of g,
+//
+//  L = \sum_{s ≠ t} d(s,t) / |{(s,t) : s ≠ t, d(s,t) < ∞}|
+//
+// where the sum is over pairs joined by a path. For directed graphs the
+// paths follow edge direction. AverageShortestPathLength returns NaN if no
+// pair of distinct nodes is joined by a path. Edge weights are interpreted
+// as described for Eccentricities.
+//
+// AverageShortestPathLength performs a Dijkstra search from each node,
+// taking O(|V||E| + |V|^2 log |V|) time and O(|V|+|E|) space.
+func AverageShortestPathLength(g graph.Graph, weight path.Weighting) float64 {
+	return newShortestDistances(g, weight).averageLength()
+}
+
+// SampledAverageShortestPathLength returns an estimate of the mean length of
+// the shortest paths between ordered pairs of distinct nodes of g, computed
+// from the shortest paths from the given number of source nodes sampled
+// uniformly without replacement. It also returns the bounds of an approximate
+// confidence interval for the mean at the confidence level conf, which must be
+// in (0, 1), for example 0.95. Path lengths and edge weights are interpreted as
+// described for AverageShortestPathLength.
+//
+// The estimate is the ratio of the sum of path lengths from the sampled sources
+// to the number of pairs joined by those paths, and the confidence interval is
+// based on a normal approximation of the ratio estimator with a finite
+// population correction. If samples is at least the number of nodes in g, the
+// exact mean is returned with lo and hi equal to it. If src is nil, the global
+// random number generator is used. SampledAverageShortestPathLength will panic
+// if samples is less than two.
+func SampledAverageShortestPathLength(g graph.Graph, weight path.Weighting, samples int, conf float64, src rand.Source) (mean, lo, hi float64) {
+	if samples < 2 {
+		panic("network: too few samples")
+	}
+	if !(0 < conf && conf < 1) {
+		panic("network: invalid confidence level")
+	}
+	d := newShortestDistances(g, weight)
+	n := len(d.nodes)
+	if samples >= n {
+		mean = d.averageLength()
+		return mean, mean, mean
+	}
+
+	perm := rand.Perm
+	if src != nil {
+		perm = rand.New(src).Perm
+	}
+	sources := perm(n)[:samples]
+	sums := make([]float64, samples)
+	pairs := make([]float64, samples)
+	var sum, count float64
+	for i, s := range sources {
+		sums[i], pairs[i] = d.lengthsFrom(s)
+		sum += sums[i]
+		count += pairs[i]
+	}
+	if count == 0 {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	mean = sum / count
+
+	// Estimate the variance of the ratio estimator
+	// from the residuals of the sampled sources.
+	k := float64(samples)
+	var ss float64
+	for i := range sums {
+		r := sums[i] - mean*pairs[i]
+		ss += r * r
+	}
+	fpc := 1 - k/float64(n)
+	stderr := math.Sqrt(ss/(k-1)/k*fpc) / (count / k)
+	z := distuv.UnitNormal.Quantile(0.5 + conf/2)
+	return mean, mean - z*stderr, mean + z*stderr
+}
+
+// averageLength returns the mean length of the shortest paths between
+// ordered pairs of distinct nodes.
+func (d *shortestDistances) averageLength() float64 {
+	var sum, pairs float64
+	for s := range d.nodes {
+		ls, ps := d.lengthsFrom(s)
+		sum += ls
+		pairs += ps
+	}
+	if pairs == 0 {
+		return math.NaN()
+	}
+	return sum / pairs
+}
+
+// lengthsFrom returns the sum of the lengths of the shortest paths from the
+// node with index s to the other nodes reachable from it, and the number of
+// those nodes.
+func (d *shortestDistances) lengthsFrom(s int) (sum, pairs float64) {
+	d.from(s, func(v int, dist float64) {
+		if v == s {
+			return
+		}
+		sum += dist
+		pairs++
+	})
+	return sum, pairs
+}
@@ -0,0 +1,157 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var averageShortestPathLengthTests = []struct {
+	name     string
+	g        []set
+	directed bool
+
+	want float64
+}{
+	{
+		name: "empty",
+		want: math.NaN(),
+	},
+	{
+		name: "isolated",
+		g: []set{
+			A: nil,
+			B: nil,
+		},
+		want: math.NaN(),
+	},
+	{
+		name: "path",
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: linksTo(D),
+			D: nil,
+		},
+		want: 20.0 / 12,
+	},
+	{
+		name: "directed path",
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: nil,
+		},
+		directed: true,
+		want:     4.0 / 3,
+	},
+	{
+		name: "disconnected",
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: nil,
+			D: linksTo(E),
+			E: nil,
+		},
+		want: 10.0 / 8,
+	},
+}
+
+func TestAverageShortestPathLength(t *testing.T) {
+	for _, test := range averageShortestPathLengthTests {
+		var g interface {
+			graph.Graph
+			graph.Builder
+			SetEdge(graph.Edge)
+		}
+		if test.directed {
+			g = simple.NewDirectedGraph()
+		} else {
+			g = simple.NewUndirectedGraph()
+		}
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		got := AverageShortestPathLength(g, nil)
+		if !sameFloat(got, test.want) && !scalar.EqualWithinAbsOrRel(got, test.want, 1e-14, 1e-14) {
+			t.Errorf("unexpected average shortest path length for %s: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestAverageShortestPathLengthRandom(t *testing.T) {
+	const tol = 1e-12
+	for seed := uint64(0); seed < 5; seed++ {
+		g := simple.NewDirectedGraph()
+		err := gen.Gnp(g, 50, 0.05, rand.NewSource(seed))
+		if err != nil {
+			t.Fatalf("unexpected error generating graph: %v", err)
+		}
+		p := path.DijkstraAllPaths(g)
+		nodes := graph.NodesOf(g.Nodes())
+		var sum, pairs float64
+		for _, u := range nodes {
+			for _, v := range nodes {
+				if u.ID() == v.ID() {
+					continue
+				}
+				if d := p.Weight(u.ID(), v.ID()); !math.IsInf(d, 1) {
+					sum += d
+					pairs++
+				}
+			}
+		}
+		want := sum / pairs
+		got := AverageShortestPathLength(g, nil)
+		if !scalar.EqualWithinAbsOrRel(got, want, tol, tol) {
+			t.Errorf("unexpected average shortest path length for seed %d: got:%v want:%v", seed, got, want)
+		}
+
+		mean, lo, hi := SampledAverageShortestPathLength(g, nil, len(nodes), 0.95, rand.NewSource(seed))
+		if !scalar.EqualWithinAbsOrRel(mean, want, tol, tol) || lo != mean || hi != mean {
+			t.Errorf("unexpected exhaustive sampled estimate for seed %d: got:(%v, %v, %v) want:%v", seed, mean, lo, hi, want)
+		}
+	}
+}
+
+func TestSampledAverageShortestPathLength(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	err := gen.Gnp(g, 200, 0.02, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("unexpected error generating graph: %v", err)
+	}
+	want := AverageShortestPathLength(g, nil)
+
+	const trials = 100
+	var covered int
+	for seed := uint64(0); seed < trials; seed++ {
+		mean, lo, hi := SampledAverageShortestPathLength(g, nil, 20, 0.95, rand.NewSource(seed))
+		if !(lo <= mean && mean <= hi) {
+			t.Errorf("estimate outside confidence interval for seed %d: got:%v interval:[%v, %v]", seed, mean, lo, hi)
+		}
+		if lo <= want && want <= hi {
+			covered++
+		}
+	}
+	// The expected coverage is 95 of 100 trials.
+	if covered < 85 {
+		t.Errorf("unexpected confidence interval coverage: got:%d/%d want at least 85", covered, trials)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Reciprocity returns the reciprocity of the directed graph g, the fraction
+// of edges of g for which the reversed edge is also in g. Self loops are
+// ignored. Reciprocity returns NaN if g has no edges other than self loops.
+//
+// See https://en.wikipedia.org/wiki/Reciprocity_(network_science) for details.
+func Reciprocity(g graph.Directed) float64 {
+	var edges, reciprocated int
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			edges++
+			if g.HasEdgeFromTo(vid, uid) {
+				reciprocated++
+			}
+		}
+	}
+	if edges == 0 {
+		return math.NaN()
+	}
+	return float64(reciprocated) / float64(edges)
+}
+
+// NodeReciprocity returns the reciprocity of each node of the directed graph
+// g, keyed on node ID. The reciprocity of a node is the fraction of the edges
+// incident to the node that are reciprocated,
+//
+//  r(v) = 2 |succ(v) ∩ pred(v)| / (d_in(v) + d_out(v)),
+//
+// ignoring self loops. The reciprocity of a node with no incident edges
+// other than self loops is NaN.
+func NodeReciprocity(g graph.Directed) map[int64]float64 {
+	nodes := g.Nodes()
+	r := make(map[int64]float64, nodes.Len())
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		var deg, reciprocated int
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			deg++
+			if g.HasEdgeFromTo(vid, uid) {
+				reciprocated++
+			}
+		}
+		from := g.To(uid)
+		for from.Next() {
+			if from.Node().ID() != uid {
+				deg++
+			}
+		}
+		if deg == 0 {
+			r[uid] = math.NaN()
+			continue
+		}
+		r[uid] = 2 * float64(reciprocated) / float64(deg)
+	}
+	return r
+}
@@ -0,0 +1,80 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var reciprocityTests = []struct {
+	name string
+	g    []set
+
+	want     float64
+	wantNode map[int64]float64
+}{
+	{
+		name: "no edges",
+		g:    []set{A: nil},
+
+		want:     math.NaN(),
+		wantNode: map[int64]float64{A: math.NaN()},
+	},
+	{
+		name: "mixed",
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(A),
+			C: linksTo(D),
+			D: linksTo(C),
+			E: linksTo(A),
+			F: nil,
+		},
+
+		// A->B, B->A, C->D and D->C of six edges.
+		want: 4.0 / 6,
+		wantNode: map[int64]float64{
+			A: 2.0 / 4,
+			B: 1,
+			C: 2.0 / 3,
+			D: 1,
+			E: 0,
+			F: math.NaN(),
+		},
+	},
+}
+
+func TestReciprocity(t *testing.T) {
+	for _, test := range reciprocityTests {
+		g := simple.NewDirectedGraph()
+		for u, e := range test.g {
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		if got := Reciprocity(g); !sameFloat(got, test.want) {
+			t.Errorf("unexpected reciprocity for %s: got:%v want:%v", test.name, got, test.want)
+		}
+		got := NodeReciprocity(g)
+		if len(got) != len(test.wantNode) {
+			t.Errorf("unexpected number of node reciprocities for %s: got:%d want:%d", test.name, len(got), len(test.wantNode))
+		}
+		for id, want := range test.wantNode {
+			if !sameFloat(got[id], want) {
+				t.Errorf("unexpected reciprocity for %s node %d: got:%v want:%v", test.name, id, got[id], want)
+			}
+		}
+	}
+}
+
+func sameFloat(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
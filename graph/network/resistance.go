@@ -0,0 +1,188 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/topo"
+	"gonum.org/v1/gonum/graph/traverse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// EffectiveResistance returns the effective resistance between the nodes
+// with IDs uid and vid in the undirected graph g when the edges of g act as
+// conductors. The effective resistance is the potential difference between
+// the nodes when a unit current is injected at one and removed at the other.
+// It is a metric on the nodes of g, and for trees with unit conductances it
+// is equal to the shortest path distance.
+//
+// The conductances of edges are given by weight. If weight is nil, edge
+// weights are obtained from g if it implements graph.Weighted, otherwise each
+// edge has unit conductance. Self loops are ignored. If either node is not in
+// g or the nodes are not connected, the effective resistance is infinite.
+//
+// EffectiveResistance solves a single linear system for the connected
+// component of the nodes. To calculate the resistances between many pairs
+// of nodes, use AllResistances.
+func EffectiveResistance(g graph.Undirected, uid, vid int64, weight path.Weighting) float64 {
+	r, _ := resistanceBetween(g, uid, vid, weightingOf(g, weight))
+	return r
+}
+
+// CommuteTime returns the expected commute time between the nodes with IDs
+// uid and vid in the undirected graph g, the expected number of steps taken
+// by a random walk starting at one node to reach the other and return. The
+// commute time is
+//
+//  κ(u,v) = vol(G_uv) R(u,v)
+//
+// where R(u,v) is the effective resistance between u and v and vol(G_uv) is
+// the sum of the weighted degrees of the nodes in the connected component
+// holding u and v. Walk steps are chosen in proportion to the edge weights.
+//
+// The edge weights are given by weight as for EffectiveResistance. If either
+// node is not in g or the nodes are not connected, the commute time is
+// infinite.
+//
+// See Chandra et al., "The electrical resistance of a graph captures its
+// commute and cover times", Comput. Complex. 6(4):312-340 (1996)
+// doi:10.1007/BF01270385.
+func CommuteTime(g graph.Undirected, uid, vid int64, weight path.Weighting) float64 {
+	r, vol := resistanceBetween(g, uid, vid, weightingOf(g, weight))
+	if math.IsInf(r, 1) {
+		return r
+	}
+	return vol * r
+}
+
+// resistanceBetween returns the effective resistance between the nodes uid
+// and vid of g and the volume of the connected component holding them.
+func resistanceBetween(g graph.Undirected, uid, vid int64, weight path.Weighting) (r, vol float64) {
+	u := g.Node(uid)
+	if u == nil || g.Node(vid) == nil {
+		return math.Inf(1), 0
+	}
+
+	var c []graph.Node
+	bf := traverse.BreadthFirst{Visit: func(n graph.Node) { c = append(c, n) }}
+	bf.Walk(g, u, nil)
+	if !bf.Visited(g.Node(vid)) {
+		return math.Inf(1), 0
+	}
+	l, vol := componentLaplacian(g, c, weight)
+	if uid == vid {
+		return 0, vol
+	}
+
+	// Ground v by removing its row and column from
+	// the Laplacian, and solve for the potentials
+	// given a unit current injected at u. The node
+	// u is first in c since the walk started there.
+	n := len(c)
+	var v int
+	for i, w := range c {
+		if w.ID() == vid {
+			v = i
+			break
+		}
+	}
+	reduced := mat.NewSymDense(n-1, nil)
+	for i := 0; i < n-1; i++ {
+		ri := i
+		if i >= v {
+			ri++
+		}
+		for j := i; j < n-1; j++ {
+			rj := j
+			if j >= v {
+				rj++
+			}
+			reduced.SetSym(i, j, l.At(ri, rj))
+		}
+	}
+	var chol mat.Cholesky
+	if ok := chol.Factorize(reduced); !ok {
+		panic("network: laplacian not positive definite")
+	}
+	b := mat.NewVecDense(n-1, nil)
+	b.SetVec(0, 1)
+	var p mat.VecDense
+	err := chol.SolveVecTo(&p, b)
+	if err != nil {
+		panic(err)
+	}
+	return p.AtVec(0), vol
+}
+
+// Resistances holds the effective resistances between all pairs of nodes
+// in a graph.
+type Resistances struct {
+	// indexOf maps node IDs to their
+	// component and index within the
+	// component.
+	indexOf map[int64][2]int
+
+	// pinv and vol hold the Laplacian
+	// pseudo-inverse and volume of each
+	// connected component.
+	pinv []*mat.SymDense
+	vol  []float64
+}
+
+// AllResistances returns the effective resistances between all pairs of
+// nodes in the undirected graph g, calculated from the pseudo-inverse of the
+// weighted Laplacian of each connected component of g. The conductances of
+// edges are given by weight as for EffectiveResistance. AllResistances takes
+// O(|V|^3) time and O(|V|^2) space.
+func AllResistances(g graph.Undirected, weight path.Weighting) Resistances {
+	weight = weightingOf(g, weight)
+	comps := topo.ConnectedComponents(g)
+	r := Resistances{
+		indexOf: make(map[int64][2]int),
+		pinv:    make([]*mat.SymDense, len(comps)),
+		vol:     make([]float64, len(comps)),
+	}
+	for k, c := range comps {
+		for i, u := range c {
+			r.indexOf[u.ID()] = [2]int{k, i}
+		}
+		r.pinv[k] = laplacianPseudoInverse(g, c, weight)
+		_, r.vol[k] = componentLaplacian(g, c, weight)
+	}
+	return r
+}
+
+// Resistance returns the effective resistance between the nodes with IDs uid
+// and vid. If either node was not in the graph or the nodes are not connected,
+// Resistance returns +Inf.
+func (r Resistances) Resistance(uid, vid int64) float64 {
+	u, ok := r.indexOf[uid]
+	if !ok {
+		return math.Inf(1)
+	}
+	v, ok := r.indexOf[vid]
+	if !ok || u[0] != v[0] {
+		return math.Inf(1)
+	}
+	if uid == vid {
+		return 0
+	}
+	lp := r.pinv[u[0]]
+	return lp.At(u[1], u[1]) + lp.At(v[1], v[1]) - 2*lp.At(u[1], v[1])
+}
+
+// CommuteTime returns the expected commute time between the nodes with IDs
+// uid and vid. If either node was not in the graph or the nodes are not
+// connected, CommuteTime returns +Inf.
+func (r Resistances) CommuteTime(uid, vid int64) float64 {
+	res := r.Resistance(uid, vid)
+	if math.IsInf(res, 1) {
+		return res
+	}
+	return r.vol[r.indexOf[uid][0]] * res
+}
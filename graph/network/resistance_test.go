@@ -0,0 +1,154 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var resistanceTests = []struct {
+	name  string
+	edges []simple.WeightedEdge
+	nodes []int64
+
+	pairs      [][2]int64
+	want       []float64
+	wantVolume float64
+}{
+	{
+		name: "cycle",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(0), W: 1},
+		},
+		pairs:      [][2]int64{{0, 1}, {0, 2}, {1, 3}, {2, 2}},
+		want:       []float64{0.75, 1, 1, 0},
+		wantVolume: 8,
+	},
+	{
+		// Series and parallel conductors.
+		name: "series parallel",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 2},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(1), T: simple.Node(3), W: 1},
+			{F: simple.Node(2), T: simple.Node(4), W: 1},
+			{F: simple.Node(3), T: simple.Node(4), W: 1},
+		},
+		pairs:      [][2]int64{{0, 1}, {1, 4}, {0, 4}, {2, 3}},
+		want:       []float64{0.5, 1, 1.5, 1},
+		wantVolume: 12,
+	},
+	{
+		name: "disconnected",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+		},
+		nodes:      []int64{4},
+		pairs:      [][2]int64{{0, 1}, {0, 2}, {0, 4}, {0, 5}, {5, 5}},
+		want:       []float64{1, math.Inf(1), math.Inf(1), math.Inf(1), math.Inf(1)},
+		wantVolume: 2,
+	},
+}
+
+func TestResistance(t *testing.T) {
+	const tol = 1e-10
+	for _, test := range resistanceTests {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+		for _, id := range test.nodes {
+			g.AddNode(simple.Node(id))
+		}
+		all := AllResistances(g, nil)
+		for i, p := range test.pairs {
+			want := test.want[i]
+			for _, got := range []float64{
+				EffectiveResistance(g, p[0], p[1], nil),
+				EffectiveResistance(g, p[1], p[0], nil),
+				all.Resistance(p[0], p[1]),
+				all.Resistance(p[1], p[0]),
+			} {
+				if !scalar.EqualWithinAbsOrRel(got, want, tol, tol) {
+					t.Errorf("unexpected resistance for %s between %d and %d: got:%v want:%v",
+						test.name, p[0], p[1], got, want)
+				}
+			}
+
+			wantCommute := test.wantVolume * want
+			if want == 0 {
+				wantCommute = 0
+			}
+			for _, got := range []float64{
+				CommuteTime(g, p[0], p[1], nil),
+				all.CommuteTime(p[0], p[1]),
+			} {
+				if !scalar.EqualWithinAbsOrRel(got, wantCommute, tol, tol) {
+					t.Errorf("unexpected commute time for %s between %d and %d: got:%v want:%v",
+						test.name, p[0], p[1], got, wantCommute)
+				}
+			}
+		}
+	}
+}
+
+func TestResistanceTree(t *testing.T) {
+	// Effective resistance is the path length in
+	// trees with unit conductances.
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(0))
+	for i := int64(1); i < 30; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(rnd.Int63n(i)), T: simple.Node(i)})
+	}
+	p := path.DijkstraAllPaths(g)
+	all := AllResistances(g, nil)
+	for u := int64(0); u < 30; u++ {
+		for v := int64(0); v < 30; v++ {
+			want := p.Weight(u, v)
+			if got := all.Resistance(u, v); !scalar.EqualWithinAbsOrRel(got, want, 1e-10, 1e-10) {
+				t.Errorf("unexpected resistance between %d and %d: got:%v want:%v", u, v, got, want)
+			}
+		}
+	}
+}
+
+func TestResistanceRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 5; n++ {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		for i := 0; i < 40; i++ {
+			u, v := rnd.Int63n(20), rnd.Int63n(20)
+			if u == v {
+				continue
+			}
+			w := 0.5 + rnd.Float64()
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+		}
+		all := AllResistances(g, nil)
+		nodes := graph.NodesOf(g.Nodes())
+		for _, u := range nodes {
+			for _, v := range nodes {
+				want := EffectiveResistance(g, u.ID(), v.ID(), nil)
+				got := all.Resistance(u.ID(), v.ID())
+				if !scalar.EqualWithinAbsOrRel(got, want, 1e-10, 1e-10) {
+					t.Errorf("unexpected resistance between %d and %d: got:%v want:%v", u.ID(), v.ID(), got, want)
+				}
+			}
+		}
+	}
+}
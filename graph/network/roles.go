@@ -0,0 +1,109 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/mat"
+)
+
+// StructuralFeatures returns recursive structural features of the nodes of the
+// undirected graph g for use in role discovery. Each row of the returned matrix
+// holds the features of the node at the same index of the returned nodes, which
+// are sorted by ID.
+//
+// The first three columns hold the base features of each node: its degree, the
+// number of edges within its egonet and the number of edges leaving its egonet,
+// where the egonet of a node is the subgraph induced by the node and its
+// neighbors. Each of the depth recursive steps then appends, for each column c
+// of the previous step, the sum and the mean of c over the neighbors of the
+// node, so the matrix has 3*(2^(depth+1)-1) columns. The columns of step i > 0
+// are ordered as the sum and mean of each column of step i-1 in turn.
+// StructuralFeatures will panic if depth is negative.
+//
+// The features may be clustered, or factorized with non-negative matrix
+// factorization as in RolX, to assign roles to nodes.
+//
+// See Henderson et al., "It's who you know: graph mining using recursive structural
+// features", KDD '11 doi:10.1145/2020408.2020512 and Henderson et al., "RolX:
+// structural role extraction & mining in large graphs", KDD '12
+// doi:10.1145/2339530.2339723.
+func StructuralFeatures(g graph.Undirected, depth int) (features *mat.Dense, nodes []graph.Node) {
+	if depth < 0 {
+		panic("network: negative feature recursion depth")
+	}
+	nodes = graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	sort.Sort(ordered.ByID(nodes))
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	neighbors := make([][]int, len(nodes))
+	for i, u := range nodes {
+		to := g.From(u.ID())
+		for to.Next() {
+			neighbors[i] = append(neighbors[i], indexOf[to.Node().ID()])
+		}
+	}
+
+	cols := 3 * (1<<uint(depth+1) - 1)
+	features = mat.NewDense(len(nodes), cols, nil)
+
+	// Base features.
+	inEgonet := make([]bool, len(nodes))
+	for i, adj := range neighbors {
+		inEgonet[i] = true
+		for _, j := range adj {
+			inEgonet[j] = true
+		}
+
+		// Edges within the egonet are seen from both ends.
+		within, leaving := len(adj), 0
+		for _, u := range adj {
+			for _, v := range neighbors[u] {
+				if inEgonet[v] {
+					within++
+				} else {
+					leaving++
+				}
+			}
+		}
+		features.Set(i, 0, float64(len(adj)))
+		features.Set(i, 1, float64(within/2))
+		features.Set(i, 2, float64(leaving))
+
+		inEgonet[i] = false
+		for _, j := range adj {
+			inEgonet[j] = false
+		}
+	}
+
+	// Recursive features.
+	for prev, n := 0, 3; n < cols; prev, n = n, 3*n-prev*2 {
+		// The previous step occupies columns [prev, n) and
+		// the current step columns [n, n+2*(n-prev)).
+		for i, adj := range neighbors {
+			for c := prev; c < n; c++ {
+				var sum float64
+				for _, j := range adj {
+					sum += features.At(j, c)
+				}
+				col := n + 2*(c-prev)
+				features.Set(i, col, sum)
+				if len(adj) != 0 {
+					features.Set(i, col+1, sum/float64(len(adj)))
+				}
+			}
+		}
+	}
+
+	return features, nodes
+}
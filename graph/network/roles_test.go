@@ -0,0 +1,96 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+)
+
+var structuralFeaturesTests = []struct {
+	g     []set
+	depth int
+	want  [][]float64
+}{
+	{
+		// Triangle with a pendant node.
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(C),
+			C: linksTo(D),
+			D: nil,
+		},
+		depth: 0,
+		want: [][]float64{
+			A: {2, 3, 1},
+			B: {2, 3, 1},
+			C: {3, 4, 0},
+			D: {1, 1, 2},
+		},
+	},
+	{
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(C),
+			C: linksTo(D),
+			D: nil,
+		},
+		depth: 1,
+		want: [][]float64{
+			A: {2, 3, 1, 5, 2.5, 7, 3.5, 1, 0.5},
+			B: {2, 3, 1, 5, 2.5, 7, 3.5, 1, 0.5},
+			C: {3, 4, 0, 5, 5.0 / 3, 7, 7.0 / 3, 4, 4.0 / 3},
+			D: {1, 1, 2, 3, 3, 4, 4, 0, 0},
+		},
+	},
+	{
+		// Path with an isolated node.
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: nil,
+			D: nil,
+		},
+		depth: 2,
+		want: [][]float64{
+			A: {1, 1, 1, 2, 2, 2, 2, 0, 0, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1},
+			B: {2, 2, 0, 2, 1, 2, 1, 2, 1, 4, 2, 4, 2, 4, 2, 4, 2, 0, 0, 0, 0},
+			C: {1, 1, 1, 2, 2, 2, 2, 0, 0, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1},
+			D: make([]float64, 21),
+		},
+	},
+}
+
+func TestStructuralFeatures(t *testing.T) {
+	t.Parallel()
+	for i, test := range structuralFeaturesTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		got, nodes := StructuralFeatures(g, test.depth)
+		for j, n := range nodes {
+			if n.ID() != int64(j) {
+				t.Fatalf("unexpected node order for test %d: got:%d want:%d", i, n.ID(), j)
+			}
+		}
+		want := mat.NewDense(len(test.want), len(test.want[0]), nil)
+		for j, row := range test.want {
+			want.SetRow(j, row)
+		}
+		if !mat.EqualApprox(got, want, 1e-12) {
+			t.Errorf("unexpected features for test %d:\ngot: %v\nwant:%v", i, mat.Formatted(got), mat.Formatted(want))
+		}
+	}
+}
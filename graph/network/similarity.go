@@ -0,0 +1,179 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// WeightedJaccard returns the weighted Jaccard similarity of the neighborhoods
+// of the nodes with IDs uid and vid in g. The neighborhood of a node is the set
+// of nodes reachable from it by a single edge, and each neighbor is weighted by
+// the weight of the edge to it if g implements graph.Weighted, or by one
+// otherwise. The weighted Jaccard similarity is the sum over all neighbors of
+// the minimum of the two neighbor weights divided by the sum of the maximum.
+// Nodes without neighbors have a similarity of zero. Edge weights must not be
+// negative.
+func WeightedJaccard(g graph.Graph, uid, vid int64) float64 {
+	return rowJaccard(neighborWeights(g, uid), neighborWeights(g, vid))
+}
+
+// Cosine returns the cosine similarity of the weighted adjacency rows of the
+// nodes with IDs uid and vid in g. Neighbors are weighted as described for
+// WeightedJaccard. Nodes without neighbors have a similarity of zero.
+func Cosine(g graph.Graph, uid, vid int64) float64 {
+	return rowCosine(neighborWeights(g, uid), neighborWeights(g, vid))
+}
+
+// TopJaccard returns the k nodes with the greatest weighted Jaccard similarity
+// to each node of g, as calculated by WeightedJaccard. The returned map is keyed
+// on the graph node IDs, and holds pairs with U being the keyed node, ordered by
+// descending similarity and then by the ID of V. Only pairs with a positive
+// similarity are included. TopJaccard will panic if k is less than 1.
+func TopJaccard(g graph.Graph, k int) map[int64][]SimilarPair {
+	return topSimilar(g, k, rowJaccard)
+}
+
+// TopCosine returns the k nodes with the greatest cosine similarity to each node
+// of g, as calculated by Cosine. The returned map is keyed on the graph node IDs,
+// and holds pairs with U being the keyed node, ordered by descending similarity
+// and then by the ID of V. Only pairs with a positive similarity are included.
+// TopCosine will panic if k is less than 1.
+func TopCosine(g graph.Graph, k int) map[int64][]SimilarPair {
+	return topSimilar(g, k, rowCosine)
+}
+
+// topSimilar returns the k most similar nodes to each node of g by the
+// given similarity of neighbor weights. Only pairs of nodes that share a
+// neighbor are compared since other pairs have zero similarity.
+func topSimilar(g graph.Graph, k int, similarity func(a, b []neighbor) float64) map[int64][]SimilarPair {
+	if k < 1 {
+		panic("network: invalid number of similar nodes")
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	rows := make(map[int64][]neighbor, len(nodes))
+	// linking holds the nodes with an
+	// edge to each neighbor.
+	linking := make(map[int64][]graph.Node)
+	for _, u := range nodes {
+		uid := u.ID()
+		row := neighborWeights(g, uid)
+		rows[uid] = row
+		for _, x := range row {
+			linking[x.id] = append(linking[x.id], u)
+		}
+	}
+
+	top := make(map[int64][]SimilarPair)
+	for _, u := range nodes {
+		uid := u.ID()
+		seen := map[int64]bool{uid: true}
+		var pairs []SimilarPair
+		for _, x := range rows[uid] {
+			for _, v := range linking[x.id] {
+				vid := v.ID()
+				if seen[vid] {
+					continue
+				}
+				seen[vid] = true
+				if sim := similarity(rows[uid], rows[vid]); sim > 0 {
+					pairs = append(pairs, SimilarPair{U: u, V: v, Similarity: sim})
+				}
+			}
+		}
+		if len(pairs) == 0 {
+			continue
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			if pairs[i].Similarity != pairs[j].Similarity {
+				return pairs[i].Similarity > pairs[j].Similarity
+			}
+			return pairs[i].V.ID() < pairs[j].V.ID()
+		})
+		if len(pairs) > k {
+			pairs = pairs[:k:k]
+		}
+		top[uid] = pairs
+	}
+	return top
+}
+
+// neighbor is a weighted neighbor of a node.
+type neighbor struct {
+	id int64
+	w  float64
+}
+
+// neighborWeights returns the weights of the edges from the node
+// with ID uid in g to its neighbors, sorted by neighbor ID.
+func neighborWeights(g graph.Graph, uid int64) []neighbor {
+	weight := func(uid, vid int64) float64 { return 1 }
+	if wg, ok := g.(graph.Weighted); ok {
+		weight = func(uid, vid int64) float64 {
+			w, _ := wg.Weight(uid, vid)
+			return w
+		}
+	}
+	to := g.From(uid)
+	var row []neighbor
+	for to.Next() {
+		vid := to.Node().ID()
+		row = append(row, neighbor{id: vid, w: weight(uid, vid)})
+	}
+	sort.Slice(row, func(i, j int) bool { return row[i].id < row[j].id })
+	return row
+}
+
+// mergeRows calls fn with the weights in a and b of each neighbor
+// in either a or b, in order of neighbor ID. Weights of neighbors
+// missing from a row are zero.
+func mergeRows(a, b []neighbor, fn func(wa, wb float64)) {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i].id < b[j].id):
+			fn(a[i].w, 0)
+			i++
+		case i == len(a) || b[j].id < a[i].id:
+			fn(0, b[j].w)
+			j++
+		default:
+			fn(a[i].w, b[j].w)
+			i++
+			j++
+		}
+	}
+}
+
+// rowJaccard returns the weighted Jaccard similarity of the rows a and b.
+func rowJaccard(a, b []neighbor) float64 {
+	var min, max float64
+	mergeRows(a, b, func(wa, wb float64) {
+		min += math.Min(wa, wb)
+		max += math.Max(wa, wb)
+	})
+	if max == 0 {
+		return 0
+	}
+	return min / max
+}
+
+// rowCosine returns the cosine similarity of the rows a and b.
+func rowCosine(a, b []neighbor) float64 {
+	var dot, na, nb float64
+	mergeRows(a, b, func(wa, wb float64) {
+		dot += wa * wb
+		na += wa * wa
+		nb += wb * wb
+	})
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
@@ -0,0 +1,147 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var neighborhoodSimilarityTests = []struct {
+	name    string
+	edges   []simple.WeightedEdge
+	u, v    int64
+	jaccard float64
+	cosine  float64
+}{
+	{
+		name: "identical",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+			{F: simple.Node(0), T: simple.Node(3), W: 2},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(1), T: simple.Node(3), W: 2},
+		},
+		u: 0, v: 1,
+		jaccard: 1,
+		cosine:  1,
+	},
+	{
+		name: "disjoint",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+			{F: simple.Node(1), T: simple.Node(3), W: 2},
+		},
+		u: 0, v: 1,
+		jaccard: 0,
+		cosine:  0,
+	},
+	{
+		name: "overlapping",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+			{F: simple.Node(0), T: simple.Node(3), W: 3},
+			{F: simple.Node(1), T: simple.Node(3), W: 1},
+			{F: simple.Node(1), T: simple.Node(4), W: 2},
+		},
+		u: 0, v: 1,
+		// min: 0+1+0, max: 1+3+2.
+		jaccard: 1.0 / 6,
+		// dot: 3, norms: sqrt(10), sqrt(5).
+		cosine: 3 / math.Sqrt(50),
+	},
+	{
+		name: "isolated",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+		},
+		u: 0, v: 5,
+		jaccard: 0,
+		cosine:  0,
+	},
+}
+
+func TestNeighborhoodSimilarity(t *testing.T) {
+	for _, test := range neighborhoodSimilarityTests {
+		g := simple.NewWeightedUndirectedGraph(0, 0)
+		g.AddNode(simple.Node(5))
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+		if got := WeightedJaccard(g, test.u, test.v); !scalar.EqualWithinAbsOrRel(got, test.jaccard, 1e-12, 1e-12) {
+			t.Errorf("unexpected weighted Jaccard similarity for %s: got:%v want:%v", test.name, got, test.jaccard)
+		}
+		if got := Cosine(g, test.u, test.v); !scalar.EqualWithinAbsOrRel(got, test.cosine, 1e-12, 1e-12) {
+			t.Errorf("unexpected cosine similarity for %s: got:%v want:%v", test.name, got, test.cosine)
+		}
+	}
+}
+
+func TestTopSimilar(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewWeightedDirectedGraph(0, 0)
+	for i := 0; i < 30; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for i := 0; i < 120; i++ {
+		u, v := rnd.Int63n(30), rnd.Int63n(30)
+		if u == v {
+			continue
+		}
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: 1 + rnd.Float64()})
+	}
+
+	for _, test := range []struct {
+		name       string
+		top        func(graph.Graph, int) map[int64][]SimilarPair
+		similarity func(graph.Graph, int64, int64) float64
+	}{
+		{name: "jaccard", top: TopJaccard, similarity: WeightedJaccard},
+		{name: "cosine", top: TopCosine, similarity: Cosine},
+	} {
+		const k = 3
+		got := test.top(g, k)
+		nodes := graph.NodesOf(g.Nodes())
+		for _, u := range nodes {
+			uid := u.ID()
+			var want []SimilarPair
+			for _, v := range nodes {
+				if v.ID() == uid {
+					continue
+				}
+				if sim := test.similarity(g, uid, v.ID()); sim > 0 {
+					want = append(want, SimilarPair{U: u, V: v, Similarity: sim})
+				}
+			}
+			sort.Slice(want, func(i, j int) bool {
+				if want[i].Similarity != want[j].Similarity {
+					return want[i].Similarity > want[j].Similarity
+				}
+				return want[i].V.ID() < want[j].V.ID()
+			})
+			if len(want) > k {
+				want = want[:k]
+			}
+			if len(got[uid]) != len(want) {
+				t.Errorf("unexpected number of %s similar nodes for node %d: got:%d want:%d",
+					test.name, uid, len(got[uid]), len(want))
+				continue
+			}
+			for i, p := range got[uid] {
+				if p.U.ID() != uid || p.V.ID() != want[i].V.ID() || p.Similarity != want[i].Similarity {
+					t.Errorf("unexpected %s similar node %d for node %d: got:%v want:%v",
+						test.name, i, uid, p, want[i])
+				}
+			}
+		}
+	}
+}
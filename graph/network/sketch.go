@@ -0,0 +1,301 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// SimilarPair is a pair of nodes with similar neighborhoods.
+type SimilarPair struct {
+	U, V       graph.Node
+	Similarity float64
+}
+
+// MinHash holds MinHash sketches of the neighborhoods of the nodes of a graph.
+// The neighborhood of a node is the set of nodes reachable from it by a single
+// edge. MinHash sketches estimate the Jaccard similarity of neighborhoods.
+//
+// See Broder, "On the resemblance and containment of documents", Compression
+// and Complexity of Sequences 1997 doi:10.1109/SEQUEN.1997.666900.
+type MinHash struct {
+	nodes  []graph.Node
+	seeds  []uint64
+	sketch map[int64][]uint64
+}
+
+// NewMinHash returns the MinHash sketches of the neighborhoods of the nodes in g,
+// using k hash functions. Larger values of k give more accurate estimates of
+// similarity; the standard error of the estimate is at most 1/(2*sqrt(k)). If src
+// is nil, the global random number generator is used to choose the hash
+// functions. NewMinHash will panic if k is less than 1.
+func NewMinHash(g graph.Graph, k int, src rand.Source) *MinHash {
+	if k < 1 {
+		panic("network: invalid number of hash functions")
+	}
+	m := MinHash{
+		nodes:  graph.NodesOf(g.Nodes()),
+		seeds:  randomSeeds(k, src),
+		sketch: make(map[int64][]uint64),
+	}
+	sort.Sort(ordered.ByID(m.nodes))
+	for _, u := range m.nodes {
+		uid := u.ID()
+		var sig []uint64
+		to := g.From(uid)
+		for to.Next() {
+			if sig == nil {
+				sig = make([]uint64, k)
+				for i := range sig {
+					sig[i] = math.MaxUint64
+				}
+			}
+			vid := uint64(to.Node().ID())
+			for i, s := range m.seeds {
+				if h := mix64(vid ^ s); h < sig[i] {
+					sig[i] = h
+				}
+			}
+		}
+		if sig != nil {
+			m.sketch[uid] = sig
+		}
+	}
+	return &m
+}
+
+// Sketch returns the MinHash signature of the neighborhood of the node with the
+// given ID. Sketch returns nil if the node has no neighbors or is not in the graph.
+// The returned slice must not be altered.
+func (m *MinHash) Sketch(id int64) []uint64 {
+	return m.sketch[id]
+}
+
+// Similarity returns the estimated Jaccard similarity of the neighborhoods of the
+// nodes with IDs uid and vid. Nodes without neighbors have a similarity of zero.
+func (m *MinHash) Similarity(uid, vid int64) float64 {
+	u, ok := m.sketch[uid]
+	if !ok {
+		return 0
+	}
+	v, ok := m.sketch[vid]
+	if !ok {
+		return 0
+	}
+	var n int
+	for i, h := range u {
+		if h == v[i] {
+			n++
+		}
+	}
+	return float64(n) / float64(len(u))
+}
+
+// SimilarPairs returns the pairs of distinct nodes with an estimated neighborhood
+// similarity of at least threshold. Candidate pairs are found by locality-sensitive
+// hashing, splitting the sketches into the given number of bands; pairs with a
+// similarity s are candidates with probability 1-(1-s^r)^bands where r is the number
+// of hash functions per band. Increasing bands finds more pairs at the cost of more
+// candidate comparisons. The returned pairs are ordered by the IDs of U and then V,
+// with U having the lower ID. SimilarPairs will panic if bands does not divide the
+// number of hash functions.
+func (m *MinHash) SimilarPairs(bands int, threshold float64) []SimilarPair {
+	k := len(m.seeds)
+	if bands < 1 || k%bands != 0 {
+		panic("network: invalid number of bands")
+	}
+	rows := k / bands
+	return similarPairs(m.nodes, bands, threshold,
+		func(id int64) bool {
+			_, ok := m.sketch[id]
+			return ok
+		},
+		func(id int64, band int) uint64 {
+			key := uint64(band)
+			for _, h := range m.sketch[id][band*rows : (band+1)*rows] {
+				key = mix64(key ^ h)
+			}
+			return key
+		},
+		m.Similarity,
+	)
+}
+
+// SimHash holds 64-bit SimHash fingerprints of the neighborhoods of the nodes of
+// a graph. The neighborhood of a node is the set of nodes reachable from it by a
+// single edge. SimHash fingerprints estimate the cosine similarity of neighborhoods.
+//
+// See Charikar, "Similarity estimation techniques from rounding algorithms",
+// STOC '02 doi:10.1145/509907.509965.
+type SimHash struct {
+	nodes       []graph.Node
+	fingerprint map[int64]uint64
+}
+
+// NewSimHash returns the SimHash fingerprints of the neighborhoods of the nodes in g.
+// If src is nil, the global random number generator is used to choose the hash
+// function.
+func NewSimHash(g graph.Graph, src rand.Source) *SimHash {
+	seed := randomSeeds(1, src)[0]
+	s := SimHash{
+		nodes:       graph.NodesOf(g.Nodes()),
+		fingerprint: make(map[int64]uint64),
+	}
+	sort.Sort(ordered.ByID(s.nodes))
+	var count [64]int
+	for _, u := range s.nodes {
+		uid := u.ID()
+		for i := range count {
+			count[i] = 0
+		}
+		var degree int
+		to := g.From(uid)
+		for to.Next() {
+			degree++
+			h := mix64(uint64(to.Node().ID()) ^ seed)
+			for i := range count {
+				if h&(1<<uint(i)) != 0 {
+					count[i]++
+				} else {
+					count[i]--
+				}
+			}
+		}
+		if degree == 0 {
+			continue
+		}
+		var f uint64
+		for i, c := range count {
+			if c > 0 {
+				f |= 1 << uint(i)
+			}
+		}
+		s.fingerprint[uid] = f
+	}
+	return &s
+}
+
+// Fingerprint returns the SimHash fingerprint of the neighborhood of the node with
+// the given ID and whether the node has any neighbors.
+func (s *SimHash) Fingerprint(id int64) (f uint64, ok bool) {
+	f, ok = s.fingerprint[id]
+	return f, ok
+}
+
+// Similarity returns the estimated cosine similarity of the neighborhoods of the
+// nodes with IDs uid and vid. Nodes without neighbors have a similarity of zero.
+func (s *SimHash) Similarity(uid, vid int64) float64 {
+	u, ok := s.fingerprint[uid]
+	if !ok {
+		return 0
+	}
+	v, ok := s.fingerprint[vid]
+	if !ok {
+		return 0
+	}
+	return math.Cos(math.Pi * float64(bits.OnesCount64(u^v)) / 64)
+}
+
+// SimilarPairs returns the pairs of distinct nodes with an estimated neighborhood
+// similarity of at least threshold. Candidate pairs are found by locality-sensitive
+// hashing, splitting the fingerprints into the given number of bands of bits. The
+// returned pairs are ordered by the IDs of U and then V, with U having the lower ID.
+// SimilarPairs will panic if bands does not divide 64.
+func (s *SimHash) SimilarPairs(bands int, threshold float64) []SimilarPair {
+	if bands < 1 || 64%bands != 0 {
+		panic("network: invalid number of bands")
+	}
+	width := uint(64 / bands)
+	var mask uint64 = math.MaxUint64
+	if width < 64 {
+		mask = 1<<width - 1
+	}
+	return similarPairs(s.nodes, bands, threshold,
+		func(id int64) bool {
+			_, ok := s.fingerprint[id]
+			return ok
+		},
+		func(id int64, band int) uint64 {
+			return s.fingerprint[id] >> (uint(band) * width) & mask
+		},
+		s.Similarity,
+	)
+}
+
+// similarPairs returns the pairs of nodes in nodes, which must be sorted by ID,
+// that share a band key in at least one band and have a similarity of at least
+// threshold.
+func similarPairs(nodes []graph.Node, bands int, threshold float64, has func(id int64) bool, key func(id int64, band int) uint64, similarity func(uid, vid int64) float64) []SimilarPair {
+	seen := make(map[[2]int]bool)
+	var pairs []SimilarPair
+	buckets := make(map[uint64][]int)
+	for band := 0; band < bands; band++ {
+		for k := range buckets {
+			delete(buckets, k)
+		}
+		for i, n := range nodes {
+			id := n.ID()
+			if !has(id) {
+				continue
+			}
+			k := key(id, band)
+			buckets[k] = append(buckets[k], i)
+		}
+		for _, b := range buckets {
+			for x, i := range b {
+				for _, j := range b[x+1:] {
+					if seen[[2]int{i, j}] {
+						continue
+					}
+					seen[[2]int{i, j}] = true
+					u, v := nodes[i], nodes[j]
+					if sim := similarity(u.ID(), v.ID()); sim >= threshold {
+						pairs = append(pairs, SimilarPair{U: u, V: v, Similarity: sim})
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		ui, uj := pairs[i].U.ID(), pairs[j].U.ID()
+		if ui != uj {
+			return ui < uj
+		}
+		return pairs[i].V.ID() < pairs[j].V.ID()
+	})
+	return pairs
+}
+
+// randomSeeds returns n random hash seeds drawn from src, or
+// from the global random number generator if src is nil.
+func randomSeeds(n int, src rand.Source) []uint64 {
+	next := rand.Uint64
+	if src != nil {
+		next = rand.New(src).Uint64
+	}
+	seeds := make([]uint64, n)
+	for i := range seeds {
+		seeds[i] = next()
+	}
+	return seeds
+}
+
+// mix64 is the SplitMix64 finalizer, a bijective
+// mixing function used to hash node IDs.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
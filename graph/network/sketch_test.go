@@ -0,0 +1,141 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// sketchGraph returns a bipartite graph where each of the nodes 0 to 39 is
+// connected to 20 random nodes with IDs of 100 and above. Node 1 has the same
+// neighbors as node 0 and node 3 shares all but one of node 2's neighbors.
+// Node 99 has no neighbors.
+func sketchGraph() *simple.UndirectedGraph {
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewUndirectedGraph()
+	g.AddNode(simple.Node(99))
+	neighbors := make([][]int64, 40)
+	for i := range neighbors {
+		switch i {
+		case 1:
+			neighbors[i] = neighbors[0]
+		case 3:
+			neighbors[i] = append([]int64{400}, neighbors[2][1:]...)
+		default:
+			seen := make(map[int64]bool)
+			for len(neighbors[i]) < 20 {
+				v := 100 + rnd.Int63n(300)
+				if seen[v] {
+					continue
+				}
+				seen[v] = true
+				neighbors[i] = append(neighbors[i], v)
+			}
+		}
+		for _, v := range neighbors[i] {
+			g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(v)})
+		}
+	}
+	return g
+}
+
+func neighborhood(g graph.Graph, id int64) map[int64]bool {
+	s := make(map[int64]bool)
+	for _, n := range graph.NodesOf(g.From(id)) {
+		s[n.ID()] = true
+	}
+	return s
+}
+
+func jaccard(a, b map[int64]bool) float64 {
+	var n int
+	for id := range a {
+		if b[id] {
+			n++
+		}
+	}
+	return float64(n) / float64(len(a)+len(b)-n)
+}
+
+func TestMinHash(t *testing.T) {
+	t.Parallel()
+	g := sketchGraph()
+	m := NewMinHash(g, 512, rand.NewSource(1))
+
+	for u := int64(0); u < 40; u++ {
+		for v := u + 1; v < 40; v++ {
+			want := jaccard(neighborhood(g, u), neighborhood(g, v))
+			got := m.Similarity(u, v)
+			if math.Abs(got-want) > 0.1 {
+				t.Errorf("unexpected similarity estimate for %d and %d: got:%v want:%v", u, v, got, want)
+			}
+		}
+	}
+	if m.Sketch(99) != nil {
+		t.Error("unexpected sketch for node without neighbors")
+	}
+	if got := m.Similarity(99, 99); got != 0 {
+		t.Errorf("unexpected similarity for node without neighbors: got:%v want:0", got)
+	}
+
+	const threshold = 0.8
+	pairs := m.SimilarPairs(128, threshold)
+	checkSimilarPairs(t, "MinHash", pairs, threshold, [][2]int64{{0, 1}, {2, 3}})
+	if pairs[0].Similarity != 1 {
+		t.Errorf("unexpected similarity for identical neighborhoods: got:%v want:1", pairs[0].Similarity)
+	}
+}
+
+func TestSimHash(t *testing.T) {
+	t.Parallel()
+	g := sketchGraph()
+	s := NewSimHash(g, rand.NewSource(1))
+
+	if got := s.Similarity(0, 1); got != 1 {
+		t.Errorf("unexpected similarity for identical neighborhoods: got:%v want:1", got)
+	}
+	if _, ok := s.Fingerprint(99); ok {
+		t.Error("unexpected fingerprint for node without neighbors")
+	}
+	if got := s.Similarity(99, 99); got != 0 {
+		t.Errorf("unexpected similarity for node without neighbors: got:%v want:0", got)
+	}
+
+	const threshold = 0.9
+	pairs := s.SimilarPairs(8, threshold)
+	checkSimilarPairs(t, "SimHash", pairs, threshold, [][2]int64{{0, 1}, {2, 3}})
+}
+
+func checkSimilarPairs(t *testing.T, name string, pairs []SimilarPair, threshold float64, want [][2]int64) {
+	t.Helper()
+	found := make(map[[2]int64]bool)
+	for i, p := range pairs {
+		uid, vid := p.U.ID(), p.V.ID()
+		if uid >= vid {
+			t.Errorf("%s: unexpected pair order: %d >= %d", name, uid, vid)
+		}
+		if i > 0 {
+			prev := pairs[i-1]
+			if prev.U.ID() > uid || (prev.U.ID() == uid && prev.V.ID() >= vid) {
+				t.Errorf("%s: pairs not sorted at index %d", name, i)
+			}
+		}
+		if p.Similarity < threshold {
+			t.Errorf("%s: unexpected pair %d and %d below threshold: %v", name, uid, vid, p.Similarity)
+		}
+		found[[2]int64{uid, vid}] = true
+	}
+	for _, w := range want {
+		if !found[w] {
+			t.Errorf("%s: expected pair %d and %d to be found", name, w[0], w[1])
+		}
+	}
+}
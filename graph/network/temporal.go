@@ -0,0 +1,165 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+)
+
+// Temporal is a graph whose edges can only be traversed at specific times.
+// It is the temporal graph type used for journeys by the path package. The
+// durations of a path.TimedTemporal are ignored by the temporal centrality
+// measures.
+type Temporal = path.Temporal
+
+// TemporalBetweenness returns the non-zero temporal betweenness centrality for
+// nodes in the temporal graph g.
+//
+//  C_TB(v) = \sum_{s ≠ v ≠ t ∈ V} (\sigma_{st}(v) / \sigma_{st})
+//
+// where \sigma_{st} and \sigma_{st}(v) are the number of shortest time-respecting
+// paths from s to t, and the subset of those paths containing v respectively.
+// A time-respecting path is a sequence of edge contacts with non-decreasing
+// times and shortest paths are those with the fewest edges. Paths that traverse
+// the same edges at different times are distinct.
+func TemporalBetweenness(g Temporal) map[int64]float64 {
+	cb := make(map[int64]float64)
+	temporalBrandes(g, func(s graph.Node, t *temporalTree) {
+		// Accumulate dependencies over the tree of
+		// time-respecting shortest paths from s in
+		// order of non-increasing distance.
+		delta := make([]float64, len(t.states))
+		for y := len(t.states) - 1; y >= 0; y-- {
+			sy := t.states[y]
+			var e float64
+			if sy.hops == t.dist[sy.id] {
+				e = sy.sigma / t.sigma[sy.id]
+			}
+			for _, x := range sy.preds {
+				delta[x] += t.states[x].sigma / sy.sigma * (e + delta[y])
+			}
+			if y != 0 && delta[y] != 0 {
+				cb[sy.id] += delta[y]
+			}
+		}
+	})
+	return cb
+}
+
+// TemporalCloseness returns the temporal closeness centrality for nodes in the
+// temporal graph g. Since temporal graphs are rarely connected by time-respecting
+// paths, the harmonic form of closeness is used.
+//
+//  C_TC(v) = \sum_{u ≠ v} 1 / d_T(u,v)
+//
+// where d_T(u,v) is the number of edges in the shortest time-respecting path
+// from u to v. A time-respecting path is a sequence of edge contacts with
+// non-decreasing times. Infinite distances are not considered.
+func TemporalCloseness(g Temporal) map[int64]float64 {
+	c := make(map[int64]float64)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		c[nodes.Node().ID()] = 0
+	}
+	temporalBrandes(g, func(s graph.Node, t *temporalTree) {
+		for id, d := range t.dist {
+			if id != s.ID() {
+				c[id] += 1 / float64(d)
+			}
+		}
+	})
+	return c
+}
+
+// temporalState is a node reached at a time
+// in a time-respecting search.
+type temporalState struct {
+	id   int64
+	time float64
+
+	hops  int
+	sigma float64
+	preds []int
+}
+
+// temporalTree is the tree of time-respecting
+// shortest paths from a source node.
+type temporalTree struct {
+	// states holds the states reached from
+	// the source in order of non-decreasing
+	// distance with the source state first.
+	states []temporalState
+
+	// dist and sigma hold the distance to
+	// and number of shortest paths to each
+	// node reached by the search.
+	dist  map[int64]int
+	sigma map[int64]float64
+}
+
+// temporalBrandes performs a breadth-first search over time-respecting paths from
+// each node in g, calling accumulate with the search tree for each source node. It
+// is the temporal analogue of brandes.
+func temporalBrandes(g Temporal, accumulate func(s graph.Node, t *temporalTree)) {
+	type key struct {
+		id   int64
+		time float64
+	}
+	nodes := g.Nodes()
+	for nodes.Next() {
+		s := nodes.Node()
+		sid := s.ID()
+		t := temporalTree{
+			states: []temporalState{{id: sid, time: math.Inf(-1), sigma: 1}},
+			dist:   map[int64]int{sid: 0},
+			sigma:  map[int64]float64{sid: 1},
+		}
+		indexOf := make(map[key]int)
+
+		// States are appended to t.states in breadth-first
+		// order, so t.states is the search queue.
+		for x := 0; x < len(t.states); x++ {
+			sx := t.states[x]
+			to := g.From(sx.id)
+			for to.Next() {
+				vid := to.Node().ID()
+				if vid == sid {
+					// Time-respecting paths returning to the
+					// source are never shortest since it is
+					// possible to wait at the source.
+					continue
+				}
+				contacts := g.Contacts(sx.id, vid)
+				for _, time := range contacts[sort.SearchFloat64s(contacts, sx.time):] {
+					k := key{id: vid, time: time}
+					y, ok := indexOf[k]
+					if !ok {
+						y = len(t.states)
+						indexOf[k] = y
+						t.states = append(t.states, temporalState{id: vid, time: time, hops: sx.hops + 1})
+						if _, ok := t.dist[vid]; !ok {
+							t.dist[vid] = sx.hops + 1
+						}
+					}
+					if t.states[y].hops == sx.hops+1 {
+						t.states[y].sigma += sx.sigma
+						t.states[y].preds = append(t.states[y].preds, x)
+					}
+				}
+			}
+		}
+		for _, st := range t.states[1:] {
+			if st.hops == t.dist[st.id] {
+				t.sigma[st.id] += st.sigma
+			}
+		}
+
+		accumulate(s, &t)
+	}
+}
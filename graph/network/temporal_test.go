@@ -0,0 +1,98 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// contactGraph is a directed Temporal graph.
+type contactGraph struct {
+	*simple.DirectedGraph
+	contacts map[[2]int64][]float64
+}
+
+func newContactGraph(contacts map[[2]int64][]float64) contactGraph {
+	g := contactGraph{DirectedGraph: simple.NewDirectedGraph(), contacts: contacts}
+	for e := range contacts {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	return g
+}
+
+func (g contactGraph) Contacts(uid, vid int64) []float64 {
+	return g.contacts[[2]int64{uid, vid}]
+}
+
+var temporalTests = []struct {
+	contacts map[[2]int64][]float64
+
+	wantBetweenness map[int64]float64
+	wantCloseness   map[int64]float64
+}{
+	{
+		// The static path A→B→E is not time-respecting,
+		// so the shortest time-respecting paths from A to
+		// E pass through C by way of either B or D.
+		contacts: map[[2]int64][]float64{
+			{A, B}: {1},
+			{B, C}: {0, 2},
+			{A, D}: {3},
+			{D, C}: {4},
+			{C, E}: {5},
+			{B, E}: {0},
+		},
+
+		wantBetweenness: map[int64]float64{
+			B: 1,
+			C: 2,
+			D: 1,
+		},
+		wantCloseness: map[int64]float64{
+			A: 0,
+			B: 1,
+			C: 2.5,
+			D: 1,
+			E: 1.0/3 + 1 + 1 + 0.5,
+		},
+	},
+}
+
+func TestTemporalBetweenness(t *testing.T) {
+	for i, test := range temporalTests {
+		g := newContactGraph(test.contacts)
+		got := TemporalBetweenness(g)
+		if len(got) != len(test.wantBetweenness) {
+			t.Errorf("unexpected number of results for test %d: got:%d want:%d", i, len(got), len(test.wantBetweenness))
+		}
+		for n, want := range test.wantBetweenness {
+			if !scalar.EqualWithinAbsOrRel(got[n], want, 1e-10, 1e-10) {
+				t.Errorf("unexpected temporal betweenness result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(got, 3), orderedFloats(test.wantBetweenness, 3))
+				break
+			}
+		}
+	}
+}
+
+func TestTemporalCloseness(t *testing.T) {
+	for i, test := range temporalTests {
+		g := newContactGraph(test.contacts)
+		got := TemporalCloseness(g)
+		if len(got) != len(test.wantCloseness) {
+			t.Errorf("unexpected number of results for test %d: got:%d want:%d", i, len(got), len(test.wantCloseness))
+		}
+		for n, want := range test.wantCloseness {
+			if !scalar.EqualWithinAbsOrRel(got[n], want, 1e-10, 1e-10) {
+				t.Errorf("unexpected temporal closeness result for test %d:\ngot: %v\nwant:%v",
+					i, orderedFloats(got, 3), orderedFloats(test.wantCloseness, 3))
+				break
+			}
+		}
+	}
+}
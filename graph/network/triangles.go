@@ -0,0 +1,134 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Triangles returns the number of triangles in the undirected graph g. If
+// fn is not nil, it is called once with the nodes of each triangle. Self
+// loops are ignored.
+//
+// Triangles uses the forward algorithm, which orients each edge from the
+// lower to the higher degree node and intersects the resulting out
+// neighborhoods, taking O(m^{3/2}) time for a graph with m edges.
+//
+// See https://doi.org/10.1016/j.tcs.2008.07.017 for details.
+func Triangles(g graph.Undirected, fn func(u, v, w graph.Node)) int {
+	f := newForward(g)
+	var n int
+	f.triangles(func(u, v, w int) {
+		n++
+		if fn != nil {
+			fn(f.nodes[u], f.nodes[v], f.nodes[w])
+		}
+	})
+	return n
+}
+
+// TriangleCounts returns the number of triangles that include each node
+// of the undirected graph g, keyed on node ID. Self loops are ignored.
+func TriangleCounts(g graph.Undirected) map[int64]int {
+	nodes, tri, _ := triangleCounts(g)
+	counts := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		counts[n.ID()] = tri[i]
+	}
+	return counts
+}
+
+// triangleCounts returns the nodes of g with the number of triangles
+// each node is in and its degree, ignoring self loops.
+func triangleCounts(g graph.Undirected) (nodes []graph.Node, tri, deg []int) {
+	f := newForward(g)
+	tri = make([]int, len(f.nodes))
+	f.triangles(func(u, v, w int) {
+		tri[u]++
+		tri[v]++
+		tri[w]++
+	})
+	return f.nodes, tri, f.deg
+}
+
+// forward is the degree oriented adjacency of an undirected graph
+// used by the forward triangle algorithm.
+type forward struct {
+	nodes []graph.Node
+
+	// deg holds the degree of each
+	// node ignoring self loops.
+	deg []int
+
+	// out holds the neighbors of each
+	// node with a higher degree rank.
+	out [][]int
+}
+
+// newForward returns the degree oriented adjacency of g.
+func newForward(g graph.Undirected) forward {
+	nodes := graph.NodesOf(g.Nodes())
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	deg := make([]int, len(nodes))
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			adj[i] = append(adj[i], indexOf[vid])
+		}
+		deg[i] = len(adj[i])
+	}
+
+	// Orient each edge from the lower to the higher
+	// ranked node where nodes are ranked by degree.
+	order := make([]int, len(nodes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		return deg[a] < deg[b] || (deg[a] == deg[b] && a < b)
+	})
+	rank := make([]int, len(nodes))
+	for r, i := range order {
+		rank[i] = r
+	}
+	out := make([][]int, len(nodes))
+	for i, a := range adj {
+		for _, j := range a {
+			if rank[j] > rank[i] {
+				out[i] = append(out[i], j)
+			}
+		}
+	}
+	return forward{nodes: nodes, deg: deg, out: out}
+}
+
+// triangles calls fn once with the node indices of each triangle.
+func (f forward) triangles(fn func(u, v, w int)) {
+	mark := make([]int, len(f.nodes))
+	for u := range f.out {
+		for _, v := range f.out[u] {
+			mark[v] = u + 1
+		}
+		for _, v := range f.out[u] {
+			for _, w := range f.out[v] {
+				if mark[w] == u+1 {
+					fn(u, v, w)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestTriangles(t *testing.T) {
+	for seed := uint64(0); seed < 5; seed++ {
+		g := simple.NewUndirectedGraph()
+		err := gen.Gnp(g, 40, 0.25, rand.NewSource(seed))
+		if err != nil {
+			t.Fatalf("unexpected error generating graph: %v", err)
+		}
+		nodes := graph.NodesOf(g.Nodes())
+		var want [][3]int64
+		wantCounts := make(map[int64]int)
+		for _, n := range nodes {
+			wantCounts[n.ID()] = 0
+		}
+		for i, u := range nodes {
+			for j := i + 1; j < len(nodes); j++ {
+				v := nodes[j]
+				if !g.HasEdgeBetween(u.ID(), v.ID()) {
+					continue
+				}
+				for _, w := range nodes[j+1:] {
+					if g.HasEdgeBetween(u.ID(), w.ID()) && g.HasEdgeBetween(v.ID(), w.ID()) {
+						want = append(want, sortedTriple(u, v, w))
+						wantCounts[u.ID()]++
+						wantCounts[v.ID()]++
+						wantCounts[w.ID()]++
+					}
+				}
+			}
+		}
+		sortTriples(want)
+
+		var got [][3]int64
+		n := Triangles(g, func(u, v, w graph.Node) {
+			got = append(got, sortedTriple(u, v, w))
+		})
+		sortTriples(got)
+		if n != len(want) {
+			t.Errorf("unexpected triangle count for seed %d: got:%d want:%d", seed, n, len(want))
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected triangles for seed %d:\ngot: %v\nwant:%v", seed, got, want)
+		}
+		if n := Triangles(g, nil); n != len(want) {
+			t.Errorf("unexpected triangle count without enumeration for seed %d: got:%d want:%d", seed, n, len(want))
+		}
+		if counts := TriangleCounts(g); !reflect.DeepEqual(counts, wantCounts) {
+			t.Errorf("unexpected per-node triangle counts for seed %d:\ngot: %v\nwant:%v", seed, counts, wantCounts)
+		}
+	}
+}
+
+func sortedTriple(u, v, w graph.Node) [3]int64 {
+	t := []int64{u.ID(), v.ID(), w.ID()}
+	sort.Slice(t, func(i, j int) bool { return t[i] < t[j] })
+	return [3]int64{t[0], t[1], t[2]}
+}
+
+func sortTriples(t [][3]int64) {
+	sort.Slice(t, func(i, j int) bool {
+		for k := range t[i] {
+			if t[i][k] != t[j][k] {
+				return t[i][k] < t[j][k]
+			}
+		}
+		return false
+	})
+}
@@ -5,8 +5,6 @@
 package path
 
 import (
-	"container/heap"
-
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/set"
 	"gonum.org/v1/gonum/graph/traverse"
@@ -25,6 +23,41 @@ import (
 // falling back to NullHeuristic otherwise. If the graph does not implement Weighted,
 // UniformCost is used. AStar will panic if g has an A*-reachable negative edge weight.
 func AStar(s, t graph.Node, g traverse.Graph, h Heuristic) (path Shortest, expanded int) {
+	return AStarWith(s, t, g, h, nil)
+}
+
+// AStarSettings holds the optional settings for AStarWith.
+type AStarSettings struct {
+	// Queue is the priority queue used to hold
+	// the open set of the search. If Queue is
+	// nil, a binary heap is used. Queue must
+	// be empty.
+	Queue AStarQueue
+
+	// Observer is notified of the progress of
+	// the search. A node is generated when it
+	// is first added to the open set and relaxed
+	// when a cheaper path to it is found while it
+	// is in the open set. If Observer is nil, no
+	// notification is made.
+	Observer Observer
+}
+
+// AStarWith is equivalent to AStar, but uses the queue and observer held in
+// settings. If settings is nil, AStarWith is equivalent to AStar. AStarWith
+// will panic if the queue in settings is not empty.
+func AStarWith(s, t graph.Node, g traverse.Graph, h Heuristic, settings *AStarSettings) (path Shortest, expanded int) {
+	var (
+		q   AStarQueue
+		obs Observer
+	)
+	if settings != nil {
+		q = settings.Queue
+		obs = settings.Observer
+	}
+	if obs != nil {
+		defer func() { obs.OnDone(expanded) }()
+	}
 	if g, ok := g.(graph.Graph); ok {
 		if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
 			return Shortest{from: s}, 0
@@ -47,22 +80,35 @@ func AStar(s, t graph.Node, g traverse.Graph, h Heuristic) (path Shortest, expan
 	path = newShortestFrom(s, []graph.Node{s, t})
 	tid := t.ID()
 
+	open := q
+	if open == nil {
+		open = NewBinaryHeap()
+	}
+	if open.Len() != 0 {
+		panic("path: A* queue not empty")
+	}
+
 	visited := make(set.Int64s)
-	open := &aStarQueue{indexOf: make(map[int64]int)}
-	heap.Push(open, aStarNode{node: s, gscore: 0, fscore: h(s, t)})
+	open.Push(s, 0, h(s, t))
+	if obs != nil {
+		obs.OnGenerate(s, 0)
+	}
 
 	for open.Len() != 0 {
-		u := heap.Pop(open).(aStarNode)
-		uid := u.node.ID()
+		u, gscore := open.Pop()
+		uid := u.ID()
 		i := path.indexOf[uid]
 		expanded++
+		if obs != nil {
+			obs.OnExpand(u, gscore)
+		}
 
 		if uid == tid {
 			break
 		}
 
 		visited.Add(uid)
-		to := g.From(u.node.ID())
+		to := g.From(uid)
 		for to.Next() {
 			v := to.Node()
 			vid := v.ID()
@@ -74,20 +120,26 @@ func AStar(s, t graph.Node, g traverse.Graph, h Heuristic) (path Shortest, expan
 				j = path.add(v)
 			}
 
-			w, ok := weight(u.node.ID(), vid)
+			w, ok := weight(uid, vid)
 			if !ok {
 				panic("path: A* unexpected invalid weight")
 			}
 			if w < 0 {
 				panic("path: A* negative edge weight")
 			}
-			g := u.gscore + w
-			if n, ok := open.node(vid); !ok {
+			g := gscore + w
+			if vg, ok := open.Score(vid); !ok {
 				path.set(j, g, i)
-				heap.Push(open, aStarNode{node: v, gscore: g, fscore: g + h(v, t)})
-			} else if g < n.gscore {
+				open.Push(v, g, g+h(v, t))
+				if obs != nil {
+					obs.OnGenerate(v, g)
+				}
+			} else if g < vg {
 				path.set(j, g, i)
-				open.update(vid, g, g+h(v, t))
+				open.Decrease(vid, g, g+h(v, t))
+				if obs != nil {
+					obs.OnRelax(u, v, g)
+				}
 			}
 		}
 	}
@@ -99,61 +151,3 @@ func AStar(s, t graph.Node, g traverse.Graph, h Heuristic) (path Shortest, expan
 func NullHeuristic(_, _ graph.Node) float64 {
 	return 0
 }
-
-// aStarNode adds A* accounting to a graph.Node.
-type aStarNode struct {
-	node   graph.Node
-	gscore float64
-	fscore float64
-}
-
-// aStarQueue is an A* priority queue.
-type aStarQueue struct {
-	indexOf map[int64]int
-	nodes   []aStarNode
-}
-
-func (q *aStarQueue) Less(i, j int) bool {
-	return q.nodes[i].fscore < q.nodes[j].fscore
-}
-
-func (q *aStarQueue) Swap(i, j int) {
-	q.indexOf[q.nodes[i].node.ID()] = j
-	q.indexOf[q.nodes[j].node.ID()] = i
-	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
-}
-
-func (q *aStarQueue) Len() int {
-	return len(q.nodes)
-}
-
-func (q *aStarQueue) Push(x interface{}) {
-	n := x.(aStarNode)
-	q.indexOf[n.node.ID()] = len(q.nodes)
-	q.nodes = append(q.nodes, n)
-}
-
-func (q *aStarQueue) Pop() interface{} {
-	n := q.nodes[len(q.nodes)-1]
-	q.nodes = q.nodes[:len(q.nodes)-1]
-	delete(q.indexOf, n.node.ID())
-	return n
-}
-
-func (q *aStarQueue) update(id int64, g, f float64) {
-	i, ok := q.indexOf[id]
-	if !ok {
-		return
-	}
-	q.nodes[i].gscore = g
-	q.nodes[i].fscore = f
-	heap.Fix(q, i)
-}
-
-func (q *aStarQueue) node(id int64) (aStarNode, bool) {
-	loc, ok := q.indexOf[id]
-	if ok {
-		return q.nodes[loc], true
-	}
-	return aStarNode{}, false
-}
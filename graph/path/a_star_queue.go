@@ -0,0 +1,266 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// AStarQueue is a priority queue holding the open set of an A* search.
+// Nodes are prioritized by their f-score, the sum of the cost of the path
+// to the node and the heuristic estimate of the cost from the node to the
+// target. Each node may be held in the queue at most once.
+type AStarQueue interface {
+	// Len returns the number of nodes in the queue.
+	Len() int
+
+	// Push adds n to the queue with the given
+	// g-score and f-score.
+	Push(n graph.Node, g, f float64)
+
+	// Pop removes and returns the node with the
+	// lowest f-score and its g-score.
+	Pop() (n graph.Node, g float64)
+
+	// Score returns the g-score of the node with
+	// the given ID and whether it is in the queue.
+	Score(id int64) (g float64, ok bool)
+
+	// Decrease lowers the g-score and f-score of
+	// the node with the given ID. If the node is
+	// not in the queue it is a no-op.
+	Decrease(id int64, g, f float64)
+}
+
+var (
+	_ AStarQueue = (*daryHeap)(nil)
+	_ AStarQueue = (*pairingHeap)(nil)
+)
+
+// aStarNode adds A* accounting to a graph.Node.
+type aStarNode struct {
+	node   graph.Node
+	gscore float64
+	fscore float64
+}
+
+// NewBinaryHeap returns an empty binary heap AStarQueue.
+// This is the queue used by AStar.
+func NewBinaryHeap() AStarQueue {
+	return NewDaryHeap(2)
+}
+
+// NewDaryHeap returns an empty d-ary heap AStarQueue. Heaps with higher
+// arity have shallower trees, making Push and Decrease cheaper and Pop
+// more expensive, which suits searches with many more edge relaxations
+// than node expansions. NewDaryHeap will panic if d is less than 2.
+func NewDaryHeap(d int) AStarQueue {
+	if d < 2 {
+		panic("path: heap arity less than 2")
+	}
+	return &daryHeap{d: d, indexOf: make(map[int64]int)}
+}
+
+// daryHeap is a d-ary heap AStarQueue.
+type daryHeap struct {
+	d       int
+	indexOf map[int64]int
+	nodes   []aStarNode
+}
+
+func (q *daryHeap) Len() int { return len(q.nodes) }
+
+func (q *daryHeap) Push(n graph.Node, g, f float64) {
+	q.indexOf[n.ID()] = len(q.nodes)
+	q.nodes = append(q.nodes, aStarNode{node: n, gscore: g, fscore: f})
+	q.up(len(q.nodes) - 1)
+}
+
+func (q *daryHeap) Pop() (graph.Node, float64) {
+	n := q.nodes[0]
+	last := len(q.nodes) - 1
+	q.swap(0, last)
+	q.nodes = q.nodes[:last]
+	delete(q.indexOf, n.node.ID())
+	if last != 0 {
+		q.down(0)
+	}
+	return n.node, n.gscore
+}
+
+func (q *daryHeap) Score(id int64) (float64, bool) {
+	i, ok := q.indexOf[id]
+	if !ok {
+		return 0, false
+	}
+	return q.nodes[i].gscore, true
+}
+
+func (q *daryHeap) Decrease(id int64, g, f float64) {
+	i, ok := q.indexOf[id]
+	if !ok {
+		return
+	}
+	q.nodes[i].gscore = g
+	q.nodes[i].fscore = f
+	q.up(i)
+}
+
+func (q *daryHeap) up(i int) {
+	for i > 0 {
+		p := (i - 1) / q.d
+		if q.nodes[p].fscore <= q.nodes[i].fscore {
+			break
+		}
+		q.swap(i, p)
+		i = p
+	}
+}
+
+func (q *daryHeap) down(i int) {
+	for {
+		first := q.d*i + 1
+		if first >= len(q.nodes) {
+			return
+		}
+		min := first
+		for c := first + 1; c < first+q.d && c < len(q.nodes); c++ {
+			if q.nodes[c].fscore < q.nodes[min].fscore {
+				min = c
+			}
+		}
+		if q.nodes[i].fscore <= q.nodes[min].fscore {
+			return
+		}
+		q.swap(i, min)
+		i = min
+	}
+}
+
+func (q *daryHeap) swap(i, j int) {
+	q.indexOf[q.nodes[i].node.ID()] = j
+	q.indexOf[q.nodes[j].node.ID()] = i
+	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
+}
+
+// NewPairingHeap returns an empty pairing heap AStarQueue. Pairing heaps
+// have constant time Push and amortized sub-logarithmic Decrease, and
+// perform well when the search makes many decrease-key operations.
+func NewPairingHeap() AStarQueue {
+	return &pairingHeap{nodeOf: make(map[int64]*pairingNode)}
+}
+
+// pairingHeap is a pairing heap AStarQueue.
+type pairingHeap struct {
+	root   *pairingNode
+	nodeOf map[int64]*pairingNode
+}
+
+// pairingNode is a node in a pairing heap. The children of a node are
+// held in a doubly linked list starting at child, with prev of the first
+// child pointing to the parent.
+type pairingNode struct {
+	aStarNode
+	child, next, prev *pairingNode
+}
+
+func (q *pairingHeap) Len() int { return len(q.nodeOf) }
+
+func (q *pairingHeap) Push(n graph.Node, g, f float64) {
+	p := &pairingNode{aStarNode: aStarNode{node: n, gscore: g, fscore: f}}
+	q.nodeOf[n.ID()] = p
+	q.root = meld(q.root, p)
+}
+
+func (q *pairingHeap) Pop() (graph.Node, float64) {
+	n := q.root
+	delete(q.nodeOf, n.node.ID())
+	q.root = mergePairs(n.child)
+	if q.root != nil {
+		q.root.prev = nil
+	}
+	return n.node, n.gscore
+}
+
+func (q *pairingHeap) Score(id int64) (float64, bool) {
+	p, ok := q.nodeOf[id]
+	if !ok {
+		return 0, false
+	}
+	return p.gscore, true
+}
+
+func (q *pairingHeap) Decrease(id int64, g, f float64) {
+	p, ok := q.nodeOf[id]
+	if !ok {
+		return
+	}
+	p.gscore = g
+	p.fscore = f
+	if p == q.root {
+		return
+	}
+
+	// Cut p from its parent's child list
+	// and meld it with the root.
+	if p.prev.child == p {
+		p.prev.child = p.next
+	} else {
+		p.prev.next = p.next
+	}
+	if p.next != nil {
+		p.next.prev = p.prev
+	}
+	p.next = nil
+	p.prev = nil
+	q.root = meld(q.root, p)
+}
+
+// meld returns the root of the heap formed by melding
+// the heaps rooted at a and b, which must be roots.
+func meld(a, b *pairingNode) *pairingNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.fscore < a.fscore {
+		a, b = b, a
+	}
+	b.prev = a
+	b.next = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs returns the root of the heap formed by
+// melding the sibling list starting at first with the
+// standard two-pass pairing strategy.
+func mergePairs(first *pairingNode) *pairingNode {
+	// Meld pairs left to right.
+	var pairs []*pairingNode
+	for first != nil {
+		a := first
+		b := a.next
+		if b == nil {
+			a.next, a.prev = nil, nil
+			pairs = append(pairs, a)
+			break
+		}
+		first = b.next
+		a.next, a.prev = nil, nil
+		b.next, b.prev = nil, nil
+		pairs = append(pairs, meld(a, b))
+	}
+
+	// Meld the pairs right to left.
+	var root *pairingNode
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = meld(pairs[i], root)
+	}
+	return root
+}
@@ -0,0 +1,122 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var aStarQueues = []struct {
+	name string
+	new  func() AStarQueue
+}{
+	{name: "binary", new: NewBinaryHeap},
+	{name: "4-ary", new: func() AStarQueue { return NewDaryHeap(4) }},
+	{name: "pairing", new: NewPairingHeap},
+}
+
+func TestAStarQueue(t *testing.T) {
+	t.Parallel()
+	for _, queue := range aStarQueues {
+		rnd := rand.New(rand.NewSource(1))
+		q := queue.new()
+		want := make(map[int64]float64)
+		var id int64
+		for i := 0; i < 10000; i++ {
+			switch op := rnd.Intn(4); {
+			case op < 2:
+				f := rnd.Float64()
+				q.Push(simple.Node(id), f, f)
+				want[id] = f
+				id++
+			case op == 2 && len(want) != 0:
+				// Decrease a random queued node.
+				vid := rnd.Int63n(id)
+				g, ok := q.Score(vid)
+				if _, queued := want[vid]; ok != queued {
+					t.Fatalf("%s: unexpected queued status for %d: got:%t want:%t", queue.name, vid, ok, queued)
+				}
+				if !ok {
+					continue
+				}
+				if g != want[vid] {
+					t.Fatalf("%s: unexpected score for %d: got:%v want:%v", queue.name, vid, g, want[vid])
+				}
+				g *= rnd.Float64()
+				q.Decrease(vid, g, g)
+				want[vid] = g
+			case op == 3 && len(want) != 0:
+				n, g := q.Pop()
+				for _, w := range want {
+					if w < g {
+						t.Fatalf("%s: popped score %v greater than queued score %v", queue.name, g, w)
+					}
+				}
+				if g != want[n.ID()] {
+					t.Fatalf("%s: unexpected popped score for %d: got:%v want:%v", queue.name, n.ID(), g, want[n.ID()])
+				}
+				delete(want, n.ID())
+			}
+			if q.Len() != len(want) {
+				t.Fatalf("%s: unexpected queue length: got:%d want:%d", queue.name, q.Len(), len(want))
+			}
+		}
+
+		var got []float64
+		for q.Len() != 0 {
+			_, g := q.Pop()
+			got = append(got, g)
+		}
+		if !sort.Float64sAreSorted(got) {
+			t.Errorf("%s: queue not drained in order", queue.name)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s: unexpected number of drained nodes: got:%d want:%d", queue.name, len(got), len(want))
+		}
+	}
+}
+
+func TestAStarWith(t *testing.T) {
+	t.Parallel()
+	for _, queue := range aStarQueues {
+		for _, test := range aStarTests {
+			want, wantExpanded := AStar(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic)
+			got, expanded := AStarWith(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic, &AStarSettings{Queue: queue.new()})
+			if got.WeightTo(test.t) != want.WeightTo(test.t) {
+				t.Errorf("unexpected cost for %q with %s queue: got:%v want:%v",
+					test.name, queue.name, got.WeightTo(test.t), want.WeightTo(test.t))
+			}
+			if queue.name == "binary" && expanded != wantExpanded {
+				t.Errorf("unexpected expanded count for %q with %s queue: got:%d want:%d",
+					test.name, queue.name, expanded, wantExpanded)
+			}
+		}
+	}
+}
+
+func BenchmarkAStarWith(b *testing.B) {
+	for _, queue := range aStarQueues {
+		for _, bm := range []struct {
+			name string
+			h    Heuristic
+		}{
+			{"NSW Undirected 100 5 10 2", nil},
+			{"NSW Undirected 100 5 10 2 heuristic", manhattan(100)},
+		} {
+			g := nswUndirected_100_5_10_2()
+			b.Run(fmt.Sprintf("%s %s", queue.name, bm.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					AStarWith(simple.Node(0), simple.Node(1), g, bm.h, &AStarSettings{Queue: queue.new()})
+				}
+			})
+		}
+	}
+}
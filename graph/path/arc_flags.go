@@ -0,0 +1,193 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// ArcFlags is an arc-flag preprocessing of a partitioned graph. Each edge
+// of the graph is flagged with the set of regions that it lies on a
+// shortest path toward, allowing shortest path searches to ignore edges
+// that do not lead toward the region holding the target.
+type ArcFlags struct {
+	g      graph.Graph
+	weight Weighting
+
+	// region holds the region index
+	// of each node in the graph.
+	region map[int64]int
+
+	// words is the number of words
+	// in the flag set of each edge.
+	words int
+	// flags holds the set of regions
+	// flagged for each edge.
+	flags map[[2]int64][]uint64
+}
+
+// NewArcFlags returns the arc-flag preprocessing of g using the node partition
+// in region, which maps node IDs to region indexes in [0, n) for some n. Every
+// node in g must be assigned a region. The partition may be obtained from any
+// graph partitioning or clustering, for example from community.Modularize, and
+// preprocessing is most effective when regions are compact and have few
+// boundary nodes.
+//
+// If the graph does not implement Weighted, UniformCost is used. NewArcFlags
+// will panic if g has a negative edge weight.
+//
+// The time complexity of NewArcFlags is O(|B|.|E|.log|V|) where B is the set
+// of region boundary nodes, nodes with an incoming edge from another region.
+func NewArcFlags(g graph.Graph, region map[int64]int) *ArcFlags {
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	var n int
+	for _, u := range nodes {
+		r, ok := region[u.ID()]
+		if !ok {
+			panic("path: node with no region")
+		}
+		if r < 0 {
+			panic("path: negative region index")
+		}
+		if r >= n {
+			n = r + 1
+		}
+	}
+
+	f := &ArcFlags{
+		g:      g,
+		weight: weight,
+		region: region,
+		words:  (n + 63) / 64,
+		flags:  make(map[[2]int64][]uint64),
+	}
+
+	// Flag all edges ending within their own region and
+	// collect the boundary nodes of each region.
+	var (
+		edges    [][2]int64
+		boundary []graph.Node
+		isBound  = make(map[int64]bool)
+	)
+	for _, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			edges = append(edges, [2]int64{uid, vid})
+			if region[uid] == region[vid] {
+				f.set(uid, vid, region[vid])
+			} else if !isBound[vid] {
+				isBound[vid] = true
+				boundary = append(boundary, v)
+			}
+		}
+	}
+
+	// Flag all edges on shortest paths toward each
+	// boundary node with the boundary node's region.
+	var rev graph.Graph = g
+	if d, ok := g.(graph.Directed); ok {
+		rev = reversed{Directed: d, weight: weight}
+	}
+	for _, b := range boundary {
+		r := region[b.ID()]
+		tree := DijkstraFrom(b, rev)
+		for _, e := range edges {
+			dv := tree.WeightTo(e[1])
+			if math.IsInf(dv, 1) {
+				continue
+			}
+			w, _ := weight(e[0], e[1])
+			if tree.WeightTo(e[0]) == dv+w {
+				f.set(e[0], e[1], r)
+			}
+		}
+	}
+
+	return f
+}
+
+// set adds the region r to the flags of the edge from uid to vid.
+func (f *ArcFlags) set(uid, vid int64, r int) {
+	e := [2]int64{uid, vid}
+	flags, ok := f.flags[e]
+	if !ok {
+		flags = make([]uint64, f.words)
+		f.flags[e] = flags
+	}
+	flags[r/64] |= 1 << uint(r%64)
+}
+
+// has returns whether the edge from uid to vid is flagged with region r.
+func (f *ArcFlags) has(uid, vid int64, r int) bool {
+	flags, ok := f.flags[[2]int64{uid, vid}]
+	return ok && flags[r/64]&(1<<uint(r%64)) != 0
+}
+
+// Shortest returns the shortest path from s to t in the preprocessed graph. The
+// search is performed with Dijkstra's algorithm, only following edges flagged as
+// leading toward the region of t, and terminates when t is reached. The path and
+// its cost are returned in a Shortest along with paths and costs to all nodes
+// explored during the search. The number of expanded nodes is also returned.
+func (f *ArcFlags) Shortest(s, t graph.Node) (path Shortest, expanded int) {
+	if f.g == nil {
+		panic("path: arc-flag preprocessing has no graph")
+	}
+	if f.g.Node(s.ID()) == nil || f.g.Node(t.ID()) == nil {
+		return Shortest{from: s}, 0
+	}
+	path = newShortestFrom(s, []graph.Node{s, t})
+	tid := t.ID()
+	r := f.region[tid]
+
+	Q := getQueue(distanceNode{node: s, dist: 0})
+	defer putQueue(Q)
+	for Q.Len() != 0 {
+		mid := Q.pop()
+		k := path.indexOf[mid.node.ID()]
+		if mid.dist > path.dist[k] {
+			continue
+		}
+		expanded++
+		mnid := mid.node.ID()
+		if mnid == tid {
+			break
+		}
+		to := f.g.From(mnid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if !f.has(mnid, vid, r) {
+				continue
+			}
+			j, ok := path.indexOf[vid]
+			if !ok {
+				j = path.add(v)
+			}
+			w, ok := f.weight(mnid, vid)
+			if !ok {
+				panic("arc flags: unexpected invalid weight")
+			}
+			joint := path.dist[k] + w
+			if joint < path.dist[j] {
+				Q.push(distanceNode{node: v, dist: joint})
+				path.set(j, joint, k)
+			}
+		}
+	}
+
+	return path, expanded
+}
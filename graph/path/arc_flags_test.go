@@ -0,0 +1,122 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestArcFlags(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+		gg := g.(graph.Graph)
+
+		for _, part := range []struct {
+			name   string
+			region func(id int64) int
+		}{
+			{name: "single", region: func(int64) int { return 0 }},
+			{name: "parity", region: func(id int64) int { return int(id & 1) }},
+			{name: "singletons"},
+		} {
+			region := make(map[int64]int)
+			nodes := graph.NodesOf(gg.Nodes())
+			for i, n := range nodes {
+				if part.region == nil {
+					region[n.ID()] = i
+				} else {
+					region[n.ID()] = part.region(n.ID())
+				}
+			}
+
+			pt, _ := NewArcFlags(gg, region).Shortest(test.Query.From(), test.Query.To())
+			p, weight := pt.To(test.Query.To().ID())
+			if weight != test.Weight {
+				t.Errorf("%q %s: unexpected weight from To: got:%f want:%f",
+					test.Name, part.name, weight, test.Weight)
+			}
+
+			var got []int64
+			for _, n := range p {
+				got = append(got, n.ID())
+			}
+			ok := len(got) == 0 && len(test.WantPaths) == 0
+			for _, sp := range test.WantPaths {
+				if reflect.DeepEqual(got, sp) {
+					ok = true
+					break
+				}
+			}
+			if !ok {
+				t.Errorf("%q %s: unexpected shortest path:\ngot: %v\nwant from:%v",
+					test.Name, part.name, p, test.WantPaths)
+			}
+		}
+	}
+}
+
+func TestArcFlagsGrid(t *testing.T) {
+	t.Parallel()
+	const size = 8
+	for _, directed := range []bool{false, true} {
+		var g interface {
+			graph.Weighted
+			graph.WeightedEdgeAdder
+		}
+		if directed {
+			g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		}
+		id := func(r, c int) simple.Node { return simple.Node(r*size + c) }
+		region := make(map[int64]int)
+		for r := 0; r < size; r++ {
+			for c := 0; c < size; c++ {
+				region[int64(id(r, c))] = 2*(r/(size/2)) + c/(size/2)
+				w := float64(1 + (r*c)%3)
+				if c+1 < size {
+					g.SetWeightedEdge(simple.WeightedEdge{F: id(r, c), T: id(r, c+1), W: w})
+				}
+				if r+1 < size {
+					g.SetWeightedEdge(simple.WeightedEdge{F: id(r, c), T: id(r+1, c), W: w})
+				}
+				if directed && r > 0 && c > 0 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: id(r, c), T: id(r-1, c-1), W: 2 * w})
+				}
+			}
+		}
+
+		flags := NewArcFlags(g, region)
+		var expanded, full int
+		for _, s := range graph.NodesOf(g.Nodes()) {
+			want := DijkstraFrom(s, g)
+			for _, u := range graph.NodesOf(g.Nodes()) {
+				got, n := flags.Shortest(s, u)
+				if got.WeightTo(u.ID()) != want.WeightTo(u.ID()) {
+					t.Errorf("unexpected weight from %d to %d directed=%t: got:%f want:%f",
+						s.ID(), u.ID(), directed, got.WeightTo(u.ID()), want.WeightTo(u.ID()))
+				}
+				expanded += n
+				full += len(want.nodes)
+			}
+		}
+		if expanded >= full {
+			t.Errorf("arc flags did not reduce search space directed=%t: expanded %d of %d", directed, expanded, full)
+		}
+	}
+}
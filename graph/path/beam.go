@@ -0,0 +1,138 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// BeamSearch finds a path from s to t in g using a layered best-first search that
+// retains at most width frontier nodes in each layer. Successors of the current
+// layer are ranked by the sum of their path cost from s and the heuristic estimate
+// of their cost to t under h, and only the best width are expanded in the next
+// layer. The path and its cost are returned in a Shortest along with paths and
+// costs to all nodes retained during the search. The number of expanded nodes is
+// also returned.
+//
+// BeamSearch bounds the memory used by the search to O(width) frontier nodes and
+// the number of expansions to width per layer, but is not complete; the returned
+// path may not be the shortest path and no path may be found even if one exists.
+// Increasing width trades time and memory for path quality.
+//
+// If h is nil, BeamSearch will use the g.HeuristicCost method if g implements
+// HeuristicCoster, falling back to NullHeuristic otherwise. If the graph does not
+// implement Weighted, UniformCost is used. BeamSearch will panic if width is less
+// than one or if g has a reachable negative edge weight.
+func BeamSearch(s, t graph.Node, g traverse.Graph, h Heuristic, width int) (path Shortest, expanded int) {
+	if width < 1 {
+		panic("path: beam width must be positive")
+	}
+	if g, ok := g.(graph.Graph); ok {
+		if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+			return Shortest{from: s}, 0
+		}
+	}
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+	if h == nil {
+		if g, ok := g.(HeuristicCoster); ok {
+			h = g.HeuristicCost
+		} else {
+			h = NullHeuristic
+		}
+	}
+
+	path = newShortestFrom(s, []graph.Node{s, t})
+	tid := t.ID()
+
+	beam := []beamNode{{node: s, f: h(s, t)}}
+	visited := make(set.Int64s)
+	var (
+		next    []beamNode
+		indexOf = make(map[int64]int)
+	)
+	for len(beam) != 0 {
+		next = next[:0]
+		for k := range indexOf {
+			delete(indexOf, k)
+		}
+		for _, u := range beam {
+			uid := u.node.ID()
+			if uid == tid {
+				return path, expanded
+			}
+			expanded++
+			visited.Add(uid)
+		}
+
+		for _, u := range beam {
+			uid := u.node.ID()
+			i := path.indexOf[uid]
+			to := g.From(uid)
+			for to.Next() {
+				v := to.Node()
+				vid := v.ID()
+				if visited.Has(vid) {
+					continue
+				}
+				w, ok := weight(uid, vid)
+				if !ok {
+					panic("path: beam search unexpected invalid weight")
+				}
+				if w < 0 {
+					panic("path: beam search negative edge weight")
+				}
+				g := path.dist[i] + w
+				if j, ok := path.indexOf[vid]; ok && g >= path.dist[j] {
+					continue
+				}
+				c := beamNode{node: v, from: i, g: g, f: g + h(v, t)}
+				if k, ok := indexOf[vid]; ok {
+					if g < next[k].g {
+						next[k] = c
+					}
+					continue
+				}
+				indexOf[vid] = len(next)
+				next = append(next, c)
+			}
+		}
+
+		sort.SliceStable(next, func(i, j int) bool { return next[i].f < next[j].f })
+		if len(next) > width {
+			next = next[:width]
+		}
+		for _, v := range next {
+			j, ok := path.indexOf[v.node.ID()]
+			if !ok {
+				j = path.add(v.node)
+			}
+			path.set(j, v.g, v.from)
+		}
+		beam, next = next, beam
+	}
+
+	return path, expanded
+}
+
+// beamNode is a frontier node in a beam search.
+type beamNode struct {
+	node graph.Node
+	// from is the index of the
+	// node's parent in the path.
+	from int
+	// g and f are the path cost
+	// from the source and the
+	// estimated total cost.
+	g, f float64
+}
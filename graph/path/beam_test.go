@@ -0,0 +1,93 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestBeamSearch(t *testing.T) {
+	t.Parallel()
+	for _, test := range aStarTests {
+		if test.name == "large open graph" {
+			// An unbounded beam on this graph is
+			// slow and is not informative.
+			continue
+		}
+
+		bfp, ok := BellmanFordFrom(simple.Node(test.s), test.g)
+		if !ok {
+			t.Fatalf("unexpected negative cycle in %q", test.name)
+		}
+		want := bfp.WeightTo(test.t)
+
+		// A beam wider than the graph is a
+		// complete breadth-limited search.
+		pt, _ := BeamSearch(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic, test.g.Nodes().Len())
+		p, cost := pt.To(test.t)
+		if !topo.IsPathIn(test.g, p) {
+			t.Errorf("got path that is not path in input graph for %q", test.name)
+		}
+		if cost != want {
+			t.Errorf("unexpected cost for %q: got:%v want:%v", test.name, cost, want)
+		}
+	}
+}
+
+func TestBeamSearchWidth(t *testing.T) {
+	t.Parallel()
+	g := testgraphs.NewGridFrom(
+		".....",
+		".*.*.",
+		".*.*.",
+		".***.",
+		".....",
+	)
+	s := g.NodeAt(0, 2)
+	d := g.NodeAt(4, 2)
+	manhattan := func(u, v graph.Node) float64 {
+		ur, uc := g.RowCol(u.ID())
+		vr, vc := g.RowCol(v.ID())
+		return math.Abs(float64(ur-vr)) + math.Abs(float64(uc-vc))
+	}
+
+	// The greedy search runs into the pocket
+	// below the source and, when the beam is
+	// narrow, loses the nodes that lead around
+	// the pocket.
+	for _, test := range []struct {
+		width     int
+		wantFound bool
+	}{
+		{width: 1, wantFound: false},
+		{width: 2, wantFound: true},
+		{width: 100, wantFound: true},
+	} {
+		pt, expanded := BeamSearch(s, d, g, manhattan, test.width)
+		p, cost := pt.To(d.ID())
+		if found := p != nil; found != test.wantFound {
+			t.Errorf("unexpected search result for width %d: got:%t want:%t", test.width, found, test.wantFound)
+			continue
+		}
+		if !test.wantFound {
+			continue
+		}
+		if !topo.IsPathIn(g, p) {
+			t.Errorf("got path that is not path in input graph for width %d", test.width)
+		}
+		if cost != 8 {
+			t.Errorf("unexpected cost for width %d: got:%v want:8", test.width, cost)
+		}
+		if lim := test.width * (int(cost) + 1); expanded > lim {
+			t.Errorf("unexpected number of expanded nodes for width %d: got:%d want:<=%d", test.width, expanded, lim)
+		}
+	}
+}
@@ -178,3 +178,161 @@ func BenchmarkBellmanFordFrom(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkDijkstraFrom(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		graph graph.Directed
+	}{
+		{"500 tenth", gnpDirected_500_tenth()},
+		{"1000 tenth", gnpDirected_1000_tenth()},
+		{"2000 tenth", gnpDirected_2000_tenth()},
+		{"500 half", gnpDirected_500_half()},
+		{"1000 half", gnpDirected_1000_half()},
+		{"2000 half", gnpDirected_2000_half()},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				DijkstraFrom(bm.graph.Node(0), bm.graph)
+			}
+		})
+	}
+}
+
+func BenchmarkDialFrom(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		graph graph.Directed
+	}{
+		{"500 tenth", gnpDirected_500_tenth()},
+		{"1000 tenth", gnpDirected_1000_tenth()},
+		{"2000 tenth", gnpDirected_2000_tenth()},
+		{"500 half", gnpDirected_500_half()},
+		{"1000 half", gnpDirected_1000_half()},
+		{"2000 half", gnpDirected_2000_half()},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				DialFrom(bm.graph.Node(0), bm.graph, 1)
+			}
+		})
+	}
+}
+
+func BenchmarkPriorityQueue(b *testing.B) {
+	for _, n := range []int{10, 1000, 100000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.ReportAllocs()
+			nodes := make([]distanceNode, n)
+			for i := range nodes {
+				nodes[i] = distanceNode{node: simple.Node(i), dist: float64((i * 7919) % n)}
+			}
+			for i := 0; i < b.N; i++ {
+				q := getQueue(nodes[0])
+				for _, v := range nodes[1:] {
+					q.push(v)
+				}
+				for q.Len() != 0 {
+					q.pop()
+				}
+				putQueue(q)
+			}
+		})
+	}
+}
+
+func BenchmarkGoldbergRadzikFrom(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		graph graph.Directed
+	}{
+		{"500 tenth", gnpDirected_500_tenth()},
+		{"1000 tenth", gnpDirected_1000_tenth()},
+		{"2000 tenth", gnpDirected_2000_tenth()},
+		{"500 half", gnpDirected_500_half()},
+		{"1000 half", gnpDirected_1000_half()},
+		{"2000 half", gnpDirected_2000_half()},
+		{"500 full", gnpDirected_500_full()},
+		{"1000 full", gnpDirected_1000_full()},
+		{"2000 full", gnpDirected_2000_full()},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				GoldbergRadzikFrom(bm.graph.Node(0), bm.graph)
+			}
+		})
+	}
+}
+
+func BenchmarkSPFAFrom(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		graph graph.Directed
+	}{
+		{"500 tenth", gnpDirected_500_tenth()},
+		{"1000 tenth", gnpDirected_1000_tenth()},
+		{"2000 tenth", gnpDirected_2000_tenth()},
+		{"500 half", gnpDirected_500_half()},
+		{"1000 half", gnpDirected_1000_half()},
+		{"2000 half", gnpDirected_2000_half()},
+		{"500 full", gnpDirected_500_full()},
+		{"1000 full", gnpDirected_1000_full()},
+		{"2000 full", gnpDirected_2000_full()},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				SPFAFrom(bm.graph.Node(0), bm.graph)
+			}
+		})
+	}
+}
+
+func BenchmarkBidirectionalBreadthFirst(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		graph graph.Undirected
+	}{
+		{"GNP Undirected 1000 tenth", gnpUndirected_1000_tenth()},
+		{"NSW Undirected 100 5 10 2", nswUndirected_100_5_10_2()},
+		{"NSW Undirected 100 5 20 2", nswUndirected_100_5_20_2()},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BidirectionalBreadthFirst(simple.Node(0), simple.Node(1), bm.graph)
+			}
+		})
+	}
+}
+
+func BenchmarkDijkstraAllPaths(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		graph graph.Directed
+	}{
+		{"500 tenth", gnpDirected_500_tenth()},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				DijkstraAllPaths(bm.graph)
+			}
+		})
+		b.Run(bm.name+" parallel", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				DijkstraAllPathsParallel(bm.graph, 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// BidirectionalBreadthFirst returns a path from s to t in g with the fewest
+// edges and the number of edges in the path. Edge weights are ignored. If there
+// is no path from s to t, path is nil and hops is -1.
+//
+// The search alternates breadth-first expansion of a frontier from s along
+// edges and a frontier from t against edges, always expanding the smaller of
+// the two frontiers, and stops when the frontiers meet. In graphs with a large
+// branching factor this expands far fewer nodes than a single breadth-first
+// search or AStar with UniformCost. If g is directed, the frontier from t is
+// expanded using the To method of g.
+func BidirectionalBreadthFirst(s, t graph.Node, g graph.Graph) (path []graph.Node, hops int) {
+	sid := s.ID()
+	tid := t.ID()
+	if g.Node(sid) == nil || g.Node(tid) == nil {
+		return nil, -1
+	}
+	if sid == tid {
+		return []graph.Node{s}, 0
+	}
+
+	to := g.From
+	if d, ok := g.(graph.Directed); ok {
+		to = d.To
+	}
+	fwd := newBFSFrontier(s, g.From)
+	rev := newBFSFrontier(t, to)
+
+	for len(fwd.level) != 0 && len(rev.level) != 0 {
+		this, other := fwd, rev
+		if len(rev.level) < len(fwd.level) {
+			this, other = rev, fwd
+		}
+		meet, ok := this.expand(other)
+		if !ok {
+			continue
+		}
+
+		path = fwd.pathTo(meet)
+		ordered.Reverse(path)
+		path = append(path, rev.pathTo(meet)[1:]...)
+		return path, len(path) - 1
+	}
+	return nil, -1
+}
+
+// bfsFrontier is one side of a bidirectional breadth-first search.
+type bfsFrontier struct {
+	// from returns the nodes adjacent
+	// to a node in the direction of
+	// the search.
+	from func(id int64) graph.Nodes
+
+	// level is the set of nodes at the
+	// current maximum search depth.
+	level []graph.Node
+
+	// parent and depth hold the search
+	// tree for the visited nodes.
+	parent map[int64]graph.Node
+	depth  map[int64]int
+}
+
+func newBFSFrontier(u graph.Node, from func(id int64) graph.Nodes) *bfsFrontier {
+	return &bfsFrontier{
+		from:   from,
+		level:  []graph.Node{u},
+		parent: map[int64]graph.Node{u.ID(): nil},
+		depth:  map[int64]int{u.ID(): 0},
+	}
+}
+
+// expand advances f by one level. If a node in the new level has been
+// visited by other, expand returns the meeting node on a shortest path
+// through the two search trees and true. The complete level is expanded
+// before returning so that the shortest connection is found.
+func (f *bfsFrontier) expand(other *bfsFrontier) (meet graph.Node, ok bool) {
+	var next []graph.Node
+	best := -1
+	for _, u := range f.level {
+		uid := u.ID()
+		d := f.depth[uid] + 1
+		it := f.from(uid)
+		for it.Next() {
+			v := it.Node()
+			vid := v.ID()
+			if _, seen := f.depth[vid]; seen {
+				continue
+			}
+			f.parent[vid] = u
+			f.depth[vid] = d
+			next = append(next, v)
+			if od, met := other.depth[vid]; met && (best < 0 || d+od < best) {
+				meet = v
+				best = d + od
+			}
+		}
+	}
+	f.level = next
+	return meet, best >= 0
+}
+
+// pathTo returns the path from n to the root of the search tree of f.
+func (f *bfsFrontier) pathTo(n graph.Node) []graph.Node {
+	var path []graph.Node
+	for n != nil {
+		path = append(path, n)
+		n = f.parent[n.ID()]
+	}
+	return path
+}
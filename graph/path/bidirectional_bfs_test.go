@@ -0,0 +1,92 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestBidirectionalBreadthFirst(t *testing.T) {
+	t.Parallel()
+	testFewestHops(t, BidirectionalBreadthFirst)
+}
+
+// testFewestHops tests a function returning a path with the fewest
+// edges and its length against the results of DijkstraFrom.
+func testFewestHops(t *testing.T, fewestHops func(s, t graph.Node, g graph.Graph) ([]graph.Node, int)) {
+	for _, directed := range []bool{false, true} {
+		for seed := uint64(1); seed <= 20; seed++ {
+			var g interface {
+				graph.Graph
+				graph.Builder
+			}
+			if directed {
+				g = simple.NewDirectedGraph()
+			} else {
+				g = simple.NewUndirectedGraph()
+			}
+			const n = 60
+			err := gen.Gnp(g, n, 0.04, rand.NewSource(seed))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := DijkstraFrom(simple.Node(0), g)
+			for id := int64(0); id < n; id++ {
+				path, hops := fewestHops(simple.Node(0), simple.Node(id), g)
+				wantHops := want.WeightTo(id)
+				if math.IsInf(wantHops, 1) {
+					if path != nil || hops != -1 {
+						t.Errorf("directed=%t seed=%d: unexpected path to %d: got:%v hops=%d want:<nil> hops=-1",
+							directed, seed, id, path, hops)
+					}
+					continue
+				}
+				if float64(hops) != wantHops {
+					t.Errorf("directed=%t seed=%d: unexpected hops to %d: got:%d want:%v",
+						directed, seed, id, hops, wantHops)
+				}
+				if len(path) != hops+1 {
+					t.Errorf("directed=%t seed=%d: unexpected path length to %d: got:%d want:%d",
+						directed, seed, id, len(path), hops+1)
+					continue
+				}
+				if path[0].ID() != 0 || path[len(path)-1].ID() != id {
+					t.Errorf("directed=%t seed=%d: unexpected path end points: got:%v want:0->%d",
+						directed, seed, path, id)
+				}
+				for i := 1; i < len(path); i++ {
+					if !g.HasEdgeBetween(path[i-1].ID(), path[i].ID()) ||
+						(directed && !g.(graph.Directed).HasEdgeFromTo(path[i-1].ID(), path[i].ID())) {
+						t.Errorf("directed=%t seed=%d: path to %d uses missing edge %d->%d",
+							directed, seed, id, path[i-1].ID(), path[i].ID())
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBidirectionalBreadthFirstMissing(t *testing.T) {
+	t.Parallel()
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+
+	path, hops := BidirectionalBreadthFirst(simple.Node(0), simple.Node(0), g)
+	if len(path) != 1 || path[0].ID() != 0 || hops != 0 {
+		t.Errorf("unexpected result for self path: got:%v hops=%d want:[0] hops=0", path, hops)
+	}
+	path, hops = BidirectionalBreadthFirst(simple.Node(0), simple.Node(2), g)
+	if path != nil || hops != -1 {
+		t.Errorf("unexpected result for absent node: got:%v hops=%d want:<nil> hops=-1", path, hops)
+	}
+}
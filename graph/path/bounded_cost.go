@@ -0,0 +1,122 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// BoundedCost finds a path from s to t in g with a cost no greater than budget
+// using the heuristic h. The search returns as soon as any such path is found,
+// rather than searching for the shortest path. The path and its cost are returned
+// in a Shortest along with paths and costs to all nodes explored during the search.
+// The number of expanded nodes is also returned. If no path within the budget
+// is found, the path to t held by the returned Shortest is nil.
+//
+// BoundedCost is a potential search; nodes are expanded in decreasing order of
+// the potential (budget-g(n))/h(n), where g(n) is the cost of the path to n and
+// h(n) is the heuristic estimate of the cost from n to t, so nodes most likely to
+// lie on a path within the budget are explored first. If h is admissible, nodes
+// that cannot lie on a path within the budget are pruned. The search depends on
+// an informative heuristic; with NullHeuristic no ordering is imposed on the
+// search.
+//
+// If h is nil, BoundedCost will use the g.HeuristicCost method if g implements
+// HeuristicCoster, falling back to NullHeuristic otherwise. If the graph does not
+// implement Weighted, UniformCost is used. BoundedCost will panic if g has a
+// reachable negative edge weight.
+//
+// See Stern, Puzis and Felner, "Potential Search: A Bounded-Cost Search Algorithm",
+// ICAPS 2011.
+func BoundedCost(s, t graph.Node, g traverse.Graph, h Heuristic, budget float64) (path Shortest, expanded int) {
+	if g, ok := g.(graph.Graph); ok {
+		if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+			return Shortest{from: s}, 0
+		}
+	}
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+	if h == nil {
+		if g, ok := g.(HeuristicCoster); ok {
+			h = g.HeuristicCost
+		} else {
+			h = NullHeuristic
+		}
+	}
+
+	path = newShortestFrom(s, []graph.Node{s, t})
+	tid := t.ID()
+	if s.ID() == tid || budget < h(s, t) {
+		return path, 0
+	}
+
+	// The queue is ordered by the inverse of the
+	// potential so that the node with the highest
+	// potential is at the head of the queue.
+	inversePotential := func(g, h float64) float64 {
+		if h == 0 {
+			return 0
+		}
+		return h / (budget - g)
+	}
+
+	open := NewBinaryHeap()
+	open.Push(s, 0, inversePotential(0, h(s, t)))
+
+	for open.Len() != 0 {
+		u, gscore := open.Pop()
+		uid := u.ID()
+		i := path.indexOf[uid]
+		expanded++
+
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("path: bounded cost unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("path: bounded cost negative edge weight")
+			}
+			g := gscore + w
+			j, ok := path.indexOf[vid]
+			if ok && g >= path.dist[j] {
+				continue
+			}
+			if vid == tid {
+				if g <= budget {
+					path.set(j, g, i)
+					return path, expanded
+				}
+				continue
+			}
+			hv := h(v, t)
+			if g+hv > budget {
+				continue
+			}
+			if !ok {
+				j = path.add(v)
+			}
+			path.set(j, g, i)
+			if _, ok := open.Score(vid); ok {
+				open.Decrease(vid, g, inversePotential(g, hv))
+			} else {
+				// Nodes that have already been expanded
+				// are reopened when reached by a cheaper
+				// path.
+				open.Push(v, g, inversePotential(g, hv))
+			}
+		}
+	}
+
+	return path, expanded
+}
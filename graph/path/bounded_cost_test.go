@@ -0,0 +1,71 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestBoundedCost(t *testing.T) {
+	t.Parallel()
+	for _, test := range aStarTests {
+		if test.name == "large open graph" {
+			continue
+		}
+
+		bfp, ok := BellmanFordFrom(simple.Node(test.s), test.g)
+		if !ok {
+			t.Fatalf("unexpected negative cycle in %q", test.name)
+		}
+		best := bfp.WeightTo(test.t)
+
+		for _, budget := range []float64{best - 1, best, 1.5 * best, math.Inf(1)} {
+			pt, _ := BoundedCost(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic, budget)
+			p, cost := pt.To(test.t)
+
+			if math.IsInf(best, 1) || best > budget {
+				if p != nil {
+					t.Errorf("unexpected path for %q with budget %v: got:%v cost=%v", test.name, budget, p, cost)
+				}
+				continue
+			}
+			if p == nil {
+				t.Errorf("expected path for %q with budget %v", test.name, budget)
+				continue
+			}
+			if !topo.IsPathIn(test.g, p) {
+				t.Errorf("got path that is not path in input graph for %q", test.name)
+			}
+			if cost > budget || cost < best {
+				t.Errorf("unexpected cost for %q with budget %v: got:%v want in [%v,%v]",
+					test.name, budget, cost, best, budget)
+			}
+		}
+	}
+}
+
+func TestBoundedCostExpansions(t *testing.T) {
+	t.Parallel()
+	test := aStarTests[len(aStarTests)-1]
+	if test.heuristic == nil {
+		t.Fatal("test requires a heuristic")
+	}
+
+	_, aStarExpanded := AStar(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic)
+
+	bfp, _ := BellmanFordFrom(simple.Node(test.s), test.g)
+	budget := 2 * bfp.WeightTo(test.t)
+	pt, expanded := BoundedCost(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic, budget)
+	if _, cost := pt.To(test.t); cost > budget {
+		t.Errorf("unexpected cost: got:%v want:<=%v", cost, budget)
+	}
+	if expanded > aStarExpanded {
+		t.Errorf("unexpected number of expanded nodes with loose budget: got:%d want:<=%d", expanded, aStarExpanded)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// DAGShortestFrom returns a shortest-path tree for the shortest paths from u to all
+// nodes in the directed acyclic graph g. Edges are relaxed in topological order,
+// so no priority queue is required and edge weights may be negative. If g is not
+// acyclic, the returned error is a topo.Unorderable holding the cycles preventing
+// a topological ordering. If the graph does not implement Weighted, UniformCost
+// is used.
+//
+// The time complexity of DAGShortestFrom is O(|V|+|E|).
+func DAGShortestFrom(u graph.Node, g graph.Directed) (path Shortest, err error) {
+	if g.Node(u.ID()) == nil {
+		return Shortest{from: u}, nil
+	}
+	sorted, err := topo.Sort(g)
+	if err != nil {
+		return Shortest{from: u}, err
+	}
+
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	path = newShortestFrom(u, sorted)
+	path.negCosts = make(map[negEdge]float64)
+
+	// Nodes preceding u in the topological
+	// ordering are not reachable from u.
+	for k := path.indexOf[u.ID()]; k < len(sorted); k++ {
+		if math.IsInf(path.dist[k], 1) {
+			continue
+		}
+		mnid := sorted[k].ID()
+		to := g.From(mnid)
+		for to.Next() {
+			vid := to.Node().ID()
+			j := path.indexOf[vid]
+			w, ok := weight(mnid, vid)
+			if !ok {
+				panic("dag: unexpected invalid weight")
+			}
+			joint := path.dist[k] + w
+			if joint < path.dist[j] {
+				path.set(j, joint, k)
+			}
+		}
+	}
+
+	return path, nil
+}
+
+// DAGLongestFrom returns a longest-path tree for the longest paths from u to all
+// nodes in the directed acyclic graph g. The returned Shortest holds the longest
+// paths and their weights. If g is not acyclic, the returned error is a
+// topo.Unorderable holding the cycles preventing a topological ordering. If the
+// graph does not implement Weighted, UniformCost is used. Edge weights may be
+// negative.
+//
+// The time complexity of DAGLongestFrom is O(|V|+|E|).
+func DAGLongestFrom(u graph.Node, g graph.Directed) (path Shortest, err error) {
+	if g.Node(u.ID()) == nil {
+		return Shortest{from: u}, nil
+	}
+	sorted, err := topo.Sort(g)
+	if err != nil {
+		return Shortest{from: u}, err
+	}
+
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	path = newShortestFrom(u, sorted)
+	path.negCosts = make(map[negEdge]float64)
+
+	// Nodes preceding u in the topological
+	// ordering are not reachable from u.
+	for k := path.indexOf[u.ID()]; k < len(sorted); k++ {
+		if math.IsInf(path.dist[k], 1) {
+			continue
+		}
+		mnid := sorted[k].ID()
+		to := g.From(mnid)
+		for to.Next() {
+			vid := to.Node().ID()
+			j := path.indexOf[vid]
+			w, ok := weight(mnid, vid)
+			if !ok {
+				panic("dag: unexpected invalid weight")
+			}
+			joint := path.dist[k] + w
+			if math.IsInf(path.dist[j], 1) || joint > path.dist[j] {
+				path.set(j, joint, k)
+			}
+		}
+	}
+
+	return path, nil
+}
+
+// CriticalPath returns a longest path in the directed acyclic graph g and its
+// weight. The path may start at any node of g. This is the critical path of g
+// when g is an activity-on-edge project network with edge weights giving the
+// durations of activities. If g is not acyclic, the returned error is a
+// topo.Unorderable holding the cycles preventing a topological ordering. If the
+// graph does not implement Weighted, UniformCost is used.
+//
+// The time complexity of CriticalPath is O(|V|+|E|).
+func CriticalPath(g graph.Directed) (path []graph.Node, weight float64, err error) {
+	sorted, err := topo.Sort(g)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(sorted) == 0 {
+		return nil, 0, nil
+	}
+
+	var w Weighting
+	if wg, ok := g.(Weighted); ok {
+		w = wg.Weight
+	} else {
+		w = UniformCost(g)
+	}
+
+	indexOf := make(map[int64]int, len(sorted))
+	for i, n := range sorted {
+		indexOf[n.ID()] = i
+	}
+	dist := make([]float64, len(sorted))
+	prev := make([]int, len(sorted))
+	for i := range prev {
+		prev[i] = -1
+	}
+	end := 0
+	for k, u := range sorted {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			j := indexOf[vid]
+			c, ok := w(uid, vid)
+			if !ok {
+				panic("dag: unexpected invalid weight")
+			}
+			if joint := dist[k] + c; joint > dist[j] {
+				dist[j] = joint
+				prev[j] = k
+			}
+		}
+		if dist[k] > dist[end] {
+			end = k
+		}
+	}
+
+	for i := end; i >= 0; i = prev[i] {
+		path = append(path, sorted[i])
+	}
+	ordered.Reverse(path)
+	return path, dist[end], nil
+}
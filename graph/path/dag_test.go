@@ -0,0 +1,206 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestDAGShortestFrom(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+		dg, ok := g.(graph.Directed)
+		if !ok {
+			continue
+		}
+
+		pt, err := DAGShortestFrom(test.Query.From(), dg)
+		if _, cyclic := topo.Sort(dg); cyclic != nil {
+			if _, ok := err.(topo.Unorderable); !ok {
+				t.Errorf("%q: expected topo.Unorderable error: got:%v", test.Name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.Name, err)
+			continue
+		}
+
+		p, weight := pt.To(test.Query.To().ID())
+		if weight != test.Weight {
+			t.Errorf("%q: unexpected weight from To: got:%f want:%f", test.Name, weight, test.Weight)
+		}
+		if weight := pt.WeightTo(test.Query.To().ID()); weight != test.Weight {
+			t.Errorf("%q: unexpected weight from WeightTo: got:%f want:%f", test.Name, weight, test.Weight)
+		}
+
+		var got []int64
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		ok = len(got) == 0 && len(test.WantPaths) == 0
+		for _, sp := range test.WantPaths {
+			if reflect.DeepEqual(got, sp) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			t.Errorf("%q: unexpected shortest path:\ngot: %v\nwant from:%v", test.Name, p, test.WantPaths)
+		}
+
+		np, weight := pt.To(test.NoPathFor.To().ID())
+		if pt.From().ID() == test.NoPathFor.From().ID() && (np != nil || !math.IsInf(weight, 1)) {
+			t.Errorf("%q: unexpected path:\ngot: path=%v weight=%f\nwant:path=<nil> weight=+Inf",
+				test.Name, np, weight)
+		}
+	}
+}
+
+var dagLongestTests = []struct {
+	name  string
+	edges []simple.WeightedEdge
+	from  graph.Node
+
+	wantWeights map[int64]float64
+	wantPaths   map[int64][]int64
+
+	wantCritical       []int64
+	wantCriticalWeight float64
+	wantCycle          bool
+}{
+	{
+		name: "project",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 3},
+			{F: simple.Node(0), T: simple.Node(2), W: 2},
+			{F: simple.Node(1), T: simple.Node(3), W: 4},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(4), W: 2},
+			{F: simple.Node(2), T: simple.Node(4), W: 8},
+			{F: simple.Node(5), T: simple.Node(1), W: 1},
+		},
+		from: simple.Node(0),
+		wantWeights: map[int64]float64{
+			0: 0, 1: 3, 2: 2, 3: 7, 4: 10, 5: math.Inf(1),
+		},
+		wantPaths: map[int64][]int64{
+			3: {0, 1, 3},
+			4: {0, 2, 4},
+			5: nil,
+		},
+		wantCritical:       []int64{0, 2, 4},
+		wantCriticalWeight: 10,
+	},
+	{
+		name: "negative weights",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: -1},
+			{F: simple.Node(1), T: simple.Node(2), W: -1},
+			{F: simple.Node(0), T: simple.Node(2), W: -3},
+			{F: simple.Node(2), T: simple.Node(3), W: 5},
+		},
+		from: simple.Node(0),
+		wantWeights: map[int64]float64{
+			0: 0, 1: -1, 2: -2, 3: 3,
+		},
+		wantPaths: map[int64][]int64{
+			2: {0, 1, 2},
+			3: {0, 1, 2, 3},
+		},
+		wantCritical:       []int64{2, 3},
+		wantCriticalWeight: 5,
+	},
+	{
+		name: "cycle",
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(0), W: 1},
+		},
+		from:      simple.Node(0),
+		wantCycle: true,
+	},
+}
+
+func TestDAGLongestFrom(t *testing.T) {
+	t.Parallel()
+	for _, test := range dagLongestTests {
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+
+		pt, err := DAGLongestFrom(test.from, g)
+		if test.wantCycle {
+			if _, ok := err.(topo.Unorderable); !ok {
+				t.Errorf("%q: expected topo.Unorderable error: got:%v", test.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		for id, want := range test.wantWeights {
+			if got := pt.WeightTo(id); got != want {
+				t.Errorf("%q: unexpected weight to %d: got:%v want:%v", test.name, id, got, want)
+			}
+		}
+		for id, want := range test.wantPaths {
+			p, _ := pt.To(id)
+			var got []int64
+			for _, n := range p {
+				got = append(got, n.ID())
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%q: unexpected path to %d: got:%v want:%v", test.name, id, got, want)
+			}
+		}
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	t.Parallel()
+	for _, test := range dagLongestTests {
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+
+		p, weight, err := CriticalPath(g)
+		if test.wantCycle {
+			if _, ok := err.(topo.Unorderable); !ok {
+				t.Errorf("%q: expected topo.Unorderable error: got:%v", test.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if weight != test.wantCriticalWeight {
+			t.Errorf("%q: unexpected critical path weight: got:%v want:%v", test.name, weight, test.wantCriticalWeight)
+		}
+		var got []int64
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.wantCritical) {
+			t.Errorf("%q: unexpected critical path: got:%v want:%v", test.name, got, test.wantCritical)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// DialFrom returns a shortest-path tree for a shortest path from u to all nodes in
+// the graph g using Dial's algorithm. Dial's algorithm is Dijkstra's algorithm with
+// the binary heap priority queue replaced by a circular array of maxWeight+1 buckets
+// indexed by path weight, and is faster than DijkstraFrom when edge weights are small
+// integers. If the graph does not implement Weighted, UniformCost is used.
+// DialFrom will panic if g has a u-reachable edge weight that is negative, is not an
+// integer or is greater than maxWeight.
+//
+// If g is a graph.Graph, all nodes of the graph will be stored in the shortest-path
+// tree, otherwise only nodes reachable from u will be stored.
+//
+// The time complexity of DialFrom is O(|E|+|V|.maxWeight).
+func DialFrom(u graph.Node, g traverse.Graph, maxWeight int) Shortest {
+	if maxWeight < 0 {
+		panic("dial: negative maximum weight")
+	}
+
+	var path Shortest
+	if h, ok := g.(graph.Graph); ok {
+		if h.Node(u.ID()) == nil {
+			return Shortest{from: u}
+		}
+		path = newShortestFrom(u, graph.NodesOf(h.Nodes()))
+	} else {
+		if g.From(u.ID()) == nil {
+			return Shortest{from: u}
+		}
+		path = newShortestFrom(u, []graph.Node{u})
+	}
+
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	// All queued path weights lie within maxWeight of the
+	// weight currently being settled, so a ring of maxWeight+1
+	// buckets holds the queue without collisions. Outdated
+	// elements are skipped as in DijkstraFrom.
+	Q := bucketQueue{buckets: make([][]distanceNode, maxWeight+1)}
+	Q.push(distanceNode{node: u, dist: 0})
+	for Q.len() != 0 {
+		mid := Q.pop()
+		k := path.indexOf[mid.node.ID()]
+		if mid.dist > path.dist[k] {
+			continue
+		}
+		mnid := mid.node.ID()
+		to := g.From(mnid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			j, ok := path.indexOf[vid]
+			if !ok {
+				j = path.add(v)
+			}
+			w, ok := weight(mnid, vid)
+			if !ok {
+				panic("dial: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("dial: negative edge weight")
+			}
+			if w > float64(maxWeight) {
+				panic("dial: edge weight exceeds maximum weight")
+			}
+			if w != math.Trunc(w) {
+				panic("dial: non-integer edge weight")
+			}
+			joint := path.dist[k] + w
+			if joint < path.dist[j] {
+				Q.push(distanceNode{node: v, dist: joint})
+				path.set(j, joint, k)
+			}
+		}
+	}
+
+	return path
+}
+
+// bucketQueue is a circular bucket priority queue for
+// integer distances spanning at most len(buckets)-1.
+type bucketQueue struct {
+	buckets [][]distanceNode
+	// cur is the index of the bucket
+	// holding the lowest distances.
+	cur int
+	n   int
+}
+
+func (q *bucketQueue) len() int { return q.n }
+
+func (q *bucketQueue) push(n distanceNode) {
+	i := int(math.Mod(n.dist, float64(len(q.buckets))))
+	q.buckets[i] = append(q.buckets[i], n)
+	q.n++
+}
+
+func (q *bucketQueue) pop() distanceNode {
+	for len(q.buckets[q.cur]) == 0 {
+		q.cur = (q.cur + 1) % len(q.buckets)
+	}
+	b := q.buckets[q.cur]
+	n := b[len(b)-1]
+	q.buckets[q.cur] = b[:len(b)-1]
+	q.n--
+	return n
+}
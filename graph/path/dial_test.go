@@ -0,0 +1,99 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+func TestDialFrom(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		g := test.Graph()
+		maxWeight := 0.0
+		integral := true
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+			w := e.Weight()
+			integral = integral && w == math.Trunc(w)
+			maxWeight = math.Max(maxWeight, w)
+		}
+		if !integral || math.IsInf(maxWeight, 1) {
+			continue
+		}
+
+		for _, tg := range []struct {
+			typ string
+			g   traverse.Graph
+		}{
+			{"complete", g.(graph.Graph)},
+			{"incremental", incremental{g.(graph.Weighted)}},
+		} {
+			var (
+				pt Shortest
+
+				panicked bool
+			)
+			func() {
+				defer func() {
+					panicked = recover() != nil
+				}()
+				pt = DialFrom(test.Query.From(), tg.g, int(maxWeight))
+			}()
+			if panicked || test.HasNegativeWeight {
+				if !test.HasNegativeWeight {
+					t.Errorf("%q %s: unexpected panic", test.Name, tg.typ)
+				}
+				if !panicked {
+					t.Errorf("%q %s: expected panic for negative edge weight", test.Name, tg.typ)
+				}
+				continue
+			}
+
+			want := DijkstraFrom(test.Query.From(), tg.g)
+			for _, n := range graph.NodesOf(g.(graph.Graph).Nodes()) {
+				if got, want := pt.WeightTo(n.ID()), want.WeightTo(n.ID()); got != want {
+					t.Errorf("%q %s: unexpected weight to %d: got:%v want:%v",
+						test.Name, tg.typ, n.ID(), got, want)
+				}
+			}
+			if weight := pt.WeightTo(test.Query.To().ID()); weight != test.Weight {
+				t.Errorf("%q %s: unexpected weight from WeightTo: got:%f want:%f",
+					test.Name, tg.typ, weight, test.Weight)
+			}
+		}
+	}
+}
+
+func TestDialFromPanics(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name      string
+		weight    float64
+		maxWeight int
+	}{
+		{name: "non-integer", weight: 0.5, maxWeight: 1},
+		{name: "too heavy", weight: 3, maxWeight: 2},
+	} {
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: test.weight})
+		panicked := func() (panicked bool) {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			DialFrom(simple.Node(0), g, test.maxWeight)
+			return false
+		}()
+		if !panicked {
+			t.Errorf("expected panic for %s edge weight", test.name)
+		}
+	}
+}
@@ -5,9 +5,12 @@
 package path
 
 import (
-	"container/heap"
+	"math"
+	"runtime"
+	"sync"
 
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
 	"gonum.org/v1/gonum/graph/traverse"
 )
 
@@ -20,19 +23,93 @@ import (
 //
 // The time complexity of DijkstrFrom is O(|E|.log|V|).
 func DijkstraFrom(u graph.Node, g traverse.Graph) Shortest {
-	var path Shortest
+	return DijkstraFromWith(u, g, nil)
+}
+
+// DijkstraSettings holds the optional settings for DijkstraFromWith.
+type DijkstraSettings struct {
+	// Observer is notified of the progress of
+	// the search. A node is generated when a
+	// path to it is first found and relaxed when
+	// a cheaper path to it is found. If Observer
+	// is nil, no notification is made.
+	Observer Observer
+}
+
+// DijkstraFromWith is equivalent to DijkstraFrom, but uses the observer held in
+// settings. If settings is nil, DijkstraFromWith is equivalent to DijkstraFrom.
+func DijkstraFromWith(u graph.Node, g traverse.Graph, settings *DijkstraSettings) Shortest {
+	var obs Observer
+	if settings != nil {
+		obs = settings.Observer
+	}
+	var expanded int
+	if obs != nil {
+		defer func() { obs.OnDone(expanded) }()
+	}
+	path, ok := newDijkstraShortest(u, g)
+	if !ok {
+		return path
+	}
+	expanded = dijkstraSearch(u, g, &path, nil, obs)
+	return path
+}
+
+// DijkstraTo returns a shortest-path tree for shortest paths from u to the nodes
+// in targets in the graph g. The search stops as soon as the shortest paths to all
+// the targets are known, so paths and weights held in the returned tree for nodes
+// that are not targets may not be shortest unless the node lies on the path to a
+// target. If the graph does not implement Weighted, UniformCost is used.
+// DijkstraTo will panic if g has a negative edge weight reachable from u before
+// all targets have been reached.
+//
+// If g is a graph.Graph, all nodes of the graph will be stored in the shortest-path
+// tree, otherwise only nodes reached from u will be stored.
+//
+// The time complexity of DijkstraTo is O(|E|.log|V|), but it will typically be
+// much lower when the targets are close to u.
+func DijkstraTo(u graph.Node, targets []graph.Node, g traverse.Graph) Shortest {
+	path, ok := newDijkstraShortest(u, g)
+	if !ok {
+		return path
+	}
+	remaining := make(set.Int64s, len(targets))
+	for _, t := range targets {
+		remaining.Add(t.ID())
+	}
+	dijkstraSearch(u, g, &path, func(n graph.Node, _ float64) bool {
+		remaining.Remove(n.ID())
+		return remaining.Count() == 0
+	}, nil)
+	return path
+}
+
+// newDijkstraShortest returns the shortest-path tree to be filled by a
+// Dijkstra search from u in g. If g is a graph.Graph, the tree holds all
+// the nodes of g, otherwise it holds only u. If u is not in g, the empty
+// tree and false are returned.
+func newDijkstraShortest(u graph.Node, g traverse.Graph) (Shortest, bool) {
 	if h, ok := g.(graph.Graph); ok {
 		if h.Node(u.ID()) == nil {
-			return Shortest{from: u}
+			return Shortest{from: u}, false
 		}
-		path = newShortestFrom(u, graph.NodesOf(h.Nodes()))
-	} else {
-		if g.From(u.ID()) == nil {
-			return Shortest{from: u}
-		}
-		path = newShortestFrom(u, []graph.Node{u})
+		return newShortestFrom(u, graph.NodesOf(h.Nodes())), true
 	}
+	if g.From(u.ID()) == nil {
+		return Shortest{from: u}, false
+	}
+	return newShortestFrom(u, []graph.Node{u}), true
+}
 
+// dijkstraSearch is the common code for the single source Dijkstra searches.
+// It performs a Dijkstra search from u in g, filling path, which must hold u,
+// and notifying obs of the progress of the search if obs is not nil. Nodes are
+// added to path as they are reached. If stop is not nil, it is called with each
+// node as it is settled and its distance from u, and the search ends before the
+// edges from the node are followed if stop returns true. The number of settled
+// nodes is returned. If the graph does not implement Weighted, UniformCost is
+// used. dijkstraSearch will panic if it reaches a negative edge weight.
+func dijkstraSearch(u graph.Node, g traverse.Graph, path *Shortest, stop func(n graph.Node, dist float64) bool, obs Observer) (expanded int) {
 	var weight Weighting
 	if wg, ok := g.(Weighted); ok {
 		weight = wg.Weight
@@ -50,13 +127,24 @@ func DijkstraFrom(u graph.Node, g traverse.Graph) Shortest {
 	//   are skipped.
 	//
 	// http://www.cs.utexas.edu/ftp/techreports/tr07-54.pdf
-	Q := priorityQueue{{node: u, dist: 0}}
+	Q := getQueue(distanceNode{node: u, dist: 0})
+	defer putQueue(Q)
+	if obs != nil {
+		obs.OnGenerate(u, 0)
+	}
 	for Q.Len() != 0 {
-		mid := heap.Pop(&Q).(distanceNode)
+		mid := Q.pop()
 		k := path.indexOf[mid.node.ID()]
 		if mid.dist > path.dist[k] {
 			continue
 		}
+		expanded++
+		if obs != nil {
+			obs.OnExpand(mid.node, mid.dist)
+		}
+		if stop != nil && stop(mid.node, mid.dist) {
+			break
+		}
 		mnid := mid.node.ID()
 		to := g.From(mnid)
 		for to.Next() {
@@ -75,13 +163,19 @@ func DijkstraFrom(u graph.Node, g traverse.Graph) Shortest {
 			}
 			joint := path.dist[k] + w
 			if joint < path.dist[j] {
-				heap.Push(&Q, distanceNode{node: v, dist: joint})
+				if obs != nil {
+					if math.IsInf(path.dist[j], 1) {
+						obs.OnGenerate(v, joint)
+					} else {
+						obs.OnRelax(mid.node, v, joint)
+					}
+				}
+				Q.push(distanceNode{node: v, dist: joint})
 				path.set(j, joint, k)
 			}
 		}
 	}
-
-	return path
+	return expanded
 }
 
 // DijkstraAllFrom returns a shortest-path tree for shortest paths from u to all nodes in
@@ -123,9 +217,10 @@ func DijkstraAllFrom(u graph.Node, g traverse.Graph) ShortestAlts {
 	//   are skipped.
 	//
 	// http://www.cs.utexas.edu/ftp/techreports/tr07-54.pdf
-	Q := priorityQueue{{node: u, dist: 0}}
+	Q := getQueue(distanceNode{node: u, dist: 0})
+	defer putQueue(Q)
 	for Q.Len() != 0 {
-		mid := heap.Pop(&Q).(distanceNode)
+		mid := Q.pop()
 		k := path.indexOf[mid.node.ID()]
 		if mid.dist > path.dist[k] {
 			continue
@@ -146,7 +241,7 @@ func DijkstraAllFrom(u graph.Node, g traverse.Graph) ShortestAlts {
 			}
 			joint := path.dist[k] + w
 			if joint < path.dist[j] {
-				heap.Push(&Q, distanceNode{node: v, dist: joint})
+				Q.push(distanceNode{node: v, dist: joint})
 				path.set(j, joint, k)
 			} else if joint == path.dist[j] {
 				path.addPath(j, k)
@@ -158,7 +253,7 @@ func DijkstraAllFrom(u graph.Node, g traverse.Graph) ShortestAlts {
 }
 
 // DijkstraAllPaths returns a shortest-path tree for shortest paths in the graph g.
-// If the graph does not implement graph.Weighter, UniformCost is used.
+// If the graph does not implement Weighted, UniformCost is used.
 // DijkstraAllPaths will panic if g has a negative edge weight.
 //
 // The time complexity of DijkstrAllPaths is O(|V|.|E|+|V|^2.log|V|).
@@ -174,49 +269,119 @@ func DijkstraAllPaths(g graph.Graph) (paths AllShortest) {
 // result of the work in the paths parameter which is a reference type.
 func dijkstraAllPaths(g graph.Graph, paths AllShortest) {
 	var weight Weighting
-	if wg, ok := g.(graph.Weighted); ok {
+	if wg, ok := g.(Weighted); ok {
 		weight = wg.Weight
 	} else {
 		weight = UniformCost(g)
 	}
 
 	var Q priorityQueue
-	for i, u := range paths.nodes {
-		// Dijkstra's algorithm here is implemented essentially as
-		// described in Function B.2 in figure 6 of UTCS Technical
-		// Report TR-07-54 with the addition of handling multiple
-		// co-equal paths.
-		//
-		// http://www.cs.utexas.edu/ftp/techreports/tr07-54.pdf
-
-		// Q must be empty at this point.
-		heap.Push(&Q, distanceNode{node: u, dist: 0})
-		for Q.Len() != 0 {
-			mid := heap.Pop(&Q).(distanceNode)
-			k := paths.indexOf[mid.node.ID()]
-			if mid.dist < paths.dist.At(i, k) {
-				paths.dist.Set(i, k, mid.dist)
+	for i := range paths.nodes {
+		dijkstraAllPathsFrom(i, g, weight, paths, &Q)
+	}
+}
+
+// DijkstraAllPathsParallel returns a shortest-path tree for shortest paths in the
+// graph g. It is equivalent to DijkstraAllPaths, but the single-source searches are
+// performed concurrently by workers goroutines, each search writing only to the
+// row of the result that corresponds to its source node. If workers is less than
+// one, runtime.GOMAXPROCS(0) workers are used. The graph g must be safe for
+// concurrent reads. Since no values are combined between searches, the returned
+// paths are identical for any number of workers.
+// DijkstraAllPathsParallel will panic if g has a negative edge weight.
+func DijkstraAllPathsParallel(g graph.Graph, workers int) (paths AllShortest) {
+	paths = newAllShortest(graph.NodesOf(g.Nodes()), false)
+
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(paths.nodes) {
+		workers = len(paths.nodes)
+	}
+
+	// Panics in the workers are recovered and the first
+	// is re-raised in the calling goroutine once all the
+	// workers have finished.
+	var (
+		once    sync.Once
+		failure interface{}
+	)
+	sources := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			var Q priorityQueue
+			for i := range sources {
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							once.Do(func() { failure = r })
+							Q = Q[:0]
+						}
+					}()
+					dijkstraAllPathsFrom(i, g, weight, paths, &Q)
+				}()
 			}
-			mnid := mid.node.ID()
-			to := g.From(mnid)
-			for to.Next() {
-				v := to.Node()
-				vid := v.ID()
-				j := paths.indexOf[vid]
-				w, ok := weight(mnid, vid)
-				if !ok {
-					panic("dijkstra: unexpected invalid weight")
-				}
-				if w < 0 {
-					panic("dijkstra: negative edge weight")
-				}
-				joint := paths.dist.At(i, k) + w
-				if joint < paths.dist.At(i, j) {
-					heap.Push(&Q, distanceNode{node: v, dist: joint})
-					paths.set(i, j, joint, k)
-				} else if joint == paths.dist.At(i, j) {
-					paths.add(i, j, k)
-				}
+		}()
+	}
+	for i := range paths.nodes {
+		sources <- i
+	}
+	close(sources)
+	wg.Wait()
+	if failure != nil {
+		panic(failure)
+	}
+
+	return paths
+}
+
+// dijkstraAllPathsFrom performs a single-source search from the node indexed
+// by i in paths.nodes, storing the result in row i of paths. The queue Q must
+// be empty and is left empty on return.
+func dijkstraAllPathsFrom(i int, g graph.Graph, weight Weighting, paths AllShortest, Q *priorityQueue) {
+	// Dijkstra's algorithm here is implemented essentially as
+	// described in Function B.2 in figure 6 of UTCS Technical
+	// Report TR-07-54 with the addition of handling multiple
+	// co-equal paths.
+	//
+	// http://www.cs.utexas.edu/ftp/techreports/tr07-54.pdf
+
+	Q.push(distanceNode{node: paths.nodes[i], dist: 0})
+	for Q.Len() != 0 {
+		mid := Q.pop()
+		k := paths.indexOf[mid.node.ID()]
+		if mid.dist < paths.dist.At(i, k) {
+			paths.dist.Set(i, k, mid.dist)
+		}
+		mnid := mid.node.ID()
+		to := g.From(mnid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			j := paths.indexOf[vid]
+			w, ok := weight(mnid, vid)
+			if !ok {
+				panic("dijkstra: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("dijkstra: negative edge weight")
+			}
+			joint := paths.dist.At(i, k) + w
+			if joint < paths.dist.At(i, j) {
+				Q.push(distanceNode{node: v, dist: joint})
+				paths.set(i, j, joint, k)
+			} else if joint == paths.dist.At(i, j) {
+				paths.add(i, j, k)
 			}
 		}
 	}
@@ -227,16 +392,78 @@ type distanceNode struct {
 	dist float64
 }
 
-// priorityQueue implements a no-dec priority queue.
+// priorityQueue implements a no-dec priority queue. It is a binary
+// heap with typed push and pop methods so that queue operations do
+// not box distanceNode values in interfaces as container/heap does.
 type priorityQueue []distanceNode
 
-func (q priorityQueue) Len() int            { return len(q) }
-func (q priorityQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
-func (q priorityQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
-func (q *priorityQueue) Push(n interface{}) { *q = append(*q, n.(distanceNode)) }
-func (q *priorityQueue) Pop() interface{} {
-	t := *q
-	var n interface{}
-	n, *q = t[len(t)-1], t[:len(t)-1]
+func (q priorityQueue) Len() int { return len(q) }
+
+// push adds n to the queue.
+func (q *priorityQueue) push(n distanceNode) {
+	*q = append(*q, n)
+	h := *q
+	i := len(h) - 1
+	for i > 0 {
+		p := (i - 1) / 2
+		if h[p].dist <= h[i].dist {
+			break
+		}
+		h[p], h[i] = h[i], h[p]
+		i = p
+	}
+}
+
+// pop removes and returns the node with the lowest distance.
+func (q *priorityQueue) pop() distanceNode {
+	h := *q
+	n := h[0]
+	last := len(h) - 1
+	h[0] = h[last]
+	h[last] = distanceNode{}
+	h = h[:last]
+	*q = h
+	i := 0
+	for {
+		l := 2*i + 1
+		if l >= len(h) {
+			break
+		}
+		min := l
+		if r := l + 1; r < len(h) && h[r].dist < h[l].dist {
+			min = r
+		}
+		if h[i].dist <= h[min].dist {
+			break
+		}
+		h[i], h[min] = h[min], h[i]
+		i = min
+	}
 	return n
 }
+
+// queuePool holds priority queue buffers
+// for reuse between searches.
+var queuePool = sync.Pool{
+	New: func() interface{} { return new(priorityQueue) },
+}
+
+// getQueue returns a priority queue from the pool holding
+// only n. The queue must be returned with putQueue.
+func getQueue(n distanceNode) *priorityQueue {
+	q := queuePool.Get().(*priorityQueue)
+	*q = append(*q, n)
+	return q
+}
+
+// putQueue returns q to the pool. The queue is cleared
+// so that it does not retain references to nodes. Slots
+// beyond the length of q have already been cleared by pop.
+func putQueue(q *priorityQueue) {
+	h := *q
+	for i := range h {
+		h[i] = distanceNode{}
+	}
+	*q = h[:0]
+	queuePool.Put(q)
+}
@@ -10,6 +10,8 @@ import (
 	"sort"
 	"testing"
 
+	"golang.org/x/exp/rand"
+
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
@@ -92,6 +94,94 @@ func TestDijkstraFrom(t *testing.T) {
 	}
 }
 
+func TestDijkstraTo(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+
+		for _, tg := range []struct {
+			typ string
+			g   traverse.Graph
+		}{
+			{"complete", g.(graph.Graph)},
+			{"incremental", incremental{g.(graph.Weighted)}},
+		} {
+			var (
+				pt Shortest
+
+				panicked bool
+			)
+			func() {
+				defer func() {
+					panicked = recover() != nil
+				}()
+				pt = DijkstraTo(test.Query.From(), []graph.Node{test.Query.To()}, tg.g)
+			}()
+			if panicked {
+				if !test.HasNegativeWeight {
+					t.Errorf("%q %s: unexpected panic", test.Name, tg.typ)
+				}
+				continue
+			}
+			if test.HasNegativeWeight {
+				// The search may terminate before
+				// reaching a negative edge weight.
+				continue
+			}
+
+			p, weight := pt.To(test.Query.To().ID())
+			if weight != test.Weight {
+				t.Errorf("%q %s: unexpected weight from To: got:%f want:%f",
+					test.Name, tg.typ, weight, test.Weight)
+			}
+
+			var got []int64
+			for _, n := range p {
+				got = append(got, n.ID())
+			}
+			ok := len(got) == 0 && len(test.WantPaths) == 0
+			for _, sp := range test.WantPaths {
+				if reflect.DeepEqual(got, sp) {
+					ok = true
+					break
+				}
+			}
+			if !ok {
+				t.Errorf("%q %s: unexpected shortest path:\ngot: %v\nwant from:%v",
+					test.Name, tg.typ, p, test.WantPaths)
+			}
+		}
+	}
+}
+
+func TestDijkstraToEarlyTermination(t *testing.T) {
+	t.Parallel()
+	g := testgraphs.NewGrid(100, 100, true)
+	from := g.NodeAt(50, 50)
+	targets := []graph.Node{g.NodeAt(49, 50), g.NodeAt(52, 53), g.NodeAt(50, 45)}
+
+	got := DijkstraTo(from, targets, g)
+	want := DijkstraFrom(from, g)
+	for _, n := range targets {
+		if got.WeightTo(n.ID()) != want.WeightTo(n.ID()) {
+			t.Errorf("unexpected weight to target %d: got:%v want:%v",
+				n.ID(), got.WeightTo(n.ID()), want.WeightTo(n.ID()))
+		}
+	}
+	far := g.NodeAt(0, 0)
+	if w := got.WeightTo(far.ID()); !math.IsInf(w, 1) {
+		t.Errorf("unexpected search of distant node: got weight %v", w)
+	}
+
+	none := DijkstraTo(from, nil, g)
+	if w := none.WeightTo(g.NodeAt(49, 50).ID()); !math.IsInf(w, 1) {
+		t.Errorf("unexpected search with no targets: got weight %v", w)
+	}
+}
+
 func TestDijkstraAllFrom(t *testing.T) {
 	t.Parallel()
 	for _, test := range testgraphs.ShortestPathTests {
@@ -210,6 +300,20 @@ type incremental struct {
 
 func TestDijkstraAllPaths(t *testing.T) {
 	t.Parallel()
+	testDijkstraAllPaths(t, DijkstraAllPaths)
+}
+
+func TestDijkstraAllPathsParallel(t *testing.T) {
+	t.Parallel()
+	for _, workers := range []int{0, 1, 4} {
+		workers := workers
+		testDijkstraAllPaths(t, func(g graph.Graph) AllShortest {
+			return DijkstraAllPathsParallel(g, workers)
+		})
+	}
+}
+
+func testDijkstraAllPaths(t *testing.T, allPaths func(graph.Graph) AllShortest) {
 	for _, test := range testgraphs.ShortestPathTests {
 		g := test.Graph()
 		for _, e := range test.Edges {
@@ -225,7 +329,7 @@ func TestDijkstraAllPaths(t *testing.T) {
 			defer func() {
 				panicked = recover() != nil
 			}()
-			pt = DijkstraAllPaths(g.(graph.Graph))
+			pt = allPaths(g.(graph.Graph))
 		}()
 		if panicked || test.HasNegativeWeight {
 			if !test.HasNegativeWeight {
@@ -336,3 +440,25 @@ func TestAllShortestAbsentNode(t *testing.T) {
 		t.Errorf("unexpected paths from absent node to itself: got:%#v want:%#v", gotPaths, wantPaths)
 	}
 }
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		q := getQueue(distanceNode{node: simple.Node(-1), dist: math.Inf(-1)})
+		for i := 0; i < n; i++ {
+			q.push(distanceNode{node: simple.Node(i), dist: rnd.Float64()})
+		}
+		var got []float64
+		for q.Len() != 0 {
+			got = append(got, q.pop().dist)
+		}
+		if len(got) != n+1 {
+			t.Errorf("unexpected number of popped nodes: got:%d want:%d", len(got), n+1)
+		}
+		if !sort.Float64sAreSorted(got) {
+			t.Errorf("priority queue not popped in order for n=%d", n)
+		}
+		putQueue(q)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// DistanceOracle is a Thorup–Zwick approximate distance oracle for an
+// undirected graph. For a stretch parameter k, the oracle answers distance
+// queries with a weight that is at least the shortest path weight and at most
+// 2k-1 times the shortest path weight, in O(k) time, using O(k.|V|^(1+1/k))
+// expected space.
+//
+// See Thorup M. and Zwick U. "Approximate distance oracles." Journal of the
+// ACM 52.1 (2005):1-24.
+type DistanceOracle struct {
+	k int
+
+	indexOf map[int64]int
+
+	// pivot[i][v] is the index of the node in A_i
+	// nearest to v, or -1 if no node in A_i is
+	// reachable from v, and pivotDist[i][v] is
+	// its distance from v.
+	pivot     [][]int
+	pivotDist [][]float64
+
+	// bunch[v] holds the distances from v
+	// to the nodes in its bunch.
+	bunch []map[int]float64
+}
+
+// NewDistanceOracle returns a DistanceOracle for the undirected graph g with
+// stretch 2k-1. The randomness of the construction is provided by src. If src
+// is nil, the global rand source is used. If the graph does not implement
+// Weighted, UniformCost is used. NewDistanceOracle will panic if k is less
+// than 1 or g has a negative edge weight.
+//
+// The expected time complexity of NewDistanceOracle is O(k.|E|.|V|^(1/k).log|V|).
+func NewDistanceOracle(g graph.Undirected, k int, src rand.Source) *DistanceOracle {
+	if k < 1 {
+		panic("path: distance oracle stretch parameter less than 1")
+	}
+	var rnd func() float64
+	if src == nil {
+		rnd = rand.Float64
+	} else {
+		rnd = rand.New(src).Float64
+	}
+
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	o := &DistanceOracle{
+		k:         k,
+		indexOf:   make(map[int64]int, len(nodes)),
+		pivot:     make([][]int, k),
+		pivotDist: make([][]float64, k+1),
+		bunch:     make([]map[int]float64, len(nodes)),
+	}
+	for i, n := range nodes {
+		o.indexOf[n.ID()] = i
+		o.bunch[i] = make(map[int]float64)
+	}
+
+	// Sample the hierarchy V = A_0 ⊇ A_1 ⊇ … ⊇ A_{k-1},
+	// with A_k empty. level[v] is the highest i such
+	// that v is in A_i.
+	level := make([]int, len(nodes))
+	p := math.Pow(float64(len(nodes)), -1/float64(k))
+	for i := 1; i < k; i++ {
+		for v, l := range level {
+			if l == i-1 && rnd() < p {
+				level[v] = i
+			}
+		}
+	}
+
+	// Find the nearest node in each A_i from
+	// every node with a multi-source search.
+	for i := 0; i < k; i++ {
+		o.pivot[i], o.pivotDist[i] = o.nearest(g, weight, nodes, level, i)
+	}
+	o.pivotDist[k] = make([]float64, len(nodes))
+	for v := range o.pivotDist[k] {
+		o.pivotDist[k][v] = math.Inf(1)
+	}
+
+	// Grow the cluster of each node w in A_i \ A_{i+1},
+	// the set of nodes v closer to w than to A_{i+1}.
+	// v is in the cluster of w exactly when w is in
+	// the bunch of v.
+	for w, l := range level {
+		o.cluster(g, weight, nodes, w, o.pivotDist[l+1])
+	}
+
+	return o
+}
+
+// nearest returns the nearest node in A_i and its distance for each node.
+func (o *DistanceOracle) nearest(g graph.Undirected, weight Weighting, nodes []graph.Node, level []int, i int) (pivot []int, dist []float64) {
+	pivot = make([]int, len(nodes))
+	dist = make([]float64, len(nodes))
+	var Q priorityQueue
+	for v := range nodes {
+		pivot[v] = -1
+		dist[v] = math.Inf(1)
+		if level[v] >= i {
+			pivot[v] = v
+			dist[v] = 0
+			Q = append(Q, distanceNode{node: nodes[v], dist: 0})
+		}
+	}
+	for Q.Len() != 0 {
+		mid := Q.pop()
+		k := o.indexOf[mid.node.ID()]
+		if mid.dist > dist[k] {
+			continue
+		}
+		o.relax(g, weight, mid, func(j int, joint float64) {
+			if joint < dist[j] {
+				dist[j] = joint
+				pivot[j] = pivot[k]
+				Q.push(distanceNode{node: nodes[j], dist: joint})
+			}
+		})
+	}
+	return pivot, dist
+}
+
+// cluster adds w to the bunches of all nodes v with a distance
+// from w less than limit[v].
+func (o *DistanceOracle) cluster(g graph.Undirected, weight Weighting, nodes []graph.Node, w int, limit []float64) {
+	dist := map[int]float64{w: 0}
+	Q := getQueue(distanceNode{node: nodes[w], dist: 0})
+	defer putQueue(Q)
+	for Q.Len() != 0 {
+		mid := Q.pop()
+		k := o.indexOf[mid.node.ID()]
+		if mid.dist > dist[k] {
+			continue
+		}
+		o.bunch[k][w] = mid.dist
+		o.relax(g, weight, mid, func(j int, joint float64) {
+			// Clusters are closed under shortest
+			// paths towards w, so nodes outside
+			// the cluster need not be explored.
+			if d, ok := dist[j]; (!ok || joint < d) && joint < limit[j] {
+				dist[j] = joint
+				Q.push(distanceNode{node: nodes[j], dist: joint})
+			}
+		})
+	}
+}
+
+// relax calls fn with the index and path weight of each neighbour of mid.
+func (o *DistanceOracle) relax(g graph.Undirected, weight Weighting, mid distanceNode, fn func(j int, joint float64)) {
+	uid := mid.node.ID()
+	to := g.From(uid)
+	for to.Next() {
+		vid := to.Node().ID()
+		w, ok := weight(uid, vid)
+		if !ok {
+			panic("path: distance oracle unexpected invalid weight")
+		}
+		if w < 0 {
+			panic("path: distance oracle negative edge weight")
+		}
+		fn(o.indexOf[vid], mid.dist+w)
+	}
+}
+
+// Stretch returns the maximum ratio of the weights returned by the
+// oracle to the shortest path weights.
+func (o *DistanceOracle) Stretch() int {
+	return 2*o.k - 1
+}
+
+// Weight returns an estimate of the weight of the shortest path between
+// the nodes with IDs uid and vid. The returned weight is at least the
+// shortest path weight and at most Stretch times the shortest path weight.
+// If there is no path between the nodes, Weight returns +Inf.
+func (o *DistanceOracle) Weight(uid, vid int64) float64 {
+	u, ok := o.indexOf[uid]
+	if !ok {
+		return math.Inf(1)
+	}
+	v, ok := o.indexOf[vid]
+	if !ok {
+		return math.Inf(1)
+	}
+
+	w := u
+	for i := 0; ; {
+		if d, ok := o.bunch[v][w]; ok {
+			return o.pivotDist[i][u] + d
+		}
+		i++
+		if i == o.k {
+			return math.Inf(1)
+		}
+		u, v = v, u
+		w = o.pivot[i][u]
+		if w < 0 {
+			return math.Inf(1)
+		}
+	}
+}
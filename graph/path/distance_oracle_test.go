@@ -0,0 +1,102 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestDistanceOracle(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name string
+		g    func() graph.Undirected
+	}{
+		{
+			name: "gnp",
+			g: func() graph.Undirected {
+				g := simple.NewUndirectedGraph()
+				err := gen.Gnp(g, 200, 0.02, rand.NewSource(1))
+				if err != nil {
+					t.Fatalf("unexpected error generating graph: %v", err)
+				}
+				return g
+			},
+		},
+		{
+			name: "weighted",
+			g: func() graph.Undirected {
+				rnd := rand.New(rand.NewSource(1))
+				g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+				for i := 0; i < 600; i++ {
+					u, v := rnd.Int63n(150), rnd.Int63n(150)
+					if u == v {
+						continue
+					}
+					g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: 1 + 9*rnd.Float64()})
+				}
+				return g
+			},
+		},
+		{
+			name: "disconnected",
+			g: func() graph.Undirected {
+				g := simple.NewUndirectedGraph()
+				for i := int64(0); i < 20; i++ {
+					g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+					g.SetEdge(simple.Edge{F: simple.Node(i + 100), T: simple.Node(i + 101)})
+				}
+				g.AddNode(simple.Node(50))
+				return g
+			},
+		},
+	} {
+		g := test.g()
+		exact := DijkstraAllPaths(g)
+		nodes := graph.NodesOf(g.Nodes())
+		for k := 1; k <= 4; k++ {
+			for seed := uint64(1); seed <= 3; seed++ {
+				o := NewDistanceOracle(g, k, rand.NewSource(seed))
+				if o.Stretch() != 2*k-1 {
+					t.Errorf("%s k=%d: unexpected stretch: got:%d want:%d", test.name, k, o.Stretch(), 2*k-1)
+				}
+				for _, u := range nodes {
+					for _, v := range nodes {
+						want := exact.Weight(u.ID(), v.ID())
+						got := o.Weight(u.ID(), v.ID())
+						if math.IsInf(want, 1) {
+							if !math.IsInf(got, 1) {
+								t.Errorf("%s k=%d seed=%d: unexpected weight between disconnected %d and %d: got:%v",
+									test.name, k, seed, u.ID(), v.ID(), got)
+							}
+							continue
+						}
+						const tol = 1e-10
+						if got < want-tol || got > float64(o.Stretch())*want+tol {
+							t.Errorf("%s k=%d seed=%d: weight between %d and %d outside stretch bound: got:%v exact:%v",
+								test.name, k, seed, u.ID(), v.ID(), got, want)
+						}
+						if k == 1 && math.Abs(got-want) > tol {
+							t.Errorf("%s k=1 seed=%d: unexpected inexact weight between %d and %d: got:%v want:%v",
+								test.name, seed, u.ID(), v.ID(), got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	o := NewDistanceOracle(simple.NewUndirectedGraph(), 2, nil)
+	if w := o.Weight(0, 1); !math.IsInf(w, 1) {
+		t.Errorf("unexpected weight for absent nodes: got:%v want:+Inf", w)
+	}
+}
@@ -41,15 +41,16 @@ func FloydWarshall(g graph.Graph) (paths AllShortest, ok bool) {
 		}
 	}
 
+	var buf [1]int
 	for k := range nodes {
 		for i := range nodes {
 			for j := range nodes {
 				ij := paths.dist.At(i, j)
 				joint := paths.dist.At(i, k) + paths.dist.At(k, j)
 				if ij > joint {
-					paths.set(i, j, joint, paths.at(i, k)...)
+					paths.set(i, j, joint, paths.at(i, k, buf[:0])...)
 				} else if ij-joint == 0 {
-					paths.add(i, j, paths.at(i, k)...)
+					paths.add(i, j, paths.at(i, k, buf[:0])...)
 				}
 			}
 		}
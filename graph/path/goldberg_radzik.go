@@ -0,0 +1,145 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// GoldbergRadzikFrom returns a shortest-path tree for a shortest path from u to all nodes in
+// the graph g, or false indicating that a negative cycle exists in the graph. If the graph
+// does not implement Weighted, UniformCost is used.
+//
+// GoldbergRadzikFrom is a label-correcting algorithm that scans nodes in passes. Each pass
+// scans, in depth-first topological order over edges with non-positive reduced cost, the
+// nodes reachable from the nodes that were improved in the previous pass and that have an
+// outgoing edge with negative reduced cost. On graphs with few negative edges this usually
+// requires far fewer edge relaxations than BellmanFordFrom. If the number of passes exceeds
+// |V| a negative cycle is reported.
+//
+// The worst case time complexity of GoldbergRadzikFrom is O(|V|.|E|).
+//
+// See Goldberg and Radzik, "A heuristic improvement of the Bellman-Ford algorithm",
+// Applied Mathematics Letters 6(3):3-6 (1993) doi:10.1016/0893-9659(93)90022-F
+func GoldbergRadzikFrom(u graph.Node, g graph.Graph) (path Shortest, ok bool) {
+	if g.Node(u.ID()) == nil {
+		return Shortest{from: u}, true
+	}
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+
+	path = newShortestFrom(u, nodes)
+	path.dist[path.indexOf[u.ID()]] = 0
+	path.negCosts = make(map[negEdge]float64)
+
+	edgeWeight := func(uid, vid int64) float64 {
+		w, ok := weight(uid, vid)
+		if !ok {
+			panic("goldberg-radzik: unexpected invalid weight")
+		}
+		return w
+	}
+
+	// improved holds the nodes whose distance was reduced
+	// after they were last scanned, the set B in the paper.
+	improved := []int{path.indexOf[u.ID()]}
+	inImproved := make([]bool, len(nodes))
+	inImproved[improved[0]] = true
+
+	// order holds the nodes to scan in the current pass,
+	// the set A in the paper, in depth-first post-order.
+	var order []int
+	visited := make([]bool, len(nodes))
+	scanned := make([]bool, len(nodes))
+
+	var visit func(j int)
+	visit = func(j int) {
+		visited[j] = true
+		uid := nodes[j].ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			k := path.indexOf[vid]
+			if visited[k] {
+				continue
+			}
+			if path.dist[j]+edgeWeight(uid, vid) <= path.dist[k] {
+				visit(k)
+			}
+		}
+		order = append(order, j)
+	}
+
+	for pass := 0; len(improved) != 0; pass++ {
+		if pass > len(nodes) {
+			path.hasNegativeCycle = true
+			return path, false
+		}
+
+		order = order[:0]
+		for i := range visited {
+			visited[i] = false
+			scanned[i] = false
+		}
+		for _, j := range improved {
+			inImproved[j] = false
+			if visited[j] || !hasNegativeReducedCost(j, g, path, edgeWeight) {
+				continue
+			}
+			visit(j)
+		}
+		improved = improved[:0]
+
+		// Scan the nodes in reverse post-order so that
+		// admissible edges are relaxed in topological order.
+		for i := len(order) - 1; i >= 0; i-- {
+			j := order[i]
+			scanned[j] = true
+			uid := nodes[j].ID()
+			to := g.From(uid)
+			for to.Next() {
+				vid := to.Node().ID()
+				k := path.indexOf[vid]
+				joint := path.dist[j] + edgeWeight(uid, vid)
+				if joint < path.dist[k] {
+					path.set(k, joint, j)
+					// Nodes waiting to be scanned in this
+					// pass will see the new distance.
+					if (!visited[k] || scanned[k]) && !inImproved[k] {
+						inImproved[k] = true
+						improved = append(improved, k)
+					}
+				}
+			}
+		}
+	}
+
+	return path, true
+}
+
+// hasNegativeReducedCost returns whether the node indexed by j in p has an
+// outgoing edge in g with a negative reduced cost.
+func hasNegativeReducedCost(j int, g graph.Graph, p Shortest, weight func(uid, vid int64) float64) bool {
+	if math.IsInf(p.dist[j], 1) {
+		return false
+	}
+	uid := p.nodes[j].ID()
+	to := g.From(uid)
+	for to.Next() {
+		vid := to.Node().ID()
+		if p.dist[j]+weight(uid, vid) < p.dist[p.indexOf[vid]] {
+			return true
+		}
+	}
+	return false
+}
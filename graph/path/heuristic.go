@@ -0,0 +1,105 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// HeuristicViolation describes a failure of a heuristic to satisfy
+// a property required by A* and related searches.
+type HeuristicViolation struct {
+	// From and To are the nodes at which the property
+	// does not hold. For admissibility To is the goal,
+	// and for consistency From and To are the ends of
+	// a graph edge.
+	From, To graph.Node
+
+	// Estimate is the heuristic estimate of the cost
+	// from From to the goal and Bound is the value
+	// it must not exceed.
+	Estimate, Bound float64
+}
+
+// CheckAdmissible returns the nodes of g for which the heuristic h overestimates the
+// cost of the shortest path to goal. The returned violations hold the true cost of the
+// path as the Bound. If the graph does not implement Weighted, UniformCost is used.
+// CheckAdmissible will panic if g has a negative edge weight.
+//
+// Nodes that cannot reach goal are not checked.
+func CheckAdmissible(g graph.Graph, h Heuristic, goal graph.Node) []HeuristicViolation {
+	if g.Node(goal.ID()) == nil {
+		return nil
+	}
+
+	// Find the true costs to the goal by searching
+	// from it over the graph's reversed edges.
+	var to Shortest
+	if d, ok := g.(graph.Directed); ok {
+		var weight Weighting
+		if wg, ok := g.(Weighted); ok {
+			weight = wg.Weight
+		} else {
+			weight = UniformCost(g)
+		}
+		to = DijkstraFrom(goal, reversed{Directed: d, weight: weight})
+	} else {
+		to = DijkstraFrom(goal, g)
+	}
+
+	var violations []HeuristicViolation
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		cost := to.WeightTo(n.ID())
+		if math.IsInf(cost, 1) {
+			continue
+		}
+		if est := h(n, goal); est > cost {
+			violations = append(violations, HeuristicViolation{From: n, To: goal, Estimate: est, Bound: cost})
+		}
+	}
+	return violations
+}
+
+// CheckConsistent returns the edges of g for which the heuristic h does not satisfy
+// the triangle inequality h(u, goal) <= w(u, v) + h(v, goal), and the goal node if
+// h(goal, goal) is not zero. The returned violations hold the right hand side of the
+// inequality as the Bound. If the graph does not implement Weighted, UniformCost
+// is used.
+//
+// A consistent heuristic is also admissible.
+func CheckConsistent(g graph.Graph, h Heuristic, goal graph.Node) []HeuristicViolation {
+	if g.Node(goal.ID()) == nil {
+		return nil
+	}
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	var violations []HeuristicViolation
+	if est := h(goal, goal); est != 0 {
+		violations = append(violations, HeuristicViolation{From: goal, To: goal, Estimate: est, Bound: 0})
+	}
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		uid := u.ID()
+		est := h(u, goal)
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			w, ok := weight(uid, v.ID())
+			if !ok {
+				panic("path: unexpected invalid weight")
+			}
+			if bound := w + h(v, goal); est > bound {
+				violations = append(violations, HeuristicViolation{From: u, To: v, Estimate: est, Bound: bound})
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,144 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// scaledManhattan returns a Manhattan distance heuristic for a
+// 5 column grid scaled by the given factor.
+func scaledManhattan(scale float64) Heuristic {
+	return func(u, v graph.Node) float64 {
+		uid := u.ID()
+		cu := uid % 5
+		ru := (uid - cu) / 5
+
+		vid := v.ID()
+		cv := vid % 5
+		rv := (vid - cv) / 5
+
+		return scale * (math.Abs(float64(ru-rv)) + math.Abs(float64(cu-cv)))
+	}
+}
+
+func TestCheckHeuristicGrid(t *testing.T) {
+	t.Parallel()
+	g := testgraphs.NewGridFrom(
+		".....",
+		".***.",
+		".....",
+		"****.",
+		".....",
+	)
+	goal := simple.Node(0)
+
+	for _, test := range []struct {
+		name           string
+		h              Heuristic
+		wantAdmissible bool
+		wantConsistent bool
+	}{
+		{name: "null", h: NullHeuristic, wantAdmissible: true, wantConsistent: true},
+		{name: "manhattan", h: scaledManhattan(1), wantAdmissible: true, wantConsistent: true},
+		{name: "scaled manhattan", h: scaledManhattan(2), wantAdmissible: false, wantConsistent: false},
+	} {
+		adm := CheckAdmissible(g, test.h, goal)
+		if (len(adm) == 0) != test.wantAdmissible {
+			t.Errorf("unexpected admissibility for %s heuristic: got:%t want:%t", test.name, len(adm) == 0, test.wantAdmissible)
+		}
+		for _, v := range adm {
+			if v.To.ID() != goal.ID() {
+				t.Errorf("unexpected goal in admissibility violation for %s heuristic: got:%d want:%d", test.name, v.To.ID(), goal.ID())
+			}
+			if v.Estimate <= v.Bound {
+				t.Errorf("violation for %s heuristic at node %d does not overestimate: %v <= %v", test.name, v.From.ID(), v.Estimate, v.Bound)
+			}
+		}
+
+		con := CheckConsistent(g, test.h, goal)
+		if (len(con) == 0) != test.wantConsistent {
+			t.Errorf("unexpected consistency for %s heuristic: got:%t want:%t", test.name, len(con) == 0, test.wantConsistent)
+		}
+		for _, v := range con {
+			if !g.HasEdgeBetween(v.From.ID(), v.To.ID()) {
+				t.Errorf("consistency violation for %s heuristic is not at an edge: %d--%d", test.name, v.From.ID(), v.To.ID())
+			}
+		}
+	}
+}
+
+func TestCheckHeuristicDirected(t *testing.T) {
+	t.Parallel()
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 1},
+		{F: simple.Node(2), T: simple.Node(0), W: 10},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	g.AddNode(simple.Node(3))
+	goal := simple.Node(2)
+
+	estimates := func(est ...float64) Heuristic {
+		return func(u, _ graph.Node) float64 { return est[u.ID()] }
+	}
+
+	for _, test := range []struct {
+		name           string
+		h              Heuristic
+		wantAdmissible []HeuristicViolation
+		wantConsistent []HeuristicViolation
+	}{
+		{
+			name: "exact",
+			// The estimate from the unreachable node 3 is not checked.
+			h: estimates(2, 1, 0, 100),
+		},
+		{
+			name: "inconsistent",
+			h:    estimates(2, 0, 0, 0),
+			wantConsistent: []HeuristicViolation{
+				{From: simple.Node(0), To: simple.Node(1), Estimate: 2, Bound: 1},
+			},
+		},
+		{
+			name: "inadmissible",
+			h:    estimates(3, 1, 0, 0),
+			wantAdmissible: []HeuristicViolation{
+				{From: simple.Node(0), To: goal, Estimate: 3, Bound: 2},
+			},
+			wantConsistent: []HeuristicViolation{
+				{From: simple.Node(0), To: simple.Node(1), Estimate: 3, Bound: 2},
+			},
+		},
+		{
+			name: "nonzero goal",
+			h:    estimates(2, 1, 1, 0),
+			wantAdmissible: []HeuristicViolation{
+				{From: goal, To: goal, Estimate: 1, Bound: 0},
+			},
+			wantConsistent: []HeuristicViolation{
+				{From: goal, To: goal, Estimate: 1, Bound: 0},
+			},
+		},
+	} {
+		got := CheckAdmissible(g, test.h, goal)
+		if !reflect.DeepEqual(got, test.wantAdmissible) {
+			t.Errorf("unexpected admissibility violations for %s heuristic:\ngot: %+v\nwant:%+v", test.name, got, test.wantAdmissible)
+		}
+		got = CheckConsistent(g, test.h, goal)
+		if !reflect.DeepEqual(got, test.wantConsistent) {
+			t.Errorf("unexpected consistency violations for %s heuristic:\ngot: %+v\nwant:%+v", test.name, got, test.wantConsistent)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// IterativeDeepening returns a path from s to t in g with the fewest edges and
+// the depth at which t was found, which is the number of edges in the path. Edge
+// weights are ignored. If t is not reachable from s by a path with at most
+// maxDepth edges, path is nil and depth is -1. If maxDepth is negative, the
+// search is not limited.
+//
+// IterativeDeepening performs repeated depth-limited depth-first searches from s
+// with increasing depth limits. Only the current path is held during the search,
+// so the memory required is proportional to the depth of t rather than the number
+// of nodes in g, at the cost of repeated expansion of the shallower nodes. This
+// makes IterativeDeepening suitable for searches in large implicit graphs. The
+// search terminates early if no path reaches the depth limit.
+func IterativeDeepening(s, t graph.Node, g traverse.Graph, maxDepth int) (path []graph.Node, depth int) {
+	if g, ok := g.(graph.Graph); ok {
+		if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+			return nil, -1
+		}
+	}
+
+	d := depthLimited{g: g, tid: t.ID(), onPath: make(set.Int64s)}
+	for limit := 0; maxDepth < 0 || limit <= maxDepth; limit++ {
+		d.path = append(d.path[:0], s)
+		d.onPath.Add(s.ID())
+		d.cutoff = false
+		if d.search(s, limit) {
+			return d.path, limit
+		}
+		d.onPath.Remove(s.ID())
+		if !d.cutoff {
+			break
+		}
+	}
+	return nil, -1
+}
+
+// depthLimited is a depth-limited depth-first search.
+type depthLimited struct {
+	g   traverse.Graph
+	tid int64
+
+	// path and onPath hold the
+	// current search path.
+	path   []graph.Node
+	onPath set.Int64s
+
+	// cutoff indicates whether the
+	// search was truncated by the
+	// depth limit.
+	cutoff bool
+}
+
+// search searches from u, the last node of d.path, for the target
+// with at most limit additional edges. If the target is found, search
+// returns true and d.path holds the path to the target.
+func (d *depthLimited) search(u graph.Node, limit int) bool {
+	uid := u.ID()
+	if uid == d.tid {
+		return true
+	}
+	if limit == 0 {
+		d.cutoff = true
+		return false
+	}
+	to := d.g.From(uid)
+	for to.Next() {
+		v := to.Node()
+		vid := v.ID()
+		if d.onPath.Has(vid) {
+			continue
+		}
+		d.path = append(d.path, v)
+		d.onPath.Add(vid)
+		if d.search(v, limit-1) {
+			return true
+		}
+		d.onPath.Remove(vid)
+		d.path = d.path[:len(d.path)-1]
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestIterativeDeepening(t *testing.T) {
+	t.Parallel()
+	for _, directed := range []bool{false, true} {
+		for seed := uint64(1); seed <= 10; seed++ {
+			var g interface {
+				graph.Graph
+				graph.Builder
+			}
+			if directed {
+				g = simple.NewDirectedGraph()
+			} else {
+				g = simple.NewUndirectedGraph()
+			}
+			const n = 30
+			err := gen.Gnp(g, n, 0.06, rand.NewSource(seed))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := DijkstraFrom(simple.Node(0), g)
+			for id := int64(0); id < n; id++ {
+				wantDepth := want.WeightTo(id)
+				for _, maxDepth := range []int{-1, 3} {
+					path, depth := IterativeDeepening(simple.Node(0), simple.Node(id), g, maxDepth)
+					if math.IsInf(wantDepth, 1) || (maxDepth >= 0 && wantDepth > float64(maxDepth)) {
+						if path != nil || depth != -1 {
+							t.Errorf("directed=%t seed=%d max=%d: unexpected path to %d: got:%v depth=%d want:<nil> depth=-1",
+								directed, seed, maxDepth, id, path, depth)
+						}
+						continue
+					}
+					if float64(depth) != wantDepth {
+						t.Errorf("directed=%t seed=%d max=%d: unexpected depth of %d: got:%d want:%v",
+							directed, seed, maxDepth, id, depth, wantDepth)
+					}
+					if len(path) != depth+1 || path[0].ID() != 0 || path[len(path)-1].ID() != id {
+						t.Errorf("directed=%t seed=%d max=%d: unexpected path to %d: got:%v",
+							directed, seed, maxDepth, id, path)
+						continue
+					}
+					if !topo.IsPathIn(g, path) {
+						t.Errorf("directed=%t seed=%d max=%d: got path that is not path in input graph: %v",
+							directed, seed, maxDepth, path)
+					}
+				}
+			}
+		}
+	}
+}
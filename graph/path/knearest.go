@@ -0,0 +1,48 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// KNearest returns the k nodes closest to u in the graph g by shortest path
+// weight, ordered by increasing weight, and the weights of the shortest paths
+// to them. The node u is not included. If fewer than k nodes are reachable
+// from u, all reachable nodes are returned. Ties between nodes at equal
+// weight are broken arbitrarily. If the graph does not implement Weighted,
+// UniformCost is used. KNearest will panic if g has a negative edge weight
+// reachable from u before the k nearest nodes have been found.
+//
+// The search stops as soon as the k nearest nodes have been found, so the
+// time complexity of KNearest is O(|E|.log|V|) in the worst case, but is
+// typically much lower for small k.
+func KNearest(u graph.Node, g traverse.Graph, k int) (nodes []graph.Node, weights []float64) {
+	if k <= 0 {
+		return nil, nil
+	}
+	if h, ok := g.(graph.Graph); ok {
+		if h.Node(u.ID()) == nil {
+			return nil, nil
+		}
+	}
+
+	// The shortest-path tree is populated lazily
+	// and the search stops when k nodes other
+	// than u have been settled.
+	uid := u.ID()
+	path := newShortestFrom(u, []graph.Node{u})
+	dijkstraSearch(u, g, &path, func(n graph.Node, dist float64) bool {
+		if n.ID() == uid {
+			return false
+		}
+		nodes = append(nodes, n)
+		weights = append(weights, dist)
+		return len(nodes) == k
+	}, nil)
+
+	return nodes, weights
+}
@@ -0,0 +1,89 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestKNearest(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+		gg := g.(graph.Graph)
+		u := test.Query.From()
+		if gg.Node(u.ID()) == nil {
+			continue
+		}
+
+		// Find the weights to all reachable nodes
+		// other than u in increasing order.
+		pt := DijkstraFrom(u, gg)
+		var all []float64
+		for _, n := range graph.NodesOf(gg.Nodes()) {
+			if w := pt.WeightTo(n.ID()); n.ID() != u.ID() && !math.IsInf(w, 1) {
+				all = append(all, w)
+			}
+		}
+		sort.Float64s(all)
+
+		for k := 0; k <= len(all)+1; k++ {
+			nodes, weights := KNearest(u, gg, k)
+			want := all
+			if k < len(want) {
+				want = want[:k]
+			}
+			if len(nodes) != len(want) || len(weights) != len(want) {
+				t.Errorf("%q k=%d: unexpected number of nodes: got:%d want:%d",
+					test.Name, k, len(nodes), len(want))
+				continue
+			}
+			for i, n := range nodes {
+				if n.ID() == u.ID() {
+					t.Errorf("%q k=%d: unexpected query node in result", test.Name, k)
+				}
+				if weights[i] != want[i] {
+					t.Errorf("%q k=%d: unexpected weight at %d: got:%v want:%v",
+						test.Name, k, i, weights[i], want[i])
+				}
+				if w := pt.WeightTo(n.ID()); weights[i] != w {
+					t.Errorf("%q k=%d: unexpected weight for node %d: got:%v want:%v",
+						test.Name, k, n.ID(), weights[i], w)
+				}
+			}
+		}
+	}
+}
+
+func TestKNearestEarlyTermination(t *testing.T) {
+	t.Parallel()
+	// The search from 0 must not reach the
+	// negative edge beyond its nearest neighbours.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(1), W: 1})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(2), W: 2})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2), T: simple.Node(3), W: 5})
+	g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(3), T: simple.Node(4), W: -1})
+
+	nodes, weights := KNearest(simple.Node(0), g, 2)
+	if len(nodes) != 2 || nodes[0].ID() != 1 || nodes[1].ID() != 2 {
+		t.Errorf("unexpected nearest nodes: got:%v want:[1 2]", nodes)
+	}
+	if len(weights) != 2 || weights[0] != 1 || weights[1] != 2 {
+		t.Errorf("unexpected nearest weights: got:%v want:[1 2]", weights)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"encoding"
+	"errors"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/codec"
+)
+
+// Preprocessing results implement encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler so they can be built once and loaded
+// when needed.
+var (
+	_ encoding.BinaryMarshaler   = (*Reach)(nil)
+	_ encoding.BinaryUnmarshaler = (*Reach)(nil)
+	_ encoding.BinaryMarshaler   = (*ArcFlags)(nil)
+	_ encoding.BinaryUnmarshaler = (*ArcFlags)(nil)
+	_ encoding.BinaryMarshaler   = (*DistanceOracle)(nil)
+	_ encoding.BinaryUnmarshaler = (*DistanceOracle)(nil)
+)
+
+// Encoding kind tags and current versions of preprocessing results.
+const (
+	reachKind    = "REAC"
+	reachVersion = 1
+
+	arcFlagsKind    = "ARCF"
+	arcFlagsVersion = 1
+
+	oracleKind    = "DORC"
+	oracleVersion = 1
+)
+
+// MarshalBinary encodes the reach of each node into a versioned binary form.
+// The preprocessed graph is not encoded.
+func (r *Reach) MarshalBinary() ([]byte, error) {
+	enc := codec.NewEncoder(reachKind, reachVersion)
+	ids := make([]int64, 0, len(r.reach))
+	for id := range r.reach {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	enc.Int(len(ids))
+	for _, id := range ids {
+		enc.Int64(id)
+		enc.Float64(r.reach[id])
+	}
+	return enc.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the binary form of a reach preprocessing into the
+// receiver. The preprocessed graph is not restored, and must be set using
+// SetGraph before the receiver is used for searches.
+func (r *Reach) UnmarshalBinary(data []byte) error {
+	dec, _, err := codec.NewDecoder(data, reachKind, reachVersion)
+	if err != nil {
+		return err
+	}
+	n := dec.Len(2)
+	reach := make(map[int64]float64, n)
+	for i := 0; i < n; i++ {
+		reach[dec.Int64()] = dec.Float64()
+	}
+	if err := dec.Err(); err != nil {
+		return err
+	}
+	r.reach = reach
+	return nil
+}
+
+// SetGraph sets the graph searched by the receiver. The graph must be the
+// graph that was preprocessed. SetGraph is used to attach the graph to a
+// reach preprocessing restored by UnmarshalBinary.
+func (r *Reach) SetGraph(g graph.Graph) {
+	r.g = g
+	r.weight = weightingOf(g)
+}
+
+// MarshalBinary encodes the regions and edge flags into a versioned binary
+// form. The preprocessed graph is not encoded.
+func (f *ArcFlags) MarshalBinary() ([]byte, error) {
+	enc := codec.NewEncoder(arcFlagsKind, arcFlagsVersion)
+	enc.Int(f.words)
+	ids := make([]int64, 0, len(f.region))
+	for id := range f.region {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	enc.Int(len(ids))
+	for _, id := range ids {
+		enc.Int64(id)
+		enc.Int(f.region[id])
+	}
+
+	edges := make([][2]int64, 0, len(f.flags))
+	for e := range f.flags {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i][0] != edges[j][0] {
+			return edges[i][0] < edges[j][0]
+		}
+		return edges[i][1] < edges[j][1]
+	})
+	enc.Int(len(edges))
+	for _, e := range edges {
+		enc.Int64(e[0])
+		enc.Int64(e[1])
+		for _, w := range f.flags[e] {
+			enc.Uint64(w)
+		}
+	}
+	return enc.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the binary form of an arc-flag preprocessing into
+// the receiver. The preprocessed graph is not restored, and must be set using
+// SetGraph before the receiver is used for searches.
+func (f *ArcFlags) UnmarshalBinary(data []byte) error {
+	dec, _, err := codec.NewDecoder(data, arcFlagsKind, arcFlagsVersion)
+	if err != nil {
+		return err
+	}
+	words := dec.Int()
+	if words < 0 || words > maxArcFlagWords {
+		return errors.New("path: invalid arc-flag width")
+	}
+	n := dec.Len(2)
+	region := make(map[int64]int, n)
+	for i := 0; i < n; i++ {
+		id := dec.Int64()
+		r := dec.Int()
+		if r < 0 || r >= 64*words {
+			return errors.New("path: invalid arc-flag region")
+		}
+		region[id] = r
+	}
+	n = dec.Len(2 + words)
+	flags := make(map[[2]int64][]uint64, n)
+	for i := 0; i < n; i++ {
+		e := [2]int64{dec.Int64(), dec.Int64()}
+		set := make([]uint64, words)
+		for j := range set {
+			set[j] = dec.Uint64()
+		}
+		flags[e] = set
+	}
+	if err := dec.Err(); err != nil {
+		return err
+	}
+	f.region = region
+	f.words = words
+	f.flags = flags
+	return nil
+}
+
+// maxArcFlagWords is the largest number of flag words
+// per edge accepted when decoding arc flags.
+const maxArcFlagWords = 1 << 20
+
+// SetGraph sets the graph searched by the receiver. The graph must be the
+// graph that was preprocessed. SetGraph is used to attach the graph to an
+// arc-flag preprocessing restored by UnmarshalBinary.
+func (f *ArcFlags) SetGraph(g graph.Graph) {
+	f.g = g
+	f.weight = weightingOf(g)
+}
+
+// MarshalBinary encodes the distance oracle into a versioned binary form.
+func (o *DistanceOracle) MarshalBinary() ([]byte, error) {
+	enc := codec.NewEncoder(oracleKind, oracleVersion)
+	enc.Int(o.k)
+
+	ids := make([]int64, len(o.indexOf))
+	for id, i := range o.indexOf {
+		ids[i] = id
+	}
+	enc.Int(len(ids))
+	for _, id := range ids {
+		enc.Int64(id)
+	}
+	for _, pivot := range o.pivot {
+		for _, p := range pivot {
+			enc.Int(p)
+		}
+	}
+	for _, dist := range o.pivotDist {
+		for _, d := range dist {
+			enc.Float64(d)
+		}
+	}
+	for _, bunch := range o.bunch {
+		members := make([]int, 0, len(bunch))
+		for w := range bunch {
+			members = append(members, w)
+		}
+		sort.Ints(members)
+		enc.Int(len(members))
+		for _, w := range members {
+			enc.Int(w)
+			enc.Float64(bunch[w])
+		}
+	}
+	return enc.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the binary form of a distance oracle into the
+// receiver.
+func (o *DistanceOracle) UnmarshalBinary(data []byte) error {
+	dec, _, err := codec.NewDecoder(data, oracleKind, oracleVersion)
+	if err != nil {
+		return err
+	}
+	k := dec.Int()
+	n := dec.Len(1)
+	if dec.Err() == nil && (k < 1 || k > len(data)/8 || (n > 0 && k > len(data)/(8*n))) {
+		return errors.New("path: invalid distance oracle stretch parameter")
+	}
+	indexOf := make(map[int64]int, n)
+	for i := 0; i < n; i++ {
+		indexOf[dec.Int64()] = i
+	}
+	if dec.Err() == nil && len(indexOf) != n {
+		return errors.New("path: duplicate distance oracle node")
+	}
+	pivot := make([][]int, k)
+	for i := range pivot {
+		pivot[i] = make([]int, n)
+		for v := range pivot[i] {
+			p := dec.Int()
+			if p < -1 || p >= n {
+				return errors.New("path: invalid distance oracle pivot")
+			}
+			pivot[i][v] = p
+		}
+	}
+	pivotDist := make([][]float64, k+1)
+	for i := range pivotDist {
+		pivotDist[i] = make([]float64, n)
+		for v := range pivotDist[i] {
+			pivotDist[i][v] = dec.Float64()
+		}
+	}
+	bunch := make([]map[int]float64, n)
+	for v := range bunch {
+		m := dec.Len(2)
+		bunch[v] = make(map[int]float64, m)
+		for i := 0; i < m; i++ {
+			w := dec.Int()
+			if w < 0 || w >= n {
+				return errors.New("path: invalid distance oracle bunch")
+			}
+			bunch[v][w] = dec.Float64()
+		}
+	}
+	if err := dec.Err(); err != nil {
+		return err
+	}
+	*o = DistanceOracle{
+		k:         k,
+		indexOf:   indexOf,
+		pivot:     pivot,
+		pivotDist: pivotDist,
+		bunch:     bunch,
+	}
+	return nil
+}
+
+// weightingOf returns the weighting of g, using UniformCost if
+// g does not implement Weighted.
+func weightingOf(g graph.Graph) Weighting {
+	if wg, ok := g.(Weighted); ok {
+		return wg.Weight
+	}
+	return UniformCost(g)
+}
@@ -0,0 +1,177 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"bytes"
+	"encoding"
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// marshalTestGraph returns a random weighted undirected graph.
+func marshalTestGraph() *simple.WeightedUndirectedGraph {
+	rnd := rand.New(rand.NewSource(1))
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for i := 0; i < 200; i++ {
+		u, v := rnd.Int63n(60), rnd.Int63n(60)
+		if u == v {
+			continue
+		}
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: 1 + 9*rnd.Float64()})
+	}
+	return g
+}
+
+func TestReachMarshal(t *testing.T) {
+	t.Parallel()
+	g := marshalTestGraph()
+	want := NewReach(g)
+	data, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling reach: %v", err)
+	}
+
+	var got Reach
+	err = got.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling reach: %v", err)
+	}
+	if !reflect.DeepEqual(got.reach, want.reach) {
+		t.Errorf("unexpected reach after round trip:\ngot: %v\nwant:%v", got.reach, want.reach)
+	}
+	checkMarshalStable(t, &got, data)
+
+	got.SetGraph(g)
+	for _, s := range []int64{0, 7, 31} {
+		for _, tid := range []int64{3, 42, 59} {
+			wantPath, _ := want.Shortest(simple.Node(s), simple.Node(tid), nil)
+			gotPath, _ := got.Shortest(simple.Node(s), simple.Node(tid), nil)
+			if gotPath.WeightTo(tid) != wantPath.WeightTo(tid) {
+				t.Errorf("unexpected path weight from %d to %d after round trip: got:%v want:%v",
+					s, tid, gotPath.WeightTo(tid), wantPath.WeightTo(tid))
+			}
+		}
+	}
+}
+
+func TestArcFlagsMarshal(t *testing.T) {
+	t.Parallel()
+	g := marshalTestGraph()
+	region := make(map[int64]int)
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		region[n.ID()] = int(n.ID() % 5)
+	}
+	want := NewArcFlags(g, region)
+	data, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling arc flags: %v", err)
+	}
+
+	var got ArcFlags
+	err = got.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling arc flags: %v", err)
+	}
+	if got.words != want.words || !reflect.DeepEqual(got.region, want.region) || !reflect.DeepEqual(got.flags, want.flags) {
+		t.Error("unexpected arc flags after round trip")
+	}
+	checkMarshalStable(t, &got, data)
+
+	got.SetGraph(g)
+	for _, s := range []int64{0, 7, 31} {
+		for _, tid := range []int64{3, 42, 59} {
+			wantPath, _ := want.Shortest(simple.Node(s), simple.Node(tid))
+			gotPath, _ := got.Shortest(simple.Node(s), simple.Node(tid))
+			if gotPath.WeightTo(tid) != wantPath.WeightTo(tid) {
+				t.Errorf("unexpected path weight from %d to %d after round trip: got:%v want:%v",
+					s, tid, gotPath.WeightTo(tid), wantPath.WeightTo(tid))
+			}
+		}
+	}
+}
+
+func TestDistanceOracleMarshal(t *testing.T) {
+	t.Parallel()
+	g := marshalTestGraph()
+	g.AddNode(simple.Node(100))
+	want := NewDistanceOracle(g, 3, rand.NewSource(1))
+	data, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling distance oracle: %v", err)
+	}
+
+	var got DistanceOracle
+	err = got.UnmarshalBinary(data)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling distance oracle: %v", err)
+	}
+	if !reflect.DeepEqual(got, *want) {
+		t.Error("unexpected distance oracle after round trip")
+	}
+	checkMarshalStable(t, &got, data)
+
+	nodes := graph.NodesOf(g.Nodes())
+	for _, u := range nodes {
+		for _, v := range nodes {
+			if got.Weight(u.ID(), v.ID()) != want.Weight(u.ID(), v.ID()) {
+				t.Errorf("unexpected weight from %d to %d after round trip", u.ID(), v.ID())
+			}
+		}
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	t.Parallel()
+	g := marshalTestGraph()
+	reach, err := NewReach(g).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling reach: %v", err)
+	}
+	oracle, err := NewDistanceOracle(g, 2, rand.NewSource(1)).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling distance oracle: %v", err)
+	}
+	future := append([]byte(nil), reach...)
+	future[8]++
+
+	for _, test := range []struct {
+		name string
+		dst  encoding.BinaryUnmarshaler
+		data []byte
+	}{
+		{name: "empty", dst: &Reach{}, data: nil},
+		{name: "bad magic", dst: &Reach{}, data: append([]byte("XXXX"), reach[4:]...)},
+		{name: "wrong kind", dst: &Reach{}, data: oracle},
+		{name: "future version", dst: &Reach{}, data: future},
+		{name: "truncated reach", dst: &Reach{}, data: reach[:len(reach)-3]},
+		{name: "trailing reach", dst: &Reach{}, data: append(append([]byte(nil), reach...), 0, 0, 0, 0, 0, 0, 0, 0)},
+		{name: "truncated oracle", dst: &DistanceOracle{}, data: oracle[:len(oracle)/2]},
+		{name: "wrong kind arc flags", dst: &ArcFlags{}, data: reach},
+	} {
+		if err := test.dst.UnmarshalBinary(test.data); err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+// checkMarshalStable checks that remarshaling the unmarshaled value m
+// gives the original encoding.
+func checkMarshalStable(t *testing.T, m encoding.BinaryMarshaler, data []byte) {
+	t.Helper()
+	again, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error remarshaling: %v", err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Error("encoding changed after round trip")
+	}
+}
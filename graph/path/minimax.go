@@ -0,0 +1,85 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// MinimaxFrom returns a minimax path tree for paths from u to all nodes in
+// the graph g. The weight of a path in the returned tree is the maximum weight
+// of the edges in the path rather than the sum of the edge weights, and the
+// path to each node minimizes that maximum. If the graph does not implement
+// Weighted, UniformCost is used. MinimaxFrom will panic if g has a u-reachable
+// negative edge weight.
+//
+// The weight of the path from u to itself is zero.
+//
+// If g is a graph.Graph, all nodes of the graph will be stored in the minimax
+// path tree, otherwise only nodes reachable from u will be stored.
+//
+// The time complexity of MinimaxFrom is O(|E|.log|V|).
+func MinimaxFrom(u graph.Node, g traverse.Graph) Shortest {
+	var path Shortest
+	if h, ok := g.(graph.Graph); ok {
+		if h.Node(u.ID()) == nil {
+			return Shortest{from: u}
+		}
+		path = newShortestFrom(u, graph.NodesOf(h.Nodes()))
+	} else {
+		if g.From(u.ID()) == nil {
+			return Shortest{from: u}
+		}
+		path = newShortestFrom(u, []graph.Node{u})
+	}
+
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	// This is Dijkstra's algorithm with path weights
+	// combined by taking the maximum edge weight rather
+	// than the sum; max is monotonic for non-negative
+	// weights, so the greedy choice remains valid.
+	Q := getQueue(distanceNode{node: u, dist: 0})
+	defer putQueue(Q)
+	for Q.Len() != 0 {
+		mid := Q.pop()
+		k := path.indexOf[mid.node.ID()]
+		if mid.dist > path.dist[k] {
+			continue
+		}
+		mnid := mid.node.ID()
+		to := g.From(mnid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			j, ok := path.indexOf[vid]
+			if !ok {
+				j = path.add(v)
+			}
+			w, ok := weight(mnid, vid)
+			if !ok {
+				panic("minimax: unexpected invalid weight")
+			}
+			if w < 0 {
+				panic("minimax: negative edge weight")
+			}
+			joint := math.Max(path.dist[k], w)
+			if joint < path.dist[j] {
+				Q.push(distanceNode{node: v, dist: joint})
+				path.set(j, joint, k)
+			}
+		}
+	}
+
+	return path
+}
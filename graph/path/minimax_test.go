@@ -0,0 +1,130 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var minimaxTests = []struct {
+	name  string
+	graph func() graph.WeightedEdgeAdder
+	edges []simple.WeightedEdge
+
+	query      simple.Edge
+	wantPath   []int64
+	wantWeight float64
+}{
+	{
+		name:  "long low road",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(4), W: 5},
+			{F: simple.Node(0), T: simple.Node(1), W: 2},
+			{F: simple.Node(1), T: simple.Node(2), W: 3},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(4), W: 2},
+		},
+		query:      simple.Edge{F: simple.Node(0), T: simple.Node(4)},
+		wantPath:   []int64{0, 1, 2, 3, 4},
+		wantWeight: 3,
+	},
+	{
+		name:  "undirected",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedUndirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node('A'), T: simple.Node('B'), W: 7},
+			{F: simple.Node('A'), T: simple.Node('C'), W: 4},
+			{F: simple.Node('C'), T: simple.Node('D'), W: 6},
+			{F: simple.Node('B'), T: simple.Node('D'), W: 1},
+			{F: simple.Node('D'), T: simple.Node('E'), W: 5},
+			{F: simple.Node('B'), T: simple.Node('E'), W: 9},
+		},
+		query:      simple.Edge{F: simple.Node('E'), T: simple.Node('A')},
+		wantPath:   []int64{'E', 'D', 'C', 'A'},
+		wantWeight: 6,
+	},
+	{
+		name:  "unreachable",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(1), T: simple.Node(0), W: 1},
+		},
+		query:      simple.Edge{F: simple.Node(0), T: simple.Node(1)},
+		wantPath:   nil,
+		wantWeight: math.Inf(1),
+	},
+	{
+		name:  "self",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+		},
+		query:      simple.Edge{F: simple.Node(0), T: simple.Node(0)},
+		wantPath:   []int64{0},
+		wantWeight: 0,
+	},
+}
+
+func TestMinimaxFrom(t *testing.T) {
+	t.Parallel()
+	for _, test := range minimaxTests {
+		g := test.graph()
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+
+		pt := MinimaxFrom(test.query.From(), g.(graph.Graph))
+		p, weight := pt.To(test.query.To().ID())
+		if weight != test.wantWeight {
+			t.Errorf("unexpected weight for %q: got:%v want:%v", test.name, weight, test.wantWeight)
+		}
+		if w := pt.WeightTo(test.query.To().ID()); w != test.wantWeight {
+			t.Errorf("unexpected weight from WeightTo for %q: got:%v want:%v", test.name, w, test.wantWeight)
+		}
+		var got []int64
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		if !reflect.DeepEqual(got, test.wantPath) {
+			t.Errorf("unexpected path for %q: got:%v want:%v", test.name, got, test.wantPath)
+		}
+	}
+}
+
+func TestMinimaxFromSpanningTree(t *testing.T) {
+	t.Parallel()
+	// In an undirected graph the minimax path between two
+	// nodes is the path between them in a minimum spanning tree.
+	for _, test := range spanningTreeTests {
+		g := test.graph()
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+		mst := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		Kruskal(mst, g)
+		for _, n := range graph.NodesOf(g.Nodes()) {
+			if mst.Node(n.ID()) == nil {
+				mst.AddNode(n)
+			}
+		}
+
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			got := MinimaxFrom(u, g)
+			want := MinimaxFrom(u, mst)
+			for _, v := range graph.NodesOf(g.Nodes()) {
+				if got.WeightTo(v.ID()) != want.WeightTo(v.ID()) {
+					t.Errorf("unexpected minimax weight for %q from %d to %d: got:%v want:%v",
+						test.name, u.ID(), v.ID(), got.WeightTo(v.ID()), want.WeightTo(v.ID()))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// FindNegativeCycle returns a negative cycle in the graph g and its weight. The
+// cycle is returned as a closed path with the first node repeated at the end. If
+// g has no negative cycle, FindNegativeCycle returns nil and a weight of zero. If
+// the graph does not implement Weighted, UniformCost is used. A negative edge in
+// an undirected graph forms a negative cycle traversing the edge in both
+// directions.
+//
+// The time complexity of FindNegativeCycle is O(|V|.|E|).
+func FindNegativeCycle(g graph.Graph) (cycle []graph.Node, weight float64) {
+	var w Weighting
+	if wg, ok := g.(Weighted); ok {
+		w = wg.Weight
+	} else {
+		w = UniformCost(g)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	if len(nodes) == 0 {
+		return nil, 0
+	}
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+
+	// Run Bellman-Ford from a virtual source connected
+	// to every node by a zero weight edge, so every
+	// negative cycle in g is reachable. A relaxation
+	// in the |V|th pass identifies a node reachable
+	// from a negative cycle.
+	dist := make([]float64, len(nodes))
+	prev := make([]int, len(nodes))
+	for i := range prev {
+		prev[i] = -1
+	}
+	last := -1
+	for pass := 0; pass < len(nodes); pass++ {
+		last = -1
+		for j, u := range nodes {
+			uid := u.ID()
+			to := g.From(uid)
+			for to.Next() {
+				vid := to.Node().ID()
+				c, ok := w(uid, vid)
+				if !ok {
+					panic("negative cycle: unexpected invalid weight")
+				}
+				k := indexOf[vid]
+				if joint := dist[j] + c; joint < dist[k] {
+					dist[k] = joint
+					prev[k] = j
+					last = k
+				}
+			}
+		}
+		if last < 0 {
+			return nil, 0
+		}
+	}
+
+	// Walk back |V| steps from the last relaxed
+	// node to ensure we are on the cycle, then
+	// collect the cycle.
+	for i := 0; i < len(nodes); i++ {
+		last = prev[last]
+	}
+	cycle = []graph.Node{nodes[last]}
+	for i := prev[last]; ; i = prev[i] {
+		cycle = append(cycle, nodes[i])
+		c, _ := w(nodes[i].ID(), cycle[len(cycle)-2].ID())
+		weight += c
+		if i == last {
+			break
+		}
+	}
+	ordered.Reverse(cycle)
+	return cycle, weight
+}
@@ -0,0 +1,170 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+var findNegativeCycleTests = []struct {
+	name  string
+	g     func() graph.WeightedEdgeAdder
+	edges []simple.WeightedEdge
+
+	wantCycle  bool
+	wantNodes  []int64
+	wantWeight float64
+}{
+	{
+		name: "bellman-ford example",
+		g:    func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node('a'), T: simple.Node('b'), W: -2},
+			{F: simple.Node('a'), T: simple.Node('f'), W: 2},
+			{F: simple.Node('b'), T: simple.Node('c'), W: 6},
+			{F: simple.Node('c'), T: simple.Node('a'), W: -5},
+			{F: simple.Node('d'), T: simple.Node('c'), W: -3},
+			{F: simple.Node('d'), T: simple.Node('e'), W: 8},
+			{F: simple.Node('e'), T: simple.Node('b'), W: 9},
+			{F: simple.Node('e'), T: simple.Node('c'), W: 2},
+		},
+		wantCycle:  true,
+		wantNodes:  []int64{'a', 'b', 'c'},
+		wantWeight: -1,
+	},
+	{
+		name: "unreachable from low IDs",
+		g:    func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(5), T: simple.Node(6), W: 1},
+			{F: simple.Node(6), T: simple.Node(7), W: -3},
+			{F: simple.Node(7), T: simple.Node(5), W: 1},
+			{F: simple.Node(7), T: simple.Node(8), W: -10},
+		},
+		wantCycle:  true,
+		wantNodes:  []int64{5, 6, 7},
+		wantWeight: -1,
+	},
+	{
+		name: "arbitrage",
+		g:    func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: func() []simple.WeightedEdge {
+			// Exchange rates between currencies with
+			// an arbitrage opportunity USD→EUR→GBP→USD.
+			rates := []struct {
+				from, to int64
+				rate     float64
+			}{
+				{0, 1, 0.9}, {1, 0, 1.1},
+				{1, 2, 0.8}, {2, 1, 1.2},
+				{2, 0, 1.45}, {0, 2, 0.65},
+			}
+			var edges []simple.WeightedEdge
+			for _, r := range rates {
+				edges = append(edges, simple.WeightedEdge{F: simple.Node(r.from), T: simple.Node(r.to), W: -math.Log(r.rate)})
+			}
+			return edges
+		}(),
+		wantCycle:  true,
+		wantNodes:  []int64{0, 1, 2},
+		wantWeight: -math.Log(0.9 * 0.8 * 1.45),
+	},
+	{
+		name: "undirected negative edge",
+		g:    func() graph.WeightedEdgeAdder { return simple.NewWeightedUndirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: -1},
+		},
+		wantCycle:  true,
+		wantNodes:  []int64{1, 2},
+		wantWeight: -2,
+	},
+	{
+		name: "zero cycle",
+		g:    func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: -1},
+			{F: simple.Node(1), T: simple.Node(0), W: 1},
+		},
+	},
+}
+
+func TestFindNegativeCycle(t *testing.T) {
+	t.Parallel()
+	for _, test := range findNegativeCycleTests {
+		g := test.g()
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+		checkNegativeCycle(t, test.name, g.(graph.Graph), test.wantCycle, test.wantNodes, test.wantWeight)
+	}
+
+	for _, test := range testgraphs.ShortestPathTests {
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+		gg := g.(graph.Graph)
+		cycle, _ := FindNegativeCycle(gg)
+		if test.HasNegativeCycle != (cycle != nil) {
+			t.Errorf("%q: unexpected negative cycle result: got:%v want cycle:%t", test.Name, cycle, test.HasNegativeCycle)
+		}
+		if cycle != nil {
+			checkNegativeCycle(t, test.Name, gg, true, nil, math.NaN())
+		}
+	}
+}
+
+func checkNegativeCycle(t *testing.T, name string, g graph.Graph, wantCycle bool, wantNodes []int64, wantWeight float64) {
+	t.Helper()
+	cycle, weight := FindNegativeCycle(g)
+	if !wantCycle {
+		if cycle != nil || weight != 0 {
+			t.Errorf("%q: unexpected negative cycle: got:%v weight:%v", name, cycle, weight)
+		}
+		return
+	}
+	if len(cycle) < 2 || cycle[0].ID() != cycle[len(cycle)-1].ID() {
+		t.Errorf("%q: cycle is not closed: %v", name, cycle)
+		return
+	}
+	if !topo.IsPathIn(g, cycle) {
+		t.Errorf("%q: cycle is not a path in the graph: %v", name, cycle)
+	}
+	wg := g.(graph.Weighted)
+	var sum float64
+	for i, v := range cycle[1:] {
+		w, _ := wg.Weight(cycle[i].ID(), v.ID())
+		sum += w
+	}
+	if math.Abs(sum-weight) > 1e-12 || weight >= 0 {
+		t.Errorf("%q: unexpected cycle weight: got:%v sum:%v", name, weight, sum)
+	}
+	if !math.IsNaN(wantWeight) && math.Abs(weight-wantWeight) > 1e-12 {
+		t.Errorf("%q: unexpected cycle weight: got:%v want:%v", name, weight, wantWeight)
+	}
+	if wantNodes != nil {
+		got := make(map[int64]bool)
+		for _, n := range cycle[:len(cycle)-1] {
+			got[n.ID()] = true
+		}
+		if len(got) != len(wantNodes) || len(cycle)-1 != len(wantNodes) {
+			t.Errorf("%q: unexpected cycle nodes: got:%v want:%c", name, cycle, wantNodes)
+		}
+		for _, id := range wantNodes {
+			if !got[id] {
+				t.Errorf("%q: missing cycle node %d in %v", name, id, cycle)
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// NodeCostWeighting returns a Weighting that adds the cost of entering each
+// node to the edge weights given by weight. The returned weight of the edge
+// from x to y is the weight of the edge plus cost(yid), so the weight of a
+// path is the sum of its edge weights and the costs of all its nodes except
+// the first. If weight is nil, edge weights are obtained from g if it
+// implements Weighted, otherwise UniformCost is used. The weight of a node to
+// itself is not altered.
+//
+// The returned Weighting is not symmetric for undirected graphs; it must be
+// used with searches that evaluate the weight of each edge in the direction
+// of travel, such as DijkstraFrom, AStar and BellmanFordFrom.
+func NodeCostWeighting(g traverse.Graph, weight Weighting, cost func(id int64) float64) Weighting {
+	if weight == nil {
+		if wg, ok := g.(Weighted); ok {
+			weight = wg.Weight
+		} else {
+			weight = UniformCost(g)
+		}
+	}
+	return func(xid, yid int64) (w float64, ok bool) {
+		w, ok = weight(xid, yid)
+		if !ok || xid == yid {
+			return w, ok
+		}
+		return w + cost(yid), true
+	}
+}
+
+// WithNodeCosts returns a view of g that is Weighted with the weights of
+// NodeCostWeighting(g, nil, cost). The returned graph is a graph.Graph if g
+// is a graph.Graph.
+//
+// Shortest paths in the returned graph minimize the sum of edge weights and
+// node entry costs. WithNodeCosts is an alternative to splitting each node v
+// into an entry node and an exit node joined by an edge with weight cost(v).
+// Node costs must be non-negative for use with DijkstraFrom, and a heuristic
+// for g remains admissible for AStar searches of the returned graph.
+func WithNodeCosts(g traverse.Graph, cost func(id int64) float64) traverse.Graph {
+	weight := NodeCostWeighting(g, nil, cost)
+	if gg, ok := g.(graph.Graph); ok {
+		return reweighted{Graph: gg, weight: weight}
+	}
+	return nodeCost{Graph: g, weight: weight}
+}
+
+// nodeCost is a Weighted traverse.Graph with weights given
+// by a Weighting.
+type nodeCost struct {
+	traverse.Graph
+	weight Weighting
+}
+
+func (g nodeCost) Weight(xid, yid int64) (w float64, ok bool) {
+	return g.weight(xid, yid)
+}
@@ -0,0 +1,105 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestWithNodeCosts(t *testing.T) {
+	t.Parallel()
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 2},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	cost := func(id int64) float64 {
+		if id == 1 {
+			return 5
+		}
+		return 0.5
+	}
+
+	pt := DijkstraFrom(simple.Node(0), g)
+	p, w := pt.To(3)
+	if got, want := nodeIDsOf(p), []int64{0, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected path without node costs: got:%v want:%v", got, want)
+	}
+	if w != 2 {
+		t.Errorf("unexpected weight without node costs: got:%v want:2", w)
+	}
+
+	pt = DijkstraFrom(simple.Node(0), WithNodeCosts(g, cost))
+	p, w = pt.To(3)
+	if got, want := nodeIDsOf(p), []int64{0, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected path with node costs: got:%v want:%v", got, want)
+	}
+	if w != 5 {
+		t.Errorf("unexpected weight with node costs: got:%v want:5", w)
+	}
+
+	// The search from the other end must pay the
+	// entry cost of the original start node.
+	pt = DijkstraFrom(simple.Node(3), WithNodeCosts(g, cost))
+	if w := pt.WeightTo(0); w != 5 {
+		t.Errorf("unexpected reverse weight with node costs: got:%v want:5", w)
+	}
+}
+
+func TestWithNodeCostsSplitting(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		const n = 20
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		costs := make([]float64, n)
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+			costs[i] = float64(rnd.Intn(10))
+		}
+		for i := 0; i < 3*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(10))})
+		}
+
+		// split has an entry node 2v and an exit node 2v+1
+		// for each node v of g, joined by an edge with the
+		// cost of v.
+		split := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for i, c := range costs {
+			split.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2 * i), T: simple.Node(2*i + 1), W: c})
+		}
+		edges := g.WeightedEdges()
+		for edges.Next() {
+			e := edges.WeightedEdge()
+			split.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(2*e.From().ID() + 1), T: simple.Node(2 * e.To().ID()), W: e.Weight()})
+		}
+
+		s := rnd.Intn(n)
+		got := DijkstraFrom(simple.Node(s), WithNodeCosts(g, func(id int64) float64 { return costs[id] }))
+		want := DijkstraFrom(simple.Node(2*s+1), split)
+		for v := int64(0); v < n; v++ {
+			if v == int64(s) {
+				continue
+			}
+			if gw, ww := got.WeightTo(v), want.WeightTo(2*v+1); gw != ww {
+				t.Errorf("unexpected weight for trial %d from %d to %d: got:%v want:%v", trial, s, v, gw, ww)
+			}
+		}
+	}
+}
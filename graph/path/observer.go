@@ -0,0 +1,68 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// Observer receives notification of the progress of a shortest path search.
+// Observers may be used to collect search statistics, to visualize the search
+// frontier or to log search progress. An Observer must not modify the graph
+// being searched.
+type Observer interface {
+	// OnExpand is called when the search expands
+	// the node n, reached with the given cost.
+	OnExpand(n graph.Node, cost float64)
+
+	// OnGenerate is called when the search first
+	// reaches the node n with the given cost.
+	OnGenerate(n graph.Node, cost float64)
+
+	// OnRelax is called when the search finds a
+	// cheaper path to the previously generated
+	// node v via the node u with the given cost.
+	OnRelax(u, v graph.Node, cost float64)
+
+	// OnDone is called when the search completes
+	// with the number of nodes that were expanded.
+	OnDone(expanded int)
+}
+
+// ObserverFuncs is an Observer that calls its non-nil fields.
+type ObserverFuncs struct {
+	Expand   func(n graph.Node, cost float64)
+	Generate func(n graph.Node, cost float64)
+	Relax    func(u, v graph.Node, cost float64)
+	Done     func(expanded int)
+}
+
+var _ Observer = ObserverFuncs{}
+
+// OnExpand calls o.Expand if it is not nil.
+func (o ObserverFuncs) OnExpand(n graph.Node, cost float64) {
+	if o.Expand != nil {
+		o.Expand(n, cost)
+	}
+}
+
+// OnGenerate calls o.Generate if it is not nil.
+func (o ObserverFuncs) OnGenerate(n graph.Node, cost float64) {
+	if o.Generate != nil {
+		o.Generate(n, cost)
+	}
+}
+
+// OnRelax calls o.Relax if it is not nil.
+func (o ObserverFuncs) OnRelax(u, v graph.Node, cost float64) {
+	if o.Relax != nil {
+		o.Relax(u, v, cost)
+	}
+}
+
+// OnDone calls o.Done if it is not nil.
+func (o ObserverFuncs) OnDone(expanded int) {
+	if o.Done != nil {
+		o.Done(expanded)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// searchRecorder is an Observer that checks the
+// consistency of the notifications it receives.
+type searchRecorder struct {
+	t    *testing.T
+	name string
+
+	cost     map[int64]float64
+	expanded map[int64]float64
+	done     []int
+}
+
+func newSearchRecorder(t *testing.T, name string) *searchRecorder {
+	return &searchRecorder{
+		t:        t,
+		name:     name,
+		cost:     make(map[int64]float64),
+		expanded: make(map[int64]float64),
+	}
+}
+
+func (r *searchRecorder) OnExpand(n graph.Node, cost float64) {
+	id := n.ID()
+	if _, ok := r.cost[id]; !ok {
+		r.t.Errorf("%q: node %d expanded before it was generated", r.name, id)
+	}
+	if _, ok := r.expanded[id]; ok {
+		r.t.Errorf("%q: node %d expanded more than once", r.name, id)
+	}
+	if cost != r.cost[id] {
+		r.t.Errorf("%q: unexpected expansion cost for node %d: got:%v want:%v", r.name, id, cost, r.cost[id])
+	}
+	r.expanded[id] = cost
+}
+
+func (r *searchRecorder) OnGenerate(n graph.Node, cost float64) {
+	id := n.ID()
+	if _, ok := r.cost[id]; ok {
+		r.t.Errorf("%q: node %d generated more than once", r.name, id)
+	}
+	r.cost[id] = cost
+}
+
+func (r *searchRecorder) OnRelax(u, v graph.Node, cost float64) {
+	uid, vid := u.ID(), v.ID()
+	if _, ok := r.expanded[uid]; !ok {
+		r.t.Errorf("%q: relaxation from unexpanded node %d", r.name, uid)
+	}
+	prev, ok := r.cost[vid]
+	if !ok {
+		r.t.Errorf("%q: node %d relaxed before it was generated", r.name, vid)
+	}
+	if cost >= prev {
+		r.t.Errorf("%q: relaxation of node %d did not reduce cost: got:%v previous:%v", r.name, vid, cost, prev)
+	}
+	r.cost[vid] = cost
+}
+
+func (r *searchRecorder) OnDone(expanded int) {
+	r.done = append(r.done, expanded)
+}
+
+func TestAStarWithObserver(t *testing.T) {
+	t.Parallel()
+	for _, test := range aStarTests {
+		if test.name == "large open graph" {
+			continue
+		}
+		obs := newSearchRecorder(t, test.name)
+		pt, expanded := AStarWith(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic, &AStarSettings{Observer: obs})
+
+		if len(obs.done) != 1 || obs.done[0] != expanded {
+			t.Errorf("unexpected done notification for %q: got:%v want:[%d]", test.name, obs.done, expanded)
+		}
+		if len(obs.expanded) != expanded {
+			t.Errorf("unexpected number of expansion notifications for %q: got:%d want:%d", test.name, len(obs.expanded), expanded)
+		}
+		for id, cost := range obs.expanded {
+			if want := pt.WeightTo(id); cost != want {
+				t.Errorf("unexpected expansion cost for node %d in %q: got:%v want:%v", id, test.name, cost, want)
+			}
+		}
+
+		want, wantExpanded := AStar(simple.Node(test.s), simple.Node(test.t), test.g, test.heuristic)
+		if expanded != wantExpanded {
+			t.Errorf("unexpected number of expanded nodes for %q: got:%d want:%d", test.name, expanded, wantExpanded)
+		}
+		if got, want := pt.WeightTo(test.t), want.WeightTo(test.t); got != want {
+			t.Errorf("unexpected cost for %q: got:%v want:%v", test.name, got, want)
+		}
+	}
+}
+
+func TestDijkstraFromWithObserver(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+
+		obs := newSearchRecorder(t, test.Name)
+		pt := DijkstraFromWith(test.Query.From(), g.(graph.Graph), &DijkstraSettings{Observer: obs})
+
+		var reachable int
+		for _, n := range graph.NodesOf(g.(graph.Graph).Nodes()) {
+			id := n.ID()
+			want := pt.WeightTo(id)
+			if math.IsInf(want, 1) {
+				if _, ok := obs.cost[id]; ok {
+					t.Errorf("%q: unreachable node %d was generated", test.Name, id)
+				}
+				continue
+			}
+			reachable++
+			cost, ok := obs.expanded[id]
+			if !ok {
+				t.Errorf("%q: reachable node %d was not expanded", test.Name, id)
+				continue
+			}
+			if cost != want {
+				t.Errorf("%q: unexpected expansion cost for node %d: got:%v want:%v", test.Name, id, cost, want)
+			}
+		}
+		if len(obs.done) != 1 || obs.done[0] != reachable {
+			t.Errorf("unexpected done notification for %q: got:%v want:[%d]", test.Name, obs.done, reachable)
+		}
+	}
+}
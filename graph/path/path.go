@@ -0,0 +1,157 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Path is a path through a graph. A Path holds the sequence of nodes
+// in the path, the sequence of weighted edges between consecutive
+// nodes and the cumulative weight of the path at each node.
+//
+// The zero value of Path is an empty path with an infinite weight,
+// representing the absence of a path.
+type Path struct {
+	nodes []graph.Node
+	edges []graph.WeightedEdge
+	// cum holds the weight of the path
+	// up to and including each node.
+	cum []float64
+}
+
+// NewPath returns a Path through the given nodes of the graph g. The weight
+// of each edge of the path is obtained from g if it implements Weighted,
+// otherwise UniformCost is used. NewPath will panic if consecutive nodes in
+// nodes are not connected in g.
+func NewPath(g graph.Graph, nodes []graph.Node) Path {
+	if len(nodes) == 0 {
+		return Path{}
+	}
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	p := Path{
+		nodes: nodes,
+		edges: make([]graph.WeightedEdge, len(nodes)-1),
+		cum:   make([]float64, len(nodes)),
+	}
+	for i, v := range nodes[1:] {
+		u := nodes[i]
+		e := g.Edge(u.ID(), v.ID())
+		if e == nil {
+			panic("path: nodes not connected")
+		}
+		w, ok := weight(u.ID(), v.ID())
+		if !ok {
+			panic("path: unexpected invalid weight")
+		}
+		p.edges[i] = pathEdge{Edge: e, weight: w}
+		p.cum[i+1] = p.cum[i] + w
+	}
+	return p
+}
+
+// newPathFromWeights returns a Path through nodes with cumulative
+// path weights given by cum. The edges of the returned Path are
+// constructed from the nodes.
+func newPathFromWeights(nodes []graph.Node, cum []float64) Path {
+	if len(nodes) == 0 {
+		return Path{}
+	}
+	p := Path{
+		nodes: nodes,
+		edges: make([]graph.WeightedEdge, len(nodes)-1),
+		cum:   cum,
+	}
+	for i, v := range nodes[1:] {
+		p.edges[i] = pathEdge{Edge: edge{from: nodes[i], to: v}, weight: cum[i+1] - cum[i]}
+	}
+	return p
+}
+
+// Len returns the number of nodes in the path.
+func (p Path) Len() int { return len(p.nodes) }
+
+// Nodes returns the nodes of the path in order. The returned
+// slice must not be altered.
+func (p Path) Nodes() []graph.Node { return p.nodes }
+
+// Edges returns the edges of the path in order. The returned
+// slice must not be altered.
+func (p Path) Edges() []graph.WeightedEdge { return p.edges }
+
+// Weight returns the total weight of the path. The weight of
+// an empty path is +Inf.
+func (p Path) Weight() float64 {
+	if len(p.nodes) == 0 {
+		return math.Inf(1)
+	}
+	return p.cum[len(p.cum)-1]
+}
+
+// WeightAt returns the cumulative weight of the path from its start
+// to the ith node. WeightAt will panic if i is out of range.
+func (p Path) WeightAt(i int) float64 { return p.cum[i] }
+
+// Contains returns whether the path includes the node n.
+func (p Path) Contains(n graph.Node) bool {
+	id := n.ID()
+	for _, u := range p.nodes {
+		if u.ID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Reverse returns a new Path with the nodes and edges of p in reverse
+// order. The edges of the returned path are the reversed edges of p.
+func (p Path) Reverse() Path {
+	if len(p.nodes) == 0 {
+		return Path{}
+	}
+	n := len(p.nodes)
+	r := Path{
+		nodes: make([]graph.Node, n),
+		edges: make([]graph.WeightedEdge, n-1),
+		cum:   make([]float64, n),
+	}
+	total := p.Weight()
+	for i, u := range p.nodes {
+		r.nodes[n-1-i] = u
+		r.cum[n-1-i] = total - p.cum[i]
+	}
+	for i, e := range p.edges {
+		r.edges[n-2-i] = e.ReversedEdge().(graph.WeightedEdge)
+	}
+	return r
+}
+
+// pathEdge is a graph.Edge with a path weight.
+type pathEdge struct {
+	graph.Edge
+	weight float64
+}
+
+func (e pathEdge) ReversedEdge() graph.Edge {
+	return pathEdge{Edge: e.Edge.ReversedEdge(), weight: e.weight}
+}
+func (e pathEdge) Weight() float64 { return e.weight }
+
+// edge is a graph.Edge between two nodes.
+type edge struct {
+	from, to graph.Node
+}
+
+func (e edge) From() graph.Node         { return e.from }
+func (e edge) To() graph.Node           { return e.to }
+func (e edge) ReversedEdge() graph.Edge { return edge{from: e.to, to: e.from} }
@@ -0,0 +1,137 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func nodeIDsOf(nodes []graph.Node) []int64 {
+	var ids []int64
+	for _, n := range nodes {
+		ids = append(ids, n.ID())
+	}
+	return ids
+}
+
+func TestNewPath(t *testing.T) {
+	t.Parallel()
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 2},
+		{F: simple.Node(1), T: simple.Node(2), W: 3},
+		{F: simple.Node(2), T: simple.Node(3), W: 0.5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	p := NewPath(g, []graph.Node{simple.Node(0), simple.Node(1), simple.Node(2), simple.Node(3)})
+	if p.Len() != 4 {
+		t.Errorf("unexpected path length: got:%d want:4", p.Len())
+	}
+	if w := p.Weight(); w != 5.5 {
+		t.Errorf("unexpected path weight: got:%v want:5.5", w)
+	}
+	wantCum := []float64{0, 2, 5, 5.5}
+	for i, want := range wantCum {
+		if got := p.WeightAt(i); got != want {
+			t.Errorf("unexpected cumulative weight at %d: got:%v want:%v", i, got, want)
+		}
+	}
+	wantEdges := [][3]float64{{0, 1, 2}, {1, 2, 3}, {2, 3, 0.5}}
+	for i, e := range p.Edges() {
+		got := [3]float64{float64(e.From().ID()), float64(e.To().ID()), e.Weight()}
+		if got != wantEdges[i] {
+			t.Errorf("unexpected edge %d: got:%v want:%v", i, got, wantEdges[i])
+		}
+	}
+	if !p.Contains(simple.Node(2)) || p.Contains(simple.Node(4)) {
+		t.Error("unexpected result from Contains")
+	}
+
+	r := p.Reverse()
+	if got, want := nodeIDsOf(r.Nodes()), []int64{3, 2, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected reversed path: got:%v want:%v", got, want)
+	}
+	if w := r.Weight(); w != 5.5 {
+		t.Errorf("unexpected reversed path weight: got:%v want:5.5", w)
+	}
+	wantRevCum := []float64{0, 0.5, 3.5, 5.5}
+	for i, want := range wantRevCum {
+		if got := r.WeightAt(i); got != want {
+			t.Errorf("unexpected reversed cumulative weight at %d: got:%v want:%v", i, got, want)
+		}
+	}
+	for i, e := range r.Edges() {
+		if e.From().ID() != r.Nodes()[i].ID() || e.To().ID() != r.Nodes()[i+1].ID() {
+			t.Errorf("unexpected reversed edge %d: got:%d->%d", i, e.From().ID(), e.To().ID())
+		}
+		if e.Weight() != wantEdges[len(wantEdges)-1-i][2] {
+			t.Errorf("unexpected reversed edge weight %d: got:%v", i, e.Weight())
+		}
+	}
+
+	var empty Path
+	if !math.IsInf(empty.Weight(), 1) || empty.Len() != 0 || empty.Reverse().Len() != 0 {
+		t.Error("unexpected empty path behavior")
+	}
+
+	panicked := func() (panicked bool) {
+		defer func() {
+			panicked = recover() != nil
+		}()
+		NewPath(g, []graph.Node{simple.Node(0), simple.Node(2)})
+		return false
+	}()
+	if !panicked {
+		t.Error("expected panic for disconnected path nodes")
+	}
+}
+
+func TestPathTo(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+
+		pt := DijkstraFrom(test.Query.From(), g.(graph.Graph))
+		want, weight := pt.To(test.Query.To().ID())
+		got := pt.PathTo(test.Query.To().ID())
+		if !reflect.DeepEqual(nodeIDsOf(got.Nodes()), nodeIDsOf(want)) {
+			t.Errorf("%q: unexpected path from PathTo: got:%v want:%v", test.Name, got.Nodes(), want)
+		}
+		if got.Weight() != weight {
+			t.Errorf("%q: unexpected weight from PathTo: got:%v want:%v", test.Name, got.Weight(), weight)
+		}
+		if len(want) != 0 {
+			np := NewPath(g.(graph.Graph), want)
+			if np.Weight() != weight {
+				t.Errorf("%q: unexpected weight from NewPath: got:%v want:%v", test.Name, np.Weight(), weight)
+			}
+		}
+
+		apt := DijkstraAllPaths(g.(graph.Graph))
+		ap := apt.PathBetween(test.Query.From().ID(), test.Query.To().ID())
+		if ap.Weight() != test.Weight {
+			t.Errorf("%q: unexpected weight from PathBetween: got:%v want:%v", test.Name, ap.Weight(), test.Weight)
+		}
+		for i, e := range ap.Edges() {
+			if math.Abs(ap.WeightAt(i)+e.Weight()-ap.WeightAt(i+1)) > 1e-12 {
+				t.Errorf("%q: inconsistent cumulative weights from PathBetween at %d", test.Name, i)
+			}
+		}
+	}
+}
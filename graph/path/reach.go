@@ -0,0 +1,184 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// Reach is a reach preprocessing of a graph. The reach of a node v is the
+// maximum over all shortest paths from s to t passing through v of
+// min(d(s, v), d(v, t)). A node whose reach is less than both its distance
+// from the source of a search and a lower bound on its distance to the target
+// cannot lie on a shortest path to the target and need not be expanded.
+//
+// See Gutman, "Reach-based routing: a new approach to shortest path algorithms
+// optimized for road networks", ALENEX 2004.
+type Reach struct {
+	g      graph.Graph
+	weight Weighting
+
+	reach map[int64]float64
+}
+
+// NewReach returns the reach preprocessing of g. If the graph does not implement
+// Weighted, UniformCost is used. NewReach will panic if g has a negative edge
+// weight.
+//
+// The reach of each node is computed from a shortest-path tree rooted at every
+// node, so the time complexity of NewReach is O(|V|.|E|.log|V|). When shortest
+// paths are not unique, the reach held for a node only considers the paths in
+// these trees and may be less than its reach over all shortest paths, but is
+// still sufficient for pruning searches.
+func NewReach(g graph.Graph) *Reach {
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	r := &Reach{
+		g:      g,
+		weight: weight,
+		reach:  make(map[int64]float64, len(nodes)),
+	}
+	for _, u := range nodes {
+		r.reach[u.ID()] = 0
+	}
+
+	var (
+		children [][]int
+		height   []float64
+		stack    []int
+	)
+	for _, s := range nodes {
+		tree := DijkstraFrom(s, g)
+
+		// Find the height of each node in the shortest-path
+		// tree, the distance to its farthest descendant.
+		children = children[:0]
+		for range tree.nodes {
+			children = append(children, nil)
+		}
+		for j, p := range tree.next {
+			if p >= 0 {
+				children[p] = append(children[p], j)
+			}
+		}
+		height = height[:0]
+		for range tree.nodes {
+			height = append(height, 0)
+		}
+		stack = append(stack[:0], tree.indexOf[s.ID()])
+		for i := 0; i < len(stack); i++ {
+			stack = append(stack, children[stack[i]]...)
+		}
+		// Children follow their parents in stack.
+		for i := len(stack) - 1; i > 0; i-- {
+			j := stack[i]
+			p := tree.next[j]
+			if h := height[j] + tree.dist[j] - tree.dist[p]; h > height[p] {
+				height[p] = h
+			}
+		}
+
+		for _, j := range stack {
+			id := tree.nodes[j].ID()
+			if rv := math.Min(tree.dist[j], height[j]); rv > r.reach[id] {
+				r.reach[id] = rv
+			}
+		}
+	}
+
+	return r
+}
+
+// Reach returns the reach of the node with the given ID. If the node is not in
+// the preprocessed graph, Reach returns zero.
+func (r *Reach) Reach(id int64) float64 {
+	return r.reach[id]
+}
+
+// Shortest returns the shortest path from s to t in the preprocessed graph. The
+// search is performed with A* using the heuristic h, and nodes are not expanded
+// if their reach shows that they cannot lie on a shortest path to t. The path and
+// its cost are returned in a Shortest along with paths and costs to all nodes
+// explored during the search. The number of expanded nodes is also returned.
+//
+// Pruning uses h as a lower bound on the distance to t, so h must be admissible
+// and should be consistent. If h is nil, Shortest will use the g.HeuristicCost
+// method if g implements HeuristicCoster, falling back to NullHeuristic otherwise.
+// No nodes are pruned with NullHeuristic.
+func (r *Reach) Shortest(s, t graph.Node, h Heuristic) (path Shortest, expanded int) {
+	if r.g == nil {
+		panic("path: reach preprocessing has no graph")
+	}
+	if r.g.Node(s.ID()) == nil || r.g.Node(t.ID()) == nil {
+		return Shortest{from: s}, 0
+	}
+	if h == nil {
+		if g, ok := r.g.(HeuristicCoster); ok {
+			h = g.HeuristicCost
+		} else {
+			h = NullHeuristic
+		}
+	}
+
+	path = newShortestFrom(s, []graph.Node{s, t})
+	tid := t.ID()
+
+	open := NewBinaryHeap()
+	visited := make(set.Int64s)
+	open.Push(s, 0, h(s, t))
+
+	for open.Len() != 0 {
+		u, gscore := open.Pop()
+		uid := u.ID()
+		i := path.indexOf[uid]
+		visited.Add(uid)
+
+		if uid == tid {
+			expanded++
+			break
+		}
+		if rv := r.reach[uid]; rv < gscore && rv < h(u, t) {
+			continue
+		}
+		expanded++
+
+		to := r.g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if visited.Has(vid) {
+				continue
+			}
+			j, ok := path.indexOf[vid]
+			if !ok {
+				j = path.add(v)
+			}
+
+			w, ok := r.weight(uid, vid)
+			if !ok {
+				panic("reach: unexpected invalid weight")
+			}
+			g := gscore + w
+			if vg, ok := open.Score(vid); !ok {
+				path.set(j, g, i)
+				open.Push(v, g, g+h(v, t))
+			} else if g < vg {
+				path.set(j, g, i)
+				open.Decrease(vid, g, g+h(v, t))
+			}
+		}
+	}
+
+	return path, expanded
+}
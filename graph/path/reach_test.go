@@ -0,0 +1,136 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestReach(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+
+		pt, _ := NewReach(g.(graph.Graph)).Shortest(test.Query.From(), test.Query.To(), nil)
+		p, weight := pt.To(test.Query.To().ID())
+		if weight != test.Weight {
+			t.Errorf("%q: unexpected weight from To: got:%f want:%f",
+				test.Name, weight, test.Weight)
+		}
+
+		var got []int64
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		ok := len(got) == 0 && len(test.WantPaths) == 0
+		for _, sp := range test.WantPaths {
+			if reflect.DeepEqual(got, sp) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			t.Errorf("%q: unexpected shortest path:\ngot: %v\nwant from:%v",
+				test.Name, p, test.WantPaths)
+		}
+	}
+}
+
+func TestReachValues(t *testing.T) {
+	t.Parallel()
+	// A path 0-1-2-3-4 with a spur 5 from 2.
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(1), T: simple.Node(2), W: 2},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+		{F: simple.Node(2), T: simple.Node(5), W: 5},
+	} {
+		g.SetWeightedEdge(e)
+	}
+	want := map[int64]float64{0: 0, 1: 1, 2: 3, 3: 1, 4: 0, 5: 0}
+
+	r := NewReach(g)
+	for id, w := range want {
+		if got := r.Reach(id); got != w {
+			t.Errorf("unexpected reach for node %d: got:%v want:%v", id, got, w)
+		}
+	}
+}
+
+func TestReachGrid(t *testing.T) {
+	t.Parallel()
+	const size = 8
+	for _, directed := range []bool{false, true} {
+		var g interface {
+			graph.Weighted
+			graph.WeightedEdgeAdder
+		}
+		if directed {
+			g = simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		} else {
+			g = simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		}
+		id := func(r, c int) simple.Node { return simple.Node(r*size + c) }
+		for r := 0; r < size; r++ {
+			for c := 0; c < size; c++ {
+				w := float64(1 + (r*c)%3)
+				if c+1 < size {
+					g.SetWeightedEdge(simple.WeightedEdge{F: id(r, c), T: id(r, c+1), W: w})
+				}
+				if r+1 < size {
+					g.SetWeightedEdge(simple.WeightedEdge{F: id(r, c), T: id(r+1, c), W: w})
+				}
+				if directed && r > 0 && c > 0 {
+					g.SetWeightedEdge(simple.WeightedEdge{F: id(r, c), T: id(r-1, c-1), W: 2 * w})
+				}
+			}
+		}
+		// Every edge has a weight of at least one for each
+		// row and column moved, so the Manhattan distance is
+		// an admissible and consistent heuristic.
+		h := func(u, v graph.Node) float64 {
+			ur, uc := u.ID()/size, u.ID()%size
+			vr, vc := v.ID()/size, v.ID()%size
+			return math.Abs(float64(ur-vr)) + math.Abs(float64(uc-vc))
+		}
+
+		reach := NewReach(g)
+		var expanded, full int
+		for _, s := range graph.NodesOf(g.Nodes()) {
+			want := DijkstraFrom(s, g)
+			for _, u := range graph.NodesOf(g.Nodes()) {
+				got, n := reach.Shortest(s, u, h)
+				if got.WeightTo(u.ID()) != want.WeightTo(u.ID()) {
+					t.Errorf("unexpected weight from %d to %d directed=%t: got:%f want:%f",
+						s.ID(), u.ID(), directed, got.WeightTo(u.ID()), want.WeightTo(u.ID()))
+				}
+				p, _ := got.To(u.ID())
+				if len(p) == 0 || p[0].ID() != s.ID() || p[len(p)-1].ID() != u.ID() {
+					t.Errorf("unexpected path from %d to %d directed=%t: %v", s.ID(), u.ID(), directed, p)
+				}
+				expanded += n
+				_, n = AStar(s, u, g, h)
+				full += n
+			}
+		}
+		if expanded >= full {
+			t.Errorf("reach did not reduce search space directed=%t: expanded %d of %d", directed, expanded, full)
+		}
+	}
+}
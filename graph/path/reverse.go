@@ -0,0 +1,30 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import "gonum.org/v1/gonum/graph"
+
+// reversed is a directed graph with the direction of all its edges
+// reversed. It is used for searches toward a target node.
+type reversed struct {
+	graph.Directed
+	weight Weighting
+}
+
+func (g reversed) From(id int64) graph.Nodes { return g.Directed.To(id) }
+
+func (g reversed) To(id int64) graph.Nodes { return g.Directed.From(id) }
+
+func (g reversed) HasEdgeFromTo(uid, vid int64) bool { return g.Directed.HasEdgeFromTo(vid, uid) }
+
+func (g reversed) Edge(uid, vid int64) graph.Edge {
+	e := g.Directed.Edge(vid, uid)
+	if e == nil {
+		return nil
+	}
+	return e.ReversedEdge()
+}
+
+func (g reversed) Weight(xid, yid int64) (w float64, ok bool) { return g.weight(yid, xid) }
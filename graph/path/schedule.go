@@ -0,0 +1,213 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// ScheduledTask is the placement of a task in a schedule.
+type ScheduledTask struct {
+	Node graph.Node
+
+	// Processor is the index of the
+	// processor running the task.
+	Processor int
+
+	// Start and Finish are the times
+	// the task starts and finishes.
+	Start, Finish float64
+}
+
+// DeadlineMiss is an error indicating that a task of a schedule finishes
+// after its deadline.
+type DeadlineMiss struct {
+	Task     ScheduledTask
+	Deadline float64
+
+	// Chain is the chain of tasks ending with
+	// the late task in which each task starts
+	// as soon as the task before it finishes.
+	// The first task of the chain was delayed
+	// only by the availability of processors,
+	// or started at time zero.
+	Chain []graph.Node
+}
+
+func (e *DeadlineMiss) Error() string {
+	return fmt.Sprintf("path: task %d finishes at %v after deadline %v", e.Task.Node.ID(), e.Task.Finish, e.Deadline)
+}
+
+// EDFSchedule returns a non-preemptive schedule of the tasks represented by
+// the nodes of the directed acyclic graph g on the given number of identical
+// processors. An edge from u to v in g requires that task u finish before
+// task v starts. The durations and deadlines of tasks are given by duration
+// and deadline. A nil deadline or a deadline of +Inf places no limit on the
+// finishing time of a task.
+//
+// Tasks are list scheduled by earliest deadline first. Each deadline is first
+// tightened so that the task finishes in time for the deadlines of its
+// successors,
+//
+//  d'(u) = min(d(u), min_{u→v} d'(v) - duration(v)),
+//
+// and whenever a processor becomes free, it is given the ready task with the
+// earliest tightened deadline that can start soonest, with ties broken by
+// node ID. The returned schedule is ordered by start time.
+//
+// If g is not acyclic, EDFSchedule returns a nil schedule and a
+// topo.Unorderable error. If a task finishes after its deadline, the complete
+// schedule is returned with a *DeadlineMiss error describing the task with
+// the greatest lateness and the chain of tasks that determined its start.
+// EDFSchedule will panic if processors is less than one or a duration is
+// negative.
+func EDFSchedule(g graph.Directed, processors int, duration, deadline func(graph.Node) float64) ([]ScheduledTask, error) {
+	if processors < 1 {
+		panic("path: invalid number of processors")
+	}
+	sorted, err := topo.Sort(g)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(sorted)
+	indexOf := make(map[int64]int, n)
+	for i, u := range sorted {
+		indexOf[u.ID()] = i
+	}
+	dur := make([]float64, n)
+	due := make([]float64, n)
+	for i, u := range sorted {
+		dur[i] = duration(u)
+		if dur[i] < 0 {
+			panic("path: negative task duration")
+		}
+		due[i] = math.Inf(1)
+		if deadline != nil {
+			due[i] = deadline(u)
+		}
+	}
+
+	// Tighten the deadlines in reverse
+	// topological order.
+	eff := make([]float64, n)
+	copy(eff, due)
+	for i := n - 1; i >= 0; i-- {
+		to := g.From(sorted[i].ID())
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			eff[i] = math.Min(eff[i], eff[j]-dur[j])
+		}
+	}
+
+	waiting := make([]int, n)
+	for i := range sorted {
+		waiting[i] = g.To(sorted[i].ID()).Len()
+	}
+	release := make([]float64, n)
+	var ready []int
+	for i, w := range waiting {
+		if w == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	free := make([]float64, processors)
+	placed := make([]ScheduledTask, n)
+	schedule := make([]ScheduledTask, 0, n)
+	for len(ready) != 0 {
+		p := 0
+		for q, f := range free {
+			if f < free[p] {
+				p = q
+			}
+		}
+
+		// Find the earliest time a ready task
+		// can start on the free processor, and
+		// choose among the tasks that can start
+		// then.
+		start := math.Inf(1)
+		for _, i := range ready {
+			start = math.Min(start, math.Max(free[p], release[i]))
+		}
+		best := -1
+		for k, i := range ready {
+			if release[i] > start {
+				continue
+			}
+			if best < 0 || eff[i] < eff[ready[best]] ||
+				(eff[i] == eff[ready[best]] && sorted[i].ID() < sorted[ready[best]].ID()) {
+				best = k
+			}
+		}
+		i := ready[best]
+		ready[best] = ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+
+		t := ScheduledTask{Node: sorted[i], Processor: p, Start: start, Finish: start + dur[i]}
+		free[p] = t.Finish
+		placed[i] = t
+		schedule = append(schedule, t)
+
+		to := g.From(sorted[i].ID())
+		for to.Next() {
+			j := indexOf[to.Node().ID()]
+			release[j] = math.Max(release[j], t.Finish)
+			waiting[j]--
+			if waiting[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+	sort.SliceStable(schedule, func(i, j int) bool { return schedule[i].Start < schedule[j].Start })
+
+	late := -1
+	for i, t := range placed {
+		if t.Finish <= due[i] {
+			continue
+		}
+		if late < 0 || t.Finish-due[i] > placed[late].Finish-due[late] {
+			late = i
+		}
+	}
+	if late < 0 {
+		return schedule, nil
+	}
+	return schedule, &DeadlineMiss{
+		Task:     placed[late],
+		Deadline: due[late],
+		Chain:    criticalChain(g, late, sorted, indexOf, placed),
+	}
+}
+
+// criticalChain returns the chain of tasks ending with the task with index
+// i in which each task starts when the task before it finishes.
+func criticalChain(g graph.Directed, i int, sorted []graph.Node, indexOf map[int64]int, placed []ScheduledTask) []graph.Node {
+	chain := []graph.Node{sorted[i]}
+	for {
+		prev := -1
+		from := g.To(sorted[i].ID())
+		for from.Next() {
+			j := indexOf[from.Node().ID()]
+			if placed[j].Finish == placed[i].Start && (prev < 0 || sorted[j].ID() < sorted[prev].ID()) {
+				prev = j
+			}
+		}
+		if prev < 0 {
+			break
+		}
+		chain = append(chain, sorted[prev])
+		i = prev
+	}
+	ordered.Reverse(chain)
+	return chain
+}
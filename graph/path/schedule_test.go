@@ -0,0 +1,171 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+type scheduleTask struct {
+	duration, deadline float64
+}
+
+var edfScheduleTests = []struct {
+	name       string
+	tasks      []scheduleTask
+	edges      []simple.Edge
+	processors int
+
+	want      []ScheduledTask
+	wantLate  int64
+	wantChain []int64
+}{
+	{
+		name: "independent",
+		tasks: []scheduleTask{
+			0: {duration: 3, deadline: 10},
+			1: {duration: 2, deadline: 4},
+			2: {duration: 2, deadline: 7},
+		},
+		processors: 1,
+		want: []ScheduledTask{
+			{Node: simple.Node(1), Start: 0, Finish: 2},
+			{Node: simple.Node(2), Start: 2, Finish: 4},
+			{Node: simple.Node(0), Start: 4, Finish: 7},
+		},
+		wantLate: -1,
+	},
+	{
+		name: "tightened deadline",
+		tasks: []scheduleTask{
+			0: {duration: 1, deadline: math.Inf(1)},
+			1: {duration: 1, deadline: 2},
+			2: {duration: 1, deadline: 3},
+		},
+		edges:      []simple.Edge{{F: simple.Node(0), T: simple.Node(1)}},
+		processors: 1,
+		want: []ScheduledTask{
+			{Node: simple.Node(0), Start: 0, Finish: 1},
+			{Node: simple.Node(1), Start: 1, Finish: 2},
+			{Node: simple.Node(2), Start: 2, Finish: 3},
+		},
+		wantLate: -1,
+	},
+	{
+		name: "parallel",
+		tasks: []scheduleTask{
+			0: {duration: 2, deadline: math.Inf(1)},
+			1: {duration: 1, deadline: math.Inf(1)},
+			2: {duration: 1, deadline: math.Inf(1)},
+			3: {duration: 1, deadline: math.Inf(1)},
+		},
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(3)},
+			{F: simple.Node(1), T: simple.Node(3)},
+		},
+		processors: 2,
+		want: []ScheduledTask{
+			{Node: simple.Node(0), Processor: 0, Start: 0, Finish: 2},
+			{Node: simple.Node(1), Processor: 1, Start: 0, Finish: 1},
+			{Node: simple.Node(2), Processor: 1, Start: 1, Finish: 2},
+			{Node: simple.Node(3), Processor: 0, Start: 2, Finish: 3},
+		},
+		wantLate: -1,
+	},
+	{
+		name: "infeasible chain",
+		tasks: []scheduleTask{
+			0: {duration: 2, deadline: math.Inf(1)},
+			1: {duration: 2, deadline: math.Inf(1)},
+			2: {duration: 2, deadline: 5},
+			3: {duration: 1, deadline: 6},
+		},
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(2)},
+		},
+		processors: 2,
+		want: []ScheduledTask{
+			{Node: simple.Node(0), Processor: 0, Start: 0, Finish: 2},
+			{Node: simple.Node(3), Processor: 1, Start: 0, Finish: 1},
+			{Node: simple.Node(1), Processor: 1, Start: 2, Finish: 4},
+			{Node: simple.Node(2), Processor: 0, Start: 4, Finish: 6},
+		},
+		wantLate:  2,
+		wantChain: []int64{0, 1, 2},
+	},
+	{
+		name: "processor bound",
+		tasks: []scheduleTask{
+			0: {duration: 3, deadline: 3},
+			1: {duration: 1, deadline: 3},
+		},
+		processors: 1,
+		want: []ScheduledTask{
+			{Node: simple.Node(0), Start: 0, Finish: 3},
+			{Node: simple.Node(1), Start: 3, Finish: 4},
+		},
+		wantLate:  1,
+		wantChain: []int64{1},
+	},
+}
+
+func TestEDFSchedule(t *testing.T) {
+	t.Parallel()
+	for _, test := range edfScheduleTests {
+		g := simple.NewDirectedGraph()
+		for i := range test.tasks {
+			g.AddNode(simple.Node(i))
+		}
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+		duration := func(n graph.Node) float64 { return test.tasks[n.ID()].duration }
+		deadline := func(n graph.Node) float64 { return test.tasks[n.ID()].deadline }
+
+		got, err := EDFSchedule(g, test.processors, duration, deadline)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected schedule for %s:\ngot: %v\nwant:%v", test.name, got, test.want)
+		}
+		if test.wantLate < 0 {
+			if err != nil {
+				t.Errorf("unexpected error for %s: %v", test.name, err)
+			}
+			continue
+		}
+		miss, ok := err.(*DeadlineMiss)
+		if !ok {
+			t.Errorf("expected deadline miss for %s: got:%v", test.name, err)
+			continue
+		}
+		if id := miss.Task.Node.ID(); id != test.wantLate {
+			t.Errorf("unexpected late task for %s: got:%d want:%d", test.name, id, test.wantLate)
+		}
+		if chain := nodeIDsOf(miss.Chain); !reflect.DeepEqual(chain, test.wantChain) {
+			t.Errorf("unexpected critical chain for %s: got:%v want:%v", test.name, chain, test.wantChain)
+		}
+	}
+}
+
+func TestEDFScheduleCycle(t *testing.T) {
+	t.Parallel()
+	g := simple.NewDirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(0)})
+	unit := func(graph.Node) float64 { return 1 }
+	schedule, err := EDFSchedule(g, 1, unit, nil)
+	if schedule != nil {
+		t.Errorf("unexpected schedule for cyclic graph: %v", schedule)
+	}
+	if _, ok := err.(topo.Unorderable); !ok {
+		t.Errorf("expected topo.Unorderable error for cyclic graph: got:%v", err)
+	}
+}
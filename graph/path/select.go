@@ -0,0 +1,180 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Algorithm is a shortest path algorithm.
+type Algorithm int
+
+const (
+	// BreadthFirst is a breadth-first search, used for
+	// graphs with uniform unit edge weights. It is
+	// implemented by DialFrom with a maximum weight of 1.
+	BreadthFirst Algorithm = iota + 1
+	// Dial is DialFrom.
+	Dial
+	// Dijkstra is DijkstraFrom or DijkstraAllPaths.
+	Dijkstra
+	// DAG is DAGShortestFrom.
+	DAG
+	// BellmanFord is BellmanFordFrom.
+	BellmanFord
+	// Johnson is JohnsonAllPaths.
+	Johnson
+	// FloydWarshallAlgorithm is FloydWarshall.
+	FloydWarshallAlgorithm
+)
+
+var algorithmNames = []string{
+	BreadthFirst:           "breadth-first",
+	Dial:                   "Dial",
+	Dijkstra:               "Dijkstra",
+	DAG:                    "DAG",
+	BellmanFord:            "Bellman-Ford",
+	Johnson:                "Johnson",
+	FloydWarshallAlgorithm: "Floyd-Warshall",
+}
+
+// String implements the fmt.Stringer interface.
+func (a Algorithm) String() string {
+	if a <= 0 || int(a) >= len(algorithmNames) {
+		return "unknown"
+	}
+	return algorithmNames[a]
+}
+
+// dialMaxWeight is the largest maximum edge weight
+// for which Dial's algorithm is selected.
+const dialMaxWeight = 64
+
+// weightProfile summarises the edges of a graph.
+type weightProfile struct {
+	nodes, edges int
+	directed     bool
+
+	// unit is whether all edge weights are 1.
+	unit bool
+	// integer is whether all edge
+	// weights are integers.
+	integer bool
+	// negative is whether any edge
+	// weight is negative.
+	negative bool
+	// max is the maximum edge weight.
+	max float64
+}
+
+// profile returns the weight profile of g.
+func profile(g graph.Graph) weightProfile {
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+	_, isUndirected := g.(graph.Undirected)
+	p := weightProfile{directed: !isUndirected, unit: true, integer: true}
+	nodes := g.Nodes()
+	for nodes.Next() {
+		p.nodes++
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if isUndirected && vid < uid {
+				continue
+			}
+			p.edges++
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("path: unexpected invalid weight")
+			}
+			p.unit = p.unit && w == 1
+			p.integer = p.integer && w == math.Trunc(w) && !math.IsInf(w, 0)
+			p.negative = p.negative || w < 0
+			p.max = math.Max(p.max, w)
+		}
+	}
+	return p
+}
+
+// dense returns whether the graph is dense enough that a
+// cubic all-pairs algorithm is preferable to repeated
+// single-source searches.
+func (p weightProfile) dense() bool {
+	n := float64(p.nodes)
+	return float64(p.edges)*math.Log2(n+1) >= n*n
+}
+
+// ShortestFrom returns a shortest-path tree for shortest paths from u to all
+// nodes in the graph g, using an algorithm selected by inspection of the
+// properties of g, and the algorithm used. The returned ok is false if a
+// negative cycle was found in g.
+//
+// The algorithm is selected as follows:
+//   - graphs without negative edge weights are searched with
+//     breadth-first search if all edge weights are 1, with Dial's
+//     algorithm if all edge weights are small integers, and with
+//     Dijkstra's algorithm otherwise;
+//   - directed acyclic graphs with negative edge weights are
+//     searched in topological order;
+//   - other graphs with negative edge weights are searched with
+//     the Bellman-Ford-Moore algorithm.
+//
+// If the graph does not implement Weighted, UniformCost is used.
+// Inspection of g has time complexity O(|V|+|E|).
+func ShortestFrom(u graph.Node, g graph.Graph) (path Shortest, alg Algorithm, ok bool) {
+	p := profile(g)
+	switch {
+	case !p.negative && p.unit:
+		return DialFrom(u, g, 1), BreadthFirst, true
+	case !p.negative && p.integer && p.max <= dialMaxWeight:
+		return DialFrom(u, g, int(p.max)), Dial, true
+	case !p.negative:
+		return DijkstraFrom(u, g), Dijkstra, true
+	}
+	if d, isDirected := g.(graph.Directed); isDirected && p.directed {
+		path, err := DAGShortestFrom(u, d)
+		if err == nil {
+			return path, DAG, true
+		}
+	}
+	path, ok = BellmanFordFrom(u, g)
+	return path, BellmanFord, ok
+}
+
+// ShortestAll returns a shortest-path tree for shortest paths between all
+// pairs of nodes in the graph g, using an algorithm selected by inspection of
+// the properties of g, and the algorithm used. The returned ok is false if a
+// negative cycle was found in g.
+//
+// The algorithm is selected as follows:
+//   - dense graphs are searched with the Floyd-Warshall algorithm;
+//   - sparse graphs without negative edge weights are searched with
+//     Dijkstra's algorithm from each node;
+//   - sparse graphs with negative edge weights are searched with
+//     Johnson's algorithm.
+//
+// A graph is considered dense when |E|.log|V| is at least |V|². If the graph
+// does not implement Weighted, UniformCost is used. Inspection of g has time
+// complexity O(|V|+|E|).
+func ShortestAll(g graph.Graph) (paths AllShortest, alg Algorithm, ok bool) {
+	p := profile(g)
+	switch {
+	case p.dense():
+		paths, ok = FloydWarshall(g)
+		return paths, FloydWarshallAlgorithm, ok
+	case !p.negative:
+		return DijkstraAllPaths(g), Dijkstra, true
+	default:
+		paths, ok = JohnsonAllPaths(g)
+		return paths, Johnson, ok
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var selectTests = []struct {
+	name     string
+	g        func() graph.Graph
+	from     graph.Node
+	wantFrom Algorithm
+	wantAll  Algorithm
+	wantOK   bool
+}{
+	{
+		name: "unweighted sparse",
+		g: func() graph.Graph {
+			g := simple.NewUndirectedGraph()
+			for i := int64(0); i < 20; i++ {
+				g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+			}
+			return g
+		},
+		from:     simple.Node(0),
+		wantFrom: BreadthFirst,
+		wantAll:  Dijkstra,
+		wantOK:   true,
+	},
+	{
+		name: "small integer weights",
+		g: func() graph.Graph {
+			g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+			for i := int64(0); i < 20; i++ {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(i + 1), W: float64(i%5 + 1)})
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i + 1), T: simple.Node(i), W: 3})
+			}
+			return g
+		},
+		from:     simple.Node(0),
+		wantFrom: Dial,
+		wantAll:  Dijkstra,
+		wantOK:   true,
+	},
+	{
+		name: "real weights dense",
+		g: func() graph.Graph {
+			g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+			for i := int64(0); i < 8; i++ {
+				for j := int64(0); j < 8; j++ {
+					if i != j {
+						g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(j), W: 0.5 + float64((i*j)%7)})
+					}
+				}
+			}
+			return g
+		},
+		from:     simple.Node(0),
+		wantFrom: Dijkstra,
+		wantAll:  FloydWarshallAlgorithm,
+		wantOK:   true,
+	},
+	{
+		name: "negative DAG",
+		g: func() graph.Graph {
+			g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+			for i := int64(0); i < 20; i++ {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(i + 1), W: -1})
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(0), T: simple.Node(20), W: -30})
+			return g
+		},
+		from:     simple.Node(0),
+		wantFrom: DAG,
+		wantAll:  Johnson,
+		wantOK:   true,
+	},
+	{
+		name: "negative cyclic",
+		g: func() graph.Graph {
+			g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+			for i := int64(0); i < 20; i++ {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(i + 1), W: -1})
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(20), T: simple.Node(0), W: 25})
+			return g
+		},
+		from:     simple.Node(0),
+		wantFrom: BellmanFord,
+		wantAll:  Johnson,
+		wantOK:   true,
+	},
+	{
+		name: "negative cycle",
+		g: func() graph.Graph {
+			g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+			for i := int64(0); i < 20; i++ {
+				g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i), T: simple.Node(i + 1), W: -1})
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(20), T: simple.Node(0), W: 5})
+			return g
+		},
+		from:     simple.Node(0),
+		wantFrom: BellmanFord,
+		wantAll:  Johnson,
+		wantOK:   false,
+	},
+}
+
+func TestShortestFromSelect(t *testing.T) {
+	t.Parallel()
+	for _, test := range selectTests {
+		g := test.g()
+		pt, alg, ok := ShortestFrom(test.from, g)
+		if alg != test.wantFrom {
+			t.Errorf("%q: unexpected algorithm: got:%v want:%v", test.name, alg, test.wantFrom)
+		}
+		if ok != test.wantOK {
+			t.Errorf("%q: unexpected ok: got:%t want:%t", test.name, ok, test.wantOK)
+		}
+		if !ok {
+			continue
+		}
+		want, _ := BellmanFordFrom(test.from, g)
+		for _, n := range graph.NodesOf(g.Nodes()) {
+			if got, want := pt.WeightTo(n.ID()), want.WeightTo(n.ID()); got != want {
+				t.Errorf("%q: unexpected weight to %d: got:%v want:%v", test.name, n.ID(), got, want)
+			}
+		}
+	}
+}
+
+func TestShortestAllSelect(t *testing.T) {
+	t.Parallel()
+	for _, test := range selectTests {
+		g := test.g()
+		paths, alg, ok := ShortestAll(g)
+		if alg != test.wantAll {
+			t.Errorf("%q: unexpected algorithm: got:%v want:%v", test.name, alg, test.wantAll)
+		}
+		if ok != test.wantOK {
+			t.Errorf("%q: unexpected ok: got:%t want:%t", test.name, ok, test.wantOK)
+		}
+		if !ok {
+			continue
+		}
+		want, _ := FloydWarshall(g)
+		nodes := graph.NodesOf(g.Nodes())
+		for _, u := range nodes {
+			for _, v := range nodes {
+				if got, want := paths.Weight(u.ID(), v.ID()), want.Weight(u.ID(), v.ID()); math.Abs(got-want) > 1e-12 {
+					t.Errorf("%q: unexpected weight from %d to %d: got:%v want:%v", test.name, u.ID(), v.ID(), got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestShortestFromSelectShortestPathTests(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+		pt, alg, ok := ShortestFrom(test.Query.From(), g.(graph.Graph))
+		if ok == test.HasNegativeCycle {
+			t.Errorf("%q: unexpected ok using %v: got:%t", test.Name, alg, ok)
+		}
+		if !ok {
+			continue
+		}
+		if w := pt.WeightTo(test.Query.To().ID()); w != test.Weight {
+			t.Errorf("%q: unexpected weight using %v: got:%v want:%v", test.Name, alg, w, test.Weight)
+		}
+	}
+}
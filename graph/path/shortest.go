@@ -188,6 +188,46 @@ func (p Shortest) To(vid int64) (path []graph.Node, weight float64) {
 	return path, math.Min(weight, p.dist[p.indexOf[vid]])
 }
 
+// PathTo returns a shortest path to v as a Path. If there is no path to v,
+// the returned Path is empty. If the path to v includes a negative cycle,
+// the weights held by the returned Path will not reflect the true path
+// weights.
+func (p Shortest) PathTo(vid int64) Path {
+	nodes, _ := p.To(vid)
+	cum := make([]float64, len(nodes))
+	for i, n := range nodes {
+		cum[i] = p.dist[p.indexOf[n.ID()]]
+	}
+	return newPathFromWeights(nodes, cum)
+}
+
+// Tree adds the shortest-path tree held by p to dst. The nodes reached from the
+// source node are added to dst along with an edge from each node's predecessor
+// in the tree to the node, created by dst.NewWeightedEdge. The weight of each
+// edge is the difference between the path weights of its end nodes, which is
+// the weight of the edge in the analysed graph for trees returned by searches
+// that sum edge weights. If the tree includes a negative cycle, the weights
+// added to dst will not reflect the true edge weights.
+//
+// Nodes held by p are used to construct dst, so if the Node types used in the
+// analysed graph are pointer or reference-like, then the values will be shared
+// between the graphs. The destination is not cleared first. If dst has nodes
+// that exist in p, Tree will panic.
+func (p Shortest) Tree(dst graph.WeightedBuilder) {
+	for i, n := range p.nodes {
+		if !math.IsInf(p.dist[i], 1) {
+			dst.AddNode(n)
+		}
+	}
+	for to, from := range p.next {
+		if from < 0 || math.IsInf(p.dist[to], 1) {
+			continue
+		}
+		w := p.dist[to] - p.dist[from]
+		dst.SetWeightedEdge(dst.NewWeightedEdge(p.nodes[from], p.nodes[to], w))
+	}
+}
+
 // ShortestAlts is a shortest-path tree created by the BellmanFordAllFrom or DijkstraAllFrom
 // single-source shortest path functions.
 type ShortestAlts struct {
@@ -453,11 +493,47 @@ func (p ShortestAlts) allTo(from, to int, seen []bool, path []graph.Node, paths
 	return paths
 }
 
+// DAG adds the directed acyclic graph of all shortest paths held by p to dst.
+// The nodes reached from the source node are added to dst along with an edge
+// from each of a node's predecessors on shortest paths to the node, created by
+// dst.NewWeightedEdge. The weight of each edge is the difference between the
+// path weights of its end nodes, which is the weight of the edge in the analysed
+// graph for searches that sum edge weights. If the paths include a negative
+// cycle, the graph added to dst is not acyclic and the weights added to dst will
+// not reflect the true edge weights.
+//
+// Nodes held by p are used to construct dst, so if the Node types used in the
+// analysed graph are pointer or reference-like, then the values will be shared
+// between the graphs. The destination is not cleared first. If dst has nodes
+// that exist in p, DAG will panic.
+func (p ShortestAlts) DAG(dst graph.WeightedBuilder) {
+	for i, n := range p.nodes {
+		if !math.IsInf(p.dist[i], 1) {
+			dst.AddNode(n)
+		}
+	}
+	for to, mids := range p.next {
+		if math.IsInf(p.dist[to], 1) {
+			continue
+		}
+		for _, from := range mids {
+			w := p.dist[to] - p.dist[from]
+			dst.SetWeightedEdge(dst.NewWeightedEdge(p.nodes[from], p.nodes[to], w))
+		}
+	}
+}
+
 // negEdge is a key into the negative costs map used by Shortest and ShortestAlts.
 type negEdge struct{ from, to int }
 
 // AllShortest is a shortest-path tree created by the DijkstraAllPaths, FloydWarshall
 // or JohnsonAllPaths all-pairs shortest paths functions.
+//
+// The memory required to hold an AllShortest for a graph with n nodes is 12n² bytes,
+// 8 bytes for the weight and 4 bytes for the first intermediate node of the paths
+// between each pair of nodes, plus the storage of the intermediate nodes for pairs
+// that are joined by more than one shortest path. A graph with 20000 nodes
+// requires at least 4.8GB.
 type AllShortest struct {
 	// nodes hold the nodes of the analysed
 	// graph.
@@ -491,17 +567,28 @@ type AllShortest struct {
 	// nil or contains a set of nodes.
 	dist *mat.Dense
 	// next contains the shortest-path
-	// tree of the graph. The first index
-	// is a linear mapping of from-dense-id
+	// tree of the graph. The index is a
+	// linear mapping of from-dense-id
 	// and to-dense-id, to-major with a
 	// stride equal to len(nodes); the
-	// slice indexed to is the list of
-	// intermediates leading from the 'from'
-	// node to the 'to' node represented
-	// by dense id.
+	// value indexed is the intermediate
+	// leading from the 'from' node to
+	// the 'to' node represented by dense
+	// id, noMid if there is none, or
+	// multiMid if there is more than one.
 	// The interpretation of next is
 	// dependent on the state of forward.
-	next [][]int
+	next []int32
+	// multi holds the intermediates for
+	// pairs with more than one, indexed
+	// by from-dense-id and keyed by
+	// to-dense-id. The maps are created
+	// on demand. Keeping the maps per
+	// from node allows single-source
+	// searches from distinct nodes to
+	// write to the same AllShortest
+	// concurrently.
+	multi []map[int][]int
 	// forward indicates the direction of
 	// path reconstruction. Forward
 	// reconstruction is used for Floyd-
@@ -531,44 +618,108 @@ func newAllShortest(nodes []graph.Node, forward bool) AllShortest {
 	for i, n := range nodes {
 		indexOf[n.ID()] = i
 	}
+	if len(nodes) > math.MaxInt32 {
+		panic("path: too many nodes")
+	}
 	dist := make([]float64, len(nodes)*len(nodes))
 	for i := range dist {
 		dist[i] = math.Inf(1)
 	}
+	next := make([]int32, len(nodes)*len(nodes))
+	for i := range next {
+		next[i] = noMid
+	}
 	return AllShortest{
 		nodes:   nodes,
 		indexOf: indexOf,
 
 		dist:    mat.NewDense(len(nodes), len(nodes), dist),
-		next:    make([][]int, len(nodes)*len(nodes)),
+		next:    next,
+		multi:   make([]map[int][]int, len(nodes)),
 		forward: forward,
 	}
 }
 
+const (
+	// noMid and multiMid are the next values in an
+	// AllShortest indicating that there is no
+	// intermediate node and that the intermediate
+	// nodes are held in the multi field.
+	noMid    = -1
+	multiMid = -2
+)
+
 // at returns a slice of node indexes into p.nodes for nodes that are mid points
-// between nodes indexed by from and to.
-func (p AllShortest) at(from, to int) (mid []int) {
-	return p.next[from+to*len(p.nodes)]
+// between nodes indexed by from and to. If there is a single mid point it is
+// appended to buf[:0], otherwise the returned slice must not be modified.
+func (p AllShortest) at(from, to int, buf []int) (mid []int) {
+	switch k := p.next[from+to*len(p.nodes)]; k {
+	case noMid:
+		return buf[:0]
+	case multiMid:
+		return p.multi[from][to]
+	default:
+		return append(buf[:0], int(k))
+	}
+}
+
+// hasMid returns whether there are any mid points between nodes indexed by
+// from and to.
+func (p AllShortest) hasMid(from, to int) bool {
+	return p.next[from+to*len(p.nodes)] != noMid
 }
 
 // set sets the weights of paths between node indexes into p.nodes for from and to
 // passing through the nodes indexed by mid.
 func (p AllShortest) set(from, to int, weight float64, mid ...int) {
 	p.dist.Set(from, to, weight)
-	p.next[from+to*len(p.nodes)] = append(p.next[from+to*len(p.nodes)][:0], mid...)
+	idx := from + to*len(p.nodes)
+	switch len(mid) {
+	case 0:
+		if p.next[idx] == multiMid {
+			delete(p.multi[from], to)
+		}
+		p.next[idx] = noMid
+	case 1:
+		if p.next[idx] == multiMid {
+			delete(p.multi[from], to)
+		}
+		p.next[idx] = int32(mid[0])
+	default:
+		if p.multi[from] == nil {
+			p.multi[from] = make(map[int][]int)
+		}
+		p.multi[from][to] = append(p.multi[from][to][:0], mid...)
+		p.next[idx] = multiMid
+	}
 }
 
 // add adds paths between node indexed in p.nodes by from and to passing through
 // the nodes indexed by mid.
 func (p AllShortest) add(from, to int, mid ...int) {
+	idx := from + to*len(p.nodes)
 loop: // These are likely to be rare, so just loop over collisions.
 	for _, k := range mid {
-		for _, v := range p.next[from+to*len(p.nodes)] {
-			if k == v {
+		switch v := p.next[idx]; v {
+		case noMid:
+			p.next[idx] = int32(k)
+		case multiMid:
+			for _, v := range p.multi[from][to] {
+				if k == v {
+					continue loop
+				}
+			}
+			p.multi[from][to] = append(p.multi[from][to], k)
+		default:
+			if int(v) == k {
 				continue loop
 			}
+			if p.multi[from] == nil {
+				p.multi[from] = make(map[int][]int)
+			}
+			p.multi[from][to] = []int{int(v), k}
+			p.next[idx] = multiMid
 		}
-		p.next[from+to*len(p.nodes)] = append(p.next[from+to*len(p.nodes)], k)
 	}
 }
 
@@ -594,7 +745,7 @@ func (p AllShortest) Weight(uid, vid int64) float64 {
 func (p AllShortest) Between(uid, vid int64) (path []graph.Node, weight float64, unique bool) {
 	from, fromOK := p.indexOf[uid]
 	to, toOK := p.indexOf[vid]
-	if !fromOK || !toOK || len(p.at(from, to)) == 0 {
+	if !fromOK || !toOK || !p.hasMid(from, to) {
 		if uid == vid {
 			if !fromOK {
 				return []graph.Node{node(uid)}, 0, true
@@ -625,9 +776,12 @@ func (p AllShortest) Between(uid, vid int64) (path []graph.Node, weight float64,
 	path = []graph.Node{n}
 	unique = true
 
-	var next int
+	var (
+		next int
+		buf  [1]int
+	)
 	for from != to {
-		c := p.at(from, to)
+		c := p.at(from, to, buf[:0])
 		if len(c) != 1 {
 			unique = false
 			next = c[rand.Intn(len(c))]
@@ -652,13 +806,29 @@ func (p AllShortest) Between(uid, vid int64) (path []graph.Node, weight float64,
 	return path, weight, unique
 }
 
+// PathBetween returns a shortest path from u to v as a Path. If more than one
+// shortest path exists between u and v, a randomly chosen path will be returned.
+// If there is no path from u to v or a negative cycle exists on the path, the
+// returned Path is empty.
+func (p AllShortest) PathBetween(uid, vid int64) Path {
+	nodes, _, _ := p.Between(uid, vid)
+	if len(nodes) == 0 {
+		return Path{}
+	}
+	cum := make([]float64, len(nodes))
+	for i, n := range nodes[1:] {
+		cum[i+1] = p.Weight(uid, n.ID())
+	}
+	return newPathFromWeights(nodes, cum)
+}
+
 // AllBetween returns all shortest paths from u to v and the weight of the paths. Paths
 // containing zero-weight cycles are not returned. If a negative cycle exists between
 // u and v, paths is returned nil and weight is returned as -Inf.
 func (p AllShortest) AllBetween(uid, vid int64) (paths [][]graph.Node, weight float64) {
 	from, fromOK := p.indexOf[uid]
 	to, toOK := p.indexOf[vid]
-	if !fromOK || !toOK || len(p.at(from, to)) == 0 {
+	if !fromOK || !toOK || !p.hasMid(from, to) {
 		if uid == vid {
 			if !fromOK {
 				return [][]graph.Node{{node(uid)}}, 0
@@ -705,7 +875,8 @@ func (p AllShortest) allBetween(from, to int, seen []bool, path []graph.Node, pa
 		return append(paths, path)
 	}
 	first := true
-	for _, n := range p.at(from, to) {
+	var buf [1]int
+	for _, n := range p.at(from, to, buf[:0]) {
 		if seen[n] {
 			continue
 		}
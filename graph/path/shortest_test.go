@@ -0,0 +1,147 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestShortestTree(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+		gg := g.(graph.Graph)
+		wg := g.(graph.Weighted)
+
+		pt := DijkstraFrom(test.Query.From(), gg)
+		tree := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		pt.Tree(tree)
+
+		var reached int
+		for _, n := range graph.NodesOf(gg.Nodes()) {
+			if !math.IsInf(pt.WeightTo(n.ID()), 1) {
+				reached++
+			}
+		}
+		if got := tree.Nodes().Len(); got != reached {
+			t.Errorf("%q: unexpected number of tree nodes: got:%d want:%d", test.Name, got, reached)
+		}
+
+		for _, n := range graph.NodesOf(tree.Nodes()) {
+			in := tree.To(n.ID()).Len()
+			switch {
+			case n.ID() == test.Query.From().ID() && in != 0:
+				t.Errorf("%q: unexpected edges into tree root: got:%d", test.Name, in)
+			case n.ID() != test.Query.From().ID() && in != 1:
+				t.Errorf("%q: unexpected number of edges into %d: got:%d want:1", test.Name, n.ID(), in)
+			}
+			for _, v := range graph.NodesOf(tree.From(n.ID())) {
+				got, _ := tree.Weight(n.ID(), v.ID())
+				want, _ := wg.Weight(n.ID(), v.ID())
+				if got != want {
+					t.Errorf("%q: unexpected weight for tree edge %d->%d: got:%v want:%v",
+						test.Name, n.ID(), v.ID(), got, want)
+				}
+			}
+		}
+
+		tpt := DijkstraFrom(test.Query.From(), tree)
+		for _, n := range graph.NodesOf(gg.Nodes()) {
+			if got, want := tpt.WeightTo(n.ID()), pt.WeightTo(n.ID()); got != want {
+				t.Errorf("%q: unexpected path weight in tree to %d: got:%v want:%v", test.Name, n.ID(), got, want)
+			}
+		}
+	}
+}
+
+func TestShortestAltsDAG(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		if test.HasNegativeWeight {
+			continue
+		}
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+		gg := g.(graph.Graph)
+		wg := g.(graph.Weighted)
+
+		pt := DijkstraAllFrom(test.Query.From(), gg)
+		dag := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		pt.DAG(dag)
+
+		for _, n := range graph.NodesOf(dag.Nodes()) {
+			for _, v := range graph.NodesOf(dag.From(n.ID())) {
+				got, _ := dag.Weight(n.ID(), v.ID())
+				want, _ := wg.Weight(n.ID(), v.ID())
+				if got != want {
+					t.Errorf("%q: unexpected weight for DAG edge %d->%d: got:%v want:%v",
+						test.Name, n.ID(), v.ID(), got, want)
+				}
+			}
+		}
+
+		dpt := DijkstraAllFrom(test.Query.From(), dag)
+		for _, n := range graph.NodesOf(gg.Nodes()) {
+			gotPaths, gotWeight := dpt.AllTo(n.ID())
+			wantPaths, wantWeight := pt.AllTo(n.ID())
+			if gotWeight != wantWeight {
+				t.Errorf("%q: unexpected path weight in DAG to %d: got:%v want:%v", test.Name, n.ID(), gotWeight, wantWeight)
+			}
+			if len(gotPaths) != len(wantPaths) {
+				t.Errorf("%q: unexpected number of paths in DAG to %d: got:%d want:%d", test.Name, n.ID(), len(gotPaths), len(wantPaths))
+			}
+		}
+	}
+}
+
+func TestAllShortestMids(t *testing.T) {
+	t.Parallel()
+	p := newAllShortest([]graph.Node{simple.Node(0), simple.Node(1), simple.Node(2), simple.Node(3)}, true)
+
+	check := func(step string, want []int) {
+		t.Helper()
+		got := p.at(0, 3, nil)
+		if len(got) == 0 && len(want) == 0 {
+			if p.hasMid(0, 3) {
+				t.Errorf("unexpected mid points after %s", step)
+			}
+			return
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected mid points after %s: got:%v want:%v", step, got, want)
+		}
+	}
+
+	check("construction", nil)
+	p.add(0, 3, 1)
+	check("first add", []int{1})
+	p.add(0, 3, 1)
+	check("repeated add", []int{1})
+	p.add(0, 3, 2, 1)
+	check("second add", []int{1, 2})
+	p.set(0, 3, 1, 2)
+	check("single set", []int{2})
+	if _, ok := p.multi[0][3]; ok {
+		t.Error("unexpected retained multiple mid points after single set")
+	}
+	p.set(0, 3, 1, 1, 2, 3)
+	check("multiple set", []int{1, 2, 3})
+	p.set(0, 3, math.Inf(1))
+	check("empty set", nil)
+}
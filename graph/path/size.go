@@ -0,0 +1,63 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"unsafe"
+
+	"gonum.org/v1/gonum/graph/internal/memsize"
+	"gonum.org/v1/gonum/mat"
+)
+
+// SizeOf returns an estimate of the number of bytes held by the shortest-path
+// tree, excluding the memory held by the nodes of the tree, which is shared
+// with the analysed graph.
+func (p Shortest) SizeOf() int64 {
+	n := int64(len(p.nodes))
+	size := int64(unsafe.Sizeof(p)) +
+		n*memsize.Interface +
+		memsize.Map(len(p.indexOf), unsafe.Sizeof(int64(0)), memsize.Word) +
+		n*int64(unsafe.Sizeof(float64(0))) +
+		n*memsize.Word
+	if p.negCosts != nil {
+		size += memsize.Map(len(p.negCosts), unsafe.Sizeof(negEdge{}), unsafe.Sizeof(float64(0)))
+	}
+	return size
+}
+
+// SizeOf returns an estimate of the number of bytes held by the shortest-path
+// forest, excluding the memory held by the nodes of the forest, which is
+// shared with the analysed graph.
+func (p AllShortest) SizeOf() int64 {
+	size := AllShortestSize(len(p.nodes))
+	for _, m := range p.multi {
+		if m == nil {
+			continue
+		}
+		size += memsize.Map(len(m), memsize.Word, memsize.Slice)
+		for _, mid := range m {
+			size += int64(cap(mid)) * memsize.Word
+		}
+	}
+	return size
+}
+
+// AllShortestSize returns an estimate of the number of bytes held by an
+// AllShortest for a graph with n nodes, excluding the memory held by the
+// nodes and by shortest paths that are not unique. AllShortestSize can be
+// used to check whether an all-pairs shortest path analysis of a graph
+// is feasible before it is performed.
+func AllShortestSize(n int) int64 {
+	if n == 0 {
+		return int64(unsafe.Sizeof(AllShortest{}))
+	}
+	n64 := int64(n)
+	return int64(unsafe.Sizeof(AllShortest{})) +
+		n64*memsize.Interface +
+		memsize.Map(n, unsafe.Sizeof(int64(0)), memsize.Word) +
+		int64(unsafe.Sizeof(mat.Dense{})) + n64*n64*int64(unsafe.Sizeof(float64(0))) +
+		n64*n64*int64(unsafe.Sizeof(int32(0))) +
+		n64*memsize.Word
+}
@@ -0,0 +1,159 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/spatial/r2"
+)
+
+// SmoothPath returns a smoothed copy of path with intermediate nodes removed
+// where a straight line joins the nodes either side of them. The visible
+// function reports whether a straight line between two nodes is unobstructed.
+// Smoothing is performed by string pulling: starting from the first node,
+// the path is followed for as long as the nodes are visible from the current
+// anchor node, and the last visible node becomes the next anchor. The first
+// and last nodes of path are always retained.
+//
+// SmoothPath is intended for paths found by A* and related searches on grids
+// and geometric graphs, where path edges are constrained to a small set of
+// directions.
+func SmoothPath(path []graph.Node, visible func(a, b graph.Node) bool) []graph.Node {
+	if len(path) < 3 {
+		return append([]graph.Node(nil), path...)
+	}
+	smooth := []graph.Node{path[0]}
+	anchor := path[0]
+	for i := 2; i < len(path); i++ {
+		if !visible(anchor, path[i]) {
+			anchor = path[i-1]
+			smooth = append(smooth, anchor)
+		}
+	}
+	return append(smooth, path[len(path)-1])
+}
+
+// SimplifyPath returns a simplified copy of path using the Douglas–Peucker
+// algorithm with the node coordinates given by coord. Intermediate nodes are
+// removed when they lie within tol of the straight line joining the retained
+// nodes either side of them. If visible is not nil, a straight line is only
+// used when visible reports that it is unobstructed. The first and last nodes
+// of path are always retained.
+//
+// See Douglas and Peucker, "Algorithms for the reduction of the number of
+// points required to represent a digitized line or its caricature",
+// Cartographica 10(2):112-122 (1973) doi:10.3138/FM57-6770-U75U-7727.
+func SimplifyPath(path []graph.Node, coord func(graph.Node) r2.Vec, tol float64, visible func(a, b graph.Node) bool) []graph.Node {
+	if len(path) < 3 {
+		return append([]graph.Node(nil), path...)
+	}
+	keep := make([]bool, len(path))
+	keep[0] = true
+	keep[len(path)-1] = true
+
+	// Spans of the path still to be simplified,
+	// held as the indices of their end nodes.
+	spans := [][2]int{{0, len(path) - 1}}
+	for len(spans) != 0 {
+		span := spans[len(spans)-1]
+		spans = spans[:len(spans)-1]
+		i, j := span[0], span[1]
+		if j-i < 2 {
+			continue
+		}
+
+		a, b := coord(path[i]), coord(path[j])
+		far := -1
+		max := -1.0
+		for k := i + 1; k < j; k++ {
+			if d := segmentDistance(coord(path[k]), a, b); d > max {
+				far, max = k, d
+			}
+		}
+		if max <= tol && (visible == nil || visible(path[i], path[j])) {
+			continue
+		}
+		keep[far] = true
+		spans = append(spans, [2]int{i, far}, [2]int{far, j})
+	}
+
+	var simple []graph.Node
+	for i, n := range path {
+		if keep[i] {
+			simple = append(simple, n)
+		}
+	}
+	return simple
+}
+
+// segmentDistance returns the distance from p to the line segment from a to b.
+func segmentDistance(p, a, b r2.Vec) float64 {
+	ab := b.Sub(a)
+	ap := p.Sub(a)
+	l2 := ab.X*ab.X + ab.Y*ab.Y
+	if l2 == 0 {
+		return math.Hypot(ap.X, ap.Y)
+	}
+	t := (ap.X*ab.X + ap.Y*ab.Y) / l2
+	t = math.Max(0, math.Min(1, t))
+	d := ap.Sub(ab.Scale(t))
+	return math.Hypot(d.X, d.Y)
+}
+
+// GridLineOfSight returns a visibility function for SmoothPath and SimplifyPath
+// on a grid of cells. The cell of a node is given by rounding the coordinates
+// returned by coord to the nearest integers, and passable reports whether the
+// cell at x, y may be crossed. A straight line between the centers of two cells
+// is unobstructed if every cell it passes through is passable. When the line
+// passes exactly through the corner of a cell, both cells adjacent to the corner
+// must be passable.
+func GridLineOfSight(coord func(graph.Node) r2.Vec, passable func(x, y int) bool) func(a, b graph.Node) bool {
+	return func(a, b graph.Node) bool {
+		pa, pb := coord(a), coord(b)
+		x0, y0 := int(math.Round(pa.X)), int(math.Round(pa.Y))
+		x1, y1 := int(math.Round(pb.X)), int(math.Round(pb.Y))
+
+		// Walk the supercover of the line,
+		// the set of all cells it touches.
+		dx, sx := x1-x0, 1
+		if dx < 0 {
+			dx, sx = -dx, -1
+		}
+		dy, sy := y1-y0, 1
+		if dy < 0 {
+			dy, sy = -dy, -1
+		}
+		x, y := x0, y0
+		e := dx - dy
+		for n := 1 + dx + dy; n > 0; n-- {
+			if !passable(x, y) {
+				return false
+			}
+			if n == 1 {
+				break
+			}
+			switch {
+			case e > 0:
+				x += sx
+				e -= 2 * dy
+			case e < 0:
+				y += sy
+				e += 2 * dx
+			default:
+				// The line passes through a corner.
+				if !passable(x+sx, y) || !passable(x, y+sy) {
+					return false
+				}
+				x += sx
+				y += sy
+				e += 2 * (dx - dy)
+				n--
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/spatial/r2"
+)
+
+// gridCoord returns a coordinate function for the nodes of g.
+func gridCoord(g *testgraphs.Grid) func(graph.Node) r2.Vec {
+	return func(n graph.Node) r2.Vec {
+		x, y := g.XY(n.ID())
+		return r2.Vec{X: x, Y: y}
+	}
+}
+
+// gridPassable returns a passability function for the cells of g.
+func gridPassable(g *testgraphs.Grid) func(x, y int) bool {
+	return func(x, y int) bool {
+		n := g.NodeAt(y, x)
+		return n != nil && g.HasOpen(n.ID())
+	}
+}
+
+// pathLength returns the Euclidean length of path.
+func pathLength(path []graph.Node, coord func(graph.Node) r2.Vec) float64 {
+	var l float64
+	for i := 1; i < len(path); i++ {
+		d := coord(path[i]).Sub(coord(path[i-1]))
+		l += math.Hypot(d.X, d.Y)
+	}
+	return l
+}
+
+var smoothPathTests = []struct {
+	name string
+	g    *testgraphs.Grid
+	s, t int64
+
+	want []int64
+}{
+	{
+		name: "open",
+		g:    testgraphs.NewGrid(6, 6, true),
+		s:    0, t: 35,
+		want: []int64{0, 35},
+	},
+	{
+		name: "wall",
+		g: testgraphs.NewGridFrom(
+			"......",
+			"......",
+			"****..",
+			"......",
+			"......",
+		),
+		s: 0, t: 24,
+	},
+	{
+		name: "maze",
+		g: testgraphs.NewGridFrom(
+			".*....",
+			".*.**.",
+			".*..*.",
+			".**.*.",
+			"....*.",
+		),
+		s: 0, t: 29,
+	},
+}
+
+func TestSmoothPath(t *testing.T) {
+	t.Parallel()
+	for _, test := range smoothPathTests {
+		coord := gridCoord(test.g)
+		visible := GridLineOfSight(coord, gridPassable(test.g))
+
+		pt, _ := AStar(simple.Node(test.s), simple.Node(test.t), test.g, nil)
+		p, _ := pt.To(test.t)
+		got := SmoothPath(p, visible)
+
+		if got[0].ID() != test.s || got[len(got)-1].ID() != test.t {
+			t.Errorf("%s: smoothed path does not join terminals: %v", test.name, got)
+		}
+		for i := 1; i < len(got); i++ {
+			if !visible(got[i-1], got[i]) {
+				t.Errorf("%s: smoothed path segment %d--%d obstructed", test.name, got[i-1].ID(), got[i].ID())
+			}
+		}
+		if pathLength(got, coord) > pathLength(p, coord)+1e-9 {
+			t.Errorf("%s: smoothed path longer than original: %v > %v",
+				test.name, pathLength(got, coord), pathLength(p, coord))
+		}
+		if len(got) >= len(p) {
+			t.Errorf("%s: smoothed path not shorter than original: %d >= %d nodes", test.name, len(got), len(p))
+		}
+		if test.want != nil {
+			var ids []int64
+			for _, n := range got {
+				ids = append(ids, n.ID())
+			}
+			if !reflect.DeepEqual(ids, test.want) {
+				t.Errorf("%s: unexpected smoothed path: got:%v want:%v", test.name, ids, test.want)
+			}
+		}
+	}
+}
+
+func TestSimplifyPath(t *testing.T) {
+	t.Parallel()
+	// A path along the x axis with a small
+	// wobble and a large detour.
+	coords := []r2.Vec{
+		{X: 0, Y: 0},
+		{X: 1, Y: 0.1},
+		{X: 2, Y: -0.1},
+		{X: 3, Y: 0},
+		{X: 4, Y: 3},
+		{X: 5, Y: 0},
+		{X: 6, Y: 0.05},
+		{X: 7, Y: 0},
+	}
+	var p []graph.Node
+	for i := range coords {
+		p = append(p, simple.Node(i))
+	}
+	coord := func(n graph.Node) r2.Vec { return coords[n.ID()] }
+
+	for _, test := range []struct {
+		tol     float64
+		visible func(a, b graph.Node) bool
+		want    []int64
+	}{
+		{tol: 0.5, want: []int64{0, 3, 4, 5, 7}},
+		{tol: 5, want: []int64{0, 7}},
+		{tol: 0, want: []int64{0, 1, 2, 3, 4, 5, 6, 7}},
+		{
+			tol: 5,
+			// Only allow lines of length at most 4.
+			visible: func(a, b graph.Node) bool { return b.ID()-a.ID() <= 4 },
+			want:    []int64{0, 4, 7},
+		},
+	} {
+		got := SimplifyPath(p, coord, test.tol, test.visible)
+		var ids []int64
+		for _, n := range got {
+			ids = append(ids, n.ID())
+		}
+		if !reflect.DeepEqual(ids, test.want) {
+			t.Errorf("unexpected simplified path for tol=%v: got:%v want:%v", test.tol, ids, test.want)
+		}
+	}
+
+	for _, short := range [][]graph.Node{nil, p[:1], p[:2]} {
+		got := SimplifyPath(short, coord, 1, nil)
+		if len(got) != len(short) {
+			t.Errorf("unexpected simplification of short path: got:%v want:%v", got, short)
+		}
+	}
+}
+
+func TestGridLineOfSight(t *testing.T) {
+	t.Parallel()
+	g := testgraphs.NewGridFrom(
+		".....",
+		".*...",
+		".....",
+		"...*.",
+		".....",
+	)
+	visible := GridLineOfSight(gridCoord(g), gridPassable(g))
+	for _, test := range []struct {
+		from, to [2]int // row, column
+		want     bool
+	}{
+		{from: [2]int{0, 0}, to: [2]int{0, 4}, want: true},
+		{from: [2]int{0, 0}, to: [2]int{2, 2}, want: false},
+		{from: [2]int{0, 0}, to: [2]int{4, 0}, want: true},
+		{from: [2]int{2, 0}, to: [2]int{0, 2}, want: false},
+		{from: [2]int{0, 0}, to: [2]int{4, 1}, want: true},
+		{from: [2]int{0, 0}, to: [2]int{4, 2}, want: false},
+		{from: [2]int{4, 4}, to: [2]int{2, 2}, want: false},
+		{from: [2]int{4, 0}, to: [2]int{0, 4}, want: true},
+		{from: [2]int{2, 2}, to: [2]int{2, 2}, want: true},
+		{from: [2]int{1, 1}, to: [2]int{1, 1}, want: false},
+	} {
+		a := g.NodeAt(test.from[0], test.from[1])
+		b := g.NodeAt(test.to[0], test.to[1])
+		if got := visible(a, b); got != test.want {
+			t.Errorf("unexpected visibility from %v to %v: got:%t want:%t", test.from, test.to, got, test.want)
+		}
+		if got := visible(b, a); got != test.want {
+			t.Errorf("unexpected visibility from %v to %v: got:%t want:%t", test.to, test.from, got, test.want)
+		}
+	}
+}
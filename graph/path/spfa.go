@@ -0,0 +1,187 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// SPFAFrom returns a shortest-path tree for a shortest path from u to all nodes in
+// the graph g, or false indicating that a negative cycle exists in the graph. If the
+// graph does not implement Weighted, UniformCost is used.
+//
+// SPFAFrom is the queue-based Bellman-Ford algorithm used by BellmanFordFrom with
+// the Small Label First and Large Label Last queue heuristics, which scan nodes with
+// smaller distances first and usually reduce the number of node scans on large sparse
+// graphs. Negative cycles are detected when a shortest path with at least |V| edges
+// is found and the cycle appears in the shortest-path tree, rather than after a worst
+// case number of scans. The queue heuristics may degrade badly on graphs with
+// negative edges, so if the number of node scans exceeds |V|², the worst case number
+// of scans for a first-in first-out queue, SPFAFrom falls back to a first-in
+// first-out queue for the remainder of the search.
+//
+// The time complexity of SPFAFrom is O(|V|.|E|).
+//
+// See Bertsekas, "A simple and fast label correcting algorithm for shortest paths",
+// Networks 23(8):703-709 (1993) doi:10.1002/net.3230230808.
+func SPFAFrom(u graph.Node, g graph.Graph) (path Shortest, ok bool) {
+	if g.Node(u.ID()) == nil {
+		return Shortest{from: u}, true
+	}
+	var weight Weighting
+	if wg, ok := g.(Weighted); ok {
+		weight = wg.Weight
+	} else {
+		weight = UniformCost(g)
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+
+	path = newShortestFrom(u, nodes)
+	path.negCosts = make(map[negEdge]float64)
+
+	// edges holds the number of edges in the
+	// current shortest path to each node.
+	edges := make([]int, len(nodes))
+
+	q := newSPFADeque(len(nodes))
+	q.pushBack(path.indexOf[u.ID()])
+	// sum holds the sum of the distances of
+	// the queued nodes for Large Label Last.
+	var sum float64
+
+	heuristics := true
+	limit := len(nodes) * len(nodes)
+	for scans := 0; q.len() != 0; scans++ {
+		if heuristics && scans > limit {
+			heuristics = false
+		}
+
+		if heuristics {
+			// Large Label Last: move nodes at the head of
+			// the queue with a distance greater than the
+			// mean distance of the queue to its tail.
+			mean := sum / float64(q.len())
+			for n := q.len(); n > 1 && path.dist[q.front()] > mean; n-- {
+				q.pushBack(q.popFront())
+			}
+		}
+		j := q.popFront()
+		sum -= path.dist[j]
+
+		uid := nodes[j].ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			k := path.indexOf[vid]
+			w, ok := weight(uid, vid)
+			if !ok {
+				panic("spfa: unexpected invalid weight")
+			}
+
+			joint := path.dist[j] + w
+			if joint >= path.dist[k] {
+				continue
+			}
+			if q.has(k) {
+				sum += joint - path.dist[k]
+			}
+			path.set(k, joint, j)
+			edges[k] = edges[j] + 1
+			if edges[k] >= len(nodes) && markNegativeCycle(path, k) {
+				path.hasNegativeCycle = true
+				return path, false
+			}
+			if q.has(k) {
+				continue
+			}
+
+			// Small Label First: queue nodes with a distance
+			// less than the node at the head of the queue at
+			// the head of the queue.
+			if heuristics && q.len() != 0 && joint < path.dist[q.front()] {
+				q.pushFront(k)
+			} else {
+				q.pushBack(k)
+			}
+			sum += joint
+		}
+	}
+
+	return path, true
+}
+
+// markNegativeCycle follows the shortest-path tree in p back from the node
+// indexed by k and, if it finds a cycle, marks the edges of the cycle as
+// having a negative infinite cost and returns true.
+func markNegativeCycle(p Shortest, k int) bool {
+	seen := make(map[int]bool)
+	for i := k; i >= 0; i = p.next[i] {
+		if !seen[i] {
+			seen[i] = true
+			continue
+		}
+		// i is on the cycle.
+		for j := i; ; {
+			next := p.next[j]
+			p.negCosts[negEdge{from: next, to: j}] = math.Inf(-1)
+			j = next
+			if j == i {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// spfaDeque is a double-ended queue of node indexes
+// holding each node at most once.
+type spfaDeque struct {
+	// data is a ring buffer holding
+	// n elements starting at head.
+	data    []int
+	head, n int
+
+	onQueue []bool
+}
+
+func newSPFADeque(n int) *spfaDeque {
+	return &spfaDeque{data: make([]int, n), onQueue: make([]bool, n)}
+}
+
+// len returns the number of nodes in the deque.
+func (q *spfaDeque) len() int { return q.n }
+
+// has returns whether the node with index i is in the deque.
+func (q *spfaDeque) has(i int) bool { return q.onQueue[i] }
+
+// front returns the node at the head of the deque.
+func (q *spfaDeque) front() int { return q.data[q.head] }
+
+// pushFront adds the node with index i to the head of the deque.
+func (q *spfaDeque) pushFront(i int) {
+	q.head = (q.head + len(q.data) - 1) % len(q.data)
+	q.data[q.head] = i
+	q.n++
+	q.onQueue[i] = true
+}
+
+// pushBack adds the node with index i to the tail of the deque.
+func (q *spfaDeque) pushBack(i int) {
+	q.data[(q.head+q.n)%len(q.data)] = i
+	q.n++
+	q.onQueue[i] = true
+}
+
+// popFront removes and returns the node at the head of the deque.
+func (q *spfaDeque) popFront() int {
+	i := q.data[q.head]
+	q.head = (q.head + 1) % len(q.data)
+	q.n--
+	q.onQueue[i] = false
+	return i
+}
@@ -0,0 +1,150 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSPFAFrom(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+
+		pt, ok := SPFAFrom(test.Query.From(), g.(graph.Graph))
+		if test.HasNegativeCycle {
+			if ok {
+				t.Errorf("%q: expected negative cycle", test.Name)
+			}
+		} else if !ok {
+			t.Fatalf("%q: unexpected negative cycle", test.Name)
+		}
+
+		if pt.From().ID() != test.Query.From().ID() {
+			t.Fatalf("%q: unexpected from node ID: got:%d want:%d", test.Name, pt.From().ID(), test.Query.From().ID())
+		}
+
+		p, weight := pt.To(test.Query.To().ID())
+		if weight != test.Weight {
+			t.Errorf("%q: unexpected weight from To: got:%f want:%f",
+				test.Name, weight, test.Weight)
+		}
+		if weight := pt.WeightTo(test.Query.To().ID()); !math.IsInf(test.Weight, -1) && weight != test.Weight {
+			t.Errorf("%q: unexpected weight from Weight: got:%f want:%f",
+				test.Name, weight, test.Weight)
+		}
+
+		var got []int64
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		ok = len(got) == 0 && len(test.WantPaths) == 0
+		for _, sp := range test.WantPaths {
+			if reflect.DeepEqual(got, sp) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			t.Errorf("%q: unexpected shortest path:\ngot: %v\nwant from:%v",
+				test.Name, p, test.WantPaths)
+		}
+
+		np, weight := pt.To(test.NoPathFor.To().ID())
+		if pt.From().ID() == test.NoPathFor.From().ID() && (np != nil || !math.IsInf(weight, 1)) {
+			t.Errorf("%q: unexpected path:\ngot: path=%v weight=%f\nwant:path=<nil> weight=+Inf",
+				test.Name, np, weight)
+		}
+	}
+}
+
+func TestSPFAFromRandom(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		const n = 30
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		// Edges only run from lower to higher IDs, so
+		// negative weights cannot form a negative cycle.
+		for i := 0; i < 4*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			if u > v {
+				u, v = v, u
+			}
+			w := float64(rnd.Intn(20))
+			if rnd.Float64() < 0.2 {
+				w = -w
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+		}
+
+		want, wantOK := BellmanFordFrom(simple.Node(0), g)
+		got, gotOK := SPFAFrom(simple.Node(0), g)
+		if gotOK != wantOK {
+			t.Fatalf("trial %d: unexpected negative cycle status: got:%t want:%t", trial, gotOK, wantOK)
+		}
+		for i := 0; i < n; i++ {
+			if got, want := got.WeightTo(int64(i)), want.WeightTo(int64(i)); got != want {
+				t.Errorf("trial %d: unexpected weight to %d: got:%v want:%v", trial, i, got, want)
+			}
+		}
+	}
+}
+
+func TestSPFAFromNegativeCycle(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	var cycles int
+	for trial := 0; trial < 100; trial++ {
+		const n = 20
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 3*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			w := float64(rnd.Intn(20) - 3)
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+		}
+
+		want, wantOK := BellmanFordFrom(simple.Node(0), g)
+		got, gotOK := SPFAFrom(simple.Node(0), g)
+		if gotOK != wantOK {
+			t.Fatalf("trial %d: unexpected negative cycle status: got:%t want:%t", trial, gotOK, wantOK)
+		}
+		if !wantOK {
+			cycles++
+			continue
+		}
+		for i := 0; i < n; i++ {
+			if got, want := got.WeightTo(int64(i)), want.WeightTo(int64(i)); got != want {
+				t.Errorf("trial %d: unexpected weight to %d: got:%v want:%v", trial, i, got, want)
+			}
+		}
+	}
+	if cycles == 0 || cycles == 100 {
+		t.Errorf("unexpected number of graphs with negative cycles: %d", cycles)
+	}
+}
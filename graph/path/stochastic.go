@@ -0,0 +1,204 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// UncertainGraph is a graph with edge costs described by independent random
+// variables.
+type UncertainGraph interface {
+	traverse.Graph
+
+	// Moments returns the mean and variance of
+	// the cost of the edge from x to y with IDs
+	// xid and yid if Edge(xid, yid) returns a
+	// non-nil Edge. Moments returns true if an
+	// edge exists between x and y or if x and y
+	// have the same ID, false otherwise.
+	Moments(xid, yid int64) (mean, variance float64, ok bool)
+}
+
+// ExpectedCostFrom returns a shortest-path tree for paths from u to all nodes in
+// the graph g that minimize the expected path cost, the sum of the mean costs of
+// the edges in the path. ExpectedCostFrom will panic if g has a u-reachable edge
+// with a negative mean cost.
+//
+// If g is a graph.Graph, all nodes of the graph will be stored in the shortest-path
+// tree, otherwise only nodes reachable from u will be stored.
+func ExpectedCostFrom(u graph.Node, g UncertainGraph) Shortest {
+	if gg, ok := g.(graph.Graph); ok {
+		return DijkstraFrom(u, expectedCostGraph{Graph: gg, moments: g.Moments})
+	}
+	return DijkstraFrom(u, expectedCost{Graph: g, moments: g.Moments})
+}
+
+// expectedCost is a Weighted traverse.Graph with weights given
+// by the mean edge costs of an UncertainGraph.
+type expectedCost struct {
+	traverse.Graph
+	moments func(xid, yid int64) (mean, variance float64, ok bool)
+}
+
+func (g expectedCost) Weight(xid, yid int64) (w float64, ok bool) {
+	w, _, ok = g.moments(xid, yid)
+	return w, ok
+}
+
+// expectedCostGraph is a Weighted graph.Graph with weights given
+// by the mean edge costs of an UncertainGraph.
+type expectedCostGraph struct {
+	graph.Graph
+	moments func(xid, yid int64) (mean, variance float64, ok bool)
+}
+
+func (g expectedCostGraph) Weight(xid, yid int64) (w float64, ok bool) {
+	w, _, ok = g.moments(xid, yid)
+	return w, ok
+}
+
+// MeanRiskPath returns the path from s to t in g that minimizes the risk-adjusted
+// cost
+//
+//	mean + lambda * sqrt(variance)
+//
+// and the mean and variance of the cost of the path. The mean and variance of the
+// cost of a path are the sums of the means and variances of its edge costs. When
+// lambda is zero the path minimizes the expected cost. If there is no path from s
+// to t, path is nil and mean and variance are +Inf.
+//
+// The risk-adjusted cost is not additive over the edges of a path, so MeanRiskPath
+// finds the set of Pareto-optimal paths with respect to mean and variance to each
+// node, and in the worst case may take time exponential in the size of g.
+// MeanRiskPath will panic if lambda is negative or if g has an s-reachable edge with
+// a negative mean or variance.
+func MeanRiskPath(s, t graph.Node, g UncertainGraph, lambda float64) (path []graph.Node, mean, variance float64) {
+	if lambda < 0 {
+		panic("path: negative risk weighting")
+	}
+	if g, ok := g.(graph.Graph); ok {
+		if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+			return nil, math.Inf(1), math.Inf(1)
+		}
+	}
+	tid := t.ID()
+	cost := func(l *riskLabel) float64 {
+		return l.mean + lambda*math.Sqrt(l.variance)
+	}
+
+	// Labels are settled in lexicographic order of
+	// mean and variance as described in Martins, "On
+	// a multicriteria shortest path problem", EJOR
+	// 16(2):236-245 (1984).
+	labels := make(map[int64][]*riskLabel)
+	src := &riskLabel{node: s}
+	labels[s.ID()] = []*riskLabel{src}
+	q := riskQueue{src}
+
+	var best *riskLabel
+	for q.Len() != 0 {
+		l := heap.Pop(&q).(*riskLabel)
+		if l.dominated {
+			continue
+		}
+		// Extensions of l cannot improve on the best
+		// path to t since the costs are monotonic.
+		if best != nil && cost(l) >= cost(best) {
+			continue
+		}
+		uid := l.node.ID()
+		if uid == tid {
+			best = l
+			continue
+		}
+
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			m, va, ok := g.Moments(uid, vid)
+			if !ok {
+				panic("path: mean-risk unexpected invalid weight")
+			}
+			if m < 0 || va < 0 {
+				panic("path: mean-risk negative edge cost moment")
+			}
+			next := &riskLabel{node: v, mean: l.mean + m, variance: l.variance + va, from: l}
+
+			// Discard the new label if it is dominated and
+			// remove any labels that it dominates.
+			existing := labels[vid]
+			var dominated bool
+			for _, e := range existing {
+				if e.mean <= next.mean && e.variance <= next.variance {
+					dominated = true
+					break
+				}
+			}
+			if dominated {
+				continue
+			}
+			kept := existing[:0]
+			for _, e := range existing {
+				if next.mean <= e.mean && next.variance <= e.variance {
+					e.dominated = true
+					continue
+				}
+				kept = append(kept, e)
+			}
+			labels[vid] = append(kept, next)
+			heap.Push(&q, next)
+		}
+	}
+
+	if best == nil {
+		return nil, math.Inf(1), math.Inf(1)
+	}
+	for l := best; l != nil; l = l.from {
+		path = append(path, l.node)
+	}
+	ordered.Reverse(path)
+	return path, best.mean, best.variance
+}
+
+// riskLabel is a path to a node in a mean-risk search.
+type riskLabel struct {
+	node           graph.Node
+	mean, variance float64
+
+	// from is the label of the path
+	// that this label extends.
+	from *riskLabel
+
+	// dominated indicates that the
+	// label has been superseded.
+	dominated bool
+}
+
+// riskQueue is a lexicographically ordered priority queue of
+// mean-risk search labels.
+type riskQueue []*riskLabel
+
+func (q riskQueue) Len() int { return len(q) }
+func (q riskQueue) Less(i, j int) bool {
+	if q[i].mean != q[j].mean {
+		return q[i].mean < q[j].mean
+	}
+	return q[i].variance < q[j].variance
+}
+func (q riskQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *riskQueue) Push(n interface{}) { *q = append(*q, n.(*riskLabel)) }
+func (q *riskQueue) Pop() interface{} {
+	t := *q
+	var n *riskLabel
+	n, *q = t[len(t)-1], t[:len(t)-1]
+	return n
+}
@@ -0,0 +1,147 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// uncertainGraph is a directed UncertainGraph.
+type uncertainGraph struct {
+	*simple.DirectedGraph
+	moments map[[2]int64][2]float64
+}
+
+func newUncertainGraph() uncertainGraph {
+	return uncertainGraph{DirectedGraph: simple.NewDirectedGraph(), moments: make(map[[2]int64][2]float64)}
+}
+
+func (g uncertainGraph) set(u, v int64, mean, variance float64) {
+	g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+	g.moments[[2]int64{u, v}] = [2]float64{mean, variance}
+}
+
+func (g uncertainGraph) Moments(xid, yid int64) (mean, variance float64, ok bool) {
+	if xid == yid {
+		return 0, 0, true
+	}
+	m, ok := g.moments[[2]int64{xid, yid}]
+	if !ok {
+		return math.Inf(1), math.Inf(1), false
+	}
+	return m[0], m[1], true
+}
+
+func TestMeanRiskPath(t *testing.T) {
+	t.Parallel()
+	// A cheap but volatile route through 1 and
+	// an expensive but reliable route through 2.
+	g := newUncertainGraph()
+	g.set(0, 1, 5, 50)
+	g.set(1, 3, 5, 50)
+	g.set(0, 2, 6, 0.5)
+	g.set(2, 3, 6, 0.5)
+	g.AddNode(simple.Node(4))
+
+	sp := ExpectedCostFrom(simple.Node(0), g)
+	if p, w := sp.To(3); w != 10 || !reflect.DeepEqual(nodeIDsOf(p), []int64{0, 1, 3}) {
+		t.Errorf("unexpected expected cost path: got:%v weight=%v want:[0 1 3] weight=10", nodeIDsOf(p), w)
+	}
+
+	for _, test := range []struct {
+		lambda         float64
+		want           []int64
+		mean, variance float64
+	}{
+		{lambda: 0, want: []int64{0, 1, 3}, mean: 10, variance: 100},
+		{lambda: 1, want: []int64{0, 2, 3}, mean: 12, variance: 1},
+	} {
+		p, mean, variance := MeanRiskPath(simple.Node(0), simple.Node(3), g, test.lambda)
+		if !reflect.DeepEqual(nodeIDsOf(p), test.want) || mean != test.mean || variance != test.variance {
+			t.Errorf("unexpected mean-risk path for lambda=%v: got:%v mean=%v variance=%v want:%v mean=%v variance=%v",
+				test.lambda, nodeIDsOf(p), mean, variance, test.want, test.mean, test.variance)
+		}
+	}
+
+	p, mean, variance := MeanRiskPath(simple.Node(0), simple.Node(4), g, 1)
+	if p != nil || !math.IsInf(mean, 1) || !math.IsInf(variance, 1) {
+		t.Errorf("unexpected path to unreachable node: got:%v mean=%v variance=%v", nodeIDsOf(p), mean, variance)
+	}
+}
+
+func TestMeanRiskPathExhaustive(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	const n = 8
+	for trial := 0; trial < 20; trial++ {
+		g := newUncertainGraph()
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 3*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			g.set(int64(u), int64(v), float64(rnd.Intn(10)), float64(rnd.Intn(30)))
+		}
+		lambda := 2 * rnd.Float64()
+		risk := func(mean, variance float64) float64 { return mean + lambda*math.Sqrt(variance) }
+
+		// Find the best risk over all simple paths from 0 to n-1.
+		want := math.Inf(1)
+		onPath := make([]bool, n)
+		var walk func(u int64, mean, variance float64)
+		walk = func(u int64, mean, variance float64) {
+			if u == n-1 {
+				want = math.Min(want, risk(mean, variance))
+				return
+			}
+			onPath[u] = true
+			for _, v := range graph.NodesOf(g.From(u)) {
+				vid := v.ID()
+				if onPath[vid] {
+					continue
+				}
+				m, va, _ := g.Moments(u, vid)
+				walk(vid, mean+m, variance+va)
+			}
+			onPath[u] = false
+		}
+		walk(0, 0, 0)
+
+		p, mean, variance := MeanRiskPath(simple.Node(0), simple.Node(n-1), g, lambda)
+		got := risk(mean, variance)
+		if math.IsInf(want, 1) {
+			if p != nil {
+				t.Errorf("trial %d: unexpected path: %v", trial, nodeIDsOf(p))
+			}
+			continue
+		}
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("trial %d: unexpected risk-adjusted cost: got:%v want:%v", trial, got, want)
+		}
+		var pm, pv float64
+		for i := 1; i < len(p); i++ {
+			m, va, ok := g.Moments(p[i-1].ID(), p[i].ID())
+			if !ok {
+				t.Fatalf("trial %d: path uses missing edge", trial)
+			}
+			pm += m
+			pv += va
+		}
+		if pm != mean || pv != variance {
+			t.Errorf("trial %d: path moments do not match returned moments: got:%v,%v want:%v,%v",
+				trial, pm, pv, mean, variance)
+		}
+	}
+}
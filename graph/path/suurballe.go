@@ -0,0 +1,265 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/flow"
+	"gonum.org/v1/gonum/graph/iterator"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Suurballe returns a pair of edge-disjoint paths from s to t in g that have
+// the minimum total weight, and that total weight. If no pair of edge-disjoint
+// paths exists, p and q are returned nil and weight is returned as +Inf. If s
+// and t are the same node, p and q hold only that node and weight is zero.
+// If the graph does not implement Weighted, UniformCost is used. Suurballe
+// will panic if g has an s-reachable negative edge weight.
+//
+// Undirected edges are considered as a pair of opposing directed edges and
+// the returned paths will not share an undirected edge.
+//
+// The time complexity of Suurballe is O(|E|.log|V|).
+func Suurballe(s, t graph.Node, g graph.Graph) (p, q []graph.Node, weight float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, nil, math.Inf(1)
+	}
+	var w Weighting
+	if wg, ok := g.(Weighted); ok {
+		w = wg.Weight
+	} else {
+		w = UniformCost(g)
+	}
+
+	first := DijkstraFrom(s, g)
+	p1, _ := first.To(t.ID())
+	switch len(p1) {
+	case 0:
+		return nil, nil, math.Inf(1)
+	case 1:
+		return p1, []graph.Node{p1[0]}, 0
+	}
+
+	// Construct the residual graph with the edges of the first
+	// path reversed and re-weight the edges using the shortest
+	// path distances so that no edge weight is negative.
+	r := suurballeResidual{
+		Graph:    g,
+		weight:   w,
+		dist:     first,
+		removed:  make(map[[2]int64]bool),
+		reversed: make(map[int64][]graph.Node),
+	}
+	for i, u := range p1[:len(p1)-1] {
+		v := p1[i+1]
+		r.removed[[2]int64{u.ID(), v.ID()}] = true
+		r.reversed[v.ID()] = append(r.reversed[v.ID()], u)
+	}
+	p2, _ := DijkstraFrom(s, r).To(t.ID())
+	if p2 == nil {
+		return nil, nil, math.Inf(1)
+	}
+
+	// Take the union of the edges of the two paths, cancelling
+	// edges of the first path that are traversed in reverse by
+	// the second path.
+	succ := make(map[int64][]graph.Node)
+	for i, u := range p1[:len(p1)-1] {
+		succ[u.ID()] = append(succ[u.ID()], p1[i+1])
+	}
+	for i, u := range p2[:len(p2)-1] {
+		v := p2[i+1]
+		if !r.isReversed(u.ID(), v.ID()) {
+			succ[u.ID()] = append(succ[u.ID()], v)
+			continue
+		}
+		to := succ[v.ID()]
+		for j, n := range to {
+			if n.ID() == u.ID() {
+				to[j] = to[len(to)-1]
+				succ[v.ID()] = to[:len(to)-1]
+				break
+			}
+		}
+	}
+
+	p = suurballeWalk(s, t, succ)
+	q = suurballeWalk(s, t, succ)
+	for _, path := range [][]graph.Node{p, q} {
+		for i, u := range path[:len(path)-1] {
+			c, _ := w(u.ID(), path[i+1].ID())
+			weight += c
+		}
+	}
+	return p, q, weight
+}
+
+// SuurballeNodeDisjoint returns a pair of paths from s to t in g that share
+// no node other than s and t and have the minimum total weight, and that total
+// weight. If no pair of node-disjoint paths exists, p and q are returned nil
+// and weight is returned as +Inf. If s and t are the same node, p and q hold
+// only that node and weight is zero. If the graph does not implement Weighted,
+// UniformCost is used. SuurballeNodeDisjoint will panic if g has an
+// s-reachable negative edge weight.
+//
+// The paths are found by Suurballe in the node split of g returned by
+// flow.SplitNodes, where each node is joined to its out node by a zero weight
+// edge, so edge-disjoint paths in the split are node-disjoint in g.
+func SuurballeNodeDisjoint(s, t graph.Node, g graph.Graph) (p, q []graph.Node, weight float64) {
+	if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+		return nil, nil, math.Inf(1)
+	}
+	if s.ID() == t.ID() {
+		return []graph.Node{s}, []graph.Node{s}, 0
+	}
+	var w Weighting
+	if wg, ok := g.(Weighted); ok {
+		w = wg.Weight
+	} else {
+		w = UniformCost(g)
+	}
+
+	split := flow.SplitNodes(g, func(int64) float64 { return 0 })
+	// SplitNodes only obtains edge weights from a
+	// graph.Weighted, so set them from w.
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if vid == uid {
+				continue
+			}
+			c, _ := w(uid, vid)
+			split.SetWeightedEdge(split.NewWeightedEdge(split.Out(uid), split.In(vid), c))
+		}
+	}
+	sp, sq, _ := Suurballe(split.Out(s.ID()), split.In(t.ID()), split)
+	if sp == nil {
+		return nil, nil, math.Inf(1)
+	}
+	p = unsplit(sp, split)
+	q = unsplit(sq, split)
+	for _, path := range [][]graph.Node{p, q} {
+		for i, u := range path[:len(path)-1] {
+			c, _ := w(u.ID(), path[i+1].ID())
+			weight += c
+		}
+	}
+	return p, q, weight
+}
+
+// unsplit returns the path in the original graph of the node split
+// corresponding to the given path in the split.
+func unsplit(path []graph.Node, split *flow.NodeSplit) []graph.Node {
+	var orig []graph.Node
+	for _, n := range path {
+		o := split.Original(n.ID())
+		if len(orig) != 0 && orig[len(orig)-1].ID() == o.ID() {
+			continue
+		}
+		orig = append(orig, o)
+	}
+	return orig
+}
+
+// suurballeWalk returns a path from s to t following the edges in succ.
+// Edges are consumed from succ as they are traversed and any cycles in
+// the walk are removed from the returned path.
+func suurballeWalk(s, t graph.Node, succ map[int64][]graph.Node) []graph.Node {
+	path := []graph.Node{s}
+	seen := map[int64]int{s.ID(): 0}
+	for u := s; u.ID() != t.ID(); {
+		to := succ[u.ID()]
+		v := to[len(to)-1]
+		succ[u.ID()] = to[:len(to)-1]
+		if i, ok := seen[v.ID()]; ok {
+			for _, n := range path[i+1:] {
+				delete(seen, n.ID())
+			}
+			path = path[:i+1]
+		} else {
+			seen[v.ID()] = len(path)
+			path = append(path, v)
+		}
+		u = v
+	}
+	return path
+}
+
+// suurballeResidual is the residual graph of the second phase of
+// Suurballe's algorithm. Edges of the first shortest path are
+// removed and replaced by zero weight reversed edges, and all other
+// edges are re-weighted by the shortest path distances from the
+// first phase.
+type suurballeResidual struct {
+	graph.Graph
+	weight Weighting
+
+	// dist holds the shortest path distances
+	// from the source of the first phase.
+	dist Shortest
+
+	// removed holds the edges of the first
+	// shortest path.
+	removed map[[2]int64]bool
+	// reversed holds the reversed edges of
+	// the first shortest path keyed on the
+	// ID of the reversed edge's from node.
+	reversed map[int64][]graph.Node
+}
+
+func (g suurballeResidual) From(id int64) graph.Nodes {
+	var nodes []graph.Node
+	to := g.Graph.From(id)
+	for to.Next() {
+		v := to.Node()
+		vid := v.ID()
+		if g.removed[[2]int64{id, vid}] || g.isReversed(id, vid) {
+			continue
+		}
+		nodes = append(nodes, v)
+	}
+	nodes = append(nodes, g.reversed[id]...)
+	if len(nodes) == 0 {
+		return graph.Empty
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
+
+func (g suurballeResidual) Edge(uid, vid int64) graph.Edge {
+	if g.isReversed(uid, vid) {
+		return simple.Edge{F: simple.Node(uid), T: simple.Node(vid)}
+	}
+	if g.removed[[2]int64{uid, vid}] {
+		return nil
+	}
+	return g.Graph.Edge(uid, vid)
+}
+
+func (g suurballeResidual) Weight(xid, yid int64) (w float64, ok bool) {
+	if g.isReversed(xid, yid) {
+		return 0, true
+	}
+	if g.removed[[2]int64{xid, yid}] {
+		return math.Inf(1), false
+	}
+	w, ok = g.weight(xid, yid)
+	return (g.dist.WeightTo(xid) + w) - g.dist.WeightTo(yid), ok
+}
+
+// isReversed returns whether the edge from uid to vid is a reversed
+// edge of the first shortest path.
+func (g suurballeResidual) isReversed(uid, vid int64) bool {
+	for _, n := range g.reversed[uid] {
+		if n.ID() == vid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var suurballeTests = []struct {
+	name  string
+	graph func() graph.WeightedEdgeAdder
+	edges []simple.WeightedEdge
+
+	query      simple.Edge
+	wantPaths  [][]int64
+	wantWeight float64
+}{
+	{
+		name:  "trap",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node('A'), T: simple.Node('B'), W: 1},
+			{F: simple.Node('B'), T: simple.Node('C'), W: 1},
+			{F: simple.Node('C'), T: simple.Node('D'), W: 1},
+			{F: simple.Node('A'), T: simple.Node('C'), W: 2},
+			{F: simple.Node('B'), T: simple.Node('D'), W: 2},
+		},
+		query: simple.Edge{F: simple.Node('A'), T: simple.Node('D')},
+		wantPaths: [][]int64{
+			{'A', 'B', 'D'},
+			{'A', 'C', 'D'},
+		},
+		wantWeight: 6,
+	},
+	{
+		name:  "undirected trap",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedUndirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node('A'), T: simple.Node('B'), W: 1},
+			{F: simple.Node('B'), T: simple.Node('C'), W: 1},
+			{F: simple.Node('C'), T: simple.Node('D'), W: 1},
+			{F: simple.Node('A'), T: simple.Node('C'), W: 2},
+			{F: simple.Node('B'), T: simple.Node('D'), W: 2},
+		},
+		query: simple.Edge{F: simple.Node('A'), T: simple.Node('D')},
+		wantPaths: [][]int64{
+			{'A', 'B', 'D'},
+			{'A', 'C', 'D'},
+		},
+		wantWeight: 6,
+	},
+	{
+		name:  "reroute",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node('A'), T: simple.Node('B'), W: 1},
+			{F: simple.Node('A'), T: simple.Node('C'), W: 2},
+			{F: simple.Node('B'), T: simple.Node('D'), W: 1},
+			{F: simple.Node('B'), T: simple.Node('E'), W: 2},
+			{F: simple.Node('C'), T: simple.Node('D'), W: 2},
+			{F: simple.Node('D'), T: simple.Node('F'), W: 1},
+			{F: simple.Node('E'), T: simple.Node('F'), W: 2},
+		},
+		query: simple.Edge{F: simple.Node('A'), T: simple.Node('F')},
+		wantPaths: [][]int64{
+			{'A', 'B', 'E', 'F'},
+			{'A', 'C', 'D', 'F'},
+		},
+		wantWeight: 10,
+	},
+	{
+		name:  "unweighted disjoint",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(4), W: 1},
+			{F: simple.Node(0), T: simple.Node(2), W: 1},
+			{F: simple.Node(2), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(4), W: 1},
+		},
+		query: simple.Edge{F: simple.Node(0), T: simple.Node(4)},
+		wantPaths: [][]int64{
+			{0, 1, 4},
+			{0, 2, 3, 4},
+		},
+		wantWeight: 5,
+	},
+	{
+		name:  "no disjoint pair",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(1), T: simple.Node(2), W: 1},
+			{F: simple.Node(0), T: simple.Node(3), W: 1},
+			{F: simple.Node(3), T: simple.Node(1), W: 1},
+		},
+		query:      simple.Edge{F: simple.Node(0), T: simple.Node(2)},
+		wantPaths:  nil,
+		wantWeight: math.Inf(1),
+	},
+	{
+		name:  "unreachable",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+			{F: simple.Node(2), T: simple.Node(1), W: 1},
+		},
+		query:      simple.Edge{F: simple.Node(0), T: simple.Node(2)},
+		wantPaths:  nil,
+		wantWeight: math.Inf(1),
+	},
+	{
+		name:  "same node",
+		graph: func() graph.WeightedEdgeAdder { return simple.NewWeightedDirectedGraph(0, math.Inf(1)) },
+		edges: []simple.WeightedEdge{
+			{F: simple.Node(0), T: simple.Node(1), W: 1},
+		},
+		query: simple.Edge{F: simple.Node(0), T: simple.Node(0)},
+		wantPaths: [][]int64{
+			{0},
+			{0},
+		},
+		wantWeight: 0,
+	},
+}
+
+func TestSuurballe(t *testing.T) {
+	t.Parallel()
+	for _, test := range suurballeTests {
+		g := test.graph()
+		for _, e := range test.edges {
+			g.SetWeightedEdge(e)
+		}
+
+		p, q, weight := Suurballe(test.query.From(), test.query.To(), g.(graph.Graph))
+		if weight != test.wantWeight {
+			t.Errorf("unexpected weight for %q: got:%v want:%v", test.name, weight, test.wantWeight)
+		}
+
+		var got [][]int64
+		for _, path := range [][]graph.Node{p, q} {
+			if path == nil {
+				continue
+			}
+			ids := make([]int64, len(path))
+			for i, n := range path {
+				ids[i] = n.ID()
+			}
+			got = append(got, ids)
+		}
+		sort.Sort(ordered.BySliceValues(got))
+		if !reflect.DeepEqual(got, test.wantPaths) {
+			t.Errorf("unexpected paths for %q:\ngot: %v\nwant:%v", test.name, got, test.wantPaths)
+		}
+	}
+}
+
+func TestSuurballeNodeDisjoint(t *testing.T) {
+	t.Parallel()
+	// The two shortest edge-disjoint paths from 0 to 7
+	// both pass through node 3. The node-disjoint pair
+	// must use the expensive path through node 6.
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	for _, e := range []simple.WeightedEdge{
+		{F: simple.Node(0), T: simple.Node(1), W: 1},
+		{F: simple.Node(0), T: simple.Node(2), W: 1},
+		{F: simple.Node(1), T: simple.Node(3), W: 1},
+		{F: simple.Node(2), T: simple.Node(3), W: 1},
+		{F: simple.Node(3), T: simple.Node(4), W: 1},
+		{F: simple.Node(3), T: simple.Node(5), W: 1},
+		{F: simple.Node(4), T: simple.Node(7), W: 1},
+		{F: simple.Node(5), T: simple.Node(7), W: 1},
+		{F: simple.Node(0), T: simple.Node(6), W: 1},
+		{F: simple.Node(6), T: simple.Node(7), W: 10},
+	} {
+		g.SetWeightedEdge(e)
+	}
+
+	pathIDs := func(p, q []graph.Node) [][]int64 {
+		var got [][]int64
+		for _, path := range [][]graph.Node{p, q} {
+			if path != nil {
+				got = append(got, nodeIDsOf(path))
+			}
+		}
+		sort.Sort(ordered.BySliceValues(got))
+		return got
+	}
+
+	p, q, weight := Suurballe(simple.Node(0), simple.Node(7), g)
+	if weight != 8 {
+		t.Errorf("unexpected edge-disjoint weight: got:%v want:8", weight)
+	}
+	for _, path := range pathIDs(p, q) {
+		if len(path) != 5 || path[2] != 3 {
+			t.Errorf("unexpected edge-disjoint path: got:%v want path through 3", path)
+		}
+	}
+
+	p, q, weight = SuurballeNodeDisjoint(simple.Node(0), simple.Node(7), g)
+	if weight != 15 {
+		t.Errorf("unexpected node-disjoint weight: got:%v want:15", weight)
+	}
+	got := pathIDs(p, q)
+	if len(got) != 2 || len(got[0]) != 5 || got[0][2] != 3 || !reflect.DeepEqual(got[1], []int64{0, 6, 7}) {
+		t.Errorf("unexpected node-disjoint paths: got:%v want one path through 3 and [0 6 7]", got)
+	}
+
+	g.RemoveEdge(0, 6)
+	p, q, weight = SuurballeNodeDisjoint(simple.Node(0), simple.Node(7), g)
+	if p != nil || q != nil || !math.IsInf(weight, 1) {
+		t.Errorf("unexpected node-disjoint result without disjoint paths: got:(%v, %v, %v)", p, q, weight)
+	}
+	p, q, weight = SuurballeNodeDisjoint(simple.Node(0), simple.Node(0), g)
+	if len(p) != 1 || len(q) != 1 || weight != 0 {
+		t.Errorf("unexpected node-disjoint result for same node: got:(%v, %v, %v)", p, q, weight)
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// Temporal is a graph whose edges can only be traversed at specific times.
+type Temporal interface {
+	graph.Graph
+
+	// Contacts returns the times at which the
+	// edge from the node with ID uid to the node
+	// with ID vid can be traversed, sorted in
+	// ascending order. If g is undirected the
+	// contacts must be the same in both
+	// directions.
+	Contacts(uid, vid int64) []float64
+}
+
+// TimedTemporal is a temporal graph whose edges take time to traverse.
+type TimedTemporal interface {
+	Temporal
+
+	// Duration returns the time taken to
+	// traverse the edge from the node with
+	// ID uid to the node with ID vid when
+	// departing at the contact time t.
+	Duration(uid, vid int64, t float64) float64
+}
+
+// TemporalEdge is a traversal of an edge of a temporal graph at one of its
+// contacts, departing from F at the time Departure and arriving at T after
+// Duration has elapsed.
+type TemporalEdge struct {
+	F, T      graph.Node
+	Departure float64
+	Duration  float64
+}
+
+// Arrival returns the arrival time of the edge at T.
+func (e TemporalEdge) Arrival() float64 { return e.Departure + e.Duration }
+
+// Journey is a time-respecting path, a sequence of temporal edges where each
+// edge departs from the node the previous edge arrives at, no earlier than the
+// previous edge's arrival.
+type Journey []TemporalEdge
+
+// Departure returns the departure time of the journey. It returns NaN if the
+// journey is empty.
+func (j Journey) Departure() float64 {
+	if len(j) == 0 {
+		return math.NaN()
+	}
+	return j[0].Departure
+}
+
+// Arrival returns the arrival time of the journey. It returns NaN if the
+// journey is empty.
+func (j Journey) Arrival() float64 {
+	if len(j) == 0 {
+		return math.NaN()
+	}
+	return j[len(j)-1].Arrival()
+}
+
+// Duration returns the time elapsed between the journey's departure and arrival.
+// It returns zero if the journey is empty.
+func (j Journey) Duration() float64 {
+	if len(j) == 0 {
+		return 0
+	}
+	return j.Arrival() - j.Departure()
+}
+
+// Nodes returns the nodes visited by the journey.
+func (j Journey) Nodes() []graph.Node {
+	if len(j) == 0 {
+		return nil
+	}
+	nodes := make([]graph.Node, 0, len(j)+1)
+	nodes = append(nodes, j[0].F)
+	for _, e := range j {
+		nodes = append(nodes, e.T)
+	}
+	return nodes
+}
+
+// EarliestArrivalFrom returns journeys from s in the temporal graph g departing no
+// earlier than start that arrive at each reachable node as early as possible. The
+// returned map is keyed by the IDs of the nodes reached from s, excluding s. Edges
+// are traversed at their contacts, taking the time given by g's Duration method if
+// g is a TimedTemporal and no time otherwise. EarliestArrivalFrom will panic if an
+// edge has a negative duration.
+//
+// The time complexity of EarliestArrivalFrom is O(|C|.log|C|) where C is the set of
+// edge contacts, dominated by sorting the contacts.
+func EarliestArrivalFrom(s graph.Node, g Temporal, start float64) map[int64]Journey {
+	sorted := sortedTemporalEdges(g, func(a, b TemporalEdge) bool {
+		return a.Departure < b.Departure
+	})
+
+	sid := s.ID()
+	arrival := map[int64]float64{sid: start}
+	prev := make(map[int64]TemporalEdge)
+	relax := func(e TemporalEdge) bool {
+		at, ok := arrival[e.F.ID()]
+		if !ok || e.Departure < at {
+			return false
+		}
+		tid := e.T.ID()
+		if t, ok := arrival[tid]; ok && t <= e.Arrival() {
+			return false
+		}
+		arrival[tid] = e.Arrival()
+		prev[tid] = e
+		return true
+	}
+	scanTemporalGroups(sorted, func(e TemporalEdge) float64 { return e.Departure }, relax)
+
+	journeys := make(map[int64]Journey, len(prev))
+	for id := range prev {
+		var j Journey
+		for id != sid {
+			e := prev[id]
+			j = append(j, e)
+			id = e.F.ID()
+		}
+		reverseJourney(j)
+		journeys[j[len(j)-1].T.ID()] = j
+	}
+	return journeys
+}
+
+// LatestDepartureTo returns journeys to t in the temporal graph g arriving no later
+// than end that depart from each node that can reach t as late as possible. The
+// returned map is keyed by the IDs of the nodes that can reach t, excluding t.
+// Edges are traversed as described for EarliestArrivalFrom. LatestDepartureTo will
+// panic if an edge has a negative duration.
+//
+// The time complexity of LatestDepartureTo is O(|C|.log|C|) where C is the set of
+// edge contacts, dominated by sorting the contacts.
+func LatestDepartureTo(t graph.Node, g Temporal, end float64) map[int64]Journey {
+	sorted := sortedTemporalEdges(g, func(a, b TemporalEdge) bool {
+		return a.Arrival() > b.Arrival()
+	})
+
+	tid := t.ID()
+	departure := map[int64]float64{tid: end}
+	next := make(map[int64]TemporalEdge)
+	relax := func(e TemporalEdge) bool {
+		dt, ok := departure[e.T.ID()]
+		if !ok || e.Arrival() > dt {
+			return false
+		}
+		fid := e.F.ID()
+		if d, ok := departure[fid]; ok && d >= e.Departure {
+			return false
+		}
+		departure[fid] = e.Departure
+		next[fid] = e
+		return true
+	}
+	scanTemporalGroups(sorted, TemporalEdge.Arrival, relax)
+
+	journeys := make(map[int64]Journey, len(next))
+	for id := range next {
+		var j Journey
+		for id != tid {
+			e := next[id]
+			j = append(j, e)
+			id = e.T.ID()
+		}
+		journeys[j[0].F.ID()] = j
+	}
+	return journeys
+}
+
+// FastestFrom returns journeys from s in the temporal graph g departing no earlier
+// than start and arriving no later than end that reach each reachable node with the
+// shortest duration. The returned map is keyed by the IDs of the nodes reached from
+// s, excluding s. Edges are traversed as described for EarliestArrivalFrom.
+// FastestFrom will panic if an edge has a negative duration.
+//
+// FastestFrom is the one-pass algorithm described in Wu et al. "Path Problems in
+// Temporal Graphs", Proc. VLDB Endow. 7(9):721-732 (2014) doi:10.14778/2732939.2732945.
+func FastestFrom(s graph.Node, g Temporal, start, end float64) map[int64]Journey {
+	sorted := sortedTemporalEdges(g, func(a, b TemporalEdge) bool {
+		return a.Departure < b.Departure
+	})
+
+	// Each node holds the non-dominated journeys reaching
+	// it as a list ordered by increasing departure time
+	// and arrival time. A journey dominates another if it
+	// departs no earlier and arrives no later.
+	sid := s.ID()
+	reached := make(map[int64][]*journeyLabel)
+	best := make(map[int64]*journeyLabel)
+	relax := func(e TemporalEdge) bool {
+		if e.Departure < start || e.Arrival() > end {
+			return false
+		}
+		fid := e.F.ID()
+		tid := e.T.ID()
+		if tid == sid {
+			return false
+		}
+		var l *journeyLabel
+		if fid == sid {
+			l = &journeyLabel{departure: e.Departure, arrival: e.Arrival(), edge: e}
+		} else {
+			// Find the latest departing journey to F
+			// that arrives before e departs.
+			labels := reached[fid]
+			i := sort.Search(len(labels), func(i int) bool { return labels[i].arrival > e.Departure }) - 1
+			if i < 0 {
+				return false
+			}
+			l = &journeyLabel{departure: labels[i].departure, arrival: e.Arrival(), edge: e, prev: labels[i]}
+		}
+
+		labels := reached[tid]
+		i := sort.Search(len(labels), func(i int) bool { return labels[i].departure >= l.departure })
+		if i < len(labels) && labels[i].arrival <= l.arrival {
+			return false
+		}
+		// Remove the journeys that depart no later and
+		// arrive no earlier than the new journey.
+		j := i
+		for j > 0 && labels[j-1].arrival >= l.arrival {
+			j--
+		}
+		k := i
+		for k < len(labels) && labels[k].departure == l.departure {
+			k++
+		}
+		labels = append(labels[:j], append([]*journeyLabel{l}, labels[k:]...)...)
+		reached[tid] = labels
+
+		if b, ok := best[tid]; !ok || l.arrival-l.departure < b.arrival-b.departure {
+			best[tid] = l
+		}
+		return true
+	}
+	scanTemporalGroups(sorted, func(e TemporalEdge) float64 { return e.Departure }, relax)
+
+	journeys := make(map[int64]Journey, len(best))
+	for id, l := range best {
+		var j Journey
+		for ; l != nil; l = l.prev {
+			j = append(j, l.edge)
+		}
+		reverseJourney(j)
+		journeys[id] = j
+	}
+	return journeys
+}
+
+// journeyLabel is a journey in a fastest path search.
+type journeyLabel struct {
+	departure, arrival float64
+
+	edge TemporalEdge
+	prev *journeyLabel
+}
+
+// sortedTemporalEdges returns the traversals of the edges of g at each of
+// their contacts sorted by less, panicking if any has a negative duration.
+func sortedTemporalEdges(g Temporal, less func(a, b TemporalEdge) bool) []TemporalEdge {
+	timed, _ := g.(TimedTemporal)
+	var sorted []TemporalEdge
+	nodes := g.Nodes()
+	for nodes.Next() {
+		u := nodes.Node()
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			for _, t := range g.Contacts(uid, vid) {
+				e := TemporalEdge{F: u, T: v, Departure: t}
+				if timed != nil {
+					e.Duration = timed.Duration(uid, vid, t)
+					if e.Duration < 0 {
+						panic("path: negative temporal edge duration")
+					}
+				}
+				sorted = append(sorted, e)
+			}
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	return sorted
+}
+
+// scanTemporalGroups calls relax for each edge of sorted in order. Groups of
+// edges with the same time key that include zero duration edges are rescanned
+// until relax returns false for all edges in the group, so that chains of
+// instantaneous edges are followed regardless of their order.
+func scanTemporalGroups(sorted []TemporalEdge, time func(TemporalEdge) float64, relax func(TemporalEdge) bool) {
+	for i := 0; i < len(sorted); {
+		j := i
+		var instant bool
+		for j < len(sorted) && time(sorted[j]) == time(sorted[i]) {
+			instant = instant || sorted[j].Duration == 0
+			j++
+		}
+		for {
+			var changed bool
+			for _, e := range sorted[i:j] {
+				if relax(e) {
+					changed = true
+				}
+			}
+			if !changed || !instant {
+				break
+			}
+		}
+		i = j
+	}
+}
+
+func reverseJourney(j Journey) {
+	for i, k := 0, len(j)-1; i < k; i, k = i+1, k-1 {
+		j[i], j[k] = j[k], j[i]
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// timetable is a directed TimedTemporal graph.
+type timetable struct {
+	*simple.DirectedGraph
+	contacts map[[2]int64][]float64
+	duration map[contact]float64
+}
+
+type contact struct {
+	uid, vid int64
+	t        float64
+}
+
+func newTimetable(edges []TemporalEdge) timetable {
+	g := timetable{
+		DirectedGraph: simple.NewDirectedGraph(),
+		contacts:      make(map[[2]int64][]float64),
+		duration:      make(map[contact]float64),
+	}
+	for _, e := range edges {
+		uid, vid := e.F.ID(), e.T.ID()
+		g.SetEdge(simple.Edge{F: e.F, T: e.T})
+		k := [2]int64{uid, vid}
+		g.contacts[k] = append(g.contacts[k], e.Departure)
+		sort.Float64s(g.contacts[k])
+		g.duration[contact{uid: uid, vid: vid, t: e.Departure}] = e.Duration
+	}
+	return g
+}
+
+func (g timetable) Contacts(uid, vid int64) []float64 {
+	return g.contacts[[2]int64{uid, vid}]
+}
+
+func (g timetable) Duration(uid, vid int64, t float64) float64 {
+	return g.duration[contact{uid: uid, vid: vid, t: t}]
+}
+
+// temporalTestGraph is a small timetable where the earliest
+// arriving journey to D is not the fastest.
+var temporalTestGraph = newTimetable([]TemporalEdge{
+	{F: simple.Node(0), T: simple.Node(1), Departure: 1, Duration: 1},
+	{F: simple.Node(0), T: simple.Node(1), Departure: 4, Duration: 1},
+	{F: simple.Node(1), T: simple.Node(2), Departure: 3, Duration: 1},
+	{F: simple.Node(1), T: simple.Node(2), Departure: 6, Duration: 1},
+	{F: simple.Node(0), T: simple.Node(2), Departure: 2, Duration: 6},
+	{F: simple.Node(2), T: simple.Node(3), Departure: 5, Duration: 1},
+	{F: simple.Node(2), T: simple.Node(3), Departure: 8, Duration: 0},
+})
+
+type journeyWant struct {
+	nodes     []int64
+	departure float64
+	arrival   float64
+}
+
+func checkJourneys(t *testing.T, name string, got map[int64]Journey, want map[int64]journeyWant) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: unexpected number of journeys: got:%d want:%d", name, len(got), len(want))
+	}
+	for id, w := range want {
+		j, ok := got[id]
+		if !ok {
+			t.Errorf("%s: missing journey for %d", name, id)
+			continue
+		}
+		for i := 1; i < len(j); i++ {
+			if j[i].F.ID() != j[i-1].T.ID() || j[i].Departure < j[i-1].Arrival() {
+				t.Errorf("%s: journey for %d is not time-respecting: %v", name, id, j)
+			}
+		}
+		if nodes := nodeIDsOf(j.Nodes()); !reflect.DeepEqual(nodes, w.nodes) ||
+			j.Departure() != w.departure || j.Arrival() != w.arrival {
+			t.Errorf("%s: unexpected journey for %d: got:%v %v-%v want:%v %v-%v",
+				name, id, nodes, j.Departure(), j.Arrival(), w.nodes, w.departure, w.arrival)
+		}
+	}
+}
+
+func TestEarliestArrivalFrom(t *testing.T) {
+	t.Parallel()
+	checkJourneys(t, "start=0", EarliestArrivalFrom(simple.Node(0), temporalTestGraph, 0), map[int64]journeyWant{
+		1: {nodes: []int64{0, 1}, departure: 1, arrival: 2},
+		2: {nodes: []int64{0, 1, 2}, departure: 1, arrival: 4},
+		3: {nodes: []int64{0, 1, 2, 3}, departure: 1, arrival: 6},
+	})
+	checkJourneys(t, "start=3", EarliestArrivalFrom(simple.Node(0), temporalTestGraph, 3), map[int64]journeyWant{
+		1: {nodes: []int64{0, 1}, departure: 4, arrival: 5},
+		2: {nodes: []int64{0, 1, 2}, departure: 4, arrival: 7},
+		3: {nodes: []int64{0, 1, 2, 3}, departure: 4, arrival: 8},
+	})
+
+	// Chains of instantaneous edges are followed
+	// independently of their order in the graph.
+	// Hiding the Duration method leaves a graph
+	// with instantaneous edges.
+	instant := struct{ Temporal }{newTimetable([]TemporalEdge{
+		{F: simple.Node(1), T: simple.Node(2), Departure: 5, Duration: 3},
+		{F: simple.Node(0), T: simple.Node(1), Departure: 5, Duration: 3},
+	})}
+	checkJourneys(t, "instant", EarliestArrivalFrom(simple.Node(0), instant, 0), map[int64]journeyWant{
+		1: {nodes: []int64{0, 1}, departure: 5, arrival: 5},
+		2: {nodes: []int64{0, 1, 2}, departure: 5, arrival: 5},
+	})
+}
+
+func TestLatestDepartureTo(t *testing.T) {
+	t.Parallel()
+	checkJourneys(t, "end=10", LatestDepartureTo(simple.Node(3), temporalTestGraph, 10), map[int64]journeyWant{
+		0: {nodes: []int64{0, 1, 2, 3}, departure: 4, arrival: 8},
+		1: {nodes: []int64{1, 2, 3}, departure: 6, arrival: 8},
+		2: {nodes: []int64{2, 3}, departure: 8, arrival: 8},
+	})
+	checkJourneys(t, "end=6", LatestDepartureTo(simple.Node(3), temporalTestGraph, 6), map[int64]journeyWant{
+		0: {nodes: []int64{0, 1, 2, 3}, departure: 1, arrival: 6},
+		1: {nodes: []int64{1, 2, 3}, departure: 3, arrival: 6},
+		2: {nodes: []int64{2, 3}, departure: 5, arrival: 6},
+	})
+}
+
+func TestFastestFrom(t *testing.T) {
+	t.Parallel()
+	checkJourneys(t, "unbounded", FastestFrom(simple.Node(0), temporalTestGraph, 0, 100), map[int64]journeyWant{
+		1: {nodes: []int64{0, 1}, departure: 1, arrival: 2},
+		2: {nodes: []int64{0, 1, 2}, departure: 1, arrival: 4},
+		3: {nodes: []int64{0, 1, 2, 3}, departure: 4, arrival: 8},
+	})
+	checkJourneys(t, "window", FastestFrom(simple.Node(0), temporalTestGraph, 0, 7), map[int64]journeyWant{
+		1: {nodes: []int64{0, 1}, departure: 1, arrival: 2},
+		2: {nodes: []int64{0, 1, 2}, departure: 1, arrival: 4},
+		3: {nodes: []int64{0, 1, 2, 3}, departure: 1, arrival: 6},
+	})
+}
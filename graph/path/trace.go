@@ -0,0 +1,168 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"fmt"
+	"strconv"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+)
+
+// SearchTrace is an Observer that records the search tree explored by a
+// shortest path search, including the order in which nodes are expanded,
+// the cost and estimated total cost of the path to each node, and the
+// parent of each node in the search tree. A SearchTrace may be passed to
+// AStarWith or DijkstraFromWith to trace a search, for example
+// to tune a heuristic. A SearchTrace should only be used for one search.
+type SearchTrace struct {
+	t graph.Node
+	h Heuristic
+
+	// current is the node being expanded.
+	current graph.Node
+
+	nodes    map[int64]*TraceNode
+	parent   map[int64]graph.Node
+	expanded []*TraceNode
+}
+
+var _ Observer = (*SearchTrace)(nil)
+
+// NewSearchTrace returns a new SearchTrace for a search to the target node t
+// using the heuristic h to estimate the total path costs of traced nodes. If h
+// is nil, NullHeuristic is used.
+func NewSearchTrace(t graph.Node, h Heuristic) *SearchTrace {
+	if h == nil {
+		h = NullHeuristic
+	}
+	return &SearchTrace{
+		t:      t,
+		h:      h,
+		nodes:  make(map[int64]*TraceNode),
+		parent: make(map[int64]graph.Node),
+	}
+}
+
+// TraceNode is a node of a traced search tree.
+type TraceNode struct {
+	graph.Node
+
+	// Order is the position of the node in
+	// the expansion order of the search, or
+	// -1 if the node was not expanded.
+	Order int
+
+	// G is the cost of the best path to the
+	// node found by the search and F is the
+	// sum of G and the heuristic estimate of
+	// the cost from the node to the target.
+	G, F float64
+}
+
+// Attributes returns the DOT attributes of the node, labelling the
+// node with its ID, expansion order and path costs. Nodes that were
+// not expanded are drawn dashed.
+func (n TraceNode) Attributes() []encoding.Attribute {
+	order := "-"
+	if n.Order >= 0 {
+		order = strconv.Itoa(n.Order)
+	}
+	attrs := []encoding.Attribute{{
+		Key:   "label",
+		Value: fmt.Sprintf("%q", fmt.Sprintf("%d\n#%s g=%g f=%g", n.ID(), order, n.G, n.F)),
+	}}
+	if n.Order < 0 {
+		attrs = append(attrs, encoding.Attribute{Key: "style", Value: "dashed"})
+	}
+	return attrs
+}
+
+// OnExpand records the expansion of n.
+func (s *SearchTrace) OnExpand(n graph.Node, cost float64) {
+	s.current = n
+	rec := s.record(n, cost)
+	rec.Order = len(s.expanded)
+	s.expanded = append(s.expanded, rec)
+}
+
+// OnGenerate records the generation of n from the
+// node being expanded.
+func (s *SearchTrace) OnGenerate(n graph.Node, cost float64) {
+	s.record(n, cost)
+	if s.current != nil {
+		s.parent[n.ID()] = s.current
+	}
+}
+
+// OnRelax records the new parent of v.
+func (s *SearchTrace) OnRelax(u, v graph.Node, cost float64) {
+	s.record(v, cost)
+	s.parent[v.ID()] = u
+}
+
+// OnDone is a no-op.
+func (s *SearchTrace) OnDone(int) {}
+
+// record updates the cost of n, adding it to
+// the trace if needed, and returns its record.
+func (s *SearchTrace) record(n graph.Node, cost float64) *TraceNode {
+	rec, ok := s.nodes[n.ID()]
+	if !ok {
+		rec = &TraceNode{Node: n, Order: -1}
+		s.nodes[n.ID()] = rec
+	}
+	rec.G = cost
+	rec.F = cost + s.h(n, s.t)
+	return rec
+}
+
+// Expanded returns the nodes expanded by the search in expansion order.
+func (s *SearchTrace) Expanded() []TraceNode {
+	nodes := make([]TraceNode, len(s.expanded))
+	for i, n := range s.expanded {
+		nodes[i] = *n
+	}
+	return nodes
+}
+
+// Node returns the trace of the node with the given ID and whether
+// the node was reached by the search.
+func (s *SearchTrace) Node(id int64) (n TraceNode, ok bool) {
+	rec, ok := s.nodes[id]
+	if !ok {
+		return TraceNode{}, false
+	}
+	return *rec, true
+}
+
+// Parent returns the parent of the node with the given ID in the search
+// tree, or nil if the node is the search root or was not reached.
+func (s *SearchTrace) Parent(id int64) graph.Node {
+	return s.parent[id]
+}
+
+// Tree adds the search tree to dst. Each node reached by the search is added
+// as a TraceNode holding the traced node, and an edge is added from each
+// node's parent to the node, with a weight equal to the difference between
+// their path costs. The TraceNode values implement encoding.Attributer, so
+// the tree may be rendered with the encoding/dot package. The destination
+// is not cleared first. If dst has nodes that exist in the trace, Tree will
+// panic.
+func (s *SearchTrace) Tree(dst graph.WeightedBuilder) {
+	nodes := make(map[int64]graph.Node, len(s.nodes))
+	for id, rec := range s.nodes {
+		n := *rec
+		nodes[id] = n
+		dst.AddNode(n)
+	}
+	for id, p := range s.parent {
+		u := nodes[p.ID()]
+		v := nodes[id]
+		w := s.nodes[id].G - s.nodes[p.ID()].G
+		dst.SetWeightedEdge(dst.NewWeightedEdge(u, v, w))
+	}
+}
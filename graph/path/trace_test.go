@@ -0,0 +1,88 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/encoding/dot"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSearchTrace(t *testing.T) {
+	t.Parallel()
+	for _, test := range aStarTests {
+		if test.name == "large open graph" {
+			continue
+		}
+		s, tn := simple.Node(test.s), simple.Node(test.t)
+		trace := NewSearchTrace(tn, test.heuristic)
+		pt, expanded := AStarWith(s, tn, test.g, test.heuristic, &AStarSettings{Observer: trace})
+
+		h := test.heuristic
+		if h == nil {
+			h = NullHeuristic
+		}
+		got := trace.Expanded()
+		if len(got) != expanded {
+			t.Errorf("unexpected number of traced expansions for %q: got:%d want:%d", test.name, len(got), expanded)
+		}
+		for i, n := range got {
+			if n.Order != i {
+				t.Errorf("unexpected expansion order for node %d in %q: got:%d want:%d", n.ID(), test.name, n.Order, i)
+			}
+			if n.G != pt.WeightTo(n.ID()) {
+				t.Errorf("unexpected g value for node %d in %q: got:%v want:%v", n.ID(), test.name, n.G, pt.WeightTo(n.ID()))
+			}
+			if want := n.G + h(n.Node, tn); n.F != want {
+				t.Errorf("unexpected f value for node %d in %q: got:%v want:%v", n.ID(), test.name, n.F, want)
+			}
+		}
+
+		want, _ := pt.To(test.t)
+		var path []int64
+		for id := test.t; ; {
+			path = append(path, id)
+			p := trace.Parent(id)
+			if p == nil {
+				break
+			}
+			id = p.ID()
+		}
+		if len(want) == 0 {
+			if _, ok := trace.Node(test.t); ok {
+				t.Errorf("unexpected trace of unreached target in %q", test.name)
+			}
+			continue
+		}
+		if len(path) != len(want) {
+			t.Errorf("unexpected traced path length for %q: got:%d want:%d", test.name, len(path), len(want))
+			continue
+		}
+		for i, n := range want {
+			if id := path[len(path)-1-i]; id != n.ID() {
+				t.Errorf("unexpected traced path for %q: got:%v want:%v", test.name, path, want)
+				break
+			}
+		}
+
+		tree := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		trace.Tree(tree)
+		for _, n := range want[1:] {
+			if tree.To(n.ID()).Len() != 1 {
+				t.Errorf("unexpected in-degree of node %d in search tree for %q", n.ID(), test.name)
+			}
+		}
+		b, err := dot.Marshal(tree, "search", "", "")
+		if err != nil {
+			t.Errorf("unexpected error marshaling search tree for %q: %v", test.name, err)
+		}
+		if !bytes.Contains(b, []byte("g=")) {
+			t.Errorf("search tree for %q missing cost labels:\n%s", test.name, b)
+		}
+	}
+}
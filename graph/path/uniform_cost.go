@@ -0,0 +1,80 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/traverse"
+)
+
+// UniformCostSearch finds the shortest path from s to t in g. The path and its cost
+// are returned in a Shortest along with paths and costs to all nodes explored during
+// the search. The number of expanded nodes is also returned. If the graph does not
+// implement Weighted, UniformCost is used. UniformCostSearch will panic if g has an
+// s-reachable negative edge weight.
+//
+// UniformCostSearch is equivalent to AStar with NullHeuristic, but avoids the
+// heuristic calls and the open set bookkeeping of AStar.
+func UniformCostSearch(s, t graph.Node, g traverse.Graph) (path Shortest, expanded int) {
+	if g, ok := g.(graph.Graph); ok {
+		if g.Node(s.ID()) == nil || g.Node(t.ID()) == nil {
+			return Shortest{from: s}, 0
+		}
+	}
+	path = newShortestFrom(s, []graph.Node{s, t})
+	tid := t.ID()
+	expanded = dijkstraSearch(s, g, &path, func(n graph.Node, _ float64) bool {
+		return n.ID() == tid
+	}, nil)
+	return path, expanded
+}
+
+// BreadthFirstPath returns a path from s to t in g with the fewest edges and the
+// number of edges in the path. Edge weights are ignored. If there is no path from s
+// to t, path is nil and hops is -1.
+//
+// BreadthFirstPath is equivalent to AStar with UniformCost and NullHeuristic, but
+// uses a simple FIFO queue in place of a priority queue.
+func BreadthFirstPath(s, t graph.Node, g traverse.Graph) (path []graph.Node, hops int) {
+	sid := s.ID()
+	tid := t.ID()
+	if g, ok := g.(graph.Graph); ok {
+		if g.Node(sid) == nil || g.Node(tid) == nil {
+			return nil, -1
+		}
+	}
+	if sid == tid {
+		return []graph.Node{s}, 0
+	}
+
+	prev := map[int64]graph.Node{sid: nil}
+	queue := []graph.Node{s}
+	for len(queue) != 0 {
+		u := queue[0]
+		queue = queue[1:]
+		to := g.From(u.ID())
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if _, seen := prev[vid]; seen {
+				continue
+			}
+			prev[vid] = u
+			if vid != tid {
+				queue = append(queue, v)
+				continue
+			}
+
+			path = []graph.Node{v}
+			for n := u; n != nil; n = prev[n.ID()] {
+				path = append(path, n)
+			}
+			ordered.Reverse(path)
+			return path, len(path) - 1
+		}
+	}
+	return nil, -1
+}
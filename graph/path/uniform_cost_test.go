@@ -0,0 +1,100 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path/internal/testgraphs"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestUniformCostSearch(t *testing.T) {
+	t.Parallel()
+	for _, test := range testgraphs.ShortestPathTests {
+		g := test.Graph()
+		for _, e := range test.Edges {
+			g.SetWeightedEdge(e)
+		}
+
+		var (
+			pt       Shortest
+			expanded int
+
+			panicked bool
+		)
+		func() {
+			defer func() {
+				panicked = recover() != nil
+			}()
+			pt, expanded = UniformCostSearch(test.Query.From(), test.Query.To(), g.(graph.Graph))
+		}()
+		if panicked || test.HasNegativeWeight {
+			if !test.HasNegativeWeight {
+				t.Errorf("%q: unexpected panic", test.Name)
+			}
+			if !panicked {
+				t.Errorf("%q: expected panic for negative edge weight", test.Name)
+			}
+			continue
+		}
+
+		if pt.From().ID() != test.Query.From().ID() {
+			t.Fatalf("%q: unexpected from node ID: got:%d want:%d", test.Name, pt.From().ID(), test.Query.From().ID())
+		}
+
+		p, weight := pt.To(test.Query.To().ID())
+		if weight != test.Weight {
+			t.Errorf("%q: unexpected weight from To: got:%f want:%f",
+				test.Name, weight, test.Weight)
+		}
+
+		var got []int64
+		for _, n := range p {
+			got = append(got, n.ID())
+		}
+		ok := len(got) == 0 && len(test.WantPaths) == 0
+		for _, sp := range test.WantPaths {
+			if reflect.DeepEqual(got, sp) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			t.Errorf("%q: unexpected shortest path:\ngot: %v\nwant from:%v",
+				test.Name, p, test.WantPaths)
+		}
+
+		_, wantExpanded := AStar(test.Query.From(), test.Query.To(), g.(graph.Graph), nil)
+		if expanded > wantExpanded {
+			t.Errorf("%q: unexpected number of expanded nodes: got:%d want at most:%d",
+				test.Name, expanded, wantExpanded)
+		}
+	}
+}
+
+func TestBreadthFirstPath(t *testing.T) {
+	t.Parallel()
+	testFewestHops(t, func(s, t graph.Node, g graph.Graph) ([]graph.Node, int) {
+		return BreadthFirstPath(s, t, g)
+	})
+}
+
+func TestBreadthFirstPathMissing(t *testing.T) {
+	t.Parallel()
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+
+	path, hops := BreadthFirstPath(simple.Node(0), simple.Node(0), g)
+	if len(path) != 1 || path[0].ID() != 0 || hops != 0 {
+		t.Errorf("unexpected result for self path: got:%v hops=%d want:[0] hops=0", path, hops)
+	}
+	path, hops = BreadthFirstPath(simple.Node(0), simple.Node(2), g)
+	if path != nil || hops != -1 {
+		t.Errorf("unexpected result for absent node: got:%v hops=%d want:<nil> hops=-1", path, hops)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+)
+
+// waypointExactLimit is the largest number of waypoints for
+// which WaypointPath finds the optimal waypoint order.
+const waypointExactLimit = 12
+
+// WaypointPath returns a short path from s to t in g that visits each of the
+// given waypoints, and the weight of the path. If weight is nil, edge weights
+// are obtained from g if it implements Weighted, otherwise UniformCost is used.
+// If there is no such path, WaypointPath returns a nil path and an infinite
+// weight. WaypointPath will panic if g has a negative edge weight.
+//
+// Shortest paths between the terminals s, t and the waypoints are found with
+// |waypoints|+1 single source searches. For up to 12 waypoints, the order of
+// waypoints minimising the weight of the path is found exactly by dynamic
+// programming over subsets of waypoints, taking O(2^k.k^2) time for k waypoints.
+// For more waypoints, a nearest neighbor order improved by 2-opt exchanges is
+// used, and the returned path may not be the shortest.
+//
+// See Held and Karp, "A dynamic programming approach to sequencing problems",
+// J. SIAM 10(1):196-210 (1962) doi:10.1137/0110015.
+func WaypointPath(s graph.Node, waypoints []graph.Node, t graph.Node, g graph.Graph, weight Weighting) (path []graph.Node, w float64) {
+	if weight != nil {
+		g = reweighted{Graph: g, weight: weight}
+	}
+
+	// terminals holds s, then the
+	// waypoints, and then t.
+	terminals := make([]graph.Node, 0, len(waypoints)+2)
+	terminals = append(terminals, s)
+	terminals = append(terminals, waypoints...)
+	terminals = append(terminals, t)
+
+	k := len(waypoints)
+	trees := make([]Shortest, k+1)
+	dist := make([][]float64, k+1)
+	for i := range trees {
+		trees[i] = DijkstraFrom(terminals[i], g)
+		dist[i] = make([]float64, len(terminals))
+		for j, n := range terminals {
+			dist[i][j] = trees[i].WeightTo(n.ID())
+		}
+	}
+
+	var order []int
+	if k <= waypointExactLimit {
+		order, w = waypointOrderExact(dist)
+	} else {
+		order, w = waypointOrderHeuristic(dist)
+	}
+	if math.IsInf(w, 1) {
+		return nil, w
+	}
+
+	path = []graph.Node{s}
+	from := 0
+	for _, to := range append(order, k+1) {
+		leg, _ := trees[from].To(terminals[to].ID())
+		path = append(path, leg[1:]...)
+		from = to
+	}
+	return path, w
+}
+
+// waypointOrderExact returns the order of waypoint indices into dist
+// minimising the weight of the path from terminal 0 through the
+// waypoints to the last terminal, and the weight of the path.
+func waypointOrderExact(dist [][]float64) (order []int, w float64) {
+	k := len(dist) - 1
+	t := k + 1
+	if k == 0 {
+		return nil, dist[0][t]
+	}
+
+	// best[mask][j] holds the weight of the shortest
+	// path from terminal 0 visiting the waypoints in
+	// mask and ending at waypoint j+1, and prev holds
+	// the preceding waypoint on that path.
+	full := 1<<uint(k) - 1
+	best := make([][]float64, full+1)
+	prev := make([][]int, full+1)
+	for mask := range best {
+		best[mask] = make([]float64, k)
+		prev[mask] = make([]int, k)
+		for j := range best[mask] {
+			best[mask][j] = math.Inf(1)
+			prev[mask][j] = -1
+		}
+	}
+	for j := 0; j < k; j++ {
+		best[1<<uint(j)][j] = dist[0][j+1]
+	}
+	for mask := 1; mask <= full; mask++ {
+		for j := 0; j < k; j++ {
+			if mask&(1<<uint(j)) == 0 || math.IsInf(best[mask][j], 1) {
+				continue
+			}
+			for n := 0; n < k; n++ {
+				if mask&(1<<uint(n)) != 0 {
+					continue
+				}
+				next := mask | 1<<uint(n)
+				joint := best[mask][j] + dist[j+1][n+1]
+				if joint < best[next][n] {
+					best[next][n] = joint
+					prev[next][n] = j
+				}
+			}
+		}
+	}
+
+	w = math.Inf(1)
+	last := -1
+	for j := 0; j < k; j++ {
+		joint := best[full][j] + dist[j+1][t]
+		if joint < w {
+			w = joint
+			last = j
+		}
+	}
+	if last < 0 {
+		return nil, w
+	}
+
+	order = make([]int, k)
+	for i, mask := k-1, full; i >= 0; i-- {
+		order[i] = last + 1
+		last, mask = prev[mask][last], mask&^(1<<uint(last))
+	}
+	return order, w
+}
+
+// waypointOrderHeuristic returns an order of waypoint indices into
+// dist giving a short path from terminal 0 through the waypoints to
+// the last terminal, and the weight of the path. The order is found
+// by nearest neighbor construction followed by 2-opt improvement.
+func waypointOrderHeuristic(dist [][]float64) (order []int, w float64) {
+	k := len(dist) - 1
+	t := k + 1
+
+	visited := make([]bool, k+1)
+	from := 0
+	for len(order) < k {
+		next := -1
+		for j := 1; j <= k; j++ {
+			if !visited[j] && (next < 0 || dist[from][j] < dist[from][next]) {
+				next = j
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+		from = next
+	}
+
+	weightOf := func(order []int) float64 {
+		var w float64
+		from := 0
+		for _, to := range order {
+			w += dist[from][to]
+			from = to
+		}
+		return w + dist[from][t]
+	}
+	reverse := func(order []int, i, j int) {
+		for ; i < j; i, j = i+1, j-1 {
+			order[i], order[j] = order[j], order[i]
+		}
+	}
+
+	// Distances may be asymmetric, so the weight of
+	// each candidate order is found in full.
+	w = weightOf(order)
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < k-1; i++ {
+			for j := i + 1; j < k; j++ {
+				reverse(order, i, j)
+				if cand := weightOf(order); cand < w {
+					w = cand
+					improved = true
+				} else {
+					reverse(order, i, j)
+				}
+			}
+		}
+	}
+	return order, w
+}
+
+// reweighted is a graph with edge weights
+// provided by a Weighting.
+type reweighted struct {
+	graph.Graph
+	weight Weighting
+}
+
+func (g reweighted) Weight(xid, yid int64) (w float64, ok bool) {
+	return g.weight(xid, yid)
+}
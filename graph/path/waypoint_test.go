@@ -0,0 +1,164 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestWaypointPathExact(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		const n = 20
+		g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 5*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(1 + rnd.Intn(10))})
+		}
+
+		perm := rnd.Perm(n)
+		s, tn := simple.Node(perm[0]), simple.Node(perm[1])
+		var waypoints []graph.Node
+		for _, id := range perm[2 : 2+trial%6] {
+			waypoints = append(waypoints, simple.Node(id))
+		}
+
+		got, w := WaypointPath(s, waypoints, tn, g, nil)
+		want := bruteForceWaypoints(s, waypoints, tn, g)
+		if w != want {
+			t.Errorf("trial %d: unexpected path weight: got:%v want:%v", trial, w, want)
+		}
+		if math.IsInf(want, 1) {
+			if got != nil {
+				t.Errorf("trial %d: unexpected path for unreachable waypoints: %v", trial, got)
+			}
+			continue
+		}
+		checkWaypointPath(t, trial, got, w, s, waypoints, tn, g)
+	}
+}
+
+func TestWaypointPathHeuristic(t *testing.T) {
+	t.Parallel()
+	// A line of nodes where the best order visits
+	// the waypoints in order of their position.
+	const n = 40
+	g := simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+	for i := 1; i < n; i++ {
+		g.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(i - 1), T: simple.Node(i), W: 1})
+	}
+	rnd := rand.New(rand.NewSource(1))
+	var waypoints []graph.Node
+	for _, id := range rnd.Perm(n - 2)[:waypointExactLimit+5] {
+		waypoints = append(waypoints, simple.Node(id+1))
+	}
+
+	got, w := WaypointPath(simple.Node(0), waypoints, simple.Node(n-1), g, nil)
+	if w != n-1 {
+		t.Errorf("unexpected path weight: got:%v want:%v", w, n-1)
+	}
+	checkWaypointPath(t, 0, got, w, simple.Node(0), waypoints, simple.Node(n-1), g)
+}
+
+func TestWaypointPathWeighting(t *testing.T) {
+	t.Parallel()
+	g := simple.NewUndirectedGraph()
+	for i := 1; i < 5; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i - 1), T: simple.Node(i)})
+	}
+	double := func(xid, yid int64) (float64, bool) {
+		if g.Edge(xid, yid) == nil {
+			return math.Inf(1), false
+		}
+		return 2, true
+	}
+
+	waypoints := []graph.Node{simple.Node(4)}
+	got, w := WaypointPath(simple.Node(2), waypoints, simple.Node(0), g, double)
+	if w != 12 {
+		t.Errorf("unexpected path weight: got:%v want:12", w)
+	}
+	want := []int64{2, 3, 4, 3, 2, 1, 0}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected path: got:%v want:%v", got, want)
+	}
+	for i, n := range got {
+		if n.ID() != want[i] {
+			t.Fatalf("unexpected path: got:%v want:%v", got, want)
+		}
+	}
+}
+
+// checkWaypointPath checks that path is a walk in g from s to t through
+// each of the waypoints with the given weight.
+func checkWaypointPath(t *testing.T, trial int, path []graph.Node, w float64, s graph.Node, waypoints []graph.Node, tn graph.Node, g graph.Weighted) {
+	t.Helper()
+	if len(path) == 0 || path[0].ID() != s.ID() || path[len(path)-1].ID() != tn.ID() {
+		t.Errorf("trial %d: path does not join terminals: %v", trial, path)
+		return
+	}
+	var sum float64
+	for i := 1; i < len(path); i++ {
+		e, ok := g.Weight(path[i-1].ID(), path[i].ID())
+		if !ok {
+			t.Errorf("trial %d: path uses missing edge %d->%d", trial, path[i-1].ID(), path[i].ID())
+			return
+		}
+		sum += e
+	}
+	if sum != w {
+		t.Errorf("trial %d: path weight does not match edge weights: got:%v want:%v", trial, w, sum)
+	}
+	on := make(map[int64]bool)
+	for _, n := range path {
+		on[n.ID()] = true
+	}
+	for _, n := range waypoints {
+		if !on[n.ID()] {
+			t.Errorf("trial %d: path does not visit waypoint %d", trial, n.ID())
+		}
+	}
+}
+
+// bruteForceWaypoints returns the weight of the shortest path from s to t
+// through the waypoints in g by trying every order of waypoints.
+func bruteForceWaypoints(s graph.Node, waypoints []graph.Node, tn graph.Node, g graph.Graph) float64 {
+	best := math.Inf(1)
+	order := make([]graph.Node, len(waypoints))
+	copy(order, waypoints)
+	var permute func(i int)
+	permute = func(i int) {
+		if i == len(order) {
+			var w float64
+			from := s
+			for _, to := range append(order, tn) {
+				w += DijkstraFrom(from, g).WeightTo(to.ID())
+				from = to
+			}
+			best = math.Min(best, w)
+			return
+		}
+		for j := i; j < len(order); j++ {
+			order[i], order[j] = order[j], order[i]
+			permute(i + 1)
+			order[i], order[j] = order[j], order[i]
+		}
+	}
+	permute(0)
+	return best
+}
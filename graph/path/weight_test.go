@@ -0,0 +1,83 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package path
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// weightOnly is an undirected graph that provides edge weights
+// through a Weight method but does not implement graph.Weighted.
+type weightOnly struct {
+	graph.Undirected
+}
+
+func (g weightOnly) Weight(xid, yid int64) (w float64, ok bool) {
+	if xid == yid {
+		return 0, true
+	}
+	if !g.HasEdgeBetween(xid, yid) {
+		return math.Inf(1), false
+	}
+	if (xid == 0 && yid == 3) || (xid == 3 && yid == 0) {
+		return 10, true
+	}
+	return 1, true
+}
+
+func TestWeightedPrecedence(t *testing.T) {
+	t.Parallel()
+	u := simple.NewUndirectedGraph()
+	for _, e := range [][2]int64{{0, 1}, {1, 2}, {2, 3}, {0, 3}} {
+		u.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	g := weightOnly{u}
+	if _, ok := graph.Graph(g).(graph.Weighted); ok {
+		t.Fatal("test graph must not implement graph.Weighted")
+	}
+
+	// All functions must use the Weight method of the
+	// graph, giving a path weight of 3 from 0 to 3
+	// rather than the unit cost weight of 1.
+	const want = 3
+	for _, test := range []struct {
+		name   string
+		weight func() float64
+	}{
+		{name: "AStar", weight: func() float64 {
+			pt, _ := AStar(simple.Node(0), simple.Node(3), g, nil)
+			return pt.WeightTo(3)
+		}},
+		{name: "BellmanFordFrom", weight: func() float64 {
+			pt, _ := BellmanFordFrom(simple.Node(0), g)
+			return pt.WeightTo(3)
+		}},
+		{name: "DijkstraFrom", weight: func() float64 {
+			return DijkstraFrom(simple.Node(0), g).WeightTo(3)
+		}},
+		{name: "DijkstraAllPaths", weight: func() float64 {
+			return DijkstraAllPaths(g).Weight(0, 3)
+		}},
+		{name: "DijkstraAllPathsParallel", weight: func() float64 {
+			return DijkstraAllPathsParallel(g, 2).Weight(0, 3)
+		}},
+		{name: "FloydWarshall", weight: func() float64 {
+			pt, _ := FloydWarshall(g)
+			return pt.Weight(0, 3)
+		}},
+		{name: "JohnsonAllPaths", weight: func() float64 {
+			pt, _ := JohnsonAllPaths(g)
+			return pt.Weight(0, 3)
+		}},
+	} {
+		if got := test.weight(); got != want {
+			t.Errorf("unexpected path weight for %s: got:%v want:%v", test.name, got, want)
+		}
+	}
+}
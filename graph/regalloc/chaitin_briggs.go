@@ -0,0 +1,346 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regalloc
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// Allocator is a Chaitin–Briggs graph coloring register allocator.
+//
+// Allocation proceeds in four phases. Moves between values that do not
+// interfere are coalesced, merging the values, when the conservative Briggs
+// test shows that the merged value remains colorable. Values are then removed
+// from the interference graph in the simplify phase, taking values with fewer
+// than K neighbors first and otherwise choosing a potential spill with the
+// lowest spill cost per neighbor. In the select phase the removed values are
+// colored in reverse order with the lowest color not used by their neighbors,
+// and values that cannot be colored are spilled.
+//
+// Following Briggs, potential spills are optimistically colored and are only
+// spilled when no color is available, so fewer values are spilled than with
+// Chaitin's original pessimistic approach.
+//
+// See Chaitin, "Register allocation & spilling via graph coloring", SIGPLAN
+// Not. 17(6):98-105 (1982) doi:10.1145/872726.806984 and Briggs, Cooper and
+// Torczon, "Improvements to graph coloring register allocation", ACM Trans.
+// Program. Lang. Syst. 16(3):428-455 (1994) doi:10.1145/177492.177575.
+type Allocator struct {
+	// K is the number of available colors.
+	K int
+
+	// Precolored holds the colors of values that
+	// must be held in specific registers. The
+	// colors must be in [0, K).
+	Precolored map[int64]int
+
+	// SpillCost returns the cost of spilling the
+	// value with the given ID. If SpillCost is nil,
+	// all values have unit spill cost.
+	SpillCost func(id int64) float64
+
+	// Moves holds the pairs of value IDs joined by
+	// copy instructions. The values of a move are
+	// candidates for coalescing.
+	Moves [][2]int64
+
+	// Coalesce is called with the IDs of the values
+	// of a move before they are coalesced, and may
+	// return false to prevent coalescing. Values
+	// are only coalesced if they also pass the
+	// Briggs test. If Coalesce is nil, all moves
+	// passing the test are coalesced.
+	Coalesce func(uid, vid int64) bool
+}
+
+// Allocation is the result of a register allocation.
+type Allocation struct {
+	// Colors holds the colors of the values
+	// that were not spilled.
+	Colors map[int64]int
+
+	// Spilled holds the IDs of the spilled
+	// values, sorted ascending.
+	Spilled []int64
+
+	// Coalesced maps the ID of each coalesced
+	// value to the ID of the value that holds
+	// it after coalescing, which shares its
+	// color or spill.
+	Coalesced map[int64]int64
+}
+
+// Allocate returns a register allocation for the values of the interference
+// graph g. The values related by moves must be nodes of g. Spilled values are
+// reported but not rewritten; a compiler would typically insert spill code for
+// them and allocate again with the new interference graph.
+//
+// Allocate will panic if K is not positive, if a precolored value has a color
+// outside [0, K) or if a move refers to a value that is not in g.
+func (a *Allocator) Allocate(g graph.Undirected) Allocation {
+	if a.K <= 0 {
+		panic("regalloc: non-positive number of colors")
+	}
+	for id, c := range a.Precolored {
+		if c < 0 || a.K <= c {
+			panic(fmt.Sprintf("regalloc: precolored value %d has invalid color %d", id, c))
+		}
+	}
+
+	s := newState(g, a)
+	s.coalesce(a)
+	stack := s.simplify(a)
+	return s.selectColors(stack)
+}
+
+// state is the working state of an allocation.
+type state struct {
+	k int
+
+	nodes []graph.Node
+	adj   map[int64]map[int64]bool
+
+	precolored map[int64]int
+
+	// alias holds the value that each
+	// coalesced value was merged into.
+	alias map[int64]int64
+}
+
+func newState(g graph.Undirected, a *Allocator) *state {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+	s := &state{
+		k:          a.K,
+		nodes:      nodes,
+		adj:        make(map[int64]map[int64]bool, len(nodes)),
+		precolored: make(map[int64]int, len(a.Precolored)),
+		alias:      make(map[int64]int64),
+	}
+	for id, c := range a.Precolored {
+		s.precolored[id] = c
+	}
+	for _, u := range nodes {
+		uid := u.ID()
+		s.adj[uid] = make(map[int64]bool)
+		to := g.From(uid)
+		for to.Next() {
+			if vid := to.Node().ID(); vid != uid {
+				s.adj[uid][vid] = true
+			}
+		}
+	}
+	return s
+}
+
+// find returns the value that id has been merged into.
+func (s *state) find(id int64) int64 {
+	for {
+		to, ok := s.alias[id]
+		if !ok {
+			return id
+		}
+		id = to
+	}
+}
+
+// coalesce merges the values of non-interfering moves that
+// pass the Briggs test, repeating until no move is merged.
+func (s *state) coalesce(a *Allocator) {
+	for _, m := range a.Moves {
+		for _, id := range m {
+			if _, ok := s.adj[id]; !ok {
+				panic(fmt.Sprintf("regalloc: move refers to missing value %d", id))
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, m := range a.Moves {
+			u, v := s.find(m[0]), s.find(m[1])
+			if u == v || s.adj[u][v] {
+				continue
+			}
+			_, uPre := s.precolored[u]
+			_, vPre := s.precolored[v]
+			if uPre && vPre {
+				continue
+			}
+			if vPre {
+				u, v = v, u
+				uPre = true
+			}
+			if uPre && s.conflicts(u, v) {
+				continue
+			}
+			if !s.briggs(u, v) {
+				continue
+			}
+			if a.Coalesce != nil && !a.Coalesce(m[0], m[1]) {
+				continue
+			}
+			s.merge(u, v)
+			changed = true
+		}
+	}
+}
+
+// conflicts returns whether v interferes with a value
+// precolored with the color of the precolored value u.
+func (s *state) conflicts(u, v int64) bool {
+	c := s.precolored[u]
+	for w := range s.adj[v] {
+		if wc, ok := s.precolored[w]; ok && wc == c {
+			return true
+		}
+	}
+	return false
+}
+
+// briggs returns whether merging u and v leaves a value
+// with fewer than k significant neighbors.
+func (s *state) briggs(u, v int64) bool {
+	var n int
+	seen := make(map[int64]bool)
+	for _, id := range []int64{u, v} {
+		for w := range s.adj[id] {
+			if seen[w] {
+				continue
+			}
+			seen[w] = true
+			// A neighbor of both u and v loses
+			// an edge when they are merged.
+			deg := len(s.adj[w])
+			if s.adj[w][u] && s.adj[w][v] {
+				deg--
+			}
+			if _, pre := s.precolored[w]; pre || deg >= s.k {
+				n++
+			}
+		}
+	}
+	return n < s.k
+}
+
+// merge merges v into u.
+func (s *state) merge(u, v int64) {
+	for w := range s.adj[v] {
+		delete(s.adj[w], v)
+		s.adj[w][u] = true
+		s.adj[u][w] = true
+	}
+	delete(s.adj, v)
+	s.alias[v] = u
+}
+
+// simplify removes the values that are not precolored from the interference
+// graph and returns them in the order they were removed.
+func (s *state) simplify(a *Allocator) []int64 {
+	degree := make(map[int64]int)
+	var remaining []int64
+	for _, n := range s.nodes {
+		id := n.ID()
+		if _, ok := s.adj[id]; !ok {
+			continue
+		}
+		if _, pre := s.precolored[id]; pre {
+			continue
+		}
+		degree[id] = len(s.adj[id])
+		remaining = append(remaining, id)
+	}
+
+	var stack []int64
+	for len(remaining) != 0 {
+		next := -1
+		for i, id := range remaining {
+			if degree[id] < s.k {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// Choose a potential spill.
+			best := 0.0
+			for i, id := range remaining {
+				cost := 1.0
+				if a.SpillCost != nil {
+					cost = a.SpillCost(id)
+				}
+				cost /= float64(degree[id])
+				if next < 0 || cost < best {
+					next, best = i, cost
+				}
+			}
+		}
+		id := remaining[next]
+		remaining = append(remaining[:next], remaining[next+1:]...)
+		stack = append(stack, id)
+		for w := range s.adj[id] {
+			if _, ok := degree[w]; ok {
+				degree[w]--
+			}
+		}
+		delete(degree, id)
+	}
+	return stack
+}
+
+// selectColors colors the values in stack in reverse order.
+func (s *state) selectColors(stack []int64) Allocation {
+	colors := make(map[int64]int, len(s.nodes))
+	for id, c := range s.precolored {
+		if _, ok := s.adj[id]; ok {
+			colors[id] = c
+		}
+	}
+	var spilled []int64
+	used := make([]bool, s.k)
+	for i := len(stack) - 1; i >= 0; i-- {
+		id := stack[i]
+		for c := range used {
+			used[c] = false
+		}
+		for w := range s.adj[id] {
+			if c, ok := colors[w]; ok {
+				used[c] = true
+			}
+		}
+		c := -1
+		for i, u := range used {
+			if !u {
+				c = i
+				break
+			}
+		}
+		if c < 0 {
+			spilled = append(spilled, id)
+			continue
+		}
+		colors[id] = c
+	}
+
+	alloc := Allocation{Colors: colors, Coalesced: make(map[int64]int64)}
+	isSpilled := make(map[int64]bool)
+	for _, id := range spilled {
+		isSpilled[id] = true
+	}
+	for id := range s.alias {
+		rep := s.find(id)
+		alloc.Coalesced[id] = rep
+		if isSpilled[rep] {
+			spilled = append(spilled, id)
+		} else {
+			colors[id] = colors[rep]
+		}
+	}
+	sort.Sort(ordered.Int64s(spilled))
+	alloc.Spilled = spilled
+	return alloc
+}
@@ -0,0 +1,200 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regalloc
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func interference(nodes []int64, edges [][2]int64) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for _, id := range nodes {
+		g.AddNode(simple.Node(id))
+	}
+	for _, e := range edges {
+		g.SetEdge(simple.Edge{F: simple.Node(e[0]), T: simple.Node(e[1])})
+	}
+	return g
+}
+
+var allocateTests = []struct {
+	name  string
+	nodes []int64
+	edges [][2]int64
+	alloc Allocator
+
+	wantSpilled   []int64
+	wantCoalesced map[int64]int64
+}{
+	{
+		name:  "empty",
+		alloc: Allocator{K: 2},
+	},
+	{
+		name:  "odd cycle",
+		edges: [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}},
+		alloc: Allocator{K: 3},
+	},
+	{
+		// Every node has degree K, so simplification
+		// blocks, but optimistic coloring succeeds.
+		name:  "square",
+		edges: [][2]int64{{0, 1}, {1, 2}, {2, 3}, {3, 0}},
+		alloc: Allocator{K: 2},
+	},
+	{
+		name:        "complete",
+		edges:       [][2]int64{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}},
+		alloc:       Allocator{K: 3},
+		wantSpilled: []int64{0},
+	},
+	{
+		name:  "complete with spill costs",
+		edges: [][2]int64{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}},
+		alloc: Allocator{
+			K:         3,
+			SpillCost: func(id int64) float64 { return float64(10 - id) },
+		},
+		wantSpilled: []int64{3},
+	},
+	{
+		name:  "precolored",
+		edges: [][2]int64{{0, 1}, {1, 2}, {0, 2}, {2, 3}},
+		alloc: Allocator{
+			K:          3,
+			Precolored: map[int64]int{0: 2, 3: 0},
+		},
+	},
+	{
+		// a = ...; b = a; c = b; with 0 interfering
+		// with all three and 1 interfering with c.
+		name:  "coalesced moves",
+		edges: [][2]int64{{0, 2}, {0, 3}, {0, 4}, {1, 4}},
+		alloc: Allocator{
+			K:     2,
+			Moves: [][2]int64{{2, 3}, {3, 4}, {0, 1}},
+		},
+		wantCoalesced: map[int64]int64{1: 0, 3: 2, 4: 2},
+	},
+	{
+		name:  "coalescing vetoed",
+		edges: [][2]int64{{0, 2}, {0, 3}, {0, 4}, {1, 4}},
+		alloc: Allocator{
+			K:        2,
+			Moves:    [][2]int64{{2, 3}, {3, 4}, {0, 1}},
+			Coalesce: func(uid, vid int64) bool { return uid != 3 },
+		},
+		wantCoalesced: map[int64]int64{1: 0, 3: 2},
+	},
+	{
+		name:  "interfering move",
+		edges: [][2]int64{{0, 1}},
+		alloc: Allocator{
+			K:     2,
+			Moves: [][2]int64{{0, 1}},
+		},
+	},
+	{
+		// Merging 2 into the precolored 0 would give
+		// it a neighbor with the same color.
+		name:  "precolored conflict",
+		edges: [][2]int64{{1, 2}},
+		alloc: Allocator{
+			K:          2,
+			Precolored: map[int64]int{0: 1, 1: 1},
+			Moves:      [][2]int64{{0, 2}},
+		},
+		nodes: []int64{0},
+	},
+}
+
+func TestAllocate(t *testing.T) {
+	t.Parallel()
+	for _, test := range allocateTests {
+		g := interference(test.nodes, test.edges)
+		got := test.alloc.Allocate(g)
+		checkAllocation(t, test.name, g, &test.alloc, got)
+
+		if !reflect.DeepEqual(got.Spilled, test.wantSpilled) {
+			t.Errorf("%s: unexpected spilled values: got:%v want:%v", test.name, got.Spilled, test.wantSpilled)
+		}
+		want := test.wantCoalesced
+		if want == nil {
+			want = make(map[int64]int64)
+		}
+		if !reflect.DeepEqual(got.Coalesced, want) {
+			t.Errorf("%s: unexpected coalesced values: got:%v want:%v", test.name, got.Coalesced, want)
+		}
+	}
+}
+
+func TestAllocateRandom(t *testing.T) {
+	t.Parallel()
+	for seed := uint64(1); seed <= 10; seed++ {
+		g := simple.NewUndirectedGraph()
+		err := gen.Gnp(g, 40, 0.2, rand.NewSource(seed))
+		if err != nil {
+			t.Fatalf("unexpected error generating graph: %v", err)
+		}
+		rnd := rand.New(rand.NewSource(seed))
+		var moves [][2]int64
+		for i := 0; i < 20; i++ {
+			moves = append(moves, [2]int64{rnd.Int63n(40), rnd.Int63n(40)})
+		}
+		a := Allocator{
+			K:          4,
+			Precolored: map[int64]int{0: 0, 1: 1, 2: 2},
+			Moves:      moves,
+			SpillCost:  func(id int64) float64 { return float64(id%7) + 1 },
+		}
+		checkAllocation(t, "random", g, &a, a.Allocate(g))
+	}
+}
+
+// checkAllocation checks that got is a valid allocation of g by a.
+func checkAllocation(t *testing.T, name string, g graph.Undirected, a *Allocator, got Allocation) {
+	t.Helper()
+	spilled := make(map[int64]bool)
+	for _, id := range got.Spilled {
+		spilled[id] = true
+	}
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		id := n.ID()
+		c, ok := got.Colors[id]
+		if ok == spilled[id] {
+			t.Errorf("%s: value %d must be either colored or spilled", name, id)
+			continue
+		}
+		if !ok {
+			if _, pre := a.Precolored[id]; pre {
+				t.Errorf("%s: precolored value %d spilled", name, id)
+			}
+			continue
+		}
+		if c < 0 || a.K <= c {
+			t.Errorf("%s: value %d has invalid color %d", name, id, c)
+		}
+		if pc, pre := a.Precolored[id]; pre && pc != c {
+			t.Errorf("%s: precolored value %d has color %d, want %d", name, id, c, pc)
+		}
+		for _, v := range graph.NodesOf(g.From(id)) {
+			if vc, ok := got.Colors[v.ID()]; ok && vc == c {
+				t.Errorf("%s: interfering values %d and %d have color %d", name, id, v.ID(), c)
+			}
+		}
+	}
+	for id, rep := range got.Coalesced {
+		if spilled[id] != spilled[rep] || got.Colors[id] != got.Colors[rep] {
+			t.Errorf("%s: coalesced value %d not allocated with %d", name, id, rep)
+		}
+	}
+}
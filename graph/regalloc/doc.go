@@ -0,0 +1,11 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package regalloc provides graph coloring register allocation.
+//
+// The allocator colors an interference graph, where nodes are program values
+// or live ranges and edges join values that are live at the same time, with a
+// limited number of colors representing machine registers. It complements the
+// control flow analysis provided by the dominator functions in the flow package.
+package regalloc // import "gonum.org/v1/gonum/graph/regalloc"
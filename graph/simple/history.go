@@ -0,0 +1,198 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple
+
+import "gonum.org/v1/gonum/graph"
+
+// History records reversible mutations of a graph so that they can be undone.
+// Mutations made through a History are applied to its graph and recorded.
+// Mutations made to the graph directly are not recorded, and undoing recorded
+// mutations after such changes may leave the graph in an unexpected state.
+//
+// Undoing a mutation restores the nodes and edges of the graph, and the
+// weights of restored edges. Node values replaced by SetEdge or
+// SetWeightedEdge for existing node IDs are not restored.
+type History struct {
+	g     graph.Graph
+	limit int
+	undo  []func()
+}
+
+// NewHistory returns a History recording mutations of g. If limit is greater
+// than zero, at most limit mutations are held, and the oldest mutation is
+// discarded when a new mutation is recorded with a full history.
+//
+// The mutation methods of the returned History, and Undo and Rewind, will
+// panic if g does not implement a graph mutation interface they require.
+func NewHistory(g graph.Graph, limit int) *History {
+	return &History{g: g, limit: limit}
+}
+
+// Graph returns the graph being mutated.
+func (h *History) Graph() graph.Graph { return h.g }
+
+// Len returns the number of mutations that can be undone. The returned
+// value may be used as a mark to pass to Rewind if the history is not
+// limited or no mutations are discarded.
+func (h *History) Len() int { return len(h.undo) }
+
+// Clear discards all recorded mutations without undoing them.
+func (h *History) Clear() { h.undo = h.undo[:0] }
+
+// Undo reverts the most recently recorded mutation and returns whether a
+// mutation was reverted.
+func (h *History) Undo() bool {
+	if len(h.undo) == 0 {
+		return false
+	}
+	last := len(h.undo) - 1
+	h.undo[last]()
+	h.undo[last] = nil
+	h.undo = h.undo[:last]
+	return true
+}
+
+// Rewind reverts recorded mutations until n mutations remain and returns
+// the number of mutations that were reverted.
+func (h *History) Rewind(n int) int {
+	if n < 0 {
+		n = 0
+	}
+	var undone int
+	for len(h.undo) > n {
+		h.Undo()
+		undone++
+	}
+	return undone
+}
+
+// record adds the reversal of a mutation to the history.
+func (h *History) record(fn func()) {
+	if h.limit > 0 && len(h.undo) == h.limit {
+		copy(h.undo, h.undo[1:])
+		h.undo = h.undo[:len(h.undo)-1]
+	}
+	h.undo = append(h.undo, fn)
+}
+
+// AddNode adds n to the graph. AddNode panics if the added node ID matches an
+// existing node ID.
+func (h *History) AddNode(n graph.Node) {
+	h.g.(graph.NodeAdder).AddNode(n)
+	id := n.ID()
+	h.record(func() { h.g.(graph.NodeRemover).RemoveNode(id) })
+}
+
+// RemoveNode removes the node with the given ID from the graph, as well as
+// any edges attached to it. If the node is not in the graph it is a no-op and
+// no mutation is recorded.
+func (h *History) RemoveNode(id int64) {
+	n := h.g.Node(id)
+	if n == nil {
+		return
+	}
+	seen := make(map[[2]int64]bool)
+	var edges []graph.Edge
+	collect := func(uid, vid int64) {
+		if seen[[2]int64{uid, vid}] {
+			return
+		}
+		seen[[2]int64{uid, vid}] = true
+		edges = append(edges, h.edge(uid, vid))
+	}
+	to := h.g.From(id)
+	for to.Next() {
+		collect(id, to.Node().ID())
+	}
+	if d, ok := h.g.(graph.Directed); ok {
+		from := d.To(id)
+		for from.Next() {
+			collect(from.Node().ID(), id)
+		}
+	}
+
+	h.g.(graph.NodeRemover).RemoveNode(id)
+	h.record(func() {
+		h.g.(graph.NodeAdder).AddNode(n)
+		for _, e := range edges {
+			h.set(e)
+		}
+	})
+}
+
+// SetEdge adds e, an edge from one node to another, to the graph, adding
+// the nodes of e if they do not exist.
+func (h *History) SetEdge(e graph.Edge) {
+	undo := h.reverter(e.From().ID(), e.To().ID())
+	h.g.(graph.EdgeAdder).SetEdge(e)
+	h.record(undo)
+}
+
+// SetWeightedEdge adds e, a weighted edge from one node to another, to the
+// graph, adding the nodes of e if they do not exist.
+func (h *History) SetWeightedEdge(e graph.WeightedEdge) {
+	undo := h.reverter(e.From().ID(), e.To().ID())
+	h.g.(graph.WeightedEdgeAdder).SetWeightedEdge(e)
+	h.record(undo)
+}
+
+// RemoveEdge removes the edge with the given end IDs from the graph, leaving
+// the terminal nodes. If the edge does not exist it is a no-op and no mutation
+// is recorded.
+func (h *History) RemoveEdge(fid, tid int64) {
+	old := h.edge(fid, tid)
+	if old == nil {
+		return
+	}
+	h.g.(graph.EdgeRemover).RemoveEdge(fid, tid)
+	h.record(func() { h.set(old) })
+}
+
+// reverter returns a function that restores the edge between the nodes
+// with IDs fid and tid and the presence of the nodes to their current
+// state.
+func (h *History) reverter(fid, tid int64) func() {
+	old := h.edge(fid, tid)
+	hasFrom := h.g.Node(fid) != nil
+	hasTo := h.g.Node(tid) != nil
+	return func() {
+		if old != nil {
+			h.set(old)
+		} else {
+			h.g.(graph.EdgeRemover).RemoveEdge(fid, tid)
+		}
+		if !hasFrom {
+			h.g.(graph.NodeRemover).RemoveNode(fid)
+		}
+		if !hasTo {
+			h.g.(graph.NodeRemover).RemoveNode(tid)
+		}
+	}
+}
+
+// edge returns the edge from uid to vid in the graph, or nil if there is
+// no such edge. If the graph is weighted, the returned edge holds its weight.
+func (h *History) edge(uid, vid int64) graph.Edge {
+	if wg, ok := h.g.(graph.Weighted); ok {
+		e := wg.WeightedEdge(uid, vid)
+		if e == nil {
+			return nil
+		}
+		return e
+	}
+	return h.g.Edge(uid, vid)
+}
+
+// set adds e to the graph, retaining its weight if it is weighted
+// and the graph is a weighted graph.
+func (h *History) set(e graph.Edge) {
+	if we, ok := e.(graph.WeightedEdge); ok {
+		if wg, ok := h.g.(graph.WeightedEdgeAdder); ok {
+			wg.SetWeightedEdge(we)
+			return
+		}
+	}
+	h.g.(graph.EdgeAdder).SetEdge(e)
+}
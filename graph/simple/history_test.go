@@ -0,0 +1,138 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simple_test
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// graphState is a comparable snapshot of a graph.
+type graphState struct {
+	nodes []int64
+	edges map[[2]int64]float64
+}
+
+func stateOf(g graph.Graph) graphState {
+	s := graphState{edges: make(map[[2]int64]float64)}
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		s.nodes = append(s.nodes, uid)
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			w := 1.0
+			if wg, ok := g.(graph.Weighted); ok {
+				w, _ = wg.Weight(uid, vid)
+			}
+			s.edges[[2]int64{uid, vid}] = w
+		}
+	}
+	sort.Slice(s.nodes, func(i, j int) bool { return s.nodes[i] < s.nodes[j] })
+	return s
+}
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		name     string
+		new      func() graph.Graph
+		weighted bool
+	}{
+		{name: "directed", new: func() graph.Graph { return simple.NewDirectedGraph() }},
+		{name: "undirected", new: func() graph.Graph { return simple.NewUndirectedGraph() }},
+		{name: "weighted directed", weighted: true, new: func() graph.Graph {
+			return simple.NewWeightedDirectedGraph(0, math.Inf(1))
+		}},
+		{name: "weighted undirected", weighted: true, new: func() graph.Graph {
+			return simple.NewWeightedUndirectedGraph(0, math.Inf(1))
+		}},
+	} {
+		rnd := rand.New(rand.NewSource(1))
+		g := test.new()
+		h := simple.NewHistory(g, 0)
+
+		const n = 10
+		var states []graphState
+		for op := 0; op < 200; op++ {
+			if h.Len() != len(states) {
+				t.Fatalf("%s: unexpected history length: got:%d want:%d", test.name, h.Len(), len(states))
+			}
+			before := stateOf(g)
+			mark := h.Len()
+
+			u, v := rnd.Int63n(n), rnd.Int63n(n)
+			switch rnd.Intn(4) {
+			case 0:
+				if g.Node(u) == nil {
+					h.AddNode(simple.Node(u))
+				}
+			case 1:
+				h.RemoveNode(u)
+			case 2:
+				if u == v {
+					continue
+				}
+				if test.weighted {
+					h.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: float64(rnd.Intn(5))})
+				} else {
+					h.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+				}
+			case 3:
+				h.RemoveEdge(u, v)
+			}
+			if h.Len() != mark {
+				states = append(states, before)
+			}
+		}
+
+		for len(states) != 0 {
+			last := len(states) - 1
+			if rnd.Intn(2) == 0 {
+				h.Undo()
+			} else {
+				last = rnd.Intn(len(states))
+				h.Rewind(last)
+			}
+			if got := stateOf(g); !reflect.DeepEqual(got, states[last]) {
+				t.Fatalf("%s: unexpected graph after rewinding to %d:\ngot: %+v\nwant:%+v", test.name, last, got, states[last])
+			}
+			states = states[:last]
+		}
+		if h.Undo() {
+			t.Errorf("%s: unexpected undo with empty history", test.name)
+		}
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	t.Parallel()
+	g := simple.NewDirectedGraph()
+	h := simple.NewHistory(g, 2)
+	h.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	h.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	h.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(3)})
+	if h.Len() != 2 {
+		t.Errorf("unexpected history length: got:%d want:2", h.Len())
+	}
+	if n := h.Rewind(0); n != 2 {
+		t.Errorf("unexpected number of reverted mutations: got:%d want:2", n)
+	}
+	want := graphState{
+		nodes: []int64{0, 1},
+		edges: map[[2]int64]float64{{0, 1}: 1},
+	}
+	if got := stateOf(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected graph after rewinding limited history:\ngot: %+v\nwant:%+v", got, want)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spectral
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Propagator is a normalized graph adjacency matrix used to propagate node
+// features along the edges of a graph, as in the aggregation step of graph
+// convolutional networks. The matrix is held in compressed sparse row form.
+type Propagator struct {
+	// Nodes holds the input graph nodes.
+	Nodes []graph.Node
+
+	// Index is a mapping from the graph
+	// node IDs to row and column indices.
+	Index map[int64]int
+
+	// rowPtr, col and val hold the non-zero
+	// elements of the matrix. The elements of
+	// row i are held in col and val between
+	// rowPtr[i] and rowPtr[i+1], in order of
+	// increasing column.
+	rowPtr []int
+	col    []int
+	val    []float64
+}
+
+var _ mat.Matrix = Propagator{}
+
+// NewSymNormPropagator returns a symmetric normalized adjacency Propagator for the
+// undirected graph g. The Propagator is defined as D^(-1/2)AD^(-1/2) where A is the
+// adjacency matrix of the input graph, with the identity matrix added if selfLoops
+// is true, and D is the diagonal matrix of the row sums of A. With selfLoops, this
+// is the renormalized propagation operator of Kipf and Welling's graph convolutional
+// network. If g implements graph.Weighted the edge weights are used as the elements
+// of the adjacency matrix.
+// If g contains self edges, NewSymNormPropagator will panic.
+//
+// See Kipf and Welling, "Semi-supervised classification with graph convolutional
+// networks", ICLR 2017 arXiv:1609.02907.
+func NewSymNormPropagator(g graph.Undirected, selfLoops bool) Propagator {
+	p, deg := newPropagator(g, selfLoops)
+	for i := range deg {
+		if deg[i] != 0 {
+			deg[i] = 1 / math.Sqrt(deg[i])
+		}
+	}
+	for i := range p.Nodes {
+		for k := p.rowPtr[i]; k < p.rowPtr[i+1]; k++ {
+			p.val[k] *= deg[i] * deg[p.col[k]]
+		}
+	}
+	return p
+}
+
+// NewMeanPropagator returns a row normalized adjacency Propagator for the graph g.
+// The Propagator is defined as D^(-1)A where A is the adjacency matrix of the input
+// graph, with the identity matrix added if selfLoops is true, and D is the diagonal
+// matrix of the row sums of A. Propagating features with the returned Propagator
+// replaces the features of each node with the mean of the features of the nodes
+// reachable from it by a single edge. If g implements graph.Weighted the edge
+// weights are used as the elements of the adjacency matrix and the mean is weighted.
+// If g contains self edges, NewMeanPropagator will panic.
+func NewMeanPropagator(g graph.Graph, selfLoops bool) Propagator {
+	p, deg := newPropagator(g, selfLoops)
+	for i := range p.Nodes {
+		if deg[i] == 0 {
+			continue
+		}
+		for k := p.rowPtr[i]; k < p.rowPtr[i+1]; k++ {
+			p.val[k] /= deg[i]
+		}
+	}
+	return p
+}
+
+// newPropagator returns the unnormalized Propagator for g
+// and the row sums of its adjacency matrix.
+func newPropagator(g graph.Graph, selfLoops bool) (p Propagator, deg []float64) {
+	nodes := graph.NodesOf(g.Nodes())
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	weight := func(uid, vid int64) float64 { return 1 }
+	if wg, ok := g.(graph.Weighted); ok {
+		weight = func(uid, vid int64) float64 {
+			w, _ := wg.Weight(uid, vid)
+			return w
+		}
+	}
+
+	p = Propagator{
+		Nodes:  nodes,
+		Index:  indexOf,
+		rowPtr: make([]int, len(nodes)+1),
+	}
+	deg = make([]float64, len(nodes))
+	var row csrRow
+	for i, u := range nodes {
+		uid := u.ID()
+		row.col = row.col[:0]
+		row.val = row.val[:0]
+		if selfLoops {
+			row.col = append(row.col, i)
+			row.val = append(row.val, 1)
+		}
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if uid == vid {
+				panic("spectral: self edge in graph")
+			}
+			row.col = append(row.col, indexOf[vid])
+			row.val = append(row.val, weight(uid, vid))
+		}
+		sort.Sort(row)
+		for _, w := range row.val {
+			deg[i] += w
+		}
+		p.col = append(p.col, row.col...)
+		p.val = append(p.val, row.val...)
+		p.rowPtr[i+1] = len(p.col)
+	}
+	return p, deg
+}
+
+// csrRow sorts the elements of a sparse row by column.
+type csrRow struct {
+	col []int
+	val []float64
+}
+
+func (r csrRow) Len() int           { return len(r.col) }
+func (r csrRow) Less(i, j int) bool { return r.col[i] < r.col[j] }
+func (r csrRow) Swap(i, j int) {
+	r.col[i], r.col[j] = r.col[j], r.col[i]
+	r.val[i], r.val[j] = r.val[j], r.val[i]
+}
+
+// Dims returns the dimensions of the matrix.
+func (p Propagator) Dims() (r, c int) { return len(p.Nodes), len(p.Nodes) }
+
+// At returns the value of the element at row i and column j.
+func (p Propagator) At(i, j int) float64 {
+	if uint(i) >= uint(len(p.Nodes)) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(len(p.Nodes)) {
+		panic(mat.ErrColAccess)
+	}
+	cols := p.col[p.rowPtr[i]:p.rowPtr[i+1]]
+	k := sort.SearchInts(cols, j)
+	if k == len(cols) || cols[k] != j {
+		return 0
+	}
+	return p.val[p.rowPtr[i]+k]
+}
+
+// T returns the transpose of the matrix.
+func (p Propagator) T() mat.Matrix { return mat.Transpose{Matrix: p} }
+
+// Propagate returns the result of applying the Propagator to the node feature
+// matrix x the given number of times, P^steps x. Row i of x holds the features
+// of the node p.Nodes[i]. Propagate will panic if the number of rows of x does
+// not match the number of nodes or steps is negative.
+func (p Propagator) Propagate(x mat.Matrix, steps int) *mat.Dense {
+	r, c := x.Dims()
+	if r != len(p.Nodes) {
+		panic(mat.ErrShape)
+	}
+	if steps < 0 {
+		panic("spectral: negative propagation steps")
+	}
+	src := mat.DenseCopyOf(x)
+	if steps == 0 {
+		return src
+	}
+	dst := mat.NewDense(r, c, nil)
+	for s := 0; s < steps; s++ {
+		for i := 0; i < r; i++ {
+			row := dst.RawRowView(i)
+			for j := range row {
+				row[j] = 0
+			}
+			for k := p.rowPtr[i]; k < p.rowPtr[i+1]; k++ {
+				floats.AddScaled(row, p.val[k], src.RawRowView(p.col[k]))
+			}
+		}
+		src, dst = dst, src
+	}
+	return src
+}
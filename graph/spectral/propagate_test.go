@@ -0,0 +1,174 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spectral
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+)
+
+var propagatorTests = []struct {
+	name     string
+	directed bool
+	g        []set
+	weights  map[[2]int64]float64
+}{
+	{
+		name: "path",
+		g: []set{
+			A: linksTo(B),
+			B: linksTo(C),
+			C: nil,
+		},
+	},
+	{
+		name: "isolated",
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(C),
+			C: nil,
+			D: nil,
+		},
+	},
+	{
+		name: "weighted",
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(C, D),
+			C: nil,
+			D: nil,
+		},
+		weights: map[[2]int64]float64{{A, B}: 2, {A, C}: 0.5, {B, C}: 3, {B, D}: 1},
+	},
+	{
+		name:     "directed",
+		directed: true,
+		g: []set{
+			A: linksTo(B, C),
+			B: linksTo(C),
+			C: linksTo(A),
+			D: linksTo(A),
+		},
+	},
+}
+
+// propagatorGraph returns the graph described by the test and its
+// dense adjacency matrix with rows and columns ordered by node ID.
+func propagatorGraph(g []set, weights map[[2]int64]float64, directed bool) (graph.Graph, *mat.Dense) {
+	var b interface {
+		graph.Graph
+		graph.WeightedBuilder
+	}
+	if directed {
+		b = simple.NewWeightedDirectedGraph(0, 0)
+	} else {
+		b = simple.NewWeightedUndirectedGraph(0, 0)
+	}
+	adj := mat.NewDense(len(g), len(g), nil)
+	for u, e := range g {
+		if b.Node(int64(u)) == nil {
+			b.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			w, ok := weights[[2]int64{int64(u), v}]
+			if !ok {
+				w = 1
+			}
+			b.SetWeightedEdge(simple.WeightedEdge{F: simple.Node(u), T: simple.Node(v), W: w})
+			adj.Set(u, int(v), w)
+			if !directed {
+				adj.Set(int(v), u, w)
+			}
+		}
+	}
+	return b, adj
+}
+
+// permuted returns the matrix m with rows and columns
+// ordered by the nodes of p.
+func permuted(m *mat.Dense, p Propagator) *mat.Dense {
+	n := len(p.Nodes)
+	dst := mat.NewDense(n, n, nil)
+	for i, u := range p.Nodes {
+		for j, v := range p.Nodes {
+			dst.Set(i, j, m.At(int(u.ID()), int(v.ID())))
+		}
+	}
+	return dst
+}
+
+func TestPropagator(t *testing.T) {
+	t.Parallel()
+	for _, test := range propagatorTests {
+		for _, selfLoops := range []bool{false, true} {
+			g, adj := propagatorGraph(test.g, test.weights, test.directed)
+			n := len(test.g)
+			if selfLoops {
+				for i := 0; i < n; i++ {
+					adj.Set(i, i, 1)
+				}
+			}
+			deg := make([]float64, n)
+			for i := range deg {
+				deg[i] = mat.Sum(adj.RowView(i))
+			}
+
+			var propagators []Propagator
+			var wants []*mat.Dense
+
+			if !test.directed {
+				sym := mat.NewDense(n, n, nil)
+				sym.Apply(func(i, j int, v float64) float64 {
+					if v == 0 {
+						return 0
+					}
+					return v / math.Sqrt(deg[i]*deg[j])
+				}, adj)
+				propagators = append(propagators, NewSymNormPropagator(g.(graph.Undirected), selfLoops))
+				wants = append(wants, sym)
+			}
+
+			mean := mat.NewDense(n, n, nil)
+			mean.Apply(func(i, j int, v float64) float64 {
+				if v == 0 {
+					return 0
+				}
+				return v / deg[i]
+			}, adj)
+			propagators = append(propagators, NewMeanPropagator(g, selfLoops))
+			wants = append(wants, mean)
+
+			for k, p := range propagators {
+				want := permuted(wants[k], p)
+				if !mat.EqualApprox(p, want, 1e-14) {
+					t.Errorf("unexpected propagator %d for %q selfLoops=%t:\ngot: %v\nwant:%v",
+						k, test.name, selfLoops, mat.Formatted(p), mat.Formatted(want))
+				}
+
+				x := mat.NewDense(n, 2, nil)
+				for i := 0; i < n; i++ {
+					x.Set(i, 0, float64(i+1))
+					x.Set(i, 1, float64(i*i))
+				}
+				for steps := 0; steps <= 3; steps++ {
+					got := p.Propagate(x, steps)
+					var wantX mat.Dense
+					wantX.CloneFrom(x)
+					for s := 0; s < steps; s++ {
+						wantX.Mul(want, &wantX)
+					}
+					if !mat.EqualApprox(got, &wantX, 1e-12) {
+						t.Errorf("unexpected propagation %d for %q selfLoops=%t steps=%d:\ngot: %v\nwant:%v",
+							k, test.name, selfLoops, steps, mat.Formatted(got), mat.Formatted(&wantX))
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spectral
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Summary holds spectral features of an undirected graph.
+type Summary struct {
+	// Adjacency holds the largest eigenvalues
+	// of the adjacency matrix of the graph in
+	// decreasing order.
+	Adjacency []float64
+
+	// Laplacian holds the largest eigenvalues
+	// of the Laplacian matrix of the graph in
+	// decreasing order.
+	Laplacian []float64
+
+	// SpectralRadius is the largest absolute
+	// value of the adjacency eigenvalues.
+	SpectralRadius float64
+
+	// SpectralGap is the difference between
+	// the two largest adjacency eigenvalues.
+	SpectralGap float64
+}
+
+// SpectralSummary returns the k largest adjacency and Laplacian eigenvalues of
+// the simple undirected graph g and the spectral radius and spectral gap of its
+// adjacency matrix. If k is greater than the number of nodes in g, all the
+// eigenvalues are returned. Since the adjacency matrix is non-negative, the
+// spectral radius is equal to the largest adjacency eigenvalue. The spectral
+// gap is only calculated when k is at least two.
+// If g contains self edges, SpectralSummary will panic.
+//
+// The eigenvalues are calculated by the Lanczos method with full
+// reorthogonalization, using the adjacency lists of g rather than a dense
+// matrix. The Lanczos basis is restarted when it spans an invariant subspace,
+// allowing repeated eigenvalues to be found.
+func SpectralSummary(g graph.Undirected, k int) Summary {
+	if k < 1 {
+		panic("spectral: non-positive number of eigenvalues")
+	}
+	adj := adjacencyLists(g)
+	if k > len(adj) {
+		k = len(adj)
+	}
+	if k == 0 {
+		return Summary{}
+	}
+	a := lanczos(len(adj), k, func(dst, x []float64) {
+		for i, to := range adj {
+			var v float64
+			for _, j := range to {
+				v += x[j]
+			}
+			dst[i] = v
+		}
+	}, rand.NewSource(1))
+	l := lanczos(len(adj), k, func(dst, x []float64) {
+		for i, to := range adj {
+			v := float64(len(to)) * x[i]
+			for _, j := range to {
+				v -= x[j]
+			}
+			dst[i] = v
+		}
+	}, rand.NewSource(1))
+
+	s := Summary{Adjacency: a, Laplacian: l, SpectralRadius: a[0]}
+	if k > 1 {
+		s.SpectralGap = a[0] - a[1]
+	}
+	return s
+}
+
+// Energy returns the energy of the simple undirected graph g, the sum of the
+// absolute values of the eigenvalues of its adjacency matrix. Energy requires
+// the complete spectrum, so it performs a dense eigendecomposition, taking
+// O(|V|^3) time.
+// If g contains self edges, Energy will panic.
+//
+// See Gutman, "The energy of a graph", Ber. Math.-Statist. Sekt. Forschungsz.
+// Graz 103:1-22 (1978).
+func Energy(g graph.Undirected) float64 {
+	adj := adjacencyLists(g)
+	if len(adj) == 0 {
+		return 0
+	}
+	a := mat.NewSymDense(len(adj), nil)
+	for i, to := range adj {
+		for _, j := range to {
+			a.SetSym(i, j, 1)
+		}
+	}
+	var eig mat.EigenSym
+	ok := eig.Factorize(a, false)
+	if !ok {
+		panic("spectral: eigendecomposition failed")
+	}
+	var e float64
+	for _, v := range eig.Values(nil) {
+		e += math.Abs(v)
+	}
+	return e
+}
+
+// adjacencyLists returns the neighbors of each node of g as node indices.
+func adjacencyLists(g graph.Undirected) [][]int {
+	nodes := graph.NodesOf(g.Nodes())
+	indexOf := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		indexOf[n.ID()] = i
+	}
+	adj := make([][]int, len(nodes))
+	for i, u := range nodes {
+		uid := u.ID()
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if uid == vid {
+				panic("spectral: self edge in graph")
+			}
+			adj[i] = append(adj[i], indexOf[vid])
+		}
+	}
+	return adj
+}
+
+// lanczos returns the k largest eigenvalues of the n×n symmetric operator mul
+// in decreasing order. The Lanczos iteration is extended until the residual
+// bounds of the k largest Ritz values are small, or the Krylov subspace fills
+// the space, in which case the Ritz values are the eigenvalues of the operator.
+func lanczos(n, k int, mul func(dst, x []float64), src rand.Source) []float64 {
+	const (
+		// breakdown is the residual norm below which the
+		// Krylov subspace is considered to be invariant.
+		breakdown = 1e-10
+
+		// tol is the relative residual bound for
+		// accepting a Ritz value as converged.
+		tol = 1e-12
+
+		// check is the number of Lanczos steps
+		// between convergence checks.
+		check = 10
+	)
+
+	rnd := rand.New(src)
+	var (
+		q     [][]float64
+		alpha []float64
+		beta  []float64
+		w     = make([]float64, n)
+	)
+	v := startVector(n, q, rnd)
+	for j := 0; ; j++ {
+		q = append(q, v)
+		mul(w, v)
+		alpha = append(alpha, floats.Dot(w, v))
+
+		// Orthogonalize against the complete basis twice,
+		// which also removes the three-term recurrence
+		// components alpha_j q_j and beta_{j-1} q_{j-1}.
+		orthogonalize(w, q)
+		orthogonalize(w, q)
+		b := floats.Norm(w, 2)
+
+		if j+1 == n {
+			vals, _ := ritz(alpha, beta, b)
+			return vals[:k]
+		}
+		// Ritz values are exact at a breakdown, but the basis
+		// holds only one vector from each eigenspace, so the
+		// multiplicities are not known until after a restart.
+		if b >= breakdown && j+1 >= k && (j+1-k)%check == 0 {
+			vals, resid := ritz(alpha, beta, b)
+			converged := true
+			for i, r := range resid[:k] {
+				if r > tol*math.Max(1, math.Abs(vals[i])) {
+					converged = false
+					break
+				}
+			}
+			if converged {
+				return vals[:k]
+			}
+		}
+
+		if b < breakdown {
+			// The basis spans an invariant subspace,
+			// so restart with a new orthogonal vector.
+			b = 0
+			v = startVector(n, q, rnd)
+		} else {
+			v = make([]float64, n)
+			floats.ScaleTo(v, 1/b, w)
+		}
+		beta = append(beta, b)
+	}
+}
+
+// ritz returns the Ritz values of the tridiagonal matrix with diagonal alpha
+// and off-diagonal beta in decreasing order, and the residual bound of each
+// value given the norm, b, of the next Lanczos residual vector.
+func ritz(alpha, beta []float64, b float64) (vals, resid []float64) {
+	m := len(alpha)
+	t := mat.NewSymDense(m, nil)
+	for i, a := range alpha {
+		t.SetSym(i, i, a)
+		if i < len(beta) {
+			t.SetSym(i, i+1, beta[i])
+		}
+	}
+	var eig mat.EigenSym
+	ok := eig.Factorize(t, true)
+	if !ok {
+		panic("spectral: eigendecomposition failed")
+	}
+	var vecs mat.Dense
+	eig.VectorsTo(&vecs)
+
+	// Values are returned in ascending order.
+	asc := eig.Values(nil)
+	vals = make([]float64, m)
+	resid = make([]float64, m)
+	for i := range asc {
+		vals[m-1-i] = asc[i]
+		resid[m-1-i] = math.Abs(b * vecs.At(m-1, i))
+	}
+	return vals, resid
+}
+
+// startVector returns a random unit vector of length n that is
+// orthogonal to the orthonormal vectors in q, where len(q) < n.
+func startVector(n int, q [][]float64, rnd *rand.Rand) []float64 {
+	v := make([]float64, n)
+	for {
+		for i := range v {
+			v[i] = rnd.NormFloat64()
+		}
+		orthogonalize(v, q)
+		orthogonalize(v, q)
+		norm := floats.Norm(v, 2)
+		if norm > 1e-8 {
+			floats.Scale(1/norm, v)
+			return v
+		}
+	}
+}
+
+// orthogonalize removes the components of v in the
+// directions of the orthonormal vectors in q.
+func orthogonalize(v []float64, q [][]float64) {
+	for _, u := range q {
+		floats.AddScaled(v, -floats.Dot(v, u), u)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spectral
+
+import (
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/mat"
+)
+
+func completeGraph(n int) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(j)})
+		}
+	}
+	return g
+}
+
+func cycleGraph(n int) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < n; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node((i + 1) % n)})
+	}
+	return g
+}
+
+var spectralSummaryTests = []struct {
+	name string
+	g    graph.Undirected
+	k    int
+
+	wantAdjacency []float64
+	wantLaplacian []float64
+	wantEnergy    float64
+}{
+	{
+		name: "empty",
+		g:    simple.NewUndirectedGraph(),
+		k:    3,
+	},
+	{
+		name:          "K5",
+		g:             completeGraph(5),
+		k:             3,
+		wantAdjacency: []float64{4, -1, -1},
+		wantLaplacian: []float64{5, 5, 5},
+		wantEnergy:    8,
+	},
+	{
+		name:          "K3 all",
+		g:             completeGraph(3),
+		k:             10,
+		wantAdjacency: []float64{2, -1, -1},
+		wantLaplacian: []float64{3, 3, 0},
+		wantEnergy:    4,
+	},
+	{
+		name:          "C6",
+		g:             cycleGraph(6),
+		k:             4,
+		wantAdjacency: []float64{2, 1, 1, -1},
+		wantLaplacian: []float64{4, 3, 3, 1},
+		wantEnergy:    8,
+	},
+}
+
+func TestSpectralSummary(t *testing.T) {
+	t.Parallel()
+	const tol = 1e-10
+	for _, test := range spectralSummaryTests {
+		got := SpectralSummary(test.g, test.k)
+		if !floats.EqualApprox(got.Adjacency, test.wantAdjacency, tol) {
+			t.Errorf("unexpected adjacency eigenvalues for %s: got:%v want:%v",
+				test.name, got.Adjacency, test.wantAdjacency)
+		}
+		if !floats.EqualApprox(got.Laplacian, test.wantLaplacian, tol) {
+			t.Errorf("unexpected Laplacian eigenvalues for %s: got:%v want:%v",
+				test.name, got.Laplacian, test.wantLaplacian)
+		}
+		if len(test.wantAdjacency) != 0 {
+			if !scalar.EqualWithinAbsOrRel(got.SpectralRadius, test.wantAdjacency[0], tol, tol) {
+				t.Errorf("unexpected spectral radius for %s: got:%v want:%v",
+					test.name, got.SpectralRadius, test.wantAdjacency[0])
+			}
+			wantGap := test.wantAdjacency[0] - test.wantAdjacency[1]
+			if !scalar.EqualWithinAbsOrRel(got.SpectralGap, wantGap, tol, tol) {
+				t.Errorf("unexpected spectral gap for %s: got:%v want:%v",
+					test.name, got.SpectralGap, wantGap)
+			}
+		}
+		if e := Energy(test.g); !scalar.EqualWithinAbsOrRel(e, test.wantEnergy, tol, tol) {
+			t.Errorf("unexpected energy for %s: got:%v want:%v", test.name, e, test.wantEnergy)
+		}
+	}
+}
+
+func TestSpectralSummaryRandom(t *testing.T) {
+	t.Parallel()
+	const (
+		n   = 200
+		k   = 5
+		tol = 1e-8
+	)
+	for seed := uint64(1); seed <= 5; seed++ {
+		g := simple.NewUndirectedGraph()
+		err := gen.Gnp(g, n, 0.05, rand.NewSource(seed))
+		if err != nil {
+			t.Fatalf("unexpected error generating graph: %v", err)
+		}
+		got := SpectralSummary(g, k)
+
+		// The Laplacian returned by NewLaplacian is
+		// symmetric for undirected graphs.
+		lap := NewLaplacian(g)
+		a := mat.NewSymDense(n, nil)
+		l := mat.NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				v := lap.At(i, j)
+				l.SetSym(i, j, v)
+				if i != j {
+					a.SetSym(i, j, -v)
+				}
+			}
+		}
+		wantAdjacency := largestEigenvalues(a, k)
+		wantLaplacian := largestEigenvalues(l, k)
+
+		if !floats.EqualApprox(got.Adjacency, wantAdjacency, tol) {
+			t.Errorf("unexpected adjacency eigenvalues for seed %d: got:%v want:%v",
+				seed, got.Adjacency, wantAdjacency)
+		}
+		if !floats.EqualApprox(got.Laplacian, wantLaplacian, tol) {
+			t.Errorf("unexpected Laplacian eigenvalues for seed %d: got:%v want:%v",
+				seed, got.Laplacian, wantLaplacian)
+		}
+	}
+}
+
+func largestEigenvalues(a mat.Symmetric, k int) []float64 {
+	var eig mat.EigenSym
+	ok := eig.Factorize(a, false)
+	if !ok {
+		panic("eigendecomposition failed")
+	}
+	vals := eig.Values(nil)
+	sort.Sort(sort.Reverse(sort.Float64Slice(vals)))
+	return vals[:k]
+}
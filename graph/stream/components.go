@@ -0,0 +1,86 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+// Components tracks the connected components of an undirected graph presented
+// as a stream of edges. It holds a union-find forest over the nodes seen in the
+// stream, so its memory use is proportional to the number of nodes rather than
+// the number of edges.
+type Components struct {
+	parent map[int64]int64
+	rank   map[int64]int
+	count  int
+}
+
+// NewComponents returns a new Components.
+func NewComponents() *Components {
+	return &Components{
+		parent: make(map[int64]int64),
+		rank:   make(map[int64]int),
+	}
+}
+
+// AddNode adds the node with the given ID to the stream as an isolated node
+// if it has not already been seen.
+func (c *Components) AddNode(id int64) {
+	if _, ok := c.parent[id]; ok {
+		return
+	}
+	c.parent[id] = id
+	c.count++
+}
+
+// Add adds the edge between the nodes with IDs uid and vid to the stream.
+func (c *Components) Add(uid, vid int64) {
+	c.AddNode(uid)
+	c.AddNode(vid)
+	u := c.find(uid)
+	v := c.find(vid)
+	if u == v {
+		return
+	}
+	ru, rv := c.rank[u], c.rank[v]
+	if ru < rv {
+		u, v = v, u
+	}
+	c.parent[v] = u
+	delete(c.rank, v)
+	if ru == rv {
+		c.rank[u]++
+	}
+	c.count--
+}
+
+// Connected returns whether the nodes with IDs uid and vid are in the same
+// connected component. Nodes that have not been seen are not connected to
+// any node.
+func (c *Components) Connected(uid, vid int64) bool {
+	if _, ok := c.parent[uid]; !ok {
+		return false
+	}
+	if _, ok := c.parent[vid]; !ok {
+		return false
+	}
+	return c.find(uid) == c.find(vid)
+}
+
+// Count returns the number of connected components seen in the stream.
+func (c *Components) Count() int { return c.count }
+
+// Nodes returns the number of nodes seen in the stream.
+func (c *Components) Nodes() int { return len(c.parent) }
+
+// find returns the root of the tree holding the node with the given ID,
+// compressing the path to the root.
+func (c *Components) find(id int64) int64 {
+	root := id
+	for c.parent[root] != root {
+		root = c.parent[root]
+	}
+	for id != root {
+		id, c.parent[id] = c.parent[id], root
+	}
+	return root
+}
@@ -0,0 +1,7 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stream provides one-pass algorithms that consume a stream of edges
+// without storing the complete graph.
+package stream // import "gonum.org/v1/gonum/graph/stream"
@@ -0,0 +1,120 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func gnp(n int, p float64, seed uint64) *simple.UndirectedGraph {
+	g := simple.NewUndirectedGraph()
+	err := gen.Gnp(g, n, p, rand.NewSource(seed))
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+func edgesOf(g *simple.UndirectedGraph) [][2]int64 {
+	var edges [][2]int64
+	it := g.Edges()
+	for it.Next() {
+		e := it.Edge()
+		edges = append(edges, [2]int64{e.From().ID(), e.To().ID()})
+	}
+	return edges
+}
+
+func triangles(g graph.Undirected) int {
+	var n int
+	for _, u := range graph.NodesOf(g.Nodes()) {
+		uid := u.ID()
+		for _, v := range graph.NodesOf(g.From(uid)) {
+			vid := v.ID()
+			if vid <= uid {
+				continue
+			}
+			for _, w := range graph.NodesOf(g.From(vid)) {
+				wid := w.ID()
+				if wid > vid && g.HasEdgeBetween(uid, wid) {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+func TestTriangleCounterExact(t *testing.T) {
+	g := gnp(50, 0.2, 1)
+	edges := edgesOf(g)
+	c := NewTriangleCounter(len(edges), rand.NewSource(1))
+	for _, e := range edges {
+		c.Add(e[0], e[1])
+	}
+	if got, want := c.Estimate(), float64(triangles(g)); got != want {
+		t.Errorf("unexpected triangle count with complete sample: got:%v want:%v", got, want)
+	}
+}
+
+func TestTriangleCounterEstimate(t *testing.T) {
+	g := gnp(200, 0.1, 1)
+	edges := edgesOf(g)
+	want := float64(triangles(g))
+
+	const trials = 50
+	var sum float64
+	for seed := uint64(0); seed < trials; seed++ {
+		c := NewTriangleCounter(len(edges)/4, rand.NewSource(seed))
+		for _, e := range edges {
+			c.Add(e[0], e[1])
+		}
+		sum += c.Estimate()
+	}
+	got := sum / trials
+	if math.Abs(got-want)/want > 0.05 {
+		t.Errorf("unexpected mean triangle estimate: got:%v want:%v", got, want)
+	}
+}
+
+func TestComponents(t *testing.T) {
+	for seed := uint64(1); seed <= 10; seed++ {
+		g := gnp(100, 0.015, seed)
+		c := NewComponents()
+		nodes := g.Nodes()
+		for nodes.Next() {
+			c.AddNode(nodes.Node().ID())
+		}
+		for _, e := range edgesOf(g) {
+			c.Add(e[0], e[1])
+		}
+
+		cc := topo.ConnectedComponents(g)
+		if c.Count() != len(cc) {
+			t.Errorf("unexpected number of components for seed %d: got:%d want:%d", seed, c.Count(), len(cc))
+		}
+		if c.Nodes() != g.Nodes().Len() {
+			t.Errorf("unexpected number of nodes for seed %d: got:%d want:%d", seed, c.Nodes(), g.Nodes().Len())
+		}
+		for _, comp := range cc {
+			for _, n := range comp[1:] {
+				if !c.Connected(comp[0].ID(), n.ID()) {
+					t.Errorf("expected nodes %d and %d to be connected for seed %d", comp[0].ID(), n.ID(), seed)
+				}
+			}
+		}
+		if len(cc) > 1 && c.Connected(cc[0][0].ID(), cc[1][0].ID()) {
+			t.Errorf("unexpected connection between components for seed %d", seed)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream
+
+import (
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// TriangleCounter estimates the number of triangles in an undirected graph
+// presented as a stream of edges. It retains a uniform random sample of at most
+// a fixed number of edges, so its memory use is independent of the length of
+// the stream. The stream must not contain repeated edges.
+//
+// TriangleCounter implements the TRIÈST-BASE algorithm described in De Stefani,
+// Epasto, Riondato and Upfal, "TRIÈST: Counting Local and Global Triangles in
+// Fully-Dynamic Streams with Fixed Memory Size", KDD 2016 doi:10.1145/2939672.2939771.
+type TriangleCounter struct {
+	size int
+	rnd  *rand.Rand
+
+	// seen is the number of edges
+	// added to the counter.
+	seen int
+
+	// edges and adj hold the edge
+	// sample as a list and as an
+	// adjacency map.
+	edges [][2]int64
+	adj   map[int64]set.Int64s
+
+	// triangles is the number of
+	// triangles in the sample.
+	triangles int
+}
+
+// NewTriangleCounter returns a new TriangleCounter that retains at most size
+// edges. If src is nil, the global random number generator is used to sample
+// edges. NewTriangleCounter will panic if size is less than 3.
+func NewTriangleCounter(size int, src rand.Source) *TriangleCounter {
+	if size < 3 {
+		panic("stream: triangle counter sample size less than 3")
+	}
+	var rnd *rand.Rand
+	if src != nil {
+		rnd = rand.New(src)
+	}
+	return &TriangleCounter{
+		size: size,
+		rnd:  rnd,
+		adj:  make(map[int64]set.Int64s),
+	}
+}
+
+// Add adds the edge between the nodes with IDs uid and vid to the stream.
+// Self edges are ignored.
+func (c *TriangleCounter) Add(uid, vid int64) {
+	if uid == vid {
+		return
+	}
+	c.seen++
+	if c.seen > c.size {
+		// Retain the new edge with probability size/seen,
+		// replacing a uniformly chosen edge in the sample.
+		if c.float64() >= float64(c.size)/float64(c.seen) {
+			return
+		}
+		i := c.intn(len(c.edges))
+		old := c.edges[i]
+		c.remove(old[0], old[1])
+		c.edges[i] = [2]int64{uid, vid}
+	} else {
+		c.edges = append(c.edges, [2]int64{uid, vid})
+	}
+	c.triangles += c.common(uid, vid)
+	c.link(uid, vid)
+	c.link(vid, uid)
+}
+
+// Estimate returns an unbiased estimate of the number of triangles in the
+// graph formed by the edges added to the counter. The returned value is
+// exact if no more than the sample size of edges have been added.
+func (c *TriangleCounter) Estimate() float64 {
+	t := float64(c.seen)
+	m := float64(c.size)
+	scale := (t * (t - 1) * (t - 2)) / (m * (m - 1) * (m - 2))
+	if scale < 1 {
+		scale = 1
+	}
+	return scale * float64(c.triangles)
+}
+
+// remove removes the edge between the nodes with IDs uid and vid from the
+// sample adjacency and updates the triangle count.
+func (c *TriangleCounter) remove(uid, vid int64) {
+	c.unlink(uid, vid)
+	c.unlink(vid, uid)
+	c.triangles -= c.common(uid, vid)
+}
+
+// common returns the number of sampled neighbors shared by the nodes with
+// IDs uid and vid.
+func (c *TriangleCounter) common(uid, vid int64) int {
+	a, b := c.adj[uid], c.adj[vid]
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var n int
+	for w := range a {
+		if b.Has(w) {
+			n++
+		}
+	}
+	return n
+}
+
+func (c *TriangleCounter) link(uid, vid int64) {
+	s, ok := c.adj[uid]
+	if !ok {
+		s = make(set.Int64s)
+		c.adj[uid] = s
+	}
+	s.Add(vid)
+}
+
+func (c *TriangleCounter) unlink(uid, vid int64) {
+	s := c.adj[uid]
+	s.Remove(vid)
+	if len(s) == 0 {
+		delete(c.adj, uid)
+	}
+}
+
+func (c *TriangleCounter) float64() float64 {
+	if c.rnd == nil {
+		return rand.Float64()
+	}
+	return c.rnd.Float64()
+}
+
+func (c *TriangleCounter) intn(n int) int {
+	if c.rnd == nil {
+		return rand.Intn(n)
+	}
+	return c.rnd.Intn(n)
+}
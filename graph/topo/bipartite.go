@@ -0,0 +1,86 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// Bipartite returns whether the undirected graph g is bipartite. If g is
+// bipartite, parts holds a partition of the nodes of g into two sets, each
+// sorted by ID, such that every edge of g joins a node in parts[0] to a node
+// in parts[1]. In each connected component, the node with the lowest ID is
+// placed in parts[0]. If g is not bipartite, cycle holds an odd length cycle
+// of g as a certificate, with the first node of the cycle repeated at the end,
+// and parts is empty.
+//
+// Bipartite colors the nodes of each connected component of g by breadth-first
+// search. The cycle is formed by the first edge found joining two nodes of the
+// same color and the search tree paths from its ends to their nearest common
+// ancestor. Self loops are odd cycles of length one.
+func Bipartite(g graph.Undirected) (parts [2][]graph.Node, cycle []graph.Node, ok bool) {
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Sort(ordered.ByID(nodes))
+
+	color := make(map[int64]int, len(nodes))
+	parent := make(map[int64]graph.Node)
+	for _, root := range nodes {
+		if _, seen := color[root.ID()]; seen {
+			continue
+		}
+		color[root.ID()] = 0
+		queue := []graph.Node{root}
+		for len(queue) != 0 {
+			u := queue[0]
+			queue = queue[1:]
+			uid := u.ID()
+			to := graph.NodesOf(g.From(uid))
+			sort.Sort(ordered.ByID(to))
+			for _, v := range to {
+				vid := v.ID()
+				c, seen := color[vid]
+				if !seen {
+					color[vid] = 1 - color[uid]
+					parent[vid] = u
+					queue = append(queue, v)
+					continue
+				}
+				if c == color[uid] {
+					return [2][]graph.Node{}, oddCycle(u, v, parent), false
+				}
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		c := color[n.ID()]
+		parts[c] = append(parts[c], n)
+	}
+	return parts, nil, true
+}
+
+// oddCycle returns the cycle formed by the edge joining u and v, two nodes at
+// the same depth in a breadth-first search tree, and their tree paths to their
+// nearest common ancestor. The first node of the cycle is repeated at the end.
+func oddCycle(u, v graph.Node, parent map[int64]graph.Node) []graph.Node {
+	if u.ID() == v.ID() {
+		return []graph.Node{u, u}
+	}
+	var up, down []graph.Node
+	for u.ID() != v.ID() {
+		up = append(up, u)
+		down = append(down, v)
+		u = parent[u.ID()]
+		v = parent[v.ID()]
+	}
+	cycle := append(up, u)
+	for i := len(down) - 1; i >= 0; i-- {
+		cycle = append(cycle, down[i])
+	}
+	return append(cycle, cycle[0])
+}
@@ -0,0 +1,160 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var bipartiteTests = []struct {
+	name string
+	g    []intset
+
+	want  bool
+	parts [2][]int64
+}{
+	{
+		name: "empty",
+		want: true,
+	},
+	{
+		name:  "path",
+		g:     []intset{0: linksTo(1), 1: linksTo(2), 2: linksTo(3), 3: nil},
+		want:  true,
+		parts: [2][]int64{{0, 2}, {1, 3}},
+	},
+	{
+		name:  "even cycle",
+		g:     []intset{0: linksTo(1, 5), 1: linksTo(2), 2: linksTo(3), 3: linksTo(4), 4: linksTo(5), 5: nil},
+		want:  true,
+		parts: [2][]int64{{0, 2, 4}, {1, 3, 5}},
+	},
+	{
+		name: "complete bipartite",
+		g: []intset{
+			0: linksTo(3, 4, 5),
+			1: linksTo(3, 4, 5),
+			2: linksTo(3, 4, 5),
+			3: nil, 4: nil, 5: nil,
+		},
+		want:  true,
+		parts: [2][]int64{{0, 1, 2}, {3, 4, 5}},
+	},
+	{
+		name: "disconnected",
+		g: []intset{
+			0: linksTo(1),
+			1: nil,
+			2: nil,
+			3: linksTo(4),
+			4: linksTo(5),
+			5: nil,
+		},
+		want:  true,
+		parts: [2][]int64{{0, 2, 3, 5}, {1, 4}},
+	},
+	{
+		name: "triangle",
+		g:    []intset{0: linksTo(1, 2), 1: linksTo(2), 2: nil},
+	},
+	{
+		name: "odd cycle",
+		g:    []intset{0: linksTo(1, 4), 1: linksTo(2), 2: linksTo(3), 3: linksTo(4), 4: nil},
+	},
+	{
+		name: "odd cycle in second component",
+		g: []intset{
+			0: linksTo(1), 1: linksTo(2), 2: nil,
+			3: linksTo(4, 7), 4: linksTo(5), 5: linksTo(6), 6: linksTo(7), 7: linksTo(8), 8: linksTo(9), 9: nil,
+		},
+	},
+	{
+		name: "petersen",
+		g: []intset{
+			0: linksTo(1, 4, 5),
+			1: linksTo(2, 6),
+			2: linksTo(3, 7),
+			3: linksTo(4, 8),
+			4: linksTo(9),
+			5: linksTo(7, 8),
+			6: linksTo(8, 9),
+			7: linksTo(9),
+			8: nil,
+			9: nil,
+		},
+	},
+	{
+		name: "batagelj zaversnik",
+		g:    batageljZaversnikGraph,
+	},
+}
+
+func TestBipartite(t *testing.T) {
+	t.Parallel()
+	for _, test := range bipartiteTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		parts, cycle, ok := Bipartite(g)
+		if ok != test.want {
+			t.Errorf("%s: unexpected bipartite result: got:%t want:%t", test.name, ok, test.want)
+			continue
+		}
+		if ok {
+			if cycle != nil {
+				t.Errorf("%s: unexpected odd cycle for bipartite graph: %v", test.name, cycle)
+			}
+			got := [2][]int64{ids(parts[0]), ids(parts[1])}
+			if !reflect.DeepEqual(got, test.parts) {
+				t.Errorf("%s: unexpected partition: got:%v want:%v", test.name, got, test.parts)
+			}
+			continue
+		}
+
+		if parts[0] != nil || parts[1] != nil {
+			t.Errorf("%s: unexpected partition for non-bipartite graph: %v", test.name, parts)
+		}
+		if len(cycle) < 4 || len(cycle)%2 != 0 {
+			t.Errorf("%s: cycle does not have odd length: %v", test.name, ids(cycle))
+			continue
+		}
+		if cycle[0].ID() != cycle[len(cycle)-1].ID() {
+			t.Errorf("%s: cycle is not closed: %v", test.name, ids(cycle))
+		}
+		if !IsPathIn(g, cycle) {
+			t.Errorf("%s: cycle is not a path in the graph: %v", test.name, ids(cycle))
+		}
+		seen := make(map[int64]bool)
+		for _, n := range cycle[1:] {
+			if seen[n.ID()] {
+				t.Errorf("%s: cycle is not simple: %v", test.name, ids(cycle))
+			}
+			seen[n.ID()] = true
+		}
+	}
+}
+
+func ids(nodes []graph.Node) []int64 {
+	if nodes == nil {
+		return nil
+	}
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
@@ -39,6 +39,53 @@ func KCore(k int, g graph.Undirected) []graph.Node {
 	return core
 }
 
+// CoreNumbers returns the core number of each node of the undirected graph
+// g, keyed on node ID. The core number of a node is the largest k such that
+// the node is in the k-core of g.
+func CoreNumbers(g graph.Undirected) map[int64]int {
+	order, offsets := degeneracyOrdering(g)
+
+	cores := make(map[int64]int, len(order))
+	var offset int
+	for k, n := range offsets {
+		for _, v := range order[offset : offset+n] {
+			cores[v.ID()] = k
+		}
+		offset += n
+	}
+	return cores
+}
+
+// KCoreSubgraph adds the subgraph of the undirected graph g induced by the
+// nodes of its k-core to dst without first clearing the destination. Edges
+// are added as described for graph.Copy. KCoreSubgraph will panic if a node
+// ID in the k-core matches a node ID in the destination.
+func KCoreSubgraph(dst graph.Builder, k int, g graph.Undirected) {
+	cores := CoreNumbers(g)
+	nodes := g.Nodes()
+	for nodes.Next() {
+		n := nodes.Node()
+		if cores[n.ID()] >= k {
+			dst.AddNode(n)
+		}
+	}
+	nodes.Reset()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		if cores[uid] < k {
+			continue
+		}
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			if cores[vid] < k {
+				continue
+			}
+			dst.SetEdge(g.Edge(uid, vid))
+		}
+	}
+}
+
 // degeneracyOrdering is the common code for DegeneracyOrdering and KCore. It
 // returns l, the nodes of g in optimal ordering for coloring number and
 // s, a set of relative offsets into l for each k-core, where k is an index
@@ -132,7 +179,74 @@ func degeneracyOrdering(g graph.Undirected) (l []graph.Node, s []int) {
 }
 
 // BronKerbosch returns the set of maximal cliques of the undirected graph g.
+// BronKerbosch is equivalent to BronKerboschWith with nil settings.
 func BronKerbosch(g graph.Undirected) [][]graph.Node {
+	return BronKerboschWith(g, nil)
+}
+
+// Pivot is a pivot selection strategy for the Bron–Kerbosch algorithm.
+type Pivot int
+
+const (
+	// DefaultPivot uses TomitaPivot when built with
+	// the tomita build tag and ArbitraryPivot otherwise.
+	DefaultPivot Pivot = iota
+
+	// NoPivot performs no pivoting, so every node of
+	// the candidate set is branched on.
+	NoPivot
+
+	// ArbitraryPivot chooses the first node found in
+	// the union of the candidate and excluded sets.
+	ArbitraryPivot
+
+	// TomitaPivot chooses the node of the union of the
+	// candidate and excluded sets with the most neighbors
+	// in the candidate set, minimizing the number of
+	// branches at each step.
+	//
+	// See Tomita, Tanaka and Takahashi, "The worst-case time
+	// complexity for generating all maximal cliques and
+	// computational experiments", Theor. Comput. Sci.
+	// 363(1):28-42 (2006) doi:10.1016/j.tcs.2006.06.015.
+	TomitaPivot
+)
+
+// BronKerboschSettings holds the settings for BronKerboschWith.
+type BronKerboschSettings struct {
+	// Pivot is the pivot selection strategy.
+	Pivot Pivot
+
+	// NoDegeneracyOrdering disables the outer loop
+	// over nodes in degeneracy order, so that the
+	// search starts from the complete node set.
+	NoDegeneracyOrdering bool
+
+	// MaxSize is the largest size of clique to
+	// report. If MaxSize is positive, cliques that
+	// reach MaxSize nodes are reported without
+	// being extended, so they may not be maximal.
+	// Maximal cliques with fewer nodes are reported
+	// as usual.
+	MaxSize int
+}
+
+// BronKerboschWith returns the set of maximal cliques of the undirected graph g
+// using the pivot strategy, ordering and clique size cutoff in settings. If
+// settings is nil, the default pivot strategy and degeneracy ordering are used
+// without a cutoff.
+func BronKerboschWith(g graph.Undirected, settings *BronKerboschSettings) [][]graph.Node {
+	if settings == nil {
+		settings = &BronKerboschSettings{}
+	}
+	bk := bronKerbosch{pivot: settings.Pivot, maxSize: settings.MaxSize}
+	if bk.pivot == DefaultPivot {
+		bk.pivot = ArbitraryPivot
+		if tomitaTanakaTakahashi {
+			bk.pivot = TomitaPivot
+		}
+	}
+
 	nodes := graph.NodesOf(g.Nodes())
 
 	// The algorithm used here is essentially BronKerbosch3 as described at
@@ -143,7 +257,12 @@ func BronKerbosch(g graph.Undirected) [][]graph.Node {
 		p.Add(n)
 	}
 	x := set.NewNodes()
-	var bk bronKerbosch
+	if settings.NoDegeneracyOrdering {
+		if len(p) != 0 {
+			bk.maximalCliquePivot(g, nil, p, x)
+		}
+		return bk.cliques
+	}
 	order, _ := degeneracyOrdering(g)
 	ordered.Reverse(order)
 	for _, v := range order {
@@ -156,21 +275,33 @@ func BronKerbosch(g graph.Undirected) [][]graph.Node {
 		p.Remove(v)
 		x.Add(v)
 	}
-	return bk
+	return bk.cliques
 }
 
-type bronKerbosch [][]graph.Node
+type bronKerbosch struct {
+	cliques [][]graph.Node
+
+	pivot   Pivot
+	maxSize int
+}
 
 func (bk *bronKerbosch) maximalCliquePivot(g graph.Undirected, r []graph.Node, p, x set.Nodes) {
 	if len(p) == 0 && len(x) == 0 {
-		*bk = append(*bk, r)
+		bk.cliques = append(bk.cliques, r)
+		return
+	}
+	if bk.maxSize > 0 && len(r) >= bk.maxSize {
+		bk.cliques = append(bk.cliques, r)
 		return
 	}
 
-	neighbours := bk.choosePivotFrom(g, p, x)
-	nu := set.NewNodesSize(len(neighbours))
-	for _, n := range neighbours {
-		nu.Add(n)
+	nu := set.NewNodes()
+	if bk.pivot != NoPivot {
+		neighbours := bk.choosePivotFrom(g, p, x)
+		nu = set.NewNodesSize(len(neighbours))
+		for _, n := range neighbours {
+			nu.Add(n)
+		}
 	}
 	for _, v := range p {
 		if nu.Has(v) {
@@ -201,11 +332,8 @@ func (bk *bronKerbosch) maximalCliquePivot(g graph.Undirected, r []graph.Node, p
 	}
 }
 
-func (*bronKerbosch) choosePivotFrom(g graph.Undirected, p, x set.Nodes) (neighbors []graph.Node) {
-	// TODO(kortschak): Investigate the impact of pivot choice that maximises
-	// |p ⋂ neighbours(u)| as a function of input size. Until then, leave as
-	// compile time option.
-	if !tomitaTanakaTakahashi {
+func (bk *bronKerbosch) choosePivotFrom(g graph.Undirected, p, x set.Nodes) (neighbors []graph.Node) {
+	if bk.pivot == ArbitraryPivot {
 		for _, n := range p {
 			return graph.NodesOf(g.From(n.ID()))
 		}
@@ -227,8 +355,8 @@ func (*bronKerbosch) choosePivotFrom(g graph.Undirected, p, x set.Nodes) (neighb
 			if c <= max {
 				continue
 			}
-			for n := range nb {
-				if _, ok := p[int64(n)]; ok {
+			for _, n := range nb {
+				if _, ok := p[n.ID()]; ok {
 					continue
 				}
 				c--
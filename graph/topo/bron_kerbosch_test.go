@@ -5,11 +5,15 @@
 package topo
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"testing"
 
+	"golang.org/x/exp/rand"
+
 	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
 	"gonum.org/v1/gonum/graph/internal/ordered"
 	"gonum.org/v1/gonum/graph/simple"
 )
@@ -126,6 +130,73 @@ func TestKCore(t *testing.T) {
 	}
 }
 
+func TestCoreNumbers(t *testing.T) {
+	for i, test := range vOrderTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		want := make(map[int64]int)
+		for k, c := range test.wantCore {
+			for _, id := range c {
+				want[id] = k
+			}
+		}
+		got := CoreNumbers(g)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected core numbers for test %d:\ngot: %v\nwant:%v", i, got, want)
+		}
+	}
+}
+
+func TestKCoreSubgraph(t *testing.T) {
+	for i, test := range vOrderTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+
+		for k := 0; k <= test.wantK+1; k++ {
+			in := make(map[int64]bool)
+			for _, c := range test.wantCore[k:] {
+				for _, id := range c {
+					in[id] = true
+				}
+			}
+			want := simple.NewUndirectedGraph()
+			for id := range in {
+				want.AddNode(simple.Node(id))
+			}
+			edges := g.Edges()
+			for edges.Next() {
+				e := edges.Edge()
+				if in[e.From().ID()] && in[e.To().ID()] {
+					want.SetEdge(e)
+				}
+			}
+
+			got := simple.NewUndirectedGraph()
+			KCoreSubgraph(got, k, g)
+			if !Equal(got, want) {
+				t.Errorf("unexpected %d-core subgraph for test %d", k, i)
+			}
+		}
+	}
+}
+
 var bronKerboschTests = []struct {
 	name string
 	g    []intset
@@ -174,6 +245,16 @@ var bronKerboschTests = []struct {
 	},
 }
 
+var bronKerboschSettings = []*BronKerboschSettings{
+	nil,
+	{Pivot: NoPivot},
+	{Pivot: ArbitraryPivot},
+	{Pivot: TomitaPivot},
+	{Pivot: NoPivot, NoDegeneracyOrdering: true},
+	{Pivot: ArbitraryPivot, NoDegeneracyOrdering: true},
+	{Pivot: TomitaPivot, NoDegeneracyOrdering: true},
+}
+
 func TestBronKerbosch(t *testing.T) {
 	for _, test := range bronKerboschTests {
 		g := simple.NewUndirectedGraph()
@@ -186,21 +267,111 @@ func TestBronKerbosch(t *testing.T) {
 				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
 			}
 		}
-		cliques := BronKerbosch(g)
-		got := make([][]int64, len(cliques))
-		for j, c := range cliques {
-			ids := make([]int64, len(c))
-			for k, n := range c {
-				ids[k] = n.ID()
+		for _, settings := range bronKerboschSettings {
+			got := cliqueIDs(BronKerboschWith(g, settings))
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexpected cliques for test %q with settings %+v:\ngot: %v\nwant:%v",
+					test.name, settings, got, test.want)
+			}
+		}
+	}
+}
+
+func TestBronKerboschMaxSize(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for u, e := range batageljZaversnikGraph {
+		if g.Node(int64(u)) == nil {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	for _, settings := range bronKerboschSettings {
+		if settings == nil {
+			continue
+		}
+		s := *settings
+		s.MaxSize = 3
+		got := cliqueIDs(BronKerboschWith(g, &s))
+		seen := make(map[string]bool)
+		for _, c := range got {
+			if len(c) > 3 {
+				t.Errorf("clique larger than cutoff with settings %+v: %v", s, c)
+			}
+			if !isCliqueIn(g, c) {
+				t.Errorf("reported node set is not a clique with settings %+v: %v", s, c)
+			}
+			key := fmt.Sprint(c)
+			if seen[key] {
+				t.Errorf("clique reported more than once with settings %+v: %v", s, c)
+			}
+			seen[key] = true
+		}
+		// Each maximal clique with at most three nodes is
+		// reported, and each larger one is represented by
+		// at least one of its subsets of three nodes.
+		for _, want := range bronKerboschTests[1].want {
+			if len(want) <= 3 {
+				if !seen[fmt.Sprint(want)] {
+					t.Errorf("maximal clique %v not reported with settings %+v", want, s)
+				}
+				continue
+			}
+			var found bool
+			for _, c := range got {
+				if len(c) == 3 && isSubset(c, want) {
+					found = true
+					break
+				}
 			}
-			sort.Sort(ordered.Int64s(ids))
-			got[j] = ids
+			if !found {
+				t.Errorf("no subset of maximal clique %v reported with settings %+v", want, s)
+			}
+		}
+	}
+}
+
+// cliqueIDs returns the IDs of the nodes in cliques, with
+// each clique and the set of cliques sorted.
+func cliqueIDs(cliques [][]graph.Node) [][]int64 {
+	got := make([][]int64, len(cliques))
+	for j, c := range cliques {
+		ids := make([]int64, len(c))
+		for k, n := range c {
+			ids[k] = n.ID()
 		}
-		sort.Sort(ordered.BySliceValues(got))
-		if !reflect.DeepEqual(got, test.want) {
-			t.Errorf("unexpected cliques for test %q:\ngot: %v\nwant:%v", test.name, got, test.want)
+		sort.Sort(ordered.Int64s(ids))
+		got[j] = ids
+	}
+	sort.Sort(ordered.BySliceValues(got))
+	return got
+}
+
+// isCliqueIn returns whether the nodes with the given IDs are a clique in g.
+func isCliqueIn(g graph.Undirected, ids []int64) bool {
+	for i, u := range ids {
+		for _, v := range ids[i+1:] {
+			if !g.HasEdgeBetween(u, v) {
+				return false
+			}
 		}
 	}
+	return true
+}
+
+// isSubset returns whether all the elements of a are in b.
+func isSubset(a, b []int64) bool {
+	in := make(map[int64]bool)
+	for _, v := range b {
+		in[v] = true
+	}
+	for _, v := range a {
+		if !in[v] {
+			return false
+		}
+	}
+	return true
 }
 
 func BenchmarkBronKerbosch(b *testing.B) {
@@ -227,3 +398,62 @@ func BenchmarkBronKerbosch(b *testing.B) {
 		})
 	}
 }
+
+var bronKerboschFamilies = []struct {
+	name string
+	g    graph.Undirected
+}{
+	{name: "gnp_100_tenth", g: gnpUndirected(100, 0.1)},
+	{name: "gnp_50_half", g: gnpUndirected(50, 0.5)},
+	{name: "preferential_attachment_1000", g: preferentialAttachment(1000, 5)},
+	{name: "tunable_clustering_1000", g: tunableClustering(1000, 5, 0.8)},
+}
+
+var pivotNames = map[Pivot]string{
+	NoPivot:        "none",
+	ArbitraryPivot: "arbitrary",
+	TomitaPivot:    "tomita",
+}
+
+func BenchmarkBronKerboschSettings(b *testing.B) {
+	for _, family := range bronKerboschFamilies {
+		for _, settings := range bronKerboschSettings {
+			if settings == nil {
+				continue
+			}
+			name := fmt.Sprintf("%s/%s/degeneracy=%t", family.name, pivotNames[settings.Pivot], !settings.NoDegeneracyOrdering)
+			b.Run(name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					BronKerboschWith(family.g, settings)
+				}
+			})
+		}
+	}
+}
+
+func gnpUndirected(n int, p float64) graph.Undirected {
+	g := simple.NewUndirectedGraph()
+	err := gen.Gnp(g, n, p, rand.NewSource(1))
+	if err != nil {
+		panic(fmt.Sprintf("topo: bad test: %v", err))
+	}
+	return g
+}
+
+func preferentialAttachment(n, m int) graph.Undirected {
+	g := simple.NewUndirectedGraph()
+	err := gen.PreferentialAttachment(g, n, m, rand.NewSource(1))
+	if err != nil {
+		panic(fmt.Sprintf("topo: bad test: %v", err))
+	}
+	return g
+}
+
+func tunableClustering(n, m int, p float64) graph.Undirected {
+	g := simple.NewUndirectedGraph()
+	err := gen.TunableClusteringScaleFree(g, n, m, p, rand.NewSource(1))
+	if err != nil {
+		panic(fmt.Sprintf("topo: bad test: %v", err))
+	}
+	return g
+}
@@ -0,0 +1,126 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math/bits"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// NodeDepth is a node and its depth, the minimal number of edges on a path
+// between it and the origin of a search.
+type NodeDepth struct {
+	Node  graph.Node
+	Depth int
+}
+
+// Descendants returns the nodes reachable from n in the directed graph g
+// along paths of at most maxDepth edges, with their minimal depth. If
+// maxDepth is negative, the depth is not limited. The node n is not
+// included. The returned nodes are sorted by depth and then by ID.
+func Descendants(g graph.Directed, n graph.Node, maxDepth int) []NodeDepth {
+	return nodeDepths(g.From, n, maxDepth)
+}
+
+// Ancestors returns the nodes from which n is reachable in the directed graph
+// g along paths of at most maxDepth edges, with their minimal depth. If
+// maxDepth is negative, the depth is not limited. The node n is not included.
+// The returned nodes are sorted by depth and then by ID.
+func Ancestors(g graph.Directed, n graph.Node, maxDepth int) []NodeDepth {
+	return nodeDepths(g.To, n, maxDepth)
+}
+
+// nodeDepths performs a breadth-first search from n following the nodes
+// returned by next, returning the nodes found at depths up to maxDepth.
+func nodeDepths(next func(id int64) graph.Nodes, n graph.Node, maxDepth int) []NodeDepth {
+	var found []NodeDepth
+	seen := set.Int64s{n.ID(): struct{}{}}
+	level := []graph.Node{n}
+	for depth := 1; len(level) != 0 && (maxDepth < 0 || depth <= maxDepth); depth++ {
+		start := len(found)
+		var nextLevel []graph.Node
+		for _, u := range level {
+			it := next(u.ID())
+			for it.Next() {
+				v := it.Node()
+				if seen.Has(v.ID()) {
+					continue
+				}
+				seen.Add(v.ID())
+				nextLevel = append(nextLevel, v)
+				found = append(found, NodeDepth{Node: v, Depth: depth})
+			}
+		}
+		level = nextLevel
+		added := found[start:]
+		sort.Slice(added, func(i, j int) bool { return added[i].Node.ID() < added[j].Node.ID() })
+	}
+	return found
+}
+
+// DescendantCounts returns the number of distinct descendants of each node
+// of the directed acyclic graph g, keyed on node ID. If g is not acyclic,
+// DescendantCounts returns a nil map and an Unorderable error.
+//
+// DescendantCounts takes O((|V|+|E|).|V|/64) time and O(|V|^2/64) space.
+func DescendantCounts(g graph.Directed) (map[int64]int, error) {
+	sorted, err := Sort(g)
+	if err != nil {
+		return nil, err
+	}
+	return lineageCounts(sorted, g.From), nil
+}
+
+// AncestorCounts returns the number of distinct ancestors of each node of
+// the directed acyclic graph g, keyed on node ID. If g is not acyclic,
+// AncestorCounts returns a nil map and an Unorderable error.
+//
+// AncestorCounts takes O((|V|+|E|).|V|/64) time and O(|V|^2/64) space.
+func AncestorCounts(g graph.Directed) (map[int64]int, error) {
+	sorted, err := Sort(g)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	}
+	return lineageCounts(sorted, g.To), nil
+}
+
+// lineageCounts returns the number of distinct nodes reachable from each
+// node by following next, where sorted is in topological order with respect
+// to next.
+func lineageCounts(sorted []graph.Node, next func(id int64) graph.Nodes) map[int64]int {
+	indexOf := make(map[int64]int, len(sorted))
+	for i, n := range sorted {
+		indexOf[n.ID()] = i
+	}
+	words := (len(sorted) + 63) / 64
+	// reach holds the bit sets of the
+	// nodes reachable from each node.
+	reach := make([][]uint64, len(sorted))
+	counts := make(map[int64]int, len(sorted))
+	for i := len(sorted) - 1; i >= 0; i-- {
+		r := make([]uint64, words)
+		it := next(sorted[i].ID())
+		for it.Next() {
+			j := indexOf[it.Node().ID()]
+			r[j/64] |= 1 << uint(j%64)
+			for k, w := range reach[j] {
+				r[k] |= w
+			}
+		}
+		reach[i] = r
+		var n int
+		for _, w := range r {
+			n += bits.OnesCount64(w)
+		}
+		counts[sorted[i].ID()] = n
+	}
+	return counts
+}
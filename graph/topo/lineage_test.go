@@ -0,0 +1,173 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// lineageGraph is a DAG with a diamond
+// from 0 through 1 and 2 to 3.
+var lineageGraph = []intset{
+	0: linksTo(1, 2),
+	1: linksTo(3),
+	2: linksTo(3),
+	3: linksTo(4),
+	4: nil,
+	5: linksTo(3),
+}
+
+var lineageTests = []struct {
+	name      string
+	ancestors bool
+	from      int64
+	maxDepth  int
+	want      []NodeDepth
+}{
+	{
+		name:     "descendants",
+		from:     0,
+		maxDepth: -1,
+		want: []NodeDepth{
+			{simple.Node(1), 1}, {simple.Node(2), 1},
+			{simple.Node(3), 2},
+			{simple.Node(4), 3},
+		},
+	},
+	{
+		name:     "descendants depth 2",
+		from:     0,
+		maxDepth: 2,
+		want: []NodeDepth{
+			{simple.Node(1), 1}, {simple.Node(2), 1},
+			{simple.Node(3), 2},
+		},
+	},
+	{
+		name:     "descendants depth 0",
+		from:     0,
+		maxDepth: 0,
+		want:     nil,
+	},
+	{
+		name:     "descendants of sink",
+		from:     4,
+		maxDepth: -1,
+		want:     nil,
+	},
+	{
+		name:      "ancestors",
+		ancestors: true,
+		from:      4,
+		maxDepth:  -1,
+		want: []NodeDepth{
+			{simple.Node(3), 1},
+			{simple.Node(1), 2}, {simple.Node(2), 2}, {simple.Node(5), 2},
+			{simple.Node(0), 3},
+		},
+	},
+	{
+		name:      "ancestors depth 1",
+		ancestors: true,
+		from:      3,
+		maxDepth:  1,
+		want: []NodeDepth{
+			{simple.Node(1), 1}, {simple.Node(2), 1}, {simple.Node(5), 1},
+		},
+	},
+}
+
+func TestLineage(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for u, e := range lineageGraph {
+		if g.Node(int64(u)) == nil {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+
+	for _, test := range lineageTests {
+		var got []NodeDepth
+		if test.ancestors {
+			got = Ancestors(g, simple.Node(test.from), test.maxDepth)
+		} else {
+			got = Descendants(g, simple.Node(test.from), test.maxDepth)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected result for %s: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+
+	desc, err := DescendantCounts(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantDesc := map[int64]int{0: 4, 1: 2, 2: 2, 3: 1, 4: 0, 5: 2}
+	if !reflect.DeepEqual(desc, wantDesc) {
+		t.Errorf("unexpected descendant counts: got:%v want:%v", desc, wantDesc)
+	}
+	anc, err := AncestorCounts(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantAnc := map[int64]int{0: 0, 1: 1, 2: 1, 3: 4, 4: 5, 5: 0}
+	if !reflect.DeepEqual(anc, wantAnc) {
+		t.Errorf("unexpected ancestor counts: got:%v want:%v", anc, wantAnc)
+	}
+
+	g.SetEdge(simple.Edge{F: simple.Node(4), T: simple.Node(1)})
+	if _, err := DescendantCounts(g); err == nil {
+		t.Error("expected error for cyclic graph")
+	}
+	if _, err := AncestorCounts(g); err == nil {
+		t.Error("expected error for cyclic graph")
+	}
+}
+
+func TestDescendantCountsRandom(t *testing.T) {
+	for seed := uint64(0); seed < 5; seed++ {
+		rnd := rand.New(rand.NewSource(seed))
+		g := simple.NewDirectedGraph()
+		const n = 150
+		for i := 0; i < n; i++ {
+			g.AddNode(simple.Node(i))
+		}
+		for i := 0; i < 2*n; i++ {
+			u, v := rnd.Intn(n), rnd.Intn(n)
+			if u == v {
+				continue
+			}
+			if u > v {
+				u, v = v, u
+			}
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+
+		desc, err := DescendantCounts(g)
+		if err != nil {
+			t.Fatalf("unexpected error for seed %d: %v", seed, err)
+		}
+		anc, err := AncestorCounts(g)
+		if err != nil {
+			t.Fatalf("unexpected error for seed %d: %v", seed, err)
+		}
+		for _, u := range graph.NodesOf(g.Nodes()) {
+			if got, want := desc[u.ID()], len(Descendants(g, u, -1)); got != want {
+				t.Errorf("unexpected descendant count for seed %d node %d: got:%d want:%d", seed, u.ID(), got, want)
+			}
+			if got, want := anc[u.ID()], len(Ancestors(g, u, -1)); got != want {
+				t.Errorf("unexpected ancestor count for seed %d node %d: got:%d want:%d", seed, u.ID(), got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// Propagation holds the nodes of a directed graph affected by a change to a
+// set of nodes, and the paths by which the change reached them.
+type Propagation struct {
+	// parent holds the node preceding each affected
+	// node on its witness path, or nil for changed nodes.
+	parent map[int64]graph.Node
+	nodes  map[int64]graph.Node
+}
+
+// Propagate returns the propagation of a change to the nodes in changed
+// through the directed graph g. A change propagates from a node u to a node
+// v if g has an edge e from u to v and propagates(e) is true, or if
+// propagates is nil. Changed nodes are affected by the change.
+//
+// The witness path recorded for each affected node is a path with the
+// fewest edges by which the change reaches it from a changed node.
+// Propagate performs a single breadth-first search seeded with all the
+// changed nodes, taking O(|V|+|E|) time.
+func Propagate(g graph.Directed, changed []graph.Node, propagates func(graph.Edge) bool) *Propagation {
+	p := Propagation{
+		parent: make(map[int64]graph.Node),
+		nodes:  make(map[int64]graph.Node),
+	}
+	var level []graph.Node
+	for _, n := range changed {
+		id := n.ID()
+		if g.Node(id) == nil {
+			continue
+		}
+		if _, ok := p.nodes[id]; ok {
+			continue
+		}
+		p.nodes[id] = n
+		p.parent[id] = nil
+		level = append(level, n)
+	}
+	for len(level) != 0 {
+		var next []graph.Node
+		for _, u := range level {
+			uid := u.ID()
+			to := g.From(uid)
+			for to.Next() {
+				v := to.Node()
+				vid := v.ID()
+				if _, ok := p.nodes[vid]; ok {
+					continue
+				}
+				if propagates != nil && !propagates(g.Edge(uid, vid)) {
+					continue
+				}
+				p.nodes[vid] = v
+				p.parent[vid] = u
+				next = append(next, v)
+			}
+		}
+		level = next
+	}
+	return &p
+}
+
+// IsAffected returns whether the node with ID id is affected by the change.
+func (p *Propagation) IsAffected(id int64) bool {
+	_, ok := p.nodes[id]
+	return ok
+}
+
+// Affected returns the nodes affected by the change, including the changed
+// nodes, sorted by ID.
+func (p *Propagation) Affected() []graph.Node {
+	nodes := make([]graph.Node, 0, len(p.nodes))
+	for _, n := range p.nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
+
+// Witness returns a path by which the change reached the node with ID id,
+// starting from a changed node and ending at the node. If the node is not
+// affected, Witness returns nil.
+func (p *Propagation) Witness(id int64) []graph.Node {
+	n, ok := p.nodes[id]
+	if !ok {
+		return nil
+	}
+	path := []graph.Node{n}
+	for u := p.parent[id]; u != nil; u = p.parent[u.ID()] {
+		path = append(path, u)
+	}
+	ordered.Reverse(path)
+	return path
+}
@@ -0,0 +1,105 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var propagateTests = []struct {
+	name       string
+	changed    []int64
+	propagates func(graph.Edge) bool
+
+	wantAffected []int64
+	wantWitness  map[int64][]int64
+}{
+	{
+		name:         "all edges",
+		changed:      []int64{1, 2},
+		wantAffected: []int64{1, 2, 3, 4},
+		wantWitness: map[int64][]int64{
+			1: {1},
+			3: {1, 3},
+			4: {1, 3, 4},
+			0: nil,
+		},
+	},
+	{
+		name:    "blocked edge",
+		changed: []int64{1, 2},
+		propagates: func(e graph.Edge) bool {
+			return e.From().ID() != 1
+		},
+		wantAffected: []int64{1, 2, 3, 4},
+		wantWitness: map[int64][]int64{
+			4: {2, 3, 4},
+		},
+	},
+	{
+		name:    "contained",
+		changed: []int64{0, 0},
+		propagates: func(e graph.Edge) bool {
+			return e.To().ID() != 3
+		},
+		wantAffected: []int64{0, 1, 2},
+		wantWitness: map[int64][]int64{
+			2: {0, 2},
+			3: nil,
+			5: nil,
+		},
+	},
+	{
+		name:         "absent node",
+		changed:      []int64{-1},
+		wantAffected: []int64{},
+		wantWitness: map[int64][]int64{
+			-1: nil,
+		},
+	},
+}
+
+func TestPropagate(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for u, e := range lineageGraph {
+		if g.Node(int64(u)) == nil {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+
+	for _, test := range propagateTests {
+		var changed []graph.Node
+		for _, id := range test.changed {
+			changed = append(changed, simple.Node(id))
+		}
+		p := Propagate(g, changed, test.propagates)
+
+		got := nodeIDsOf(p.Affected())
+		if !reflect.DeepEqual(got, test.wantAffected) {
+			t.Errorf("unexpected affected nodes for %s: got:%v want:%v", test.name, got, test.wantAffected)
+		}
+		for _, id := range test.wantAffected {
+			if !p.IsAffected(id) {
+				t.Errorf("expected node %d to be affected for %s", id, test.name)
+			}
+		}
+		for id, want := range test.wantWitness {
+			got := nodeIDsOf(p.Witness(id))
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected witness for node %d for %s: got:%v want:%v", id, test.name, got, want)
+			}
+			if p.IsAffected(id) != (want != nil) {
+				t.Errorf("unexpected affected status for node %d for %s", id, test.name)
+			}
+		}
+	}
+}
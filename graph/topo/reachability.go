@@ -0,0 +1,140 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"math/bits"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+)
+
+// ReachMatrix is a bit matrix recording the nodes of a directed graph that
+// are reachable from each of a set of source nodes.
+type ReachMatrix struct {
+	sources []graph.Node
+	nodes   []graph.Node
+	indexOf map[int64]int
+
+	// reach holds a bit set of the indexes
+	// of the sources reaching each node.
+	reach [][]uint64
+}
+
+// Reachability returns the reachability of the nodes of the directed graph g
+// from each of the given sources. Each source reaches itself. Sources that
+// are not in g reach no nodes.
+//
+// The traversal work is shared between the sources; the strongly connected
+// components of g are found once and the sets of sources reaching each
+// component are propagated along the condensation of g in topological order,
+// taking O((|V|+|E|).⌈|sources|/64⌉) time.
+func Reachability(sources []graph.Node, g graph.Directed) *ReachMatrix {
+	sccs := TarjanSCC(g)
+
+	m := ReachMatrix{
+		sources: sources,
+		indexOf: make(map[int64]int),
+	}
+	words := (len(sources) + 63) / 64
+	// component holds the index of the component
+	// containing each node.
+	component := make(map[int64]int)
+	for i, c := range sccs {
+		for _, n := range c {
+			component[n.ID()] = i
+		}
+	}
+	compReach := make([][]uint64, len(sccs))
+	for i, s := range sources {
+		c, ok := component[s.ID()]
+		if !ok {
+			continue
+		}
+		if compReach[c] == nil {
+			compReach[c] = make([]uint64, words)
+		}
+		compReach[c][i/64] |= 1 << uint(i%64)
+	}
+
+	// TarjanSCC returns components in reverse
+	// topological order, so iterate backwards.
+	for i := len(sccs) - 1; i >= 0; i-- {
+		r := compReach[i]
+		if r == nil {
+			continue
+		}
+		for _, u := range sccs[i] {
+			to := g.From(u.ID())
+			for to.Next() {
+				j := component[to.Node().ID()]
+				if j == i {
+					continue
+				}
+				if compReach[j] == nil {
+					compReach[j] = make([]uint64, words)
+				}
+				for k, w := range r {
+					compReach[j][k] |= w
+				}
+			}
+		}
+		for _, u := range sccs[i] {
+			m.indexOf[u.ID()] = len(m.nodes)
+			m.nodes = append(m.nodes, u)
+			m.reach = append(m.reach, r)
+		}
+	}
+	return &m
+}
+
+// Sources returns the sources of the reachability matrix.
+func (m *ReachMatrix) Sources() []graph.Node {
+	return m.sources
+}
+
+// Reaches returns whether the ith source reaches the node with ID vid.
+func (m *ReachMatrix) Reaches(i int, vid int64) bool {
+	j, ok := m.indexOf[vid]
+	if !ok {
+		return false
+	}
+	return m.reach[j][i/64]&(1<<uint(i%64)) != 0
+}
+
+// ReachedBy returns the number of sources reaching the node with ID vid.
+func (m *ReachMatrix) ReachedBy(vid int64) int {
+	j, ok := m.indexOf[vid]
+	if !ok {
+		return 0
+	}
+	var n int
+	for _, w := range m.reach[j] {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Reached returns the nodes reached by the ith source, sorted by ID.
+func (m *ReachMatrix) Reached(i int) []graph.Node {
+	var nodes []graph.Node
+	for j, n := range m.nodes {
+		if m.reach[j][i/64]&(1<<uint(i%64)) != 0 {
+			nodes = append(nodes, n)
+		}
+	}
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
+
+// ReachedByAny returns the nodes reached by at least one source, sorted
+// by ID.
+func (m *ReachMatrix) ReachedByAny() []graph.Node {
+	nodes := make([]graph.Node, len(m.nodes))
+	copy(nodes, m.nodes)
+	sort.Sort(ordered.ByID(nodes))
+	return nodes
+}
@@ -0,0 +1,89 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/graphs/gen"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestReachability(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for u, e := range batageljZaversnikGraph {
+		if g.Node(int64(u)) == nil {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	// Close a cycle through 17, 18 and 19.
+	g.SetEdge(simple.Edge{F: simple.Node(19), T: simple.Node(17)})
+
+	sources := []graph.Node{simple.Node(1), simple.Node(18), simple.Node(-1), simple.Node(12)}
+	m := Reachability(sources, g)
+
+	want := [][]int64{
+		{1, 2, 3, 4, 5},
+		{17, 18, 19, 20},
+		nil,
+		{12, 17, 18, 19, 20},
+	}
+	for i := range sources {
+		got := nodeIDsOf(m.Reached(i))
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("unexpected nodes reached by source %d: got:%v want:%v", sources[i].ID(), got, want[i])
+		}
+	}
+	if got, want := nodeIDsOf(m.ReachedByAny()), []int64{1, 2, 3, 4, 5, 12, 17, 18, 19, 20}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected nodes reached by any source: got:%v want:%v", got, want)
+	}
+	for id, want := range map[int64]int{1: 1, 5: 1, 12: 1, 17: 2, 20: 2, 6: 0, -1: 0} {
+		if got := m.ReachedBy(id); got != want {
+			t.Errorf("unexpected number of sources reaching %d: got:%d want:%d", id, got, want)
+		}
+	}
+}
+
+func TestReachabilityRandom(t *testing.T) {
+	for seed := uint64(0); seed < 5; seed++ {
+		g := simple.NewDirectedGraph()
+		err := gen.Gnp(g, 100, 0.015, rand.NewSource(seed))
+		if err != nil {
+			t.Fatalf("unexpected error generating graph: %v", err)
+		}
+		nodes := graph.NodesOf(g.Nodes())
+		// Use enough sources to span
+		// more than one bit set word.
+		sources := nodes[:70]
+		m := Reachability(sources, g)
+		for i, s := range sources {
+			for _, v := range nodes {
+				want := PathExistsIn(g, s, v)
+				if got := m.Reaches(i, v.ID()); got != want {
+					t.Errorf("unexpected reachability for seed %d from %d to %d: got:%t want:%t",
+						seed, s.ID(), v.ID(), got, want)
+				}
+			}
+		}
+	}
+}
+
+func nodeIDsOf(nodes []graph.Node) []int64 {
+	if nodes == nil {
+		return nil
+	}
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	return ids
+}
@@ -9,6 +9,7 @@ import (
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/internal/set"
 	"gonum.org/v1/gonum/graph/traverse"
 )
 
@@ -50,6 +51,73 @@ func PathExistsIn(g graph.Graph, from, to graph.Node) bool {
 	return t.Walk(g, from, func(n graph.Node, _ int) bool { return n.ID() == to.ID() }) != nil
 }
 
+// Reachable returns whether there is a path in g from u to v. If g is directed
+// the path must follow the direction of the edges.
+//
+// Reachable performs a bidirectional breadth-first search, alternately expanding
+// the smaller of the frontiers from u along edges and from v against edges, and
+// returns as soon as the frontiers meet. Only the IDs of visited nodes and of
+// the current frontiers are held, so for nodes that are close in large graphs
+// Reachable visits and stores far fewer nodes than PathExistsIn.
+func Reachable(u, v graph.Node, g graph.Graph) bool {
+	uid := u.ID()
+	vid := v.ID()
+	if g.Node(uid) == nil || g.Node(vid) == nil {
+		return false
+	}
+	if uid == vid {
+		return true
+	}
+
+	to := g.From
+	if d, ok := g.(graph.Directed); ok {
+		to = d.To
+	}
+	var (
+		fwd = reachFrontier{from: g.From, level: []int64{uid}, seen: set.Int64s{uid: struct{}{}}}
+		rev = reachFrontier{from: to, level: []int64{vid}, seen: set.Int64s{vid: struct{}{}}}
+	)
+	for len(fwd.level) != 0 && len(rev.level) != 0 {
+		this, other := &fwd, &rev
+		if len(rev.level) < len(fwd.level) {
+			this, other = &rev, &fwd
+		}
+		if this.expand(other.seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// reachFrontier is one side of a bidirectional reachability search.
+type reachFrontier struct {
+	from  func(id int64) graph.Nodes
+	level []int64
+	seen  set.Int64s
+}
+
+// expand advances f by one level, returning whether a node visited
+// by the search on the other side, held in other, was reached.
+func (f *reachFrontier) expand(other set.Int64s) bool {
+	var next []int64
+	for _, uid := range f.level {
+		it := f.from(uid)
+		for it.Next() {
+			vid := it.Node().ID()
+			if other.Has(vid) {
+				return true
+			}
+			if f.seen.Has(vid) {
+				continue
+			}
+			f.seen.Add(vid)
+			next = append(next, vid)
+		}
+	}
+	f.level = next
+	return false
+}
+
 // ConnectedComponents returns the connected components of the undirected graph g.
 func ConnectedComponents(g graph.Undirected) [][]graph.Node {
 	var (
@@ -86,6 +86,10 @@ func TestPathExistsInUndirected(t *testing.T) {
 		if got != test.want {
 			t.Errorf("unexpected result for path existence in test %d: got:%t want %t", i, got, test.want)
 		}
+		got = Reachable(simple.Node(test.from), simple.Node(test.to), g)
+		if got != test.want {
+			t.Errorf("unexpected result for reachability in test %d: got:%t want %t", i, got, test.want)
+		}
 	}
 }
 
@@ -125,6 +129,10 @@ func TestPathExistsInDirected(t *testing.T) {
 		if got != test.want {
 			t.Errorf("unexpected result for path existence in test %d: got:%t want %t", i, got, test.want)
 		}
+		got = Reachable(simple.Node(test.from), simple.Node(test.to), g)
+		if got != test.want {
+			t.Errorf("unexpected result for reachability in test %d: got:%t want %t", i, got, test.want)
+		}
 	}
 }
 
@@ -0,0 +1,151 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traffic
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/path"
+)
+
+// Demand is a volume of traffic travelling from one node to another.
+type Demand struct {
+	From, To graph.Node
+	Volume   float64
+}
+
+// Delay returns the travel time on the edge from the node with ID uid to
+// the node with ID vid when the edge carries the given volume of traffic.
+// Delay functions must be positive and non-decreasing with volume.
+type Delay func(uid, vid int64, volume float64) float64
+
+// BPR returns the Bureau of Public Roads volume-delay travel time for an
+// edge with the free flow travel time t0 and capacity c carrying volume v,
+//  t0 × (1 + 0.15 × (v/c)^4).
+func BPR(t0, c, v float64) float64 {
+	r := v / c
+	r *= r
+	return t0 * (1 + 0.15*r*r)
+}
+
+// Equilibrium returns the user-equilibrium assignment of the travel demand
+// onto the edges of g, calculated using the Frank-Wolfe algorithm. At user
+// equilibrium, no traveller can reduce their travel time by changing their
+// route. Edge travel times are given by delay. The returned flows are keyed
+// by the IDs of the from and to nodes of each edge; undirected edges carry
+// independent flows in each direction.
+//
+// Iteration continues until the relative gap between the total travel time
+// of the current assignment and of the all-or-nothing assignment of the
+// demand to shortest paths at current travel times is less than tol, or
+// iters iterations have been made. The returned ok indicates whether the
+// assignment converged to within tol. Demand between nodes that are not
+// connected is not assigned.
+func Equilibrium(g graph.Graph, demand []Demand, delay Delay, tol float64, iters int) (flow map[[2]int64]float64, ok bool) {
+	var edges [][2]int64
+	nodes := g.Nodes()
+	for nodes.Next() {
+		uid := nodes.Node().ID()
+		to := g.From(uid)
+		for to.Next() {
+			edges = append(edges, [2]int64{uid, to.Node().ID()})
+		}
+	}
+
+	times := make(map[[2]int64]float64, len(edges))
+	update := func(x map[[2]int64]float64) {
+		for _, e := range edges {
+			times[e] = delay(e[0], e[1], x[e])
+		}
+	}
+
+	update(nil)
+	flow = allOrNothing(g, times, demand)
+	for {
+		update(flow)
+		target := allOrNothing(g, times, demand)
+
+		var current, best float64
+		for _, e := range edges {
+			current += times[e] * flow[e]
+			best += times[e] * target[e]
+		}
+		if current == 0 || (current-best)/current < tol {
+			return flow, true
+		}
+		if iters <= 0 {
+			return flow, false
+		}
+		iters--
+
+		// Find the step toward the all-or-nothing assignment
+		// minimising the Beckmann objective by bisection on
+		// its derivative.
+		lo, hi := 0.0, 1.0
+		for i := 0; i < 50; i++ {
+			alpha := (lo + hi) / 2
+			var deriv float64
+			for _, e := range edges {
+				d := target[e] - flow[e]
+				deriv += delay(e[0], e[1], flow[e]+alpha*d) * d
+			}
+			if deriv > 0 {
+				hi = alpha
+			} else {
+				lo = alpha
+			}
+		}
+		alpha := (lo + hi) / 2
+		for _, e := range edges {
+			f := flow[e] + alpha*(target[e]-flow[e])
+			if f == 0 {
+				delete(flow, e)
+				continue
+			}
+			flow[e] = f
+		}
+	}
+}
+
+// allOrNothing returns the assignment of all demand to the shortest paths
+// in g with the given edge travel times.
+func allOrNothing(g graph.Graph, times map[[2]int64]float64, demand []Demand) map[[2]int64]float64 {
+	flow := make(map[[2]int64]float64)
+	tg := timed{Graph: g, times: times}
+	trees := make(map[int64]path.Shortest)
+	for _, d := range demand {
+		tree, ok := trees[d.From.ID()]
+		if !ok {
+			tree = path.DijkstraFrom(d.From, tg)
+			trees[d.From.ID()] = tree
+		}
+		p, w := tree.To(d.To.ID())
+		if math.IsInf(w, 1) {
+			continue
+		}
+		for i, u := range p[:len(p)-1] {
+			flow[[2]int64{u.ID(), p[i+1].ID()}] += d.Volume
+		}
+	}
+	return flow
+}
+
+// timed is a graph weighted by edge travel times.
+type timed struct {
+	graph.Graph
+	times map[[2]int64]float64
+}
+
+func (g timed) Weight(xid, yid int64) (w float64, ok bool) {
+	if xid == yid {
+		return 0, true
+	}
+	w, ok = g.times[[2]int64{xid, yid}]
+	if !ok {
+		return math.Inf(1), false
+	}
+	return w, true
+}
@@ -0,0 +1,153 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traffic
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestBPR(t *testing.T) {
+	t.Parallel()
+	for _, test := range []struct {
+		t0, c, v float64
+		want     float64
+	}{
+		{t0: 10, c: 100, v: 0, want: 10},
+		{t0: 10, c: 100, v: 100, want: 11.5},
+		{t0: 2, c: 50, v: 100, want: 2 * (1 + 0.15*16)},
+	} {
+		got := BPR(test.t0, test.c, test.v)
+		if !scalar.EqualWithinAbsOrRel(got, test.want, 1e-12, 1e-12) {
+			t.Errorf("unexpected BPR time for t0=%v c=%v v=%v: got:%v want:%v", test.t0, test.c, test.v, got, test.want)
+		}
+	}
+}
+
+var equilibriumTests = []struct {
+	name   string
+	edges  []simple.Edge
+	delay  Delay
+	demand []Demand
+
+	want map[[2]int64]float64
+}{
+	{
+		// Two routes from 0 to 3, via 1 with travel time 10+x
+		// and via 2 with travel time 15+x/2. At equilibrium
+		// both routes carry half of the demand of 20.
+		name: "two routes",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(1), T: simple.Node(3)},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(2), T: simple.Node(3)},
+		},
+		delay: func(uid, vid int64, v float64) float64 {
+			switch [2]int64{uid, vid} {
+			case [2]int64{0, 1}:
+				return 10 + v
+			case [2]int64{0, 2}:
+				return 15 + v/2
+			default:
+				return 0
+			}
+		},
+		demand: []Demand{
+			{From: simple.Node(0), To: simple.Node(3), Volume: 20},
+			{From: simple.Node(3), To: simple.Node(0), Volume: 5},
+		},
+		want: map[[2]int64]float64{
+			{0, 1}: 10, {1, 3}: 10,
+			{0, 2}: 10, {2, 3}: 10,
+		},
+	},
+	{
+		// Braess's network with demand of 6 from 0 to 3.
+		// At equilibrium all routes take 92 time units.
+		name: "braess",
+		edges: []simple.Edge{
+			{F: simple.Node(0), T: simple.Node(1)},
+			{F: simple.Node(0), T: simple.Node(2)},
+			{F: simple.Node(1), T: simple.Node(2)},
+			{F: simple.Node(1), T: simple.Node(3)},
+			{F: simple.Node(2), T: simple.Node(3)},
+		},
+		delay: func(uid, vid int64, v float64) float64 {
+			switch [2]int64{uid, vid} {
+			case [2]int64{0, 1}, [2]int64{2, 3}:
+				return 10 * v
+			case [2]int64{1, 2}:
+				return 10 + v
+			default:
+				return 50 + v
+			}
+		},
+		demand: []Demand{
+			{From: simple.Node(0), To: simple.Node(3), Volume: 6},
+		},
+		want: map[[2]int64]float64{
+			{0, 1}: 4, {0, 2}: 2, {1, 2}: 2,
+			{1, 3}: 2, {2, 3}: 4,
+		},
+	},
+}
+
+func TestEquilibrium(t *testing.T) {
+	t.Parallel()
+	for _, test := range equilibriumTests {
+		g := simple.NewDirectedGraph()
+		for _, e := range test.edges {
+			g.SetEdge(e)
+		}
+
+		got, ok := Equilibrium(g, test.demand, test.delay, 1e-6, 1000)
+		if !ok {
+			t.Errorf("%q: failed to converge", test.name)
+		}
+		for e, want := range test.want {
+			if math.Abs(got[e]-want) > 1e-2 {
+				t.Errorf("%q: unexpected flow on %v: got:%v want:%v", test.name, e, got[e], want)
+			}
+		}
+		for e, f := range got {
+			if _, ok := test.want[e]; !ok && f > 1e-2 {
+				t.Errorf("%q: unexpected flow on %v: got:%v want:0", test.name, e, f)
+			}
+		}
+	}
+}
+
+func TestEquilibriumNoIterations(t *testing.T) {
+	t.Parallel()
+	g := simple.NewDirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	delay := func(_, _ int64, v float64) float64 { return 1 + v }
+	demand := []Demand{{From: simple.Node(0), To: simple.Node(2), Volume: 3}}
+
+	// The initial assignment of a single
+	// route is already at equilibrium.
+	got, ok := Equilibrium(g, demand, delay, 1e-6, 0)
+	if !ok {
+		t.Error("expected convergence of initial equilibrium with no iterations")
+	}
+	if got[[2]int64{0, 1}] != 3 || got[[2]int64{1, 2}] != 3 {
+		t.Errorf("unexpected flow: got:%v", got)
+	}
+
+	two := equilibriumTests[0]
+	tg := simple.NewDirectedGraph()
+	for _, e := range two.edges {
+		tg.SetEdge(e)
+	}
+	_, ok = Equilibrium(tg, two.demand, two.delay, 1e-6, 0)
+	if ok {
+		t.Error("unexpected convergence of non-equilibrium assignment with no iterations")
+	}
+}
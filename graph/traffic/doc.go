@@ -0,0 +1,6 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package traffic provides traffic assignment functions for road networks.
+package traffic // import "gonum.org/v1/gonum/graph/traffic"
@@ -0,0 +1,92 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"container/heap"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// Scored is a node and its score.
+type Scored struct {
+	Node  graph.Node
+	Score float64
+}
+
+// BestFirst performs a best-first exploration of the graph g from the seed
+// nodes and returns the k highest scoring nodes visited, ordered by
+// descending score with ties broken by ascending node ID. The seeds are
+// not included in the returned nodes.
+//
+// Each node is scored once by the score function on its first visit. The
+// exploration repeatedly expands the highest scoring visited node that has
+// not yet been expanded, visiting its unvisited neighbors as returned by
+// g.From, until no unexpanded nodes remain or budget nodes excluding the
+// seeds have been visited. If budget is less than or equal to zero the
+// exploration is not limited. If k is less than or equal to zero all
+// visited nodes are returned.
+func BestFirst(g Graph, seeds []graph.Node, score func(graph.Node) float64, k, budget int) []Scored {
+	visited := make(set.Int64s)
+	var frontier scoredQueue
+	for _, n := range seeds {
+		if visited.Has(n.ID()) {
+			continue
+		}
+		visited.Add(n.ID())
+		heap.Push(&frontier, Scored{Node: n, Score: score(n)})
+	}
+
+	var found []Scored
+	for frontier.Len() > 0 && (budget <= 0 || len(found) < budget) {
+		u := heap.Pop(&frontier).(Scored)
+		to := g.From(u.Node.ID())
+		for to.Next() {
+			v := to.Node()
+			vid := v.ID()
+			if visited.Has(vid) {
+				continue
+			}
+			visited.Add(vid)
+			s := Scored{Node: v, Score: score(v)}
+			found = append(found, s)
+			heap.Push(&frontier, s)
+			if budget > 0 && len(found) == budget {
+				break
+			}
+		}
+	}
+
+	sort.Sort(byScore(found))
+	if k > 0 && k < len(found) {
+		found = found[:k:k]
+	}
+	return found
+}
+
+// byScore sorts scored nodes by descending score and ascending ID.
+type byScore []Scored
+
+func (s byScore) Len() int { return len(s) }
+func (s byScore) Less(i, j int) bool {
+	if s[i].Score != s[j].Score {
+		return s[i].Score > s[j].Score
+	}
+	return s[i].Node.ID() < s[j].Node.ID()
+}
+func (s byScore) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// scoredQueue is a max-priority queue of scored nodes.
+type scoredQueue struct{ byScore }
+
+func (q *scoredQueue) Push(n interface{}) { q.byScore = append(q.byScore, n.(Scored)) }
+func (q *scoredQueue) Pop() interface{} {
+	t := q.byScore[len(q.byScore)-1]
+	q.byScore[len(q.byScore)-1] = Scored{}
+	q.byScore = q.byScore[:len(q.byScore)-1]
+	return t
+}
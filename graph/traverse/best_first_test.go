@@ -0,0 +1,108 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+var (
+	bestFirstGraph = []intset{
+		0: linksTo(1, 2),
+		1: linksTo(3, 4),
+		2: linksTo(5),
+		5: linksTo(6),
+		6: nil,
+	}
+	bestFirstScores = map[int64]float64{
+		1: 0.9,
+		2: 0.1,
+		3: 0.5,
+		4: 0.2,
+		5: 0.8,
+		6: 1.0,
+	}
+)
+
+var bestFirstTests = []struct {
+	name   string
+	seeds  []int64
+	k      int
+	budget int
+	want   []int64
+}{
+	{
+		name:  "all",
+		seeds: []int64{0},
+		want:  []int64{6, 1, 5, 3, 4, 2},
+	},
+	{
+		name:  "top 3",
+		seeds: []int64{0},
+		k:     3,
+		want:  []int64{6, 1, 5},
+	},
+	{
+		name:   "budget 4",
+		seeds:  []int64{0},
+		k:      3,
+		budget: 4,
+		want:   []int64{1, 3, 4},
+	},
+	{
+		name:   "budget 3",
+		seeds:  []int64{0},
+		budget: 3,
+		want:   []int64{1, 3, 2},
+	},
+	{
+		name:  "multiple seeds",
+		seeds: []int64{3, 6, 3},
+		want:  []int64{1, 5, 4, 2, 0},
+	},
+	{
+		name:   "multiple seeds budget",
+		seeds:  []int64{3, 6},
+		budget: 2,
+		want:   []int64{5, 2},
+	},
+}
+
+func TestBestFirst(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	for u, e := range bestFirstGraph {
+		// Add nodes that are not defined by an edge.
+		if g.Node(int64(u)) == nil {
+			g.AddNode(simple.Node(u))
+		}
+		for v := range e {
+			g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+		}
+	}
+	score := func(n graph.Node) float64 { return bestFirstScores[n.ID()] }
+
+	for _, test := range bestFirstTests {
+		var seeds []graph.Node
+		for _, id := range test.seeds {
+			seeds = append(seeds, g.Node(id))
+		}
+		got := BestFirst(g, seeds, score, test.k, test.budget)
+		var gotIDs []int64
+		for _, s := range got {
+			if s.Score != score(s.Node) {
+				t.Errorf("unexpected score for node %d in %q: got:%v want:%v",
+					s.Node.ID(), test.name, s.Score, score(s.Node))
+			}
+			gotIDs = append(gotIDs, s.Node.ID())
+		}
+		if !reflect.DeepEqual(gotIDs, test.want) {
+			t.Errorf("unexpected result for %q: got:%v want:%v", test.name, gotIDs, test.want)
+		}
+	}
+}
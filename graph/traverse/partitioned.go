@@ -0,0 +1,213 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"sync"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/set"
+)
+
+// HashPartition returns a partition function that assigns nodes to one of n
+// partitions by a hash of their IDs. HashPartition will panic if n is less
+// than one.
+func HashPartition(n int) func(graph.Node) int {
+	if n < 1 {
+		panic("traverse: invalid number of partitions")
+	}
+	return func(node graph.Node) int {
+		// Mix the ID bits so that regularly
+		// spaced IDs are spread over partitions.
+		z := uint64(node.ID()) + 0x9e3779b97f4a7c15
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		z ^= z >> 31
+		return int(z % uint64(n))
+	}
+}
+
+// LabelPartition returns a partition function that assigns nodes to the
+// partition given by their label in labels, for example the regions of a
+// graph partitioning or the communities of a clustering. Nodes without a
+// label are assigned to partition zero.
+func LabelPartition(labels map[int64]int) func(graph.Node) int {
+	return func(node graph.Node) int {
+		return labels[node.ID()]
+	}
+}
+
+// SplitFrontier returns the nodes of frontier divided into n partitions by the
+// partition function part, which must return values in [0, n). The order of
+// nodes in each partition is the order of the nodes in frontier.
+func SplitFrontier(frontier []graph.Node, n int, part func(graph.Node) int) [][]graph.Node {
+	parts := make([][]graph.Node, n)
+	for _, node := range frontier {
+		p := part(node)
+		parts[p] = append(parts[p], node)
+	}
+	return parts
+}
+
+// PartitionedBreadthFirst implements stateful level-synchronous breadth-first
+// graph traversal. Each frontier of the traversal is split into partitions that
+// are expanded concurrently, and the nodes found by each partition are merged
+// to form the next frontier. The Expand and Merge hooks allow the expansion of
+// partitions to be delegated to external workers, so distributed traversals can
+// be built on PartitionedBreadthFirst.
+type PartitionedBreadthFirst struct {
+	// Partitions is the number of partitions of
+	// each frontier. If Partitions is less than
+	// one, a single partition is used.
+	Partitions int
+
+	// Partition returns the partition of a node,
+	// in [0, Partitions). If Partition is nil,
+	// HashPartition(Partitions) is used.
+	Partition func(graph.Node) int
+
+	// Visit is called on all nodes on their first visit.
+	Visit func(graph.Node)
+
+	// Traverse is called on all edges that may be traversed
+	// during the walk. This includes edges that would hop to
+	// an already visited node. Traverse may be called
+	// concurrently by the expansions of different partitions.
+	//
+	// The value returned by Traverse determines whether
+	// an edge can be traversed during the walk.
+	Traverse func(graph.Edge) bool
+
+	// Expand returns the nodes reached from the nodes of
+	// partition part of the frontier. Expand is called
+	// concurrently for each non-empty partition. Expand may
+	// return visited nodes and duplicate nodes, which are
+	// discarded when the next frontier is formed. If Expand
+	// is nil, the nodes reached by edges of g that satisfy
+	// Traverse and lead to unvisited nodes are returned.
+	Expand func(g Graph, part int, frontier []graph.Node) []graph.Node
+
+	// Merge returns the candidate nodes of the next frontier
+	// at the given depth from the nodes found by each partition
+	// of the current frontier, indexed by partition. If Merge is
+	// nil, the found nodes are concatenated in partition order.
+	Merge func(depth int, found [][]graph.Node) []graph.Node
+
+	visited set.Int64s
+}
+
+// Walk performs a level-synchronous breadth-first traversal of the graph g
+// starting from the given node, depending on the Traverse field and the until
+// parameter if they are non-nil. The traversal returns the first node for which
+// until(node, depth) is true, with nodes in each frontier tested in the order
+// given by Merge. During the traversal, if the Visit field is non-nil, it is
+// called with each node the first time it is visited.
+func (b *PartitionedBreadthFirst) Walk(g Graph, from graph.Node, until func(n graph.Node, d int) bool) graph.Node {
+	if b.visited == nil {
+		b.visited = make(set.Int64s)
+	}
+	n := b.Partitions
+	if n < 1 {
+		n = 1
+	}
+	part := b.Partition
+	if part == nil {
+		part = HashPartition(n)
+	}
+	expand := b.Expand
+	if expand == nil {
+		expand = b.expand
+	}
+	merge := b.Merge
+	if merge == nil {
+		merge = concatenate
+	}
+
+	if b.Visit != nil && !b.visited.Has(from.ID()) {
+		b.Visit(from)
+	}
+	b.visited.Add(from.ID())
+
+	frontier := []graph.Node{from}
+	found := make([][]graph.Node, n)
+	for depth := 0; len(frontier) != 0; depth++ {
+		for _, t := range frontier {
+			if until != nil && until(t, depth) {
+				return t
+			}
+		}
+
+		parts := SplitFrontier(frontier, n, part)
+		var wg sync.WaitGroup
+		for p, nodes := range parts {
+			found[p] = nil
+			if len(nodes) == 0 {
+				continue
+			}
+			wg.Add(1)
+			go func(p int, nodes []graph.Node) {
+				defer wg.Done()
+				found[p] = expand(g, p, nodes)
+			}(p, nodes)
+		}
+		wg.Wait()
+
+		frontier = frontier[:0:0]
+		for _, node := range merge(depth+1, found) {
+			nid := node.ID()
+			if b.visited.Has(nid) {
+				continue
+			}
+			if b.Visit != nil {
+				b.Visit(node)
+			}
+			b.visited.Add(nid)
+			frontier = append(frontier, node)
+		}
+	}
+
+	return nil
+}
+
+// expand returns the unvisited nodes reached from frontier
+// by edges of g that satisfy b.Traverse.
+func (b *PartitionedBreadthFirst) expand(g Graph, _ int, frontier []graph.Node) []graph.Node {
+	var found []graph.Node
+	for _, t := range frontier {
+		tid := t.ID()
+		to := g.From(tid)
+		for to.Next() {
+			n := to.Node()
+			nid := n.ID()
+			if b.Traverse != nil && !b.Traverse(g.Edge(tid, nid)) {
+				continue
+			}
+			if b.visited.Has(nid) {
+				continue
+			}
+			found = append(found, n)
+		}
+	}
+	return found
+}
+
+// concatenate returns the nodes of found in partition order.
+func concatenate(_ int, found [][]graph.Node) []graph.Node {
+	var nodes []graph.Node
+	for _, f := range found {
+		nodes = append(nodes, f...)
+	}
+	return nodes
+}
+
+// Visited returned whether the node n was visited during a traverse.
+func (b *PartitionedBreadthFirst) Visited(n graph.Node) bool {
+	return b.visited.Has(n.ID())
+}
+
+// Reset resets the state of the traverser for reuse.
+func (b *PartitionedBreadthFirst) Reset() {
+	b.visited = nil
+}
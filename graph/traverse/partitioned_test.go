@@ -0,0 +1,176 @@
+// Copyright ©2020 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package traverse
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/internal/ordered"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestPartitionedBreadthFirst(t *testing.T) {
+	for i, test := range breadthFirstTests {
+		g := simple.NewUndirectedGraph()
+		for u, e := range test.g {
+			// Add nodes that are not defined by an edge.
+			if g.Node(int64(u)) == nil {
+				g.AddNode(simple.Node(u))
+			}
+			for v := range e {
+				g.SetEdge(simple.Edge{F: simple.Node(u), T: simple.Node(v)})
+			}
+		}
+		labels := make(map[int64]int)
+		for id := range test.g {
+			labels[int64(id)] = id % 2
+		}
+		for _, partition := range []struct {
+			n    int
+			part func(graph.Node) int
+		}{
+			{n: 0},
+			{n: 1},
+			{n: 3},
+			{n: 2, part: LabelPartition(labels)},
+		} {
+			w := PartitionedBreadthFirst{
+				Partitions: partition.n,
+				Partition:  partition.part,
+				Traverse:   test.edge,
+			}
+			var got [][]int64
+			final := w.Walk(g, test.from, func(n graph.Node, d int) bool {
+				if test.until != nil && test.until(n, d) {
+					return true
+				}
+				if d >= len(got) {
+					got = append(got, []int64(nil))
+				}
+				got[d] = append(got[d], n.ID())
+				return false
+			})
+			if !test.final[final] {
+				t.Errorf("unexepected final node for test %d with %d partitions:\ngot:  %v\nwant: %v",
+					i, partition.n, final, test.final)
+			}
+			for _, l := range got {
+				sort.Sort(ordered.Int64s(l))
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexepected BFS level structure for test %d with %d partitions:\ngot:  %v\nwant: %v",
+					i, partition.n, got, test.want)
+			}
+		}
+	}
+}
+
+func TestPartitionedBreadthFirstHooks(t *testing.T) {
+	g := gnpUndirected(100, 0.05)
+	const parts = 4
+
+	var want BreadthFirst
+	wantDepth := make(map[int64]int)
+	want.Walk(g, simple.Node(0), func(n graph.Node, d int) bool {
+		wantDepth[n.ID()] = d
+		return false
+	})
+
+	var (
+		mu       sync.Mutex
+		expanded = make(map[int64]int)
+		merged   []int
+	)
+	w := PartitionedBreadthFirst{
+		Partitions: parts,
+		Expand: func(g Graph, part int, frontier []graph.Node) []graph.Node {
+			// Expand as an external worker would, without
+			// knowledge of the visited nodes.
+			var found []graph.Node
+			for _, n := range frontier {
+				if p := HashPartition(parts)(n); p != part {
+					t.Errorf("node %d expanded by partition %d, want %d", n.ID(), part, p)
+				}
+				mu.Lock()
+				expanded[n.ID()]++
+				mu.Unlock()
+				found = append(found, graph.NodesOf(g.From(n.ID()))...)
+			}
+			return found
+		},
+		Merge: func(depth int, found [][]graph.Node) []graph.Node {
+			if len(found) != parts {
+				t.Errorf("unexpected number of merged partitions: got:%d want:%d", len(found), parts)
+			}
+			merged = append(merged, depth)
+			var nodes []graph.Node
+			for _, f := range found {
+				nodes = append(nodes, f...)
+			}
+			return nodes
+		},
+	}
+	gotDepth := make(map[int64]int)
+	w.Walk(g, simple.Node(0), func(n graph.Node, d int) bool {
+		gotDepth[n.ID()] = d
+		return false
+	})
+
+	if !reflect.DeepEqual(gotDepth, wantDepth) {
+		t.Errorf("unexpected node depths:\ngot:  %v\nwant: %v", gotDepth, wantDepth)
+	}
+	for id := range wantDepth {
+		if expanded[id] != 1 {
+			t.Errorf("unexpected number of expansions of node %d: got:%d want:1", id, expanded[id])
+		}
+		if !w.Visited(simple.Node(id)) {
+			t.Errorf("node %d not marked visited", id)
+		}
+	}
+	for i, d := range merged {
+		if d != i+1 {
+			t.Errorf("unexpected merge depth: got:%d want:%d", d, i+1)
+		}
+	}
+
+	w.Reset()
+	if w.Visited(simple.Node(0)) {
+		t.Error("node visited after reset")
+	}
+}
+
+func TestSplitFrontier(t *testing.T) {
+	var frontier []graph.Node
+	for id := 0; id < 1000; id++ {
+		frontier = append(frontier, simple.Node(id))
+	}
+	const n = 7
+	parts := SplitFrontier(frontier, n, HashPartition(n))
+	if len(parts) != n {
+		t.Fatalf("unexpected number of partitions: got:%d want:%d", len(parts), n)
+	}
+	var total int
+	for p, nodes := range parts {
+		if len(nodes) == 0 {
+			t.Errorf("partition %d is empty", p)
+		}
+		for i, node := range nodes {
+			if i > 0 && node.ID() <= nodes[i-1].ID() {
+				t.Errorf("partition %d not in frontier order", p)
+			}
+			if got := HashPartition(n)(node); got != p {
+				t.Errorf("node %d in partition %d, want %d", node.ID(), p, got)
+			}
+		}
+		total += len(nodes)
+	}
+	if total != len(frontier) {
+		t.Errorf("unexpected number of partitioned nodes: got:%d want:%d", total, len(frontier))
+	}
+}